@@ -158,11 +158,20 @@ func (r *ProtocolIncus) DoHTTP(req *http.Request) (*http.Response, error) {
 // User-Agent (if r.httpUserAgent is set).
 // X-Incus-authenticated (if r.requireAuthenticated is set).
 // OIDC Authorization header (if r.oidcClient is set).
+// X-Incus-timeout (if the request context has a deadline).
 func (r *ProtocolIncus) addClientHeaders(req *http.Request) {
 	if r.httpUserAgent != "" {
 		req.Header.Set("User-Agent", r.httpUserAgent)
 	}
 
+	deadline, ok := req.Context().Deadline()
+	if ok {
+		timeout := time.Until(deadline)
+		if timeout > 0 {
+			req.Header.Set("X-Incus-timeout", timeout.String())
+		}
+	}
+
 	if r.requireAuthenticated {
 		req.Header.Set("X-Incus-authenticated", "true")
 	}
@@ -221,7 +230,7 @@ func incusParseResponse(resp *http.Response) (*api.Response, string, error) {
 
 	// Handle errors
 	if response.Type == api.ErrorResponse {
-		return nil, "", api.StatusErrorf(resp.StatusCode, response.Error)
+		return nil, "", api.StatusReasonErrorf(resp.StatusCode, response.ErrorReason, response.Error)
 	}
 
 	return &response, etag, nil
@@ -104,3 +104,37 @@ func (r *ProtocolIncus) CreateCertificateToken(certificate api.CertificatesPost)
 
 	return op, nil
 }
+
+// ImportCertificates adds a batch of certificates to the Incus trust store.
+func (r *ProtocolIncus) ImportCertificates(certificates api.CertificatesImportPost) (*api.CertificatesImportResult, error) {
+	if !r.HasExtension("certificates_import") {
+		return nil, fmt.Errorf("The server is missing the required \"certificates_import\" API extension")
+	}
+
+	result := api.CertificatesImportResult{}
+
+	// Send the request
+	_, err := r.queryStruct("POST", "/certificates/import", certificates, "", &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetCertificatesTrustStore returns the certificates currently loaded in the Incus trust store.
+func (r *ProtocolIncus) GetCertificatesTrustStore() (*api.CertificatesTrustStore, error) {
+	if !r.HasExtension("certificates_trust_store") {
+		return nil, fmt.Errorf("The server is missing the required \"certificates_trust_store\" API extension")
+	}
+
+	trustStore := api.CertificatesTrustStore{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", "/certificates/trust-store", nil, "", &trustStore)
+	if err != nil {
+		return nil, err
+	}
+
+	return &trustStore, nil
+}
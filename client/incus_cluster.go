@@ -61,6 +61,21 @@ func (r *ProtocolIncus) DeleteClusterMember(name string, force bool) error {
 	return nil
 }
 
+// ForceRemoveClusterMember removes a dead member from the cluster.
+func (r *ProtocolIncus) ForceRemoveClusterMember(name string) error {
+	err := r.CheckExtension("cluster_member_force_remove")
+	if err != nil {
+		return err
+	}
+
+	_, _, err = r.query("POST", fmt.Sprintf("/cluster/members/%s/force-remove", name), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // GetClusterMemberNames returns the URLs of the current members in the cluster.
 func (r *ProtocolIncus) GetClusterMemberNames() ([]string, error) {
 	if !r.HasExtension("clustering") {
@@ -150,6 +165,10 @@ func (r *ProtocolIncus) CreateClusterMember(member api.ClusterMembersPost) (Oper
 		return nil, fmt.Errorf("The server is missing the required \"clustering_join_token\" API extension")
 	}
 
+	if (member.Expiry != "" || member.Reusable) && !r.HasExtension("cluster_join_token_options") {
+		return nil, fmt.Errorf("The server is missing the required \"cluster_join_token_options\" API extension")
+	}
+
 	op, _, err := r.queryOperation("POST", "/cluster/members", member, "", true)
 	if err != nil {
 		return nil, err
@@ -172,6 +191,22 @@ func (r *ProtocolIncus) UpdateClusterCertificate(certs api.ClusterCertificatePut
 	return nil
 }
 
+// RotateClusterCertificate replaces the cluster certificate with a newly generated one on every member.
+func (r *ProtocolIncus) RotateClusterCertificate() (*api.ClusterCertificate, error) {
+	err := r.CheckExtension("cluster_certificate_rotate")
+	if err != nil {
+		return nil, err
+	}
+
+	cert := api.ClusterCertificate{}
+	_, err = r.queryStruct("POST", "/cluster/certificate", nil, "", &cert)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cert, nil
+}
+
 // GetClusterMemberState gets state information about a cluster member.
 func (r *ProtocolIncus) GetClusterMemberState(name string) (*api.ClusterMemberState, string, error) {
 	err := r.CheckExtension("cluster_member_state")
@@ -189,6 +224,38 @@ func (r *ProtocolIncus) GetClusterMemberState(name string) (*api.ClusterMemberSt
 	return &state, etag, err
 }
 
+// GetClusterMembersHeartbeat gets the state of all cluster members as seen by the last heartbeat.
+func (r *ProtocolIncus) GetClusterMembersHeartbeat() ([]api.ClusterMemberHeartbeat, error) {
+	err := r.CheckExtension("cluster_heartbeat_state")
+	if err != nil {
+		return nil, err
+	}
+
+	members := []api.ClusterMemberHeartbeat{}
+	_, err = r.queryStruct("GET", "/cluster/heartbeat", nil, "", &members)
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// GetClusterMembersFeatures gets the kernel and LXC features detected on all online cluster members.
+func (r *ProtocolIncus) GetClusterMembersFeatures() ([]api.ClusterMemberFeatures, error) {
+	err := r.CheckExtension("cluster_features")
+	if err != nil {
+		return nil, err
+	}
+
+	members := []api.ClusterMemberFeatures{}
+	_, err = r.queryStruct("GET", "/cluster/features", nil, "", &members)
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
 // UpdateClusterMemberState evacuates or restores a cluster member.
 func (r *ProtocolIncus) UpdateClusterMemberState(name string, state api.ClusterMemberStatePost) (Operation, error) {
 	if !r.HasExtension("clustering_evacuation") {
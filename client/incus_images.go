@@ -501,6 +501,53 @@ func (r *ProtocolIncus) CreateImage(image api.ImagesPost, args *ImageCreateArgs)
 
 	// Setup the headers
 	req.Header.Set("Content-Type", contentType)
+	setImageUploadHeaders(req, image)
+
+	// Set the user agent
+	if image.Source != nil && image.Source.Fingerprint != "" && image.Source.Secret != "" && image.Source.Mode == "push" {
+		// Set fingerprint
+		req.Header.Set("X-Incus-fingerprint", image.Source.Fingerprint)
+
+		// Set secret
+		req.Header.Set("X-Incus-secret", image.Source.Secret)
+	}
+
+	// Send the request
+	resp, err := r.DoHTTP(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if ioErr != nil {
+		return nil, err
+	}
+
+	// Handle errors
+	response, _, err := incusParseResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get to the operation
+	respOperation, err := response.MetadataAsOperation()
+	if err != nil {
+		return nil, err
+	}
+
+	// Setup an Operation wrapper
+	op := operation{
+		Operation: *respOperation,
+		r:         r,
+		chActive:  make(chan bool),
+	}
+
+	return &op, nil
+}
+
+// setImageUploadHeaders sets the headers describing an image being uploaded.
+func setImageUploadHeaders(req *http.Request, image api.ImagesPost) {
 	if image.Public {
 		req.Header.Set("X-Incus-public", "true")
 	}
@@ -528,28 +575,68 @@ func (r *ProtocolIncus) CreateImage(image api.ImagesPost, args *ImageCreateArgs)
 
 		req.Header.Set("X-Incus-profiles", imgProfiles.Encode())
 	}
+}
 
-	// Set the user agent
-	if image.Source != nil && image.Source.Fingerprint != "" && image.Source.Secret != "" && image.Source.Mode == "push" {
-		// Set fingerprint
-		req.Header.Set("X-Incus-fingerprint", image.Source.Fingerprint)
+// CreateImageStream streams a unified image directly into the image store, optionally creating its volume on the given storage pool.
+func (r *ProtocolIncus) CreateImageStream(image api.ImagesPost, args *ImageCreateArgs, pool string) (Operation, error) {
+	if !r.HasExtension("images_streaming_upload") {
+		return nil, fmt.Errorf("The server is missing the required \"images_streaming_upload\" API extension")
+	}
 
-		// Set secret
-		req.Header.Set("X-Incus-secret", image.Source.Secret)
+	if args == nil || args.MetaFile == nil {
+		return nil, fmt.Errorf("Image file is required")
 	}
 
-	// Send the request
-	resp, err := r.DoHTTP(req)
+	if args.RootfsFile != nil {
+		return nil, fmt.Errorf("Only unified images can be streamed")
+	}
+
+	body := args.MetaFile
+	if args.ProgressHandler != nil {
+		body = &ioprogress.ProgressReader{
+			ReadCloser: io.NopCloser(args.MetaFile),
+			Tracker: &ioprogress.ProgressTracker{
+				Handler: func(received int64, speed int64) {
+					args.ProgressHandler(ioprogress.ProgressData{Text: fmt.Sprintf("%s (%s/s)", units.GetByteSizeString(received, 2), units.GetByteSizeString(speed, 2))})
+				},
+			},
+		}
+	}
+
+	// Prepare the HTTP request
+	reqURL, err := r.setQueryAttributes(fmt.Sprintf("%s/1.0/images/stream", r.httpBaseURL.String()))
 	if err != nil {
 		return nil, err
 	}
 
-	defer func() { _ = resp.Body.Close() }()
+	if pool != "" {
+		reqURL, err = setQueryParam(reqURL, "pool", pool)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	if ioErr != nil {
+	req, err := http.NewRequest("POST", reqURL, body)
+	if err != nil {
 		return nil, err
 	}
 
+	// Setup the headers
+	req.Header.Set("Content-Type", "application/octet-stream")
+	setImageUploadHeaders(req, image)
+
+	if image.Source != nil && image.Source.Fingerprint != "" {
+		req.Header.Set("X-Incus-fingerprint", image.Source.Fingerprint)
+	}
+
+	// Send the request
+	resp, err := r.DoHTTP(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
 	// Handle errors
 	response, _, err := incusParseResponse(resp)
 	if err != nil {
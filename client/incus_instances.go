@@ -1084,6 +1084,26 @@ func (r *ProtocolIncus) DeleteInstance(name string) (Operation, error) {
 	return op, nil
 }
 
+// UnplugInstanceDevice removes a host device from a running instance without restarting it.
+func (r *ProtocolIncus) UnplugInstanceDevice(name string, device string) error {
+	if !r.HasExtension("resources_assignments") {
+		return fmt.Errorf("The server is missing the required \"resources_assignments\" API extension")
+	}
+
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return err
+	}
+
+	// Send the request
+	_, _, err = r.query("DELETE", fmt.Sprintf("%s/%s/devices/%s", path, url.PathEscape(name), url.PathEscape(device)), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // ExecInstance requests that Incus spawns a command inside the instance.
 func (r *ProtocolIncus) ExecInstance(instanceName string, exec api.InstanceExecPost, args *InstanceExecArgs) (Operation, error) {
 	if exec.RecordOutput {
@@ -2049,6 +2069,28 @@ func (r *ProtocolIncus) UpdateInstanceSnapshot(instanceName string, name string,
 	return op, nil
 }
 
+// GetInstanceEffectiveProfiles returns the profiles applied to the instance, along with the project each was resolved from.
+func (r *ProtocolIncus) GetInstanceEffectiveProfiles(name string) (*api.InstanceEffectiveProfiles, error) {
+	if !r.HasExtension("instances_effective_profiles") {
+		return nil, fmt.Errorf("The server is missing the required \"instances_effective_profiles\" API extension")
+	}
+
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := api.InstanceEffectiveProfiles{}
+
+	// Fetch the raw value
+	_, err = r.queryStruct("GET", fmt.Sprintf("%s/%s/profiles", path, url.PathEscape(name)), nil, "", &profiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return &profiles, nil
+}
+
 // GetInstanceState returns a InstanceState entry for the provided instance name.
 func (r *ProtocolIncus) GetInstanceState(name string) (*api.InstanceState, string, error) {
 	var uri string
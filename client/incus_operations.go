@@ -3,6 +3,7 @@ package incus
 import (
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/gorilla/websocket"
 
@@ -72,6 +73,52 @@ func (r *ProtocolIncus) GetOperationsAllProjects() ([]api.Operation, error) {
 	return operations, nil
 }
 
+// GetOperationsHistory returns the completed operations kept in the operations history, most recent first.
+func (r *ProtocolIncus) GetOperationsHistory(args *OperationsHistoryArgs) ([]api.OperationHistory, error) {
+	err := r.CheckExtension("operations_history")
+	if err != nil {
+		return nil, err
+	}
+
+	v := url.Values{}
+	if args != nil {
+		if args.Type != "" {
+			v.Set("type", args.Type)
+		}
+
+		if args.Status != "" {
+			v.Set("status", args.Status)
+		}
+
+		if !args.Since.IsZero() {
+			v.Set("since", args.Since.UTC().Format(time.RFC3339))
+		}
+
+		if !args.Until.IsZero() {
+			v.Set("until", args.Until.UTC().Format(time.RFC3339))
+		}
+
+		if args.AllProjects {
+			v.Set("all-projects", "true")
+		}
+	}
+
+	path := "/operations/history"
+	if len(v) > 0 {
+		path = fmt.Sprintf("%s?%s", path, v.Encode())
+	}
+
+	operations := []api.OperationHistory{}
+
+	// Fetch the raw value.
+	_, err = r.queryStruct("GET", path, nil, "", &operations)
+	if err != nil {
+		return nil, err
+	}
+
+	return operations, nil
+}
+
 // GetOperation returns an Operation entry for the provided uuid.
 func (r *ProtocolIncus) GetOperation(uuid string) (*api.Operation, string, error) {
 	op := api.Operation{}
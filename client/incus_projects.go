@@ -78,6 +78,80 @@ func (r *ProtocolIncus) GetProjectState(name string) (*api.ProjectState, error)
 	return &projectState, nil
 }
 
+// GetProjectStorageVolumeAttribution returns the project that storage volumes of the given type requested in the project are attributed to.
+func (r *ProtocolIncus) GetProjectStorageVolumeAttribution(name string, volumeType string, volumeName string) (*api.ProjectStorageVolumeAttribution, error) {
+	if !r.HasExtension("projects_storage_volume_attribution") {
+		return nil, fmt.Errorf("The server is missing the required \"projects_storage_volume_attribution\" API extension")
+	}
+
+	attribution := api.ProjectStorageVolumeAttribution{}
+
+	v := url.Values{}
+	v.Set("type", volumeType)
+	if volumeName != "" {
+		v.Set("volume", volumeName)
+	}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", fmt.Sprintf("/projects/%s/storage-volume-attribution?%s", url.PathEscape(name), v.Encode()), nil, "", &attribution)
+	if err != nil {
+		return nil, err
+	}
+
+	return &attribution, nil
+}
+
+// CreateProjects defines several projects sharing the same description and configuration.
+func (r *ProtocolIncus) CreateProjects(projects api.ProjectsBulkPost) (*api.ProjectsBulkResult, error) {
+	if !r.HasExtension("projects_bulk") {
+		return nil, fmt.Errorf("The server is missing the required \"projects_bulk\" API extension")
+	}
+
+	result := api.ProjectsBulkResult{}
+
+	// Send the request
+	_, err := r.queryStruct("POST", "/projects-bulk", projects, "", &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetProjectDependencies returns the entities still attributed to the project.
+func (r *ProtocolIncus) GetProjectDependencies(name string) (*api.ProjectDependencies, error) {
+	if !r.HasExtension("projects_dependencies") {
+		return nil, fmt.Errorf("The server is missing the required \"projects_dependencies\" API extension")
+	}
+
+	dependencies := api.ProjectDependencies{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", fmt.Sprintf("/projects/%s/dependencies", url.PathEscape(name)), nil, "", &dependencies)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dependencies, nil
+}
+
+// GetProjectDNSRecords returns the DNS records the network zones of the project would serve.
+func (r *ProtocolIncus) GetProjectDNSRecords(name string) (*api.ProjectDNSRecords, error) {
+	if !r.HasExtension("projects_dns_records") {
+		return nil, fmt.Errorf("The server is missing the required \"projects_dns_records\" API extension")
+	}
+
+	records := api.ProjectDNSRecords{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", fmt.Sprintf("/projects/%s/dns-records", url.PathEscape(name)), nil, "", &records)
+	if err != nil {
+		return nil, err
+	}
+
+	return &records, nil
+}
+
 // CreateProject defines a new project.
 func (r *ProtocolIncus) CreateProject(project api.ProjectsPost) error {
 	if !r.HasExtension("projects") {
@@ -123,6 +197,23 @@ func (r *ProtocolIncus) RenameProject(name string, project api.ProjectPost) (Ope
 	return op, nil
 }
 
+// MoveProjectEntities moves (or copies) profiles and networks from the project to another project.
+func (r *ProtocolIncus) MoveProjectEntities(name string, req api.ProjectMovePost) (*api.ProjectMoveResult, error) {
+	if !r.HasExtension("projects_move") {
+		return nil, fmt.Errorf("The server is missing the required \"projects_move\" API extension")
+	}
+
+	result := api.ProjectMoveResult{}
+
+	// Send the request
+	_, err := r.queryStruct("POST", fmt.Sprintf("/projects/%s/move", url.PathEscape(name)), req, "", &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
 // DeleteProject deletes a project.
 func (r *ProtocolIncus) DeleteProject(name string) error {
 	if !r.HasExtension("projects") {
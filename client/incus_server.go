@@ -47,7 +47,86 @@ func (r *ProtocolIncus) GetServer() (*api.Server, string, error) {
 // UpdateServer updates the server status to match the provided Server struct.
 func (r *ProtocolIncus) UpdateServer(server api.ServerPut, ETag string) error {
 	// Send the request
-	_, _, err := r.query("PUT", "", server, ETag)
+	resp, _, err := r.query("PUT", "", server, ETag)
+	if err != nil {
+		return err
+	}
+
+	// Slow reloads are run in a background operation, wait for it to complete.
+	if resp.Type == api.AsyncResponse {
+		respOperation, err := resp.MetadataAsOperation()
+		if err != nil {
+			return err
+		}
+
+		op := operation{
+			Operation:    *respOperation,
+			r:            r,
+			chActive:     make(chan bool),
+			skipListener: true,
+		}
+
+		return op.Wait()
+	}
+
+	return nil
+}
+
+// GetServerConfig returns the full configuration of the server, split by scope.
+func (r *ProtocolIncus) GetServerConfig() (*api.ServerConfig, string, error) {
+	if !r.HasExtension("server_config_export") {
+		return nil, "", fmt.Errorf("The server is missing the required \"server_config_export\" API extension")
+	}
+
+	config := api.ServerConfig{}
+
+	etag, err := r.queryStruct("GET", "/config", nil, "", &config)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &config, etag, nil
+}
+
+// ImportServerConfig replaces the full configuration of the server.
+func (r *ProtocolIncus) ImportServerConfig(config api.ServerConfig, ETag string) error {
+	if !r.HasExtension("server_config_export") {
+		return fmt.Errorf("The server is missing the required \"server_config_export\" API extension")
+	}
+
+	_, _, err := r.query("PUT", "/config", config, ETag)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetConnections returns the live client connections to the server.
+func (r *ProtocolIncus) GetConnections() ([]api.Connection, error) {
+	err := r.CheckExtension("connections")
+	if err != nil {
+		return nil, err
+	}
+
+	conns := []api.Connection{}
+
+	_, err = r.queryStruct("GET", "/connections", nil, "", &conns)
+	if err != nil {
+		return nil, err
+	}
+
+	return conns, nil
+}
+
+// DeleteConnection forcibly closes a client connection to the server.
+func (r *ProtocolIncus) DeleteConnection(id string) error {
+	err := r.CheckExtension("connections")
+	if err != nil {
+		return err
+	}
+
+	_, _, err = r.query("DELETE", api.NewURL().Path("connections", id).String(), nil, "")
 	if err != nil {
 		return err
 	}
@@ -98,6 +177,57 @@ func (r *ProtocolIncus) GetServerResources() (*api.Resources, error) {
 	return &resources, nil
 }
 
+// GetServerResourceAssignments returns the host resources assigned to the running instances of the server.
+func (r *ProtocolIncus) GetServerResourceAssignments() ([]api.ResourcesAssignment, error) {
+	if !r.HasExtension("resources_assignments") {
+		return nil, fmt.Errorf("The server is missing the required \"resources_assignments\" API extension")
+	}
+
+	assignments := []api.ResourcesAssignment{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", "/resources/assignments", nil, "", &assignments)
+	if err != nil {
+		return nil, err
+	}
+
+	return assignments, nil
+}
+
+// GetServerResourceUsage returns the instances consuming limited host resources, grouped by class of resource.
+func (r *ProtocolIncus) GetServerResourceUsage() ([]api.ResourcesUsage, error) {
+	if !r.HasExtension("resources_usage") {
+		return nil, fmt.Errorf("The server is missing the required \"resources_usage\" API extension")
+	}
+
+	usage := []api.ResourcesUsage{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", "/resources/usage", nil, "", &usage)
+	if err != nil {
+		return nil, err
+	}
+
+	return usage, nil
+}
+
+// GetServerResourceFeatures returns the kernel and LXC features detected on the server.
+func (r *ProtocolIncus) GetServerResourceFeatures() (*api.ResourcesFeatures, error) {
+	if !r.HasExtension("resources_features") {
+		return nil, fmt.Errorf("The server is missing the required \"resources_features\" API extension")
+	}
+
+	features := api.ResourcesFeatures{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", "/resources/features", nil, "", &features)
+	if err != nil {
+		return nil, err
+	}
+
+	return &features, nil
+}
+
 // UseProject returns a client that will use a specific project.
 func (r *ProtocolIncus) UseProject(name string) InstanceServer {
 	return &ProtocolIncus{
@@ -5,6 +5,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/pkg/sftp"
@@ -76,7 +77,14 @@ type InstanceServer interface {
 	GetMetrics() (metrics string, err error)
 	GetServer() (server *api.Server, ETag string, err error)
 	GetServerResources() (resources *api.Resources, err error)
+	GetServerResourceAssignments() (assignments []api.ResourcesAssignment, err error)
+	GetServerResourceFeatures() (features *api.ResourcesFeatures, err error)
+	GetServerResourceUsage() (usage []api.ResourcesUsage, err error)
 	UpdateServer(server api.ServerPut, ETag string) (err error)
+	GetServerConfig() (config *api.ServerConfig, ETag string, err error)
+	ImportServerConfig(config api.ServerConfig, ETag string) (err error)
+	GetConnections() (connections []api.Connection, err error)
+	DeleteConnection(id string) (err error)
 	ApplyServerPreseed(config api.InitPreseed) error
 	HasExtension(extension string) (exists bool)
 	RequireAuthenticated(authenticated bool)
@@ -92,6 +100,8 @@ type InstanceServer interface {
 	UpdateCertificate(fingerprint string, certificate api.CertificatePut, ETag string) (err error)
 	DeleteCertificate(fingerprint string) (err error)
 	CreateCertificateToken(certificate api.CertificatesPost) (op Operation, err error)
+	ImportCertificates(certificates api.CertificatesImportPost) (result *api.CertificatesImportResult, err error)
+	GetCertificatesTrustStore() (trustStore *api.CertificatesTrustStore, err error)
 
 	// Instance functions.
 	GetInstanceNames(instanceType api.InstanceType) (names []string, err error)
@@ -113,6 +123,7 @@ type InstanceServer interface {
 	RenameInstance(name string, instance api.InstancePost) (op Operation, err error)
 	MigrateInstance(name string, instance api.InstancePost) (op Operation, err error)
 	DeleteInstance(name string) (op Operation, err error)
+	UnplugInstanceDevice(name string, device string) (err error)
 	UpdateInstances(state api.InstancesPut, ETag string) (op Operation, err error)
 	RebuildInstance(instanceName string, req api.InstanceRebuildPost) (op Operation, err error)
 	RebuildInstanceFromImage(source ImageServer, image api.Image, instanceName string, req api.InstanceRebuildPost) (op RemoteOperation, err error)
@@ -150,6 +161,7 @@ type InstanceServer interface {
 	GetInstanceBackupFile(instanceName string, name string, req *BackupFileRequest) (resp *BackupFileResponse, err error)
 	CreateInstanceFromBackup(args InstanceBackupArgs) (op Operation, err error)
 
+	GetInstanceEffectiveProfiles(name string) (profiles *api.InstanceEffectiveProfiles, err error)
 	GetInstanceState(name string) (state *api.InstanceState, ETag string, err error)
 	UpdateInstanceState(name string, state api.InstanceStatePut, ETag string) (op Operation, err error)
 
@@ -172,6 +184,7 @@ type InstanceServer interface {
 
 	// Image functions
 	CreateImage(image api.ImagesPost, args *ImageCreateArgs) (op Operation, err error)
+	CreateImageStream(image api.ImagesPost, args *ImageCreateArgs, pool string) (op Operation, err error)
 	CopyImage(source ImageServer, image api.Image, args *ImageCopyArgs) (op RemoteOperation, err error)
 	UpdateImage(fingerprint string, image api.ImagePut, ETag string) (err error)
 	DeleteImage(fingerprint string) (op Operation, err error)
@@ -249,6 +262,7 @@ type InstanceServer interface {
 	GetOperationUUIDs() (uuids []string, err error)
 	GetOperations() (operations []api.Operation, err error)
 	GetOperationsAllProjects() (operations []api.Operation, err error)
+	GetOperationsHistory(args *OperationsHistoryArgs) (operations []api.OperationHistory, err error)
 	GetOperation(uuid string) (op *api.Operation, ETag string, err error)
 	GetOperationWait(uuid string, timeout int) (op *api.Operation, ETag string, err error)
 	GetOperationWaitSecret(uuid string, secret string, timeout int) (op *api.Operation, ETag string, err error)
@@ -269,9 +283,14 @@ type InstanceServer interface {
 	GetProjects() (projects []api.Project, err error)
 	GetProject(name string) (project *api.Project, ETag string, err error)
 	GetProjectState(name string) (project *api.ProjectState, err error)
+	GetProjectDependencies(name string) (dependencies *api.ProjectDependencies, err error)
+	GetProjectDNSRecords(name string) (records *api.ProjectDNSRecords, err error)
+	GetProjectStorageVolumeAttribution(name string, volumeType string, volumeName string) (attribution *api.ProjectStorageVolumeAttribution, err error)
 	CreateProject(project api.ProjectsPost) (err error)
+	CreateProjects(projects api.ProjectsBulkPost) (result *api.ProjectsBulkResult, err error)
 	UpdateProject(name string, project api.ProjectPut, ETag string) (err error)
 	RenameProject(name string, project api.ProjectPost) (op Operation, err error)
+	MoveProjectEntities(name string, req api.ProjectMovePost) (result *api.ProjectMoveResult, err error)
 	DeleteProject(name string) (err error)
 
 	// Storage pool functions ("storage" API extension)
@@ -340,6 +359,7 @@ type InstanceServer interface {
 	GetCluster() (cluster *api.Cluster, ETag string, err error)
 	UpdateCluster(cluster api.ClusterPut, ETag string) (op Operation, err error)
 	DeleteClusterMember(name string, force bool) (err error)
+	ForceRemoveClusterMember(name string) (err error)
 	GetClusterMemberNames() (names []string, err error)
 	GetClusterMembers() (members []api.ClusterMember, err error)
 	GetClusterMember(name string) (member *api.ClusterMember, ETag string, err error)
@@ -347,7 +367,10 @@ type InstanceServer interface {
 	RenameClusterMember(name string, member api.ClusterMemberPost) (err error)
 	CreateClusterMember(member api.ClusterMembersPost) (op Operation, err error)
 	UpdateClusterCertificate(certs api.ClusterCertificatePut, ETag string) (err error)
+	RotateClusterCertificate() (cert *api.ClusterCertificate, err error)
 	GetClusterMemberState(name string) (*api.ClusterMemberState, string, error)
+	GetClusterMembersHeartbeat() (members []api.ClusterMemberHeartbeat, err error)
+	GetClusterMembersFeatures() (members []api.ClusterMemberFeatures, err error)
 	UpdateClusterMemberState(name string, state api.ClusterMemberStatePost) (op Operation, err error)
 	GetClusterGroups() ([]api.ClusterGroup, error)
 	GetClusterGroupNames() ([]string, error)
@@ -513,6 +536,25 @@ type StoragePoolVolumeBackupArgs struct {
 	Name string
 }
 
+// The OperationsHistoryArgs struct is used to filter the completed operations returned from the history.
+// API extension: operations_history.
+type OperationsHistoryArgs struct {
+	// Only return operations of this type, such as instance-create (all types if empty)
+	Type string
+
+	// Only return operations with this final status (Success, Failure or Cancelled)
+	Status string
+
+	// Only return operations completed at or after this time
+	Since time.Time
+
+	// Only return operations completed at or before this time
+	Until time.Time
+
+	// Return operations from all projects
+	AllProjects bool
+}
+
 // The InstanceBackupArgs struct is used when creating a instance from a backup.
 type InstanceBackupArgs struct {
 	// The backup file
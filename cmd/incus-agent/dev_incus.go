@@ -64,6 +64,30 @@ var DevIncusConfigGet = devIncusHandler{"/1.0/config", func(d *Daemon, w http.Re
 
 	defer client.Disconnect()
 
+	// With recursion, return the keys along with their values.
+	if r.URL.Query().Get("recursion") == "1" {
+		resp, _, err := client.RawQuery("GET", "/1.0/config?recursion=1", nil, "")
+		if err != nil {
+			return smartResponse(err)
+		}
+
+		var config map[string]string
+
+		err = resp.MetadataAsStruct(&config)
+		if err != nil {
+			return smartResponse(fmt.Errorf("Failed parsing response from host: %w", err))
+		}
+
+		filtered := map[string]string{}
+		for k, v := range config {
+			if strings.HasPrefix(k, "user.") || strings.HasPrefix(k, "cloud-init.") {
+				filtered[k] = v
+			}
+		}
+
+		return okResponse(filtered, "json")
+	}
+
 	resp, _, err := client.RawQuery("GET", "/1.0/config", nil, "")
 	if err != nil {
 		return smartResponse(err)
@@ -217,6 +241,40 @@ var DevIncusDevicesGet = devIncusHandler{"/1.0/devices", func(d *Daemon, w http.
 	return okResponse(devices, "json")
 }}
 
+var DevIncusMetrics = devIncusHandler{"/1.0/metrics", func(d *Daemon, w http.ResponseWriter, r *http.Request) *devIncusResponse {
+	client, err := getVsockClient(d)
+	if err != nil {
+		return smartResponse(fmt.Errorf("Failed connecting to host over vsock: %w", err))
+	}
+
+	defer client.Disconnect()
+
+	if r.Method == "GET" {
+		resp, _, err := client.RawQuery(r.Method, "/1.0/metrics", nil, "")
+		if err != nil {
+			return smartResponse(err)
+		}
+
+		var metrics map[string]api.DevIncusMetric
+
+		err = resp.MetadataAsStruct(&metrics)
+		if err != nil {
+			return smartResponse(fmt.Errorf("Failed parsing response from host: %w", err))
+		}
+
+		return okResponse(metrics, "json")
+	} else if r.Method == "PUT" {
+		_, _, err := client.RawQuery(r.Method, "/1.0/metrics", r.Body, "")
+		if err != nil {
+			return smartResponse(err)
+		}
+
+		return okResponse("", "raw")
+	}
+
+	return &devIncusResponse{fmt.Sprintf("method %q not allowed", r.Method), http.StatusBadRequest, "raw"}
+}}
+
 var handlers = []devIncusHandler{
 	{"/", func(d *Daemon, w http.ResponseWriter, r *http.Request) *devIncusResponse {
 		return okResponse([]string{"/1.0"}, "json")
@@ -227,6 +285,7 @@ var handlers = []devIncusHandler{
 	DevIncusMetadataGet,
 	devIncusEventsGet,
 	DevIncusDevicesGet,
+	DevIncusMetrics,
 }
 
 func hoistReq(f func(*Daemon, http.ResponseWriter, *http.Request) *devIncusResponse, d *Daemon) func(http.ResponseWriter, *http.Request) {
@@ -112,7 +112,7 @@ func authenticate(r *http.Request, cert *x509.Certificate) bool {
 	clientCerts := map[string]x509.Certificate{"0": *cert}
 
 	for _, cert := range r.TLS.PeerCertificates {
-		trusted, _ := localUtil.CheckTrustState(*cert, clientCerts, nil, false)
+		trusted, _ := localUtil.CheckTrustState(*cert, clientCerts, nil, false, nil)
 		if trusted {
 			return true
 		}
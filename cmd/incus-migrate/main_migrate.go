@@ -19,6 +19,7 @@ import (
 
 	"github.com/lxc/incus/client"
 	cli "github.com/lxc/incus/internal/cmd"
+	internalInstance "github.com/lxc/incus/internal/instance"
 	"github.com/lxc/incus/internal/revert"
 	"github.com/lxc/incus/internal/version"
 	"github.com/lxc/incus/shared/api"
@@ -300,6 +301,12 @@ func (c *cmdMigrate) RunInteractive(server incus.InstanceServer) (cmdMigrateData
 			return cmdMigrateData{}, err
 		}
 
+		err = internalInstance.ValidName(instanceName)
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			continue
+		}
+
 		if util.ValueInSlice(instanceName, instanceNames) {
 			fmt.Printf("Instance %q already exists\n", instanceName)
 			continue
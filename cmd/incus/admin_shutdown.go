@@ -20,6 +20,7 @@ type cmdAdminShutdown struct {
 	global *cmdGlobal
 
 	flagForce   bool
+	flagRestart bool
 	flagTimeout int
 }
 
@@ -33,10 +34,14 @@ func (c *cmdAdminShutdown) Command() *cobra.Command {
   followed by having itself shutdown and exit.
 
   This can take quite a while as instances can take a long time to
-  shutdown, especially if a non-standard timeout was configured for them.`))
+  shutdown, especially if a non-standard timeout was configured for them.
+
+  With --restart, the instances are left running so that the daemon
+  can be restarted without disrupting them.`))
 	cmd.RunE = c.Run
 	cmd.Flags().IntVarP(&c.flagTimeout, "timeout", "t", 0, "Number of seconds to wait before giving up"+"``")
 	cmd.Flags().BoolVarP(&c.flagForce, "force", "f", false, "Force shutdown instead of waiting for running operations to finish"+"``")
+	cmd.Flags().BoolVar(&c.flagRestart, "restart", false, "Leave instances running as the daemon is being restarted"+"``")
 
 	return cmd
 }
@@ -53,6 +58,7 @@ func (c *cmdAdminShutdown) Run(cmd *cobra.Command, args []string) error {
 
 	v := url.Values{}
 	v.Set("force", strconv.FormatBool(c.flagForce))
+	v.Set("restart", strconv.FormatBool(c.flagRestart))
 
 	chResult := make(chan error, 1)
 	go func() {
@@ -7,6 +7,7 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	yaml "gopkg.in/yaml.v2"
@@ -85,6 +86,10 @@ func (c *cmdCluster) Command() *cobra.Command {
 	cmdClusterUpdateCertificate := cmdClusterUpdateCertificate{global: c.global, cluster: c}
 	cmd.AddCommand(cmdClusterUpdateCertificate.Command())
 
+	// Rotate certificate
+	cmdClusterRotateCertificate := cmdClusterRotateCertificate{global: c.global, cluster: c}
+	cmd.AddCommand(cmdClusterRotateCertificate.Command())
+
 	// Evacuate cluster member
 	cmdClusterEvacuate := cmdClusterEvacuate{global: c.global, cluster: c}
 	cmd.AddCommand(cmdClusterEvacuate.Command())
@@ -793,6 +798,9 @@ func (c *cmdClusterEdit) Run(cmd *cobra.Command, args []string) error {
 type cmdClusterAdd struct {
 	global  *cmdGlobal
 	cluster *cmdCluster
+
+	flagExpiry   string
+	flagReusable bool
 }
 
 func (c *cmdClusterAdd) Command() *cobra.Command {
@@ -800,6 +808,8 @@ func (c *cmdClusterAdd) Command() *cobra.Command {
 	cmd.Use = usage("add", i18n.G("[[<remote>:]<name>]"))
 	cmd.Short = i18n.G("Request a join token for adding a cluster member")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(`Request a join token for adding a cluster member`))
+	cmd.Flags().StringVar(&c.flagExpiry, "expiry", "", i18n.G("How long the token is valid for (e.g. 30M or 1d)")+"``")
+	cmd.Flags().BoolVar(&c.flagReusable, "reusable", false, i18n.G("Allow the token to be used multiple times until it expires"))
 
 	cmd.RunE = c.Run
 
@@ -829,6 +839,8 @@ func (c *cmdClusterAdd) Run(cmd *cobra.Command, args []string) error {
 	// Request the join token.
 	member := api.ClusterMembersPost{
 		ServerName: resource.name,
+		Expiry:     c.flagExpiry,
+		Reusable:   c.flagReusable,
 	}
 
 	op, err := resource.server.CreateClusterMember(member)
@@ -912,6 +924,7 @@ func (c *cmdClusterListTokens) Run(cmd *cobra.Command, args []string) error {
 		ServerName string
 		Token      string
 		ExpiresAt  string
+		ExpiresIn  string
 	}
 
 	displayTokens := make([]displayToken, 0)
@@ -930,17 +943,23 @@ func (c *cmdClusterListTokens) Run(cmd *cobra.Command, args []string) error {
 			continue // Operation is not a valid cluster member join token operation.
 		}
 
+		expiresIn := ""
+		if !joinToken.ExpiresAt.IsZero() {
+			expiresIn = time.Until(joinToken.ExpiresAt).Round(time.Second).String()
+		}
+
 		displayTokens = append(displayTokens, displayToken{
 			ServerName: joinToken.ServerName,
 			Token:      joinToken.String(),
 			ExpiresAt:  joinToken.ExpiresAt.Format("2006/01/02 15:04 MST"),
+			ExpiresIn:  expiresIn,
 		})
 	}
 
 	// Render the table.
 	data := [][]string{}
 	for _, token := range displayTokens {
-		line := []string{token.ServerName, token.Token, token.ExpiresAt}
+		line := []string{token.ServerName, token.Token, token.ExpiresAt, token.ExpiresIn}
 		data = append(data, line)
 	}
 
@@ -950,6 +969,7 @@ func (c *cmdClusterListTokens) Run(cmd *cobra.Command, args []string) error {
 		i18n.G("NAME"),
 		i18n.G("TOKEN"),
 		i18n.G("EXPIRES AT"),
+		i18n.G("EXPIRES IN"),
 	}
 
 	return cli.RenderTable(c.flagFormat, header, data, displayTokens)
@@ -1129,6 +1149,75 @@ func (c *cmdClusterUpdateCertificate) Run(cmd *cobra.Command, args []string) err
 	return nil
 }
 
+// Rotate Certificates.
+type cmdClusterRotateCertificate struct {
+	global  *cmdGlobal
+	cluster *cmdCluster
+}
+
+func (c *cmdClusterRotateCertificate) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("rotate-certificate", i18n.G("[<remote>:]"))
+	cmd.Aliases = []string{"rotate-cert"}
+	cmd.Short = i18n.G("Rotate cluster certificate")
+	cmd.Long = cli.FormatSection(i18n.G("Description"),
+		i18n.G("Replace the cluster certificate with a newly generated one on all cluster members."))
+
+	cmd.RunE = c.Run
+	return cmd
+}
+
+func (c *cmdClusterRotateCertificate) Run(cmd *cobra.Command, args []string) error {
+	conf := c.global.conf
+
+	exit, err := c.global.CheckArgs(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	remote := ""
+	if len(args) == 1 {
+		remote = args[0]
+	}
+
+	resources, err := c.global.ParseServers(remote)
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	// Check if clustered.
+	cluster, _, err := resource.server.GetCluster()
+	if err != nil {
+		return err
+	}
+
+	if !cluster.Enabled {
+		return fmt.Errorf(i18n.G("Server isn't part of a cluster"))
+	}
+
+	cert, err := resource.server.RotateClusterCertificate()
+	if err != nil {
+		return err
+	}
+
+	certf := conf.ServerCertPath(resource.remote)
+	if util.PathExists(certf) {
+		err = os.WriteFile(certf, []byte(cert.ClusterCertificate), 0644)
+		if err != nil {
+			return fmt.Errorf(i18n.G("Could not write new remote certificate for remote '%s' with error: %v"), resource.remote, err)
+		}
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Successfully rotated cluster certificates for remote %s")+"\n", resource.remote)
+	}
+
+	return nil
+}
+
 type cmdClusterEvacuateAction struct {
 	global *cmdGlobal
 
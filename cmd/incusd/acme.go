@@ -2,8 +2,11 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 
 	"github.com/gorilla/mux"
 
@@ -120,32 +123,46 @@ func autoRenewCertificate(ctx context.Context, d *Daemon, force bool) error {
 			return nil
 		}
 
-		if clustered {
-			req := api.ClusterCertificatePut{
-				ClusterCertificate:    string(newCert.Certificate),
-				ClusterCertificateKey: string(newCert.PrivateKey),
-			}
+		hook, hookFailure := s.GlobalConfig.ACMEHook()
 
-			err = updateClusterCertificate(s.ShutdownCtx, s, d.gateway, nil, req)
-			if err != nil {
-				return err
-			}
+		// Keep the certificate in use so that it can be restored if the post-renewal hook fails.
+		oldCertPrefix := "server"
+		if clustered {
+			oldCertPrefix = "cluster"
+		}
 
-			return nil
+		oldCert, err := os.ReadFile(filepath.Join(s.OS.VarDir, oldCertPrefix+".crt"))
+		if err != nil {
+			return err
 		}
 
-		cert, err := localtls.KeyPairFromRaw(newCert.Certificate, newCert.PrivateKey)
+		oldKey, err := os.ReadFile(filepath.Join(s.OS.VarDir, oldCertPrefix+".key"))
 		if err != nil {
 			return err
 		}
 
-		s.Endpoints.NetworkUpdateCert(cert)
+		err = acmeRunHook(ctx, hook, acme.HookStagePre, newCert.Certificate)
+		if err != nil && hookFailure != acme.HookFailureIgnore {
+			return fmt.Errorf("Deferring certificate renewal: %w", err)
+		}
 
-		err = util.WriteCert(s.OS.VarDir, "server", newCert.Certificate, newCert.PrivateKey, nil)
+		err = acmeApplyCertificate(d, clustered, newCert.Certificate, newCert.PrivateKey)
 		if err != nil {
 			return err
 		}
 
+		err = acmeRunHook(ctx, hook, acme.HookStagePost, newCert.Certificate)
+		if err != nil && hookFailure == acme.HookFailureRollback {
+			logger.Warn("Restoring previous server certificate", logger.Ctx{"err": err})
+
+			revertErr := acmeApplyCertificate(d, clustered, oldCert, oldKey)
+			if revertErr != nil {
+				return fmt.Errorf("Failed restoring previous certificate: %w", revertErr)
+			}
+
+			return fmt.Errorf("Rolled back certificate renewal: %w", err)
+		}
+
 		return nil
 	}
 
@@ -174,6 +191,48 @@ func autoRenewCertificate(ctx context.Context, d *Daemon, force bool) error {
 	return nil
 }
 
+// acmeApplyCertificate replaces the certificate in use by the server (or all cluster members).
+func acmeApplyCertificate(d *Daemon, clustered bool, cert []byte, key []byte) error {
+	s := d.State()
+
+	if clustered {
+		req := api.ClusterCertificatePut{
+			ClusterCertificate:    string(cert),
+			ClusterCertificateKey: string(key),
+		}
+
+		return updateClusterCertificate(s.ShutdownCtx, s, d.gateway, nil, req)
+	}
+
+	certInfo, err := localtls.KeyPairFromRaw(cert, key)
+	if err != nil {
+		return err
+	}
+
+	s.Endpoints.NetworkUpdateCert(certInfo)
+
+	return util.WriteCert(s.OS.VarDir, "server", cert, key, nil)
+}
+
+// acmeRunHook runs the certificate renewal hook (if any) for the given stage and logs its result.
+func acmeRunHook(ctx context.Context, hook string, stage string, cert []byte) error {
+	if hook == "" {
+		return nil
+	}
+
+	l := logger.AddContext(logger.Ctx{"hook": hook, "stage": stage})
+
+	err := acme.RunHook(ctx, hook, stage, cert)
+	if err != nil {
+		l.Error("Certificate renewal hook failed", logger.Ctx{"err": err})
+		return fmt.Errorf("Certificate renewal hook failed at %s stage: %w", stage, err)
+	}
+
+	l.Info("Certificate renewal hook succeeded")
+
+	return nil
+}
+
 func autoRenewCertificateTask(d *Daemon) (task.Func, task.Schedule) {
 	f := func(ctx context.Context) {
 		_ = autoRenewCertificate(ctx, d, false)
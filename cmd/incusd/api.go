@@ -214,7 +214,10 @@ func metricsServer(d *Daemon) *http.Server {
 		_ = response.NotFound(nil).Render(w)
 	})
 
-	return &http.Server{Handler: &httpServer{r: mux, d: d}}
+	return &http.Server{
+		Handler:     &httpServer{r: mux, d: d},
+		ConnContext: request.SaveConnectionInContext,
+	}
 }
 
 func storageBucketsServer(d *Daemon) *http.Server {
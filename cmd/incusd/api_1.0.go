@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"sync"
 
 	"github.com/lxc/incus/client"
 	"github.com/lxc/incus/internal/revert"
@@ -15,19 +16,26 @@ import (
 	clusterConfig "github.com/lxc/incus/internal/server/cluster/config"
 	"github.com/lxc/incus/internal/server/config"
 	"github.com/lxc/incus/internal/server/db"
+	"github.com/lxc/incus/internal/server/db/operationtype"
+	"github.com/lxc/incus/internal/server/db/query"
+	"github.com/lxc/incus/internal/server/events"
 	instanceDrivers "github.com/lxc/incus/internal/server/instance/drivers"
+	"github.com/lxc/incus/internal/server/instance/instancetype"
 	"github.com/lxc/incus/internal/server/lifecycle"
 	"github.com/lxc/incus/internal/server/node"
+	"github.com/lxc/incus/internal/server/operations"
 	"github.com/lxc/incus/internal/server/project"
 	"github.com/lxc/incus/internal/server/request"
 	"github.com/lxc/incus/internal/server/response"
 	scriptletLoad "github.com/lxc/incus/internal/server/scriptlet/load"
+	"github.com/lxc/incus/internal/server/state"
 	localUtil "github.com/lxc/incus/internal/server/util"
 	"github.com/lxc/incus/internal/version"
 	"github.com/lxc/incus/shared/api"
 	"github.com/lxc/incus/shared/logger"
 	"github.com/lxc/incus/shared/osarch"
 	localtls "github.com/lxc/incus/shared/tls"
+	"github.com/lxc/incus/shared/util"
 )
 
 var api10Cmd = APIEndpoint{
@@ -36,23 +44,41 @@ var api10Cmd = APIEndpoint{
 	Put:   APIEndpointAction{Handler: api10Put},
 }
 
+var api10ReadyCmd = APIEndpoint{
+	Path: "ready",
+
+	Get: APIEndpointAction{Handler: api10ReadyGet, AllowUntrusted: true},
+}
+
 var api10 = []APIEndpoint{
 	api10Cmd,
+	api10ReadyCmd,
 	api10ResourcesCmd,
+	api10ResourcesAssignmentsCmd,
+	api10ResourcesFeaturesCmd,
+	api10ResourcesUsageCmd,
+	certificatesImportCmd,     // Must come before certificateCmd to not be matched as a fingerprint.
+	certificatesTrustStoreCmd, // Must come before certificateCmd to not be matched as a fingerprint.
 	certificateCmd,
 	certificatesCmd,
 	clusterCmd,
+	clusterFeaturesCmd,
+	clusterHeartbeatCmd,
 	clusterGroupCmd,
 	clusterGroupsCmd,
 	clusterNodeCmd,
+	clusterNodeForceRemoveCmd,
 	clusterNodeStateCmd,
 	clusterNodesCmd,
 	clusterCertificateCmd,
+	connectionCmd,
+	connectionsCmd,
 	instanceBackupCmd,
 	instanceBackupExportCmd,
 	instanceBackupsCmd,
 	instanceCmd,
 	instanceConsoleCmd,
+	instanceDeviceCmd,
 	instanceExecCmd,
 	instanceFileCmd,
 	instanceExecOutputCmd,
@@ -62,14 +88,17 @@ var api10 = []APIEndpoint{
 	instanceMetadataCmd,
 	instanceMetadataTemplatesCmd,
 	instancesCmd,
+	instanceProfilesCmd,
 	instanceRebuildCmd,
 	instanceSFTPCmd,
 	instanceSnapshotCmd,
 	instanceSnapshotsCmd,
 	instanceStateCmd,
 	eventsCmd,
+	eventsLoggingCmd,
 	imageAliasCmd,
 	imageAliasesCmd,
+	imagesStreamCmd, // Must come before imageCmd to not be matched as a fingerprint.
 	imageCmd,
 	imageExportCmd,
 	imageRefreshCmd,
@@ -94,6 +123,7 @@ var api10 = []APIEndpoint{
 	networkZonesCmd,
 	networkZoneRecordCmd,
 	networkZoneRecordsCmd,
+	operationsHistoryCmd, // Must be registered before operationCmd.
 	operationCmd,
 	operationsCmd,
 	operationWait,
@@ -102,7 +132,14 @@ var api10 = []APIEndpoint{
 	profilesCmd,
 	projectCmd,
 	projectsCmd,
+	projectsBulkCmd,
 	projectStateCmd,
+	projectDependenciesCmd,
+	projectDNSRecordsCmd,
+	projectMoveCmd,
+	projectStorageVolumeAttributionCmd,
+	serverConfigCmd,
+	serverConfigSchemaCmd,
 	storagePoolCmd,
 	storagePoolResourcesCmd,
 	storagePoolsCmd,
@@ -316,6 +353,8 @@ func api10Get(d *Daemon, r *http.Request) response.Response {
 		"idmapped_mounts":           fmt.Sprintf("%v", s.OS.IdmappedMounts),
 	}
 
+	env.APIExtensionsAvailability = api10ExtensionsAvailability(s)
+
 	drivers := instanceDrivers.DriverStatuses()
 	for _, driver := range drivers {
 		// Only report the supported drivers.
@@ -368,6 +407,18 @@ func api10Get(d *Daemon, r *http.Request) response.Response {
 	fullSrv.AuthUserName = requestor.Username
 	fullSrv.AuthUserMethod = requestor.Protocol
 
+	message, severity, expiry, _ := s.GlobalConfig.Message()
+	if message != "" {
+		fullSrv.Message = &api.ServerMessage{
+			Message:  message,
+			Severity: severity,
+		}
+
+		if !expiry.IsZero() {
+			fullSrv.Message.ExpiresAt = &expiry
+		}
+	}
+
 	if s.Authorizer.UserIsAdmin(r) {
 		fullSrv.Config, err = daemonConfigRender(s)
 		if err != nil {
@@ -378,11 +429,37 @@ func api10Get(d *Daemon, r *http.Request) response.Response {
 	return response.SyncResponseETag(true, fullSrv, fullSrv.Config)
 }
 
+// api10ExtensionsAvailability returns whether the API extensions which depend on the host are usable on this server.
+func api10ExtensionsAvailability(s *state.State) map[string]bool {
+	err, found := s.InstanceTypes[instancetype.VM]
+	vmSupported := found && err == nil
+
+	seccompNotify := s.OS.SeccompListener && s.OS.LXCFeatures["seccomp_notify"]
+	seccompNotifyContinue := seccompNotify && s.OS.SeccompListenerContinue
+
+	return map[string]bool{
+		"virtual-machines":                               vmSupported,
+		"agent_nic_config":                               vmSupported,
+		"vsock_api":                                      vmSupported,
+		"container_syscall_intercept":                    seccompNotify,
+		"container_syscall_intercept_bpf_devices":        seccompNotify,
+		"container_syscall_intercept_hugetlbfs":          seccompNotifyContinue,
+		"container_syscall_intercept_mount":              seccompNotifyContinue,
+		"container_syscall_intercept_mount_fuse":         seccompNotifyContinue,
+		"container_syscall_intercept_sched_setscheduler": seccompNotify,
+		"container_syscall_intercept_sysinfo":            seccompNotify,
+		"syscall_intercept_audit":                        seccompNotify,
+		"syscall_intercept_profiles":                     seccompNotify,
+		"seccomp_notify":                                 seccompNotify,
+	}
+}
+
 // swagger:operation PUT /1.0 server server_put
 //
 //	Update the server configuration
 //
 //	Updates the entire server configuration.
+//	When requested, slow reloads (like moving the images or backups storage) are run in a background operation which is returned.
 //
 //	---
 //	consumes:
@@ -395,6 +472,11 @@ func api10Get(d *Daemon, r *http.Request) response.Response {
 //	    description: Cluster member name
 //	    type: string
 //	    example: server01
+//	  - in: query
+//	    name: async
+//	    description: Whether to run slow reloads in a background operation
+//	    type: boolean
+//	    example: true
 //	  - in: body
 //	    name: server
 //	    description: Server configuration
@@ -404,6 +486,8 @@ func api10Get(d *Daemon, r *http.Request) response.Response {
 //	responses:
 //	  "200":
 //	    $ref: "#/responses/EmptySyncResponse"
+//	  "202":
+//	    $ref: "#/responses/Operation"
 //	  "400":
 //	    $ref: "#/responses/BadRequest"
 //	  "403":
@@ -477,11 +561,39 @@ func api10Put(d *Daemon, r *http.Request) response.Response {
 	return doApi10Update(d, r, req, false)
 }
 
+// swagger:operation GET /1.0/ready server server_ready_get
+//
+//	Check whether the server is ready
+//
+//	Returns a 200 status code once the server is fully initialized and
+//	a 503 status code while it's still starting up or shutting down.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "503":
+//	    description: Server not ready
+func api10ReadyGet(d *Daemon, r *http.Request) response.Response {
+	if d.shutdownCtx.Err() != nil {
+		return response.Unavailable(fmt.Errorf("Daemon is shutting down"))
+	}
+
+	if d.waitReady.Err() == nil {
+		return response.Unavailable(api.StatusReasonErrorf(http.StatusServiceUnavailable, api.ErrorReasonSetupInProgress, "Daemon not ready yet"))
+	}
+
+	return response.EmptySyncResponse
+}
+
 // swagger:operation PATCH /1.0 server server_patch
 //
 //	Partially update the server configuration
 //
 //	Updates a subset of the server configuration.
+//	When requested, slow reloads (like moving the images or backups storage) are run in a background operation which is returned.
 //
 //	---
 //	consumes:
@@ -494,6 +606,11 @@ func api10Put(d *Daemon, r *http.Request) response.Response {
 //	    description: Cluster member name
 //	    type: string
 //	    example: server01
+//	  - in: query
+//	    name: async
+//	    description: Whether to run slow reloads in a background operation
+//	    type: boolean
+//	    example: true
 //	  - in: body
 //	    name: server
 //	    description: Server configuration
@@ -503,6 +620,8 @@ func api10Put(d *Daemon, r *http.Request) response.Response {
 //	responses:
 //	  "200":
 //	    $ref: "#/responses/EmptySyncResponse"
+//	  "202":
+//	    $ref: "#/responses/Operation"
 //	  "400":
 //	    $ref: "#/responses/BadRequest"
 //	  "403":
@@ -549,6 +668,22 @@ func api10Patch(d *Daemon, r *http.Request) response.Response {
 func doApi10Update(d *Daemon, r *http.Request, req api.ServerPut, patch bool) response.Response {
 	s := d.State()
 
+	// Keep the current value of the hidden keys when sent back as rendered.
+	if patch {
+		for _, key := range daemonConfigHiddenKeys {
+			if req.Config[key] == daemonConfigHiddenValue {
+				delete(req.Config, key)
+			}
+		}
+	} else {
+		currentConfig := s.GlobalConfig.Dump()
+		for key, value := range s.LocalConfig.Dump() {
+			currentConfig[key] = value
+		}
+
+		daemonConfigUnhide(req.Config, currentConfig)
+	}
+
 	// First deal with config specific to the local daemon
 	nodeValues := map[string]string{}
 
@@ -635,23 +770,15 @@ func doApi10Update(d *Daemon, r *http.Request, req api.ServerPut, patch bool) re
 	revert := revert.New()
 	defer revert.Fail()
 
+	// Restore the whole previous config as a replace may also have cleared keys which weren't part of the request.
 	revert.Add(func() {
-		for key := range nodeValues {
-			val, ok := oldNodeConfig[key]
-			if !ok {
-				nodeValues[key] = ""
-			} else {
-				nodeValues[key] = val
-			}
-		}
-
 		err = s.DB.Node.Transaction(r.Context(), func(ctx context.Context, tx *db.NodeTx) error {
 			newNodeConfig, err := node.ConfigLoad(ctx, tx)
 			if err != nil {
 				return fmt.Errorf("Failed to load node config: %w", err)
 			}
 
-			_, err = newNodeConfig.Replace(nodeValues)
+			_, err = newNodeConfig.Replace(oldNodeConfig)
 			if err != nil {
 				return fmt.Errorf("Failed updating node config: %w", err)
 			}
@@ -699,22 +826,13 @@ func doApi10Update(d *Daemon, r *http.Request, req api.ServerPut, patch bool) re
 	}
 
 	revert.Add(func() {
-		for key := range req.Config {
-			val, ok := oldClusterConfig[key]
-			if !ok {
-				req.Config[key] = ""
-			} else {
-				req.Config[key] = val
-			}
-		}
-
 		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 			newClusterConfig, err = clusterConfig.Load(ctx, tx)
 			if err != nil {
 				return fmt.Errorf("Failed to load cluster config: %w", err)
 			}
 
-			_, err = newClusterConfig.Replace(req.Config)
+			_, err = newClusterConfig.Replace(oldClusterConfig)
 			if err != nil {
 				return fmt.Errorf("Failed updating cluster config: %w", err)
 			}
@@ -765,6 +883,27 @@ func doApi10Update(d *Daemon, r *http.Request, req api.ServerPut, patch bool) re
 		return response.SmartError(err)
 	}
 
+	// Run the slow reloads, in a background operation if the client asked for it.
+	reloads := api10UpdateReloads(nodeChanged, clusterChanged)
+	if len(reloads) > 0 && util.IsTrue(queryParam(r, "async")) {
+		reloadReverter := revert.Clone()
+		revert.Success()
+
+		return doApi10UpdateReloads(s, r, reloads, reloadReverter)
+	}
+
+	for _, reload := range api10Reloads {
+		value, ok := reloads[reload.key]
+		if !ok {
+			continue
+		}
+
+		err = reload.run(s.ShutdownCtx, s, value)
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed reloading %q: %w", reload.key, err))
+		}
+	}
+
 	revert.Success()
 
 	s.Events.SendLifecycle(project.Default, lifecycle.ConfigUpdated.Event(request.CreateRequestor(r), nil))
@@ -772,15 +911,135 @@ func doApi10Update(d *Daemon, r *http.Request, req api.ServerPut, patch bool) re
 	return response.EmptySyncResponse
 }
 
+// api10Reload is a configuration reload which may take a while.
+type api10Reload struct {
+	key         string
+	description string
+	cancellable bool
+	run         func(ctx context.Context, s *state.State, value string) error
+}
+
+// api10Reloads lists the configuration reloads which can be run in a background operation rather than
+// while handling the request, in the order they're run in.
+var api10Reloads = []api10Reload{
+	{
+		key:         "storage.backups_volume",
+		description: "Moving backups storage",
+		run: func(ctx context.Context, s *state.State, value string) error {
+			return daemonStorageMove(s, "backups", value)
+		},
+	},
+	{
+		key:         "storage.images_volume",
+		description: "Moving images storage",
+		run: func(ctx context.Context, s *state.State, value string) error {
+			return daemonStorageMove(s, "images", value)
+		},
+	},
+	{
+		key:         "cluster.images_minimal_replica",
+		description: "Synchronizing images",
+		cancellable: true,
+		run: func(ctx context.Context, s *state.State, value string) error {
+			err := autoSyncImages(ctx, s, nil)
+			if err != nil {
+				logger.Warn("Could not auto-sync images", logger.Ctx{"err": err})
+			}
+
+			return nil
+		},
+	},
+}
+
+// api10UpdateReloads returns the slow reloads needed by the changed configuration keys along with their new values.
+func api10UpdateReloads(nodeChanged map[string]string, clusterChanged map[string]string) map[string]string {
+	reloads := map[string]string{}
+	for _, reload := range api10Reloads {
+		value, ok := nodeChanged[reload.key]
+		if !ok {
+			value, ok = clusterChanged[reload.key]
+		}
+
+		if ok {
+			reloads[reload.key] = value
+		}
+	}
+
+	return reloads
+}
+
+// doApi10UpdateReloads runs the given slow reloads in an operation, reverting the configuration if they fail.
+func doApi10UpdateReloads(s *state.State, r *http.Request, reloads map[string]string, reverter *revert.Reverter) response.Response {
+	var stepLock sync.Mutex
+	var step api10Reload
+
+	run := func(op *operations.Operation) error {
+		defer reverter.Fail()
+
+		done := 0
+		for _, reload := range api10Reloads {
+			value, ok := reloads[reload.key]
+			if !ok {
+				continue
+			}
+
+			stepLock.Lock()
+			step = reload
+			stepLock.Unlock()
+
+			done++
+			_ = op.UpdateMetadata(map[string]any{"reload_progress": fmt.Sprintf("%s (%d/%d)", reload.description, done, len(reloads))})
+
+			err := reload.run(op.Context(), s, value)
+			if err != nil {
+				return fmt.Errorf("Failed reloading %q: %w", reload.key, err)
+			}
+
+			// Stop there if the operation got cancelled.
+			if op.Context().Err() != nil {
+				return op.Context().Err()
+			}
+		}
+
+		reverter.Success()
+
+		s.Events.SendLifecycle(project.Default, lifecycle.ConfigUpdated.Event(op.Requestor(), nil))
+
+		return nil
+	}
+
+	onCancel := func(op *operations.Operation) error {
+		stepLock.Lock()
+		defer stepLock.Unlock()
+
+		if !step.cancellable {
+			return fmt.Errorf("This reload step can't be cancelled")
+		}
+
+		return nil
+	}
+
+	op, err := operations.OperationCreate(s, "", operations.OperationClassTask, operationtype.ServerConfigReload, nil, nil, run, onCancel, nil, r)
+	if err != nil {
+		reverter.Fail()
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
+
 func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]string, nodeConfig *node.Config, clusterConfig *clusterConfig.Config) error {
 	s := d.State()
 
 	bgpChanged := false
 	dnsChanged := false
 	lokiChanged := false
+	auditLogChanged := false
+	lifecycleHookChanged := false
 	acmeDomainChanged := false
 	acmeCAURLChanged := false
 	oidcChanged := false
+	openfgaChanged := false
 	syslogSocketChanged := false
 
 	for key := range clusterChanged {
@@ -793,15 +1052,13 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 			fallthrough
 		case "core.proxy_ignore_hosts":
 			daemonConfigSetProxy(d, clusterConfig)
-		case "cluster.images_minimal_replica":
-			err := autoSyncImages(s.ShutdownCtx, s)
-			if err != nil {
-				logger.Warn("Could not auto-sync images", logger.Ctx{"err": err})
-			}
-
-		case "cluster.offline_threshold":
+		case "cluster.offline_threshold", "cluster.heartbeat_interval", "cluster.heartbeat_profile":
 			d.gateway.HeartbeatOfflineThreshold = clusterConfig.OfflineThreshold()
+			d.gateway.HeartbeatInterval = clusterConfig.HeartbeatInterval()
+			d.gateway.HeartbeatPowerSave = clusterConfig.HeartbeatPowerSave()
 			d.taskClusterHeartbeat.Reset()
+		case "cluster.member_state_delay":
+			d.gateway.HeartbeatStateDelay = clusterConfig.MemberStateDelay()
 		case "images.auto_update_interval":
 			fallthrough
 		case "images.remote_cache_expiry":
@@ -811,6 +1068,17 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 
 		case "core.bgp_asn":
 			bgpChanged = true
+		case "core.database_retry.attempts", "core.database_retry.backoff":
+			attempts, backoff := clusterConfig.DatabaseRetry()
+			query.SetRetryPolicy(int(attempts), backoff)
+		case "core.database_slow_threshold":
+			db.SetSlowTransactionThreshold(clusterConfig.DatabaseSlowThreshold())
+		case "core.log_sampling_every", "core.log_sampling_limit":
+			events.LoggingSampler.Configure(clusterConfig.LogSampling())
+		case "cluster.local_event_types":
+			d.events.SetLocalOnlyTypes(clusterConfig.LocalEventTypes())
+		case "core.max_concurrent_operations":
+			operations.SetTasksLimit(int(clusterConfig.MaxConcurrentOperations()))
 		case "loki.api.url":
 			fallthrough
 		case "loki.auth.username":
@@ -825,12 +1093,31 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 			fallthrough
 		case "loki.types":
 			lokiChanged = true
+		case "audit.log.format":
+			fallthrough
+		case "audit.log.rotation.interval":
+			fallthrough
+		case "audit.log.rotation.max_files":
+			fallthrough
+		case "audit.log.rotation.size":
+			auditLogChanged = true
+		case "instances.hook":
+			fallthrough
+		case "instances.hook.actions":
+			fallthrough
+		case "instances.hook.timeout":
+			lifecycleHookChanged = true
 		case "acme.ca_url":
 			acmeCAURLChanged = true
 		case "acme.domain":
 			acmeDomainChanged = true
 		case "oidc.issuer", "oidc.client.id", "oidc.audience":
 			oidcChanged = true
+		case "openfga.api.url", "openfga.api.token", "openfga.store.id", "openfga.store.model_id", "openfga.cache.ttl", "openfga.fail_closed", "openfga.tls_restrictions":
+			openfgaChanged = true
+		case "core.trust_ca_revocation", "core.trust_ca_revocation.fail_closed", "core.trust_ca_revocation.cache_ttl":
+			// Don't keep statuses obtained with the previous settings.
+			d.revocationChecker.Flush()
 		}
 	}
 
@@ -844,6 +1131,8 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 			dnsChanged = true
 		case "core.syslog_socket":
 			syslogSocketChanged = true
+		case "audit.log.path":
+			auditLogChanged = true
 		}
 	}
 
@@ -880,33 +1169,28 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 		}
 	}
 
-	value, ok = nodeChanged["core.metrics_address"]
-	if ok {
-		err := s.Endpoints.MetricsUpdateAddress(value, s.Endpoints.NetworkCert())
+	_, certChanged := nodeChanged["core.metrics_certificate"]
+	_, keyChanged := nodeChanged["core.metrics_key"]
+	if certChanged || keyChanged {
+		metricsCert, err := metricsCertificate(nodeConfig)
 		if err != nil {
 			return err
 		}
-	}
 
-	value, ok = nodeChanged["core.storage_buckets_address"]
-	if ok {
-		err := s.Endpoints.StorageBucketsUpdateAddress(value, s.Endpoints.NetworkCert())
-		if err != nil {
-			return err
-		}
+		s.Endpoints.MetricsUpdateCert(metricsCert)
 	}
 
-	value, ok = nodeChanged["storage.backups_volume"]
+	value, ok = nodeChanged["core.metrics_address"]
 	if ok {
-		err := daemonStorageMove(s, "backups", value)
+		err := s.Endpoints.MetricsUpdateAddress(value, s.Endpoints.MetricsCert())
 		if err != nil {
 			return err
 		}
 	}
 
-	value, ok = nodeChanged["storage.images_volume"]
+	value, ok = nodeChanged["core.storage_buckets_address"]
 	if ok {
-		err := daemonStorageMove(s, "images", value)
+		err := s.Endpoints.StorageBucketsUpdateAddress(value, s.Endpoints.NetworkCert())
 		if err != nil {
 			return err
 		}
@@ -945,6 +1229,19 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 		}
 	}
 
+	if auditLogChanged {
+		auditLogFormat, auditLogRotateSize, auditLogRotateInterval, auditLogMaxFiles := clusterConfig.AuditLog()
+
+		err := d.setupAuditLog(nodeConfig.AuditLogPath(), auditLogFormat, auditLogRotateSize, auditLogRotateInterval, auditLogMaxFiles)
+		if err != nil {
+			return fmt.Errorf("Failed setting up the audit log: %w", err)
+		}
+	}
+
+	if lifecycleHookChanged {
+		d.setupLifecycleHook(clusterConfig.InstancesHook())
+	}
+
 	if acmeCAURLChanged || acmeDomainChanged {
 		err := autoRenewCertificate(s.ShutdownCtx, d, acmeCAURLChanged)
 		if err != nil {
@@ -971,6 +1268,13 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 		}
 	}
 
+	if openfgaChanged {
+		err := d.setupOpenFGA(clusterConfig.OpenFGA())
+		if err != nil {
+			return err
+		}
+	}
+
 	if syslogSocketChanged {
 		err := d.setupSyslogSocket(nodeConfig.SyslogSocket())
 		if err != nil {
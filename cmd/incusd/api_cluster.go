@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
 	"errors"
@@ -10,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -102,10 +104,29 @@ var clusterNodeStateCmd = APIEndpoint{
 	Post: APIEndpointAction{Handler: clusterNodeStatePost},
 }
 
+var clusterNodeForceRemoveCmd = APIEndpoint{
+	Path: "cluster/members/{name}/force-remove",
+
+	Post: APIEndpointAction{Handler: clusterNodeForceRemovePost},
+}
+
+var clusterHeartbeatCmd = APIEndpoint{
+	Path: "cluster/heartbeat",
+
+	Get: APIEndpointAction{Handler: clusterHeartbeatGet, AccessHandler: allowAuthenticated},
+}
+
+var clusterFeaturesCmd = APIEndpoint{
+	Path: "cluster/features",
+
+	Get: APIEndpointAction{Handler: clusterFeaturesGet, AccessHandler: allowAuthenticated},
+}
+
 var clusterCertificateCmd = APIEndpoint{
 	Path: "cluster/certificate",
 
-	Put: APIEndpointAction{Handler: clusterCertificatePut},
+	Post: APIEndpointAction{Handler: clusterCertificatePost},
+	Put:  APIEndpointAction{Handler: clusterCertificatePut},
 }
 
 var clusterGroupsCmd = APIEndpoint{
@@ -131,6 +152,18 @@ var internalClusterAcceptCmd = APIEndpoint{
 	Post: APIEndpointAction{Handler: internalClusterPostAccept},
 }
 
+var internalClusterAcceptValidateCmd = APIEndpoint{
+	Path: "cluster/accept/validate",
+
+	Post: APIEndpointAction{Handler: internalClusterPostAcceptValidate},
+}
+
+var internalClusterCertificatePendingCmd = APIEndpoint{
+	Path: "cluster/certificate/pending",
+
+	Put: APIEndpointAction{Handler: internalClusterCertificatePendingPut},
+}
+
 var internalClusterRebalanceCmd = APIEndpoint{
 	Path: "cluster/rebalance",
 
@@ -161,6 +194,12 @@ var internalClusterHealCmd = APIEndpoint{
 	Post: APIEndpointAction{Handler: internalClusterHeal},
 }
 
+var internalClusterStepDownCmd = APIEndpoint{
+	Path: "cluster/step-down",
+
+	Post: APIEndpointAction{Handler: internalClusterPostStepDown},
+}
+
 // swagger:operation GET /1.0/cluster cluster cluster_get
 //
 //	Get the cluster configuration
@@ -216,6 +255,14 @@ func clusterGet(d *Daemon, r *http.Request) response.Response {
 		MemberConfig: memberConfig,
 	}
 
+	if cluster.Enabled {
+		threshold, trailing := d.gateway.SnapshotParams()
+		cluster.RaftSnapshot = &api.ClusterRaftSnapshot{
+			Threshold: threshold,
+			Trailing:  trailing,
+		}
+	}
+
 	return response.SyncResponseETag(true, cluster, cluster)
 }
 
@@ -437,7 +484,9 @@ func clusterPutBootstrap(d *Daemon, r *http.Request, req api.ClusterPut) respons
 	}
 
 	// Add the cluster flag from the agent
-	version.UserAgentFeatures([]string{"cluster"})
+	d.globalConfigMu.Lock()
+	version.UserAgentFeatures(userAgentFeatures(true, d.localConfig, d.globalConfig))
+	d.globalConfigMu.Unlock()
 
 	return operations.OperationResponse(op)
 }
@@ -544,6 +593,8 @@ func clusterPutJoin(d *Daemon, r *http.Request, req api.ClusterPut) response.Res
 		UserAgent:     version.UserAgent,
 	}
 
+	cluster.SetClientKeyPair(args, serverCert)
+
 	// Asynchronously join the cluster.
 	run := func(op *operations.Operation) error {
 		logger.Debug("Running cluster join operation")
@@ -655,13 +706,23 @@ func clusterPutJoin(d *Daemon, r *http.Request, req api.ClusterPut) response.Res
 			return fmt.Errorf("Failed request to add member: %w", err)
 		}
 
+		// The cluster doesn't send its private key when held in a PKCS#11 token, in which case the same key
+		// must be available to this member through its own token.
+		pkcs11NetworkKey := ""
+		if len(info.PrivateKey) == 0 {
+			pkcs11NetworkKey, _ = config.PKCS11Keys()
+			if pkcs11NetworkKey == "" {
+				return fmt.Errorf("The cluster private key is held in a PKCS#11 token, core.pkcs11_network_key must be set to join")
+			}
+		}
+
 		// Update our TLS configuration using the returned cluster certificate.
 		err = internalUtil.WriteCert(s.OS.VarDir, "cluster", []byte(req.ClusterCertificate), info.PrivateKey, nil)
 		if err != nil {
 			return fmt.Errorf("Failed to save cluster certificate: %w", err)
 		}
 
-		networkCert, err := internalUtil.LoadClusterCert(s.OS.VarDir)
+		networkCert, err := loadNetworkCert(s.OS.VarDir, config.CertificateKey(), pkcs11NetworkKey)
 		if err != nil {
 			return fmt.Errorf("Failed to parse cluster certificate: %w", err)
 		}
@@ -798,7 +859,9 @@ func clusterPutJoin(d *Daemon, r *http.Request, req api.ClusterPut) response.Res
 		}
 
 		// Add the cluster flag from the agent
-		version.UserAgentFeatures([]string{"cluster"})
+		d.globalConfigMu.Lock()
+		version.UserAgentFeatures(userAgentFeatures(true, d.localConfig, d.globalConfig))
+		d.globalConfigMu.Unlock()
 
 		// Notify the leader of successful join, possibly triggering
 		// role changes.
@@ -808,7 +871,7 @@ func clusterPutJoin(d *Daemon, r *http.Request, req api.ClusterPut) response.Res
 		}
 
 		// Ensure all images are available after this node has joined.
-		err = autoSyncImages(s.ShutdownCtx, s)
+		err = autoSyncImages(s.ShutdownCtx, s, nil)
 		if err != nil {
 			logger.Warn("Failed to sync images")
 		}
@@ -1290,11 +1353,21 @@ func clusterNodesPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("This server is not clustered"))
 	}
 
-	expiry, err := internalInstance.GetExpiry(time.Now(), s.GlobalConfig.ClusterJoinTokenExpiry())
+	expiryExpr := s.GlobalConfig.ClusterJoinTokenExpiry()
+	if req.Expiry != "" {
+		expiryExpr = req.Expiry
+	}
+
+	expiry, err := internalInstance.GetExpiry(time.Now(), expiryExpr)
 	if err != nil {
 		return response.BadRequest(err)
 	}
 
+	// A reusable token must eventually stop being valid.
+	if req.Reusable && expiry.IsZero() {
+		return response.BadRequest(fmt.Errorf("Reusable join tokens require an expiry"))
+	}
+
 	// Get target addresses for existing online members, so that it can be encoded into the join token so that
 	// the joining member will not have to specify a joining address during the join process.
 	// Use anonymous interface type to align with how the API response will be returned for consistency when
@@ -1381,6 +1454,7 @@ func clusterNodesPost(d *Daemon, r *http.Request) response.Response {
 		"fingerprint": fingerprint,
 		"addresses":   onlineNodeAddresses,
 		"expiresAt":   expiry,
+		"reusable":    req.Reusable,
 	}
 
 	resources := map[string][]api.URL{}
@@ -1782,6 +1856,16 @@ func clusterValidateConfig(config map[string]string) error {
 		//  defaultdesc: `all`
 		//  shortdesc: Controls how instances are scheduled to run on this member
 		"scheduler.instance": validate.Optional(validate.IsOneOf("all", "group", "manual")),
+
+		// gendoc:generate(entity=cluster, group=cluster, key=images.sync_filter)
+		// Comma-separated list of `project=<name>`, `alias=<name>` and `fingerprint=<prefix>` selectors.
+		// When set, the member only receives copies of the images matching at least one of the selectors
+		// when images are synchronized across the cluster.
+		// ---
+		//  type: string
+		//  defaultdesc: all images
+		//  shortdesc: Images that are synchronized to this member
+		"images.sync_filter": validate.Optional(imageSyncFilterValidate),
 	}
 
 	for k, v := range config {
@@ -2025,7 +2109,7 @@ func clusterNodeDelete(d *Daemon, r *http.Request) response.Response {
 
 	logger.Info("Deleting member from cluster", logger.Ctx{"name": name, "force": force})
 
-	err = autoSyncImages(s.ShutdownCtx, s)
+	err = autoSyncImages(s.ShutdownCtx, s, nil)
 	if err != nil {
 		if force == 0 {
 			return response.SmartError(fmt.Errorf("Failed to sync images: %w", err))
@@ -2124,7 +2208,7 @@ func clusterNodeDelete(d *Daemon, r *http.Request) response.Response {
 	s.UpdateCertificateCache()
 
 	// Ensure all images are available after this node has been deleted.
-	err = autoSyncImages(s.ShutdownCtx, s)
+	err = autoSyncImages(s.ShutdownCtx, s, nil)
 	if err != nil {
 		logger.Warn("Failed to sync images")
 	}
@@ -2135,6 +2219,168 @@ func clusterNodeDelete(d *Daemon, r *http.Request) response.Response {
 	return response.EmptySyncResponse
 }
 
+// swagger:operation POST /1.0/cluster/members/{name}/force-remove cluster cluster_member_force_remove_post
+//
+//	Force remove a dead cluster member
+//
+//	Removes a member which is permanently gone from the cluster.
+//	The member must be offline and unreachable, and removing it mustn't cause the database to lose quorum.
+//	If the member held a database role, it's demoted and its role is handed over to another member.
+//	Anything still recorded on the member, like its instances and volumes, is removed from the database.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func clusterNodeForceRemovePost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	leader, err := d.gateway.LeaderAddress()
+	if err != nil {
+		if errors.Is(err, cluster.ErrNodeIsNotClustered) {
+			return response.BadRequest(fmt.Errorf("This server isn't clustered"))
+		}
+
+		return response.SmartError(err)
+	}
+
+	// Only the leader can change the raft configuration.
+	if leader != s.LocalConfig.ClusterAddress() {
+		client, err := cluster.Connect(leader, s.Endpoints.NetworkCert(), s.ServerCert(), r, false)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		return response.ForwardedResponse(client, r)
+	}
+
+	d.clusterMembershipMutex.Lock()
+	defer d.clusterMembershipMutex.Unlock()
+
+	var member db.NodeInfo
+	var members []db.NodeInfo
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		member, err = tx.GetNodeByName(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		members, err = tx.GetNodes(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed getting cluster members: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Make sure that the member is really gone.
+	if member.Address == leader {
+		return response.BadRequest(fmt.Errorf("The cluster leader can't be force removed"))
+	}
+
+	offlineThreshold := s.GlobalConfig.OfflineThreshold()
+	if !member.IsOffline(offlineThreshold) {
+		return response.BadRequest(fmt.Errorf("Cluster member %q isn't offline", name))
+	}
+
+	if cluster.HasConnectivity(s.Endpoints.NetworkCert(), s.ServerCert(), member.Address) {
+		return response.BadRequest(fmt.Errorf("Cluster member %q is still reachable", name))
+	}
+
+	raftNodes, err := d.gateway.RaftNodes()
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed loading raft members: %w", err))
+	}
+
+	var raftNode *db.RaftNode
+	for i := range raftNodes {
+		if raftNodes[i].Address == member.Address {
+			raftNode = &raftNodes[i]
+			break
+		}
+	}
+
+	// Removing a voter changes the raft configuration, which needs a majority of the current voters.
+	if raftNode != nil && raftNode.Role == db.RaftVoter {
+		online := make(map[string]bool, len(members))
+		for _, m := range members {
+			online[m.Address] = !m.IsOffline(offlineThreshold)
+		}
+
+		voters := 0
+		onlineVoters := 0
+		for _, node := range raftNodes {
+			if node.Role != db.RaftVoter {
+				continue
+			}
+
+			voters++
+			if node.Address != member.Address && online[node.Address] {
+				onlineVoters++
+			}
+		}
+
+		if onlineVoters < voters/2+1 {
+			return response.BadRequest(fmt.Errorf("Removing cluster member %q would lose quorum (%d of %d voters online)", name, onlineVoters, voters))
+		}
+	}
+
+	logger.Info("Force removing dead member from cluster", logger.Ctx{"name": name, "address": member.Address})
+
+	// Demote the member first so that it no longer counts towards quorum.
+	if raftNode != nil && raftNode.Role != db.RaftSpare {
+		err = d.gateway.DemoteOfflineNode(raftNode.ID)
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed demoting cluster member %q: %w", name, err))
+		}
+	}
+
+	_, err = cluster.Leave(s, d.gateway, name, true)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = cluster.Purge(s.DB.Cluster, name)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed to remove member from database: %w", err))
+	}
+
+	// Hand the database role of the removed member over to another member.
+	err = rebalanceMemberRoles(s, d.gateway, r, nil)
+	if err != nil {
+		logger.Warn("Failed to rebalance dqlite nodes", logger.Ctx{"err": err})
+	}
+
+	s.UpdateCertificateCache()
+
+	// Ensure all images are available after this member has been removed.
+	err = autoSyncImages(s.ShutdownCtx, s, nil)
+	if err != nil {
+		logger.Warn("Failed to sync images", logger.Ctx{"err": err})
+	}
+
+	requestor := request.CreateRequestor(r)
+	s.Events.SendLifecycle(projectParam(r), lifecycle.ClusterMemberRemoved.Event(name, requestor, logger.Ctx{"force": true}))
+
+	return response.EmptySyncResponse
+}
+
 // swagger:operation PUT /1.0/cluster/certificate cluster clustering_update_cert
 //
 //	Update the certificate for the cluster
@@ -2197,6 +2443,141 @@ func clusterCertificatePut(d *Daemon, r *http.Request) response.Response {
 	return response.EmptySyncResponse
 }
 
+// swagger:operation POST /1.0/cluster/certificate cluster clustering_rotate_cert
+//
+//	Rotate the certificate for the cluster
+//
+//	Generates a new cluster certificate and replaces the existing one on each cluster member.
+//	All members trust both certificates while the replacement is in progress and the
+//	existing certificate is restored on all of them if it fails on any.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: New cluster certificate
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/ClusterCertificate"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func clusterCertificatePost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	clustered, err := cluster.Enabled(s.DB.Node)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if !clustered {
+		return response.BadRequest(fmt.Errorf("This server isn't clustered"))
+	}
+
+	acmeDomain, _, _, _ := s.GlobalConfig.ACME()
+	if acmeDomain != "" {
+		return response.BadRequest(fmt.Errorf("The cluster certificate is managed through ACME"))
+	}
+
+	networkCert := s.Endpoints.NetworkCert()
+	if networkCert.CA() != nil {
+		return response.BadRequest(fmt.Errorf("Can't rotate a cluster certificate issued by a CA"))
+	}
+
+	oldCert, err := x509.ParseCertificate(networkCert.KeyPair().Certificate[0])
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	certBytes, keyBytes, err := internalUtil.RenewCert(oldCert, s.LocalConfig.CertificateKey())
+	if err != nil {
+		return response.InternalError(fmt.Errorf("Failed generating new cluster certificate: %w", err))
+	}
+
+	certBlock, _ := pem.Decode(certBytes)
+	if certBlock == nil {
+		return response.InternalError(fmt.Errorf("Invalid new cluster certificate"))
+	}
+
+	// Have all members trust the new certificate before any of them switches to it, so that they can keep
+	// talking to each other during the transition.
+	err = clusterCertificateSetPending(s, r, certBlock.Bytes, cluster.NotifyAll)
+	if err != nil {
+		_ = clusterCertificateSetPending(s, r, nil, cluster.NotifyAlive)
+		return response.SmartError(fmt.Errorf("Failed distributing new cluster certificate: %w", err))
+	}
+
+	// The certificate left behind for later distribution by a failed update is only relevant to ACME.
+	newClusterCertFilename := internalUtil.VarPath(acme.ClusterCertFilename)
+	hadNewClusterCert := util.PathExists(newClusterCertFilename)
+
+	req := api.ClusterCertificatePut{
+		ClusterCertificate:    string(certBytes),
+		ClusterCertificateKey: string(keyBytes),
+	}
+
+	err = updateClusterCertificate(r.Context(), s, d.gateway, r, req)
+
+	// Either all members switched to the new certificate or all of them were reverted to the old one.
+	clearErr := clusterCertificateSetPending(s, r, nil, cluster.NotifyAlive)
+	if clearErr != nil {
+		logger.Warn("Failed clearing pending cluster certificate", logger.Ctx{"err": clearErr})
+	}
+
+	if err != nil {
+		if !hadNewClusterCert {
+			_ = os.Remove(newClusterCertFilename)
+		}
+
+		return response.SmartError(fmt.Errorf("Failed rotating cluster certificate: %w", err))
+	}
+
+	requestor := request.CreateRequestor(r)
+	s.Events.SendLifecycle(projectParam(r), lifecycle.ClusterCertificateUpdated.Event("certificate", requestor, nil))
+
+	return response.SyncResponse(true, api.ClusterCertificate{ClusterCertificate: string(certBytes)})
+}
+
+// clusterCertificateSetPending sets (or clears if nil) the network certificate trusted alongside the current one
+// on all cluster members.
+func clusterCertificateSetPending(s *state.State, r *http.Request, cert []byte, policy cluster.NotifierPolicy) error {
+	cluster.SetPendingNetworkCert(cert)
+
+	notifier, err := cluster.NewNotifier(s, s.Endpoints.NetworkCert(), s.ServerCert(), policy)
+	if err != nil {
+		return err
+	}
+
+	req := internalClusterCertificatePendingRequest{}
+	if cert != nil {
+		req.Certificate = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}))
+	}
+
+	return notifier(func(client incus.InstanceServer) error {
+		_, _, err := client.RawQuery("PUT", "/internal/cluster/certificate/pending", req, "")
+		return err
+	})
+}
+
 func updateClusterCertificate(ctx context.Context, s *state.State, gateway *cluster.Gateway, r *http.Request, req api.ClusterCertificatePut) error {
 	revert := revert.New()
 	defer revert.Fail()
@@ -2384,6 +2765,7 @@ func internalClusterPostAccept(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
+	// The private key is empty when held in a PKCS#11 token, the joining member then uses its own token.
 	accepted := internalClusterPostAcceptResponse{
 		RaftNodes:  make([]internalRaftNode, len(nodes)),
 		PrivateKey: s.Endpoints.NetworkPrivateKey(),
@@ -2398,28 +2780,69 @@ func internalClusterPostAccept(d *Daemon, r *http.Request) response.Response {
 	return response.SyncResponse(true, accepted)
 }
 
-// A request for the /internal/cluster/accept endpoint.
-type internalClusterPostAcceptRequest struct {
-	Name         string                        `json:"name" yaml:"name"`
-	Address      string                        `json:"address" yaml:"address"`
-	Schema       int                           `json:"schema" yaml:"schema"`
-	API          int                           `json:"api" yaml:"api"`
-	StoragePools []api.StoragePool             `json:"storage_pools" yaml:"storage_pools"`
-	Networks     []api.InitNetworksProjectPost `json:"networks" yaml:"networks"`
-	Architecture int                           `json:"architecture" yaml:"architecture"`
-}
+// Check whether a new member would be accepted into the cluster, without modifying anything.
+// All the checks run by internalClusterPostAccept are performed and every failure is reported back.
+func internalClusterPostAcceptValidate(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
 
-// A Response for the /internal/cluster/accept endpoint.
-type internalClusterPostAcceptResponse struct {
-	RaftNodes  []internalRaftNode `json:"raft_nodes" yaml:"raft_nodes"`
-	PrivateKey []byte             `json:"private_key" yaml:"private_key"`
-}
+	req := internalClusterPostAcceptRequest{}
 
-// Represent a node that is part of the dqlite raft cluster.
-type internalRaftNode struct {
-	ID      uint64 `json:"id" yaml:"id"`
-	Address string `json:"address" yaml:"address"`
-	Role    int    `json:"role" yaml:"role"`
+	// Parse the request
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	result := internalClusterPostAcceptValidateResponse{Reasons: []string{}}
+
+	err = cluster.ValidateAccept(s, req.Name, req.Address, req.Schema, req.API)
+	if err != nil {
+		result.Reasons = append(result.Reasons, err.Error())
+	}
+
+	err = clusterCheckStoragePoolsMatch(s.DB.Cluster, req.StoragePools)
+	if err != nil {
+		result.Reasons = append(result.Reasons, err.Error())
+	}
+
+	err = clusterCheckNetworksMatch(s.DB.Cluster, req.Networks)
+	if err != nil {
+		result.Reasons = append(result.Reasons, err.Error())
+	}
+
+	result.Valid = len(result.Reasons) == 0
+
+	return response.SyncResponse(true, result)
+}
+
+// A request for the /internal/cluster/accept endpoint.
+type internalClusterPostAcceptRequest struct {
+	Name         string                        `json:"name" yaml:"name"`
+	Address      string                        `json:"address" yaml:"address"`
+	Schema       int                           `json:"schema" yaml:"schema"`
+	API          int                           `json:"api" yaml:"api"`
+	StoragePools []api.StoragePool             `json:"storage_pools" yaml:"storage_pools"`
+	Networks     []api.InitNetworksProjectPost `json:"networks" yaml:"networks"`
+	Architecture int                           `json:"architecture" yaml:"architecture"`
+}
+
+// A Response for the /internal/cluster/accept endpoint.
+type internalClusterPostAcceptResponse struct {
+	RaftNodes  []internalRaftNode `json:"raft_nodes" yaml:"raft_nodes"`
+	PrivateKey []byte             `json:"private_key" yaml:"private_key"`
+}
+
+// A Response for the /internal/cluster/accept/validate endpoint.
+type internalClusterPostAcceptValidateResponse struct {
+	Valid   bool     `json:"valid" yaml:"valid"`
+	Reasons []string `json:"reasons" yaml:"reasons"`
+}
+
+// Represent a node that is part of the dqlite raft cluster.
+type internalRaftNode struct {
+	ID      uint64 `json:"id" yaml:"id"`
+	Address string `json:"address" yaml:"address"`
+	Role    int    `json:"role" yaml:"role"`
 	Name    string `json:"name" yaml:"name"`
 }
 
@@ -2650,6 +3073,41 @@ type internalClusterPostAssignRequest struct {
 	RaftNodes []internalRaftNode `json:"raft_nodes" yaml:"raft_nodes"`
 }
 
+// Used to trust an additional network certificate while the cluster certificate is being rotated.
+func internalClusterCertificatePendingPut(d *Daemon, r *http.Request) response.Response {
+	req := internalClusterCertificatePendingRequest{}
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Certificate == "" {
+		cluster.SetPendingNetworkCert(nil)
+		return response.EmptySyncResponse
+	}
+
+	certBlock, _ := pem.Decode([]byte(req.Certificate))
+	if certBlock == nil {
+		return response.BadRequest(fmt.Errorf("Certificate must be base64 encoded PEM certificate"))
+	}
+
+	_, err = x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid certificate: %w", err))
+	}
+
+	cluster.SetPendingNetworkCert(certBlock.Bytes)
+
+	return response.EmptySyncResponse
+}
+
+// A request for the internalClusterCertificatePendingPut API endpoint.
+type internalClusterCertificatePendingRequest struct {
+	// Certificate (PEM encoded) to trust, clears the pending certificate if empty.
+	Certificate string `json:"certificate" yaml:"certificate"`
+}
+
 // Used to to transfer the responsibilities of a member to another one.
 func internalClusterPostHandover(d *Daemon, r *http.Request) response.Response {
 	s := d.State()
@@ -2734,6 +3192,59 @@ type internalClusterPostHandoverRequest struct {
 	Address string `json:"address" yaml:"address"`
 }
 
+// Used to make the local member give up the database leadership while keeping the daemon running.
+func internalClusterPostStepDown(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	clustered, err := cluster.Enabled(s.DB.Node)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if !clustered {
+		return response.BadRequest(fmt.Errorf("This server isn't clustered"))
+	}
+
+	localClusterAddress := s.LocalConfig.ClusterAddress()
+
+	leader, err := d.gateway.LeaderAddress()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if leader != localClusterAddress {
+		return response.BadRequest(fmt.Errorf("This member isn't the cluster leader (current leader is %q)", leader))
+	}
+
+	d.clusterMembershipMutex.Lock()
+	defer d.clusterMembershipMutex.Unlock()
+
+	// This fails if no other voter is online to take over.
+	logger.Info("Transferring leadership", logger.Ctx{"address": localClusterAddress})
+	err = d.gateway.TransferLeadership()
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed to transfer leadership: %w", err))
+	}
+
+	// Wait for the new leader to be known.
+	for i := 0; i < 20; i++ {
+		leader, err = d.gateway.LeaderAddress()
+		if err == nil && leader != "" && leader != localClusterAddress {
+			logger.Info("Transferred leadership", logger.Ctx{"address": localClusterAddress, "leader": leader})
+			return response.SyncResponse(true, internalClusterStepDownResponse{Leader: leader})
+		}
+
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	return response.InternalError(fmt.Errorf("Timed out waiting for the new leader to be elected"))
+}
+
+type internalClusterStepDownResponse struct {
+	// Address of the new leader.
+	Leader string `json:"leader" yaml:"leader"`
+}
+
 func clusterCheckStoragePoolsMatch(cluster *db.Cluster, reqPools []api.StoragePool) error {
 	poolNames, err := cluster.GetCreatedStoragePoolNames()
 	if err != nil && !response.IsNotFoundError(err) {
@@ -2853,6 +3364,246 @@ func internalClusterRaftNodeDelete(d *Daemon, r *http.Request) response.Response
 	return response.SyncResponse(true, nil)
 }
 
+// swagger:operation GET /1.0/cluster/heartbeat cluster cluster_heartbeat_get
+//
+//	Get the heartbeat state of the cluster members
+//
+//	Returns the state of all cluster members, including their role in the database.
+//	The request is answered by the database leader from the cluster database, so
+//	that the result is the same whichever member it's sent to.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: Cluster members heartbeat state
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of cluster members
+//	          items:
+//	            $ref: "#/definitions/ClusterMemberHeartbeat"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func clusterHeartbeatGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	leader, err := d.gateway.LeaderAddress()
+	if err != nil {
+		if errors.Is(err, cluster.ErrNodeIsNotClustered) {
+			return response.BadRequest(fmt.Errorf("This server isn't clustered"))
+		}
+
+		return response.SmartError(err)
+	}
+
+	// Only the leader has the authoritative view of the raft cluster and measures the heartbeat latency.
+	if leader != s.LocalConfig.ClusterAddress() {
+		client, err := cluster.Connect(leader, s.Endpoints.NetworkCert(), s.ServerCert(), r, false)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		return response.ForwardedResponse(client, r)
+	}
+
+	raftNodes, err := d.gateway.RaftNodes()
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed loading raft members: %w", err))
+	}
+
+	raftNodesByAddress := make(map[string]db.RaftNode, len(raftNodes))
+	for _, raftNode := range raftNodes {
+		raftNodesByAddress[raftNode.Address] = raftNode
+	}
+
+	// Get the latencies measured by the last heartbeat round.
+	latencies := map[int64]time.Duration{}
+
+	d.lastNodeListMu.Lock()
+	heartbeatData := d.lastNodeList
+	d.lastNodeListMu.Unlock()
+
+	if heartbeatData != nil {
+		heartbeatData.Lock()
+		for id, member := range heartbeatData.Members {
+			latencies[id] = member.Latency
+		}
+
+		heartbeatData.Unlock()
+	}
+
+	var members []api.ClusterMemberHeartbeat
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		nodes, err := tx.GetNodes(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed getting cluster members: %w", err)
+		}
+
+		members = make([]api.ClusterMemberHeartbeat, 0, len(nodes))
+		for _, node := range nodes {
+			member := api.ClusterMemberHeartbeat{
+				ServerName:    node.Name,
+				Address:       node.Address,
+				Online:        !node.IsOffline(s.GlobalConfig.OfflineThreshold()),
+				LastHeartbeat: node.Heartbeat,
+				Latency:       latencies[node.ID].Milliseconds(),
+				Leader:        node.Address == leader,
+				Schema:        node.Schema,
+				APIExtensions: node.APIExtensions,
+			}
+
+			raftNode, ok := raftNodesByAddress[node.Address]
+			if ok {
+				member.RaftID = raftNode.ID
+				member.DatabaseRole = raftNode.Role.String()
+			}
+
+			members = append(members, member)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	sort.Slice(members, func(i, j int) bool { return members[i].ServerName < members[j].ServerName })
+
+	return response.SyncResponse(true, members)
+}
+
+// clusterFeaturesCacheTTL is how long the features collected from the cluster members are re-used for.
+const clusterFeaturesCacheTTL = 30 * time.Second
+
+// clusterFeaturesCache holds the features last collected from the cluster members.
+var clusterFeaturesCache struct {
+	sync.Mutex
+
+	members []api.ClusterMemberFeatures
+	expiry  time.Time
+}
+
+// swagger:operation GET /1.0/cluster/features cluster cluster_features_get
+//
+//	Get the features of the cluster members
+//
+//	Returns the kernel and LXC features detected on all online cluster members.
+//	The result is collected by the member answering the request and cached for a short time.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: Cluster members features
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of cluster members
+//	          items:
+//	            $ref: "#/definitions/ClusterMemberFeatures"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func clusterFeaturesGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	clustered, err := cluster.Enabled(s.DB.Node)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if !clustered {
+		return response.BadRequest(fmt.Errorf("This server isn't clustered"))
+	}
+
+	clusterFeaturesCache.Lock()
+	defer clusterFeaturesCache.Unlock()
+
+	if clusterFeaturesCache.members != nil && time.Now().Before(clusterFeaturesCache.expiry) {
+		return response.SyncResponse(true, clusterFeaturesCache.members)
+	}
+
+	localFeatures := s.OS.Features()
+	localFeatures.IdmappedMountsPolicy = s.LocalConfig.IdmappedMounts()
+
+	members := []api.ClusterMemberFeatures{{ServerName: s.ServerName, Features: *localFeatures}}
+	membersMu := sync.Mutex{}
+
+	// Query the other online members.
+	notifier, err := cluster.NewNotifier(s, s.Endpoints.NetworkCert(), s.ServerCert(), cluster.NotifyAlive)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = notifier(func(client incus.InstanceServer) error {
+		server, _, err := client.GetServer()
+		if err != nil {
+			return err
+		}
+
+		features, err := client.GetServerResourceFeatures()
+		if err != nil {
+			return err
+		}
+
+		membersMu.Lock()
+		members = append(members, api.ClusterMemberFeatures{ServerName: server.Environment.ServerName, Features: *features})
+		membersMu.Unlock()
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	sort.Slice(members, func(i, j int) bool { return members[i].ServerName < members[j].ServerName })
+
+	clusterFeaturesCache.members = members
+	clusterFeaturesCache.expiry = time.Now().Add(clusterFeaturesCacheTTL)
+
+	return response.SyncResponse(true, members)
+}
+
 // swagger:operation GET /1.0/cluster/members/{name}/state cluster cluster_member_state_get
 //
 //	Get state of the cluster member
@@ -2906,6 +3657,11 @@ func clusterNodeStateGet(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	// Report the threshold used by the heartbeats of this member, which follows cluster.offline_threshold live.
+	if d.gateway != nil {
+		memberState.OfflineThreshold = int64(d.gateway.HeartbeatOfflineThreshold.Seconds())
+	}
+
 	return response.SyncResponse(true, memberState)
 }
 
@@ -4415,35 +5171,100 @@ func autoHealClusterTask(d *Daemon) (task.Func, task.Schedule) {
 			return // Skip healing if not cluster leader.
 		}
 
+		var members []db.NodeInfo
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			members, err = tx.GetNodes(ctx)
+			if err != nil {
+				return fmt.Errorf("Failed getting cluster members: %w", err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			logger.Error("Failed healing cluster instances", logger.Ctx{"err": err})
+			return
+		}
+
 		var offlineMembers []db.NodeInfo
-		{
-			var members []db.NodeInfo
-			err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
-				members, err = tx.GetNodes(ctx)
-				if err != nil {
-					return fmt.Errorf("Failed getting cluster members: %w", err)
-				}
+		for _, member := range members {
+			// Ignore members which have been evacuated, and those which haven't exceeded the
+			// healing offline trigger threshold.
+			if member.State == db.ClusterMemberStateEvacuated || !member.IsOffline(healingThreshold) {
+				continue
+			}
 
-				return nil
-			})
+			offlineMembers = append(offlineMembers, member)
+		}
+
+		if len(offlineMembers) == 0 {
+			return // Skip healing if there are no cluster members to evacuate.
+		}
+
+		requireQuorum, maxMembers, window := s.GlobalConfig.ClusterHealingPolicy()
+
+		if requireQuorum {
+			hasQuorum, err := autoHealClusterHasQuorum(ctx, s, members)
 			if err != nil {
-				logger.Error("Failed healing cluster instances", logger.Ctx{"err": err})
+				logger.Error("Failed checking database voters quorum", logger.Ctx{"err": err})
 				return
 			}
 
-			for _, member := range members {
-				// Ignore members which have been evacuated, and those which haven't exceeded the
-				// healing offline trigger threshold.
-				if member.State == db.ClusterMemberStateEvacuated || !member.IsOffline(healingThreshold) {
-					continue
+			if !hasQuorum {
+				logger.Warn("Not healing cluster instances as a majority of database voters is offline", logger.Ctx{"members": autoHealClusterMemberNames(offlineMembers)})
+				return
+			}
+		}
+
+		// The evacuations are recorded in the cluster database so that the limit holds across leader changes.
+		var skippedMembers []db.NodeInfo
+		recentEvacuations := 0
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			// Forget about evacuations which are outside of the healing window.
+			windowStart := time.Now().Add(-window)
+			err := tx.DeleteClusterHealingEvacuations(ctx, windowStart)
+			if err != nil {
+				return err
+			}
+
+			if maxMembers > 0 {
+				recentEvacuations, err = tx.GetClusterHealingEvacuationsCount(ctx, windowStart)
+				if err != nil {
+					return err
 				}
 
-				offlineMembers = append(offlineMembers, member)
+				remaining := int(maxMembers) - recentEvacuations
+				if remaining < 0 {
+					remaining = 0
+				}
+
+				if len(offlineMembers) > remaining {
+					skippedMembers = offlineMembers[remaining:]
+					offlineMembers = offlineMembers[:remaining]
+				}
 			}
+
+			// Record the evacuations upfront so that partial failures still count against the limit.
+			for _, member := range offlineMembers {
+				err := tx.CreateClusterHealingEvacuation(ctx, member.Name)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			logger.Error("Failed recording automatic evacuations", logger.Ctx{"err": err})
+			return
 		}
 
 		if len(offlineMembers) == 0 {
-			return // Skip healing if there are no cluster members to evacuate.
+			logger.Warn("Not healing cluster instances as too many members were recently evacuated", logger.Ctx{"members": autoHealClusterMemberNames(skippedMembers), "evacuated": recentEvacuations, "limit": maxMembers, "window": window})
+			return
+		}
+
+		if len(skippedMembers) > 0 {
+			logger.Warn("Not healing all cluster instances as too many members are offline", logger.Ctx{"members": autoHealClusterMemberNames(skippedMembers), "evacuated": recentEvacuations, "limit": maxMembers, "window": window})
 		}
 
 		opRun := func(op *operations.Operation) error {
@@ -4478,6 +5299,55 @@ func autoHealClusterTask(d *Daemon) (task.Func, task.Schedule) {
 	return f, task.Every(time.Minute)
 }
 
+// autoHealClusterHasQuorum checks whether a majority of the database voters is online.
+func autoHealClusterHasQuorum(ctx context.Context, s *state.State, members []db.NodeInfo) (bool, error) {
+	var raftNodes []db.RaftNode
+	err := s.DB.Node.Transaction(ctx, func(ctx context.Context, tx *db.NodeTx) error {
+		var err error
+
+		raftNodes, err = tx.GetRaftNodes(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed loading RAFT nodes: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	offlineThreshold := s.GlobalConfig.OfflineThreshold()
+
+	voters := 0
+	onlineVoters := 0
+	for _, raftNode := range raftNodes {
+		if raftNode.Role != db.RaftVoter {
+			continue
+		}
+
+		voters++
+
+		for _, member := range members {
+			if member.Address == raftNode.Address && !member.IsOffline(offlineThreshold) {
+				onlineVoters++
+				break
+			}
+		}
+	}
+
+	return onlineVoters > voters/2, nil
+}
+
+// autoHealClusterMemberNames returns the names of the given members.
+func autoHealClusterMemberNames(members []db.NodeInfo) []string {
+	names := make([]string, 0, len(members))
+	for _, member := range members {
+		names = append(names, member.Name)
+	}
+
+	return names
+}
+
 func autoHealCluster(ctx context.Context, s *state.State, offlineMembers []db.NodeInfo) error {
 	logger.Info("Healing cluster instances")
 
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	internalInstance "github.com/lxc/incus/internal/instance"
+	clusterConfig "github.com/lxc/incus/internal/server/cluster/config"
+	"github.com/lxc/incus/internal/server/config"
+	"github.com/lxc/incus/internal/server/node"
+	"github.com/lxc/incus/internal/server/response"
+	localUtil "github.com/lxc/incus/internal/server/util"
+	"github.com/lxc/incus/internal/version"
+	"github.com/lxc/incus/shared/api"
+)
+
+var serverConfigCmd = APIEndpoint{
+	Path: "config",
+
+	Get: APIEndpointAction{Handler: serverConfigGet},
+	Put: APIEndpointAction{Handler: serverConfigPut},
+}
+
+var serverConfigSchemaCmd = APIEndpoint{
+	Path: "config/schema",
+
+	Get: APIEndpointAction{Handler: serverConfigSchemaGet, AccessHandler: allowAuthenticated},
+}
+
+// swagger:operation GET /1.0/config server server_config_get
+//
+//	Export the server configuration
+//
+//	Returns both the server-specific and the cluster-wide configuration,
+//	suitable for a later import through `PUT /1.0/config`.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	responses:
+//	  "200":
+//	    description: Server configuration
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/ServerConfig"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func serverConfigGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// If a target was specified, forward the request to the relevant node.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	serverConfig := serverConfigCurrent(d)
+
+	return response.SyncResponseETag(true, serverConfig, serverConfig)
+}
+
+// swagger:operation PUT /1.0/config server server_config_put
+//
+//	Import the server configuration
+//
+//	Replaces both the server-specific and the cluster-wide configuration.
+//	The whole configuration is validated before being applied and
+//	any change is reverted if it can't be applied.
+//	Configurations exported from a newer server version are rejected.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	  - in: body
+//	    name: config
+//	    description: Server configuration
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ServerConfig"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func serverConfigPut(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// If a target was specified, forward the request to the relevant node.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	// Don't apply changes to settings until daemon is fully started.
+	<-d.waitReady.Done()
+
+	req := api.ServerConfig{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = serverConfigValidateVersion(req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = serverConfigValidateScopes(req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// Keep the current value of the hidden keys when sent back as exported.
+	d.globalConfigMu.Lock()
+	daemonConfigUnhide(req.Local, d.localConfig.Dump())
+	daemonConfigUnhide(req.Global, d.globalConfig.Dump())
+	d.globalConfigMu.Unlock()
+
+	// Validate both scopes before changing anything so that invalid values don't get partially applied.
+	_, err = config.Load(node.ConfigSchema, req.Local)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	_, err = config.Load(clusterConfig.ConfigSchema, req.Global)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// Fail if the configuration changed since the client exported it.
+	err = localUtil.EtagCheck(r, serverConfigCurrent(d))
+	if err != nil {
+		return response.PreconditionFailed(err)
+	}
+
+	// Apply both scopes as a single update so that a failure reverts everything.
+	update := api.ServerPut{Config: map[string]string{}}
+	for key, value := range req.Global {
+		update.Config[key] = value
+	}
+
+	for key, value := range req.Local {
+		update.Config[key] = value
+	}
+
+	return doApi10Update(d, r, update, false)
+}
+
+// serverConfigCurrent returns the current configuration of the server, with the hidden keys masked.
+func serverConfigCurrent(d *Daemon) api.ServerConfig {
+	// Take both scopes under the same lock so that they're consistent with each other.
+	d.globalConfigMu.Lock()
+	defer d.globalConfigMu.Unlock()
+
+	return api.ServerConfig{
+		Version: version.Version,
+		Local:   daemonConfigHide(d.localConfig.Dump()),
+		Global:  daemonConfigHide(d.globalConfig.Dump()),
+	}
+}
+
+// serverConfigValidateVersion checks that the configuration wasn't exported from a newer server.
+func serverConfigValidateVersion(serverConfig api.ServerConfig) error {
+	if serverConfig.Version == "" {
+		return nil
+	}
+
+	exportVersion, err := version.Parse(serverConfig.Version)
+	if err != nil {
+		return fmt.Errorf("Invalid configuration version %q: %w", serverConfig.Version, err)
+	}
+
+	currentVersion, err := version.Parse(version.Version)
+	if err != nil {
+		return err
+	}
+
+	if exportVersion.Compare(currentVersion) > 0 {
+		return fmt.Errorf("Configuration was exported from a newer version of the server (%s > %s)", exportVersion, currentVersion)
+	}
+
+	return nil
+}
+
+// serverConfigValidateScopes checks that all the keys of the configuration are known and in the right scope.
+func serverConfigValidateScopes(serverConfig api.ServerConfig) error {
+	var errs []string
+
+	for key := range serverConfig.Local {
+		_, ok := node.ConfigSchema[key]
+		if ok {
+			continue
+		}
+
+		_, ok = clusterConfig.ConfigSchema[key]
+		if ok || internalInstance.IsUserConfig(key) {
+			errs = append(errs, fmt.Sprintf("%q is a global configuration key", key))
+		} else {
+			errs = append(errs, fmt.Sprintf("Unknown local configuration key %q", key))
+		}
+	}
+
+	for key := range serverConfig.Global {
+		_, ok := clusterConfig.ConfigSchema[key]
+		if ok || internalInstance.IsUserConfig(key) {
+			continue
+		}
+
+		_, ok = node.ConfigSchema[key]
+		if ok {
+			errs = append(errs, fmt.Sprintf("%q is a local configuration key", key))
+		} else {
+			errs = append(errs, fmt.Sprintf("Unknown global configuration key %q", key))
+		}
+	}
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("Invalid configuration: %s", strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// swagger:operation GET /1.0/config/schema server server_config_schema_get
+//
+//	Get the server configuration schema
+//
+//	Returns the definition of all the server configuration keys.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: Server configuration keys
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of configuration keys
+//	          items:
+//	            $ref: "#/definitions/ServerConfigKey"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func serverConfigSchemaGet(d *Daemon, r *http.Request) response.Response {
+	keys := []api.ServerConfigKey{}
+
+	addKeys := func(scope string, schema config.Schema) {
+		for _, name := range schema.Keys() {
+			key := schema[name]
+			keys = append(keys, api.ServerConfigKey{
+				Key:             name,
+				Scope:           scope,
+				Type:            key.Type.String(),
+				Default:         key.Default,
+				Deprecated:      key.Deprecated,
+				RequiresRestart: key.Restart,
+			})
+		}
+	}
+
+	addKeys("local", node.ConfigSchema)
+	addKeys("global", clusterConfig.ConfigSchema)
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Key < keys[j].Key })
+
+	return response.SyncResponse(true, keys)
+}
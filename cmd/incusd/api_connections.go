@@ -0,0 +1,140 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+
+	"github.com/lxc/incus/internal/server/response"
+	"github.com/lxc/incus/shared/api"
+)
+
+var connectionsCmd = APIEndpoint{
+	Path: "connections",
+
+	Get: APIEndpointAction{Handler: connectionsGet},
+}
+
+var connectionCmd = APIEndpoint{
+	Path: "connections/{id}",
+
+	Delete: APIEndpointAction{Handler: connectionDelete},
+}
+
+// swagger:operation GET /1.0/connections server connections_get
+//
+//	Get the active connections
+//
+//	Returns the live client connections to the network listeners of the server,
+//	along with the identity they were authenticated with.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	responses:
+//	  "200":
+//	    description: Active connections
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of connections
+//	          items:
+//	            $ref: "#/definitions/Connection"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func connectionsGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// Connections are tracked separately by each member.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	conns := d.endpoints.Connections()
+
+	result := make([]api.Connection, 0, len(conns))
+	for _, conn := range conns {
+		result = append(result, api.Connection{
+			ID:            conn.ID,
+			Listener:      conn.Listener,
+			RemoteAddress: conn.RemoteAddress,
+			Protocol:      conn.Protocol,
+			Identity:      conn.Identity,
+			ConnectedAt:   conn.ConnectedAt,
+		})
+	}
+
+	return response.SyncResponse(true, result)
+}
+
+// swagger:operation DELETE /1.0/connections/{id} server connection_delete
+//
+//	Close a connection
+//
+//	Forcibly closes a client connection, including any event listener or
+//	websocket running over it.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func connectionDelete(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	id, err := url.PathUnescape(mux.Vars(r)["id"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Connections are tracked separately by each member.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	err = d.endpoints.ConnectionClose(id)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
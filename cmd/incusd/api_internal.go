@@ -5,6 +5,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -17,7 +18,6 @@ import (
 	"strings"
 
 	"github.com/gorilla/mux"
-	"golang.org/x/sys/unix"
 
 	internalInstance "github.com/lxc/incus/internal/instance"
 	"github.com/lxc/incus/internal/jmap"
@@ -25,11 +25,15 @@ import (
 	"github.com/lxc/incus/internal/server/backup"
 	"github.com/lxc/incus/internal/server/db"
 	"github.com/lxc/incus/internal/server/db/cluster"
+	"github.com/lxc/incus/internal/server/db/operationtype"
 	"github.com/lxc/incus/internal/server/db/query"
 	"github.com/lxc/incus/internal/server/db/warningtype"
 	deviceConfig "github.com/lxc/incus/internal/server/device/config"
+	firewallDrivers "github.com/lxc/incus/internal/server/firewall/drivers"
 	"github.com/lxc/incus/internal/server/instance"
 	"github.com/lxc/incus/internal/server/instance/instancetype"
+	"github.com/lxc/incus/internal/server/network"
+	"github.com/lxc/incus/internal/server/operations"
 	"github.com/lxc/incus/internal/server/project"
 	"github.com/lxc/incus/internal/server/response"
 	"github.com/lxc/incus/internal/server/state"
@@ -47,27 +51,36 @@ import (
 var apiInternal = []APIEndpoint{
 	internalBGPStateCmd,
 	internalClusterAcceptCmd,
+	internalClusterAcceptValidateCmd,
 	internalClusterAssignCmd,
+	internalClusterCertificatePendingCmd,
 	internalClusterHandoverCmd,
 	internalClusterRaftNodeCmd,
 	internalClusterRebalanceCmd,
 	internalClusterHealCmd,
+	internalClusterStepDownCmd,
 	internalContainerOnStartCmd,
 	internalContainerOnStopCmd,
 	internalContainerOnStopNSCmd,
+	internalEventsCmd,
+	internalFirewallCmd,
 	internalGarbageCollectorCmd,
 	internalImageOptimizeCmd,
 	internalImageRefreshCmd,
+	internalNetworkSelfTestCmd,
 	internalRAFTSnapshotCmd,
 	internalReadyCmd,
 	internalShutdownCmd,
 	internalSQLCmd,
+	internalSQLQueriesCmd,
+	internalSQLQueryCmd,
 	internalWarningCreateCmd,
 }
 
 var internalShutdownCmd = APIEndpoint{
 	Path: "shutdown",
 
+	Get: APIEndpointAction{Handler: internalShutdownGet},
 	Put: APIEndpointAction{Handler: internalShutdown},
 }
 
@@ -132,6 +145,25 @@ var internalWarningCreateCmd = APIEndpoint{
 	Post: APIEndpointAction{Handler: internalCreateWarning},
 }
 
+var internalEventsCmd = APIEndpoint{
+	Path: "events",
+
+	Get: APIEndpointAction{Handler: internalEventsGet},
+}
+
+var internalFirewallCmd = APIEndpoint{
+	Path: "firewall",
+
+	Get:  APIEndpointAction{Handler: internalFirewallGet},
+	Post: APIEndpointAction{Handler: internalFirewallPost},
+}
+
+var internalNetworkSelfTestCmd = APIEndpoint{
+	Path: "networks/{networkName}/selftest",
+
+	Get: APIEndpointAction{Handler: internalNetworkSelfTestGet},
+}
+
 var internalBGPStateCmd = APIEndpoint{
 	Path: "testing/bgp",
 
@@ -143,6 +175,21 @@ type internalImageOptimizePost struct {
 	Pool  string    `json:"pool"  yaml:"pool"`
 }
 
+type internalFirewallReapplyResult struct {
+	Networks  int `json:"networks"  yaml:"networks"`
+	Instances int `json:"instances" yaml:"instances"`
+	Rules     int `json:"rules"     yaml:"rules"`
+}
+
+type internalShutdownReadiness struct {
+	Operations               int      `json:"operations"                   yaml:"operations"`
+	ExecConsoleOperations    int      `json:"exec_console_operations"      yaml:"exec_console_operations"`
+	OperationsTimeout        int64    `json:"operations_timeout"           yaml:"operations_timeout"`
+	Instances                int      `json:"instances"                    yaml:"instances"`
+	InstancesShutdownTimeout int64    `json:"instances_shutdown_timeout"   yaml:"instances_shutdown_timeout"`
+	StorageVolumes           []string `json:"storage_volumes"              yaml:"storage_volumes"`
+}
+
 type internalWarningCreatePost struct {
 	Location       string `json:"location"         yaml:"location"`
 	Project        string `json:"project"          yaml:"project"`
@@ -229,15 +276,79 @@ func internalWaitReady(d *Daemon, r *http.Request) response.Response {
 	}
 
 	if d.waitReady.Err() == nil {
-		return response.Unavailable(fmt.Errorf("Daemon not ready yet"))
+		return response.Unavailable(api.StatusReasonErrorf(http.StatusServiceUnavailable, api.ErrorReasonSetupInProgress, "Daemon not ready yet"))
 	}
 
 	return response.EmptySyncResponse
 }
 
+// internalShutdownGet reports what a shutdown of the daemon would have to wait for.
+// This reads the same state as the shutdown sequence so that callers can pick appropriate timeouts.
+func internalShutdownGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	result := internalShutdownReadiness{
+		OperationsTimeout: int64(s.GlobalConfig.ShutdownTimeout().Seconds()),
+		StorageVolumes:    []string{},
+	}
+
+	// Operations the shutdown sequence waits on.
+	for _, op := range operations.Clone() {
+		if !operationBlocksShutdown(op) {
+			continue
+		}
+
+		result.Operations++
+
+		if op.Type() == operationtype.CommandExec || op.Type() == operationtype.ConsoleShow {
+			result.ExecConsoleOperations++
+		}
+	}
+
+	// Running instances and the longest time one of them may take to shutdown.
+	instances, err := instance.LoadNodeAll(s, instancetype.Any)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	for _, inst := range instances {
+		if !inst.IsRunning() {
+			continue
+		}
+
+		result.Instances++
+
+		timeoutSeconds := 30
+		value, ok := inst.ExpandedConfig()["boot.host_shutdown_timeout"]
+		if ok {
+			timeoutSeconds, _ = strconv.Atoi(value)
+		}
+
+		if int64(timeoutSeconds) > result.InstancesShutdownTimeout {
+			result.InstancesShutdownTimeout = int64(timeoutSeconds)
+		}
+	}
+
+	// Daemon storage volumes to unmount.
+	for _, volume := range []string{s.LocalConfig.StorageBackupsVolume(), s.LocalConfig.StorageImagesVolume()} {
+		if volume != "" {
+			result.StorageVolumes = append(result.StorageVolumes, volume)
+		}
+	}
+
+	return response.SyncResponse(true, result)
+}
+
 func internalShutdown(d *Daemon, r *http.Request) response.Response {
 	force := queryParam(r, "force")
-	logger.Info("Asked to shutdown by API", logger.Ctx{"force": force})
+
+	// Restarting the daemon leaves the instances running.
+	mode := daemonStopModeShutdown
+	if util.IsTrue(queryParam(r, "restart")) {
+		mode = daemonStopModeRestart
+	}
+
+	logger.Info("Asked to shutdown by API", logger.Ctx{"force": force, "mode": mode})
 
 	if d.State().ShutdownCtx.Err() != nil {
 		return response.SmartError(api.StatusErrorf(http.StatusTooManyRequests, "Shutdown already in progress"))
@@ -255,7 +366,7 @@ func internalShutdown(d *Daemon, r *http.Request) response.Response {
 		<-d.setupChan // Wait for daemon to start.
 
 		// Run shutdown sequence synchronously.
-		stopErr := d.Stop(forceCtx, unix.SIGPWR)
+		stopErr := d.Stop(forceCtx, mode)
 		err := response.SmartError(stopErr).Render(w)
 		if err != nil {
 			return err
@@ -502,10 +613,10 @@ func internalSQLPost(d *Daemon, r *http.Request) response.Response {
 	return response.SyncResponse(true, batch)
 }
 
-func internalSQLSelect(tx *sql.Tx, query string, result *internalSQL.SQLResult) error {
+func internalSQLSelect(tx *sql.Tx, query string, result *internalSQL.SQLResult, args ...any) error {
 	result.Type = "select"
 
-	rows, err := tx.Query(query)
+	rows, err := tx.Query(query, args...)
 	if err != nil {
 		return fmt.Errorf("Failed to execute query: %w", err)
 	}
@@ -565,8 +676,9 @@ func internalSQLExec(tx *sql.Tx, query string, result *internalSQL.SQLResult) er
 }
 
 // internalImportFromBackup creates instance, storage pool and volume DB records from an instance's backup file.
+// If checkProjectLimits is true, the project limits are checked again when recording the instance.
 // It expects the instance volume to be mounted so that the backup.yaml file is readable.
-func internalImportFromBackup(s *state.State, projectName string, instName string, allowNameOverride bool) error {
+func internalImportFromBackup(s *state.State, projectName string, instName string, allowNameOverride bool, checkProjectLimits bool) error {
 	if instName == "" {
 		return fmt.Errorf("The name of the instance is required")
 	}
@@ -759,7 +871,7 @@ func internalImportFromBackup(s *state.State, projectName string, instName strin
 		return err
 	}
 
-	_, instOp, cleanup, err := instance.CreateInternal(s, *instDBArgs, true)
+	_, instOp, cleanup, err := instance.CreateInternal(s, *instDBArgs, true, checkProjectLimits)
 	if err != nil {
 		return fmt.Errorf("Failed creating instance record: %w", err)
 	}
@@ -861,7 +973,7 @@ func internalImportFromBackup(s *state.State, projectName string, instName strin
 			Name:         snapInstName,
 			Profiles:     profiles,
 			Stateful:     snap.Stateful,
-		}, true)
+		}, true, false)
 		if err != nil {
 			return fmt.Errorf("Failed creating instance snapshot record %q: %w", snap.Name, err)
 		}
@@ -1007,3 +1119,150 @@ func internalBGPState(d *Daemon, r *http.Request) response.Response {
 
 	return response.SyncResponse(true, s.BGP.Debug())
 }
+
+// internalEventsGet returns the delivery counters of the event listeners, to diagnose slow event consumers.
+func internalEventsGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	return response.SyncResponse(true, s.Events.Debug())
+}
+
+// internalFirewallGet returns the firewall rules currently managed by Incus.
+// The rules can be filtered by network or by instance (along with its project).
+func internalFirewallGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	rules, err := s.Firewall.Dump()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	networkName := queryParam(r, "network")
+	instanceName := queryParam(r, "instance")
+	projectName := queryParam(r, "project")
+	if instanceName != "" && projectName == "" {
+		projectName = project.Default
+	}
+
+	filtered := make([]firewallDrivers.Rule, 0, len(rules))
+	for _, rule := range rules {
+		if networkName != "" && rule.Network != networkName {
+			continue
+		}
+
+		if instanceName != "" && (rule.Instance != instanceName || rule.Project != projectName) {
+			continue
+		}
+
+		if instanceName == "" && projectName != "" && rule.Project != projectName {
+			continue
+		}
+
+		filtered = append(filtered, rule)
+	}
+
+	return response.SyncResponse(true, filtered)
+}
+
+// internalFirewallPost re-applies the firewall rules of the local managed bridge networks and running instances.
+// This is useful when the host firewall has been flushed by external tooling.
+func internalFirewallPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	var projectNames []string
+	err := s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+		projectNames, err = cluster.GetProjectNames(ctx, tx.Tx())
+		return err
+	})
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed loading projects: %w", err))
+	}
+
+	result := internalFirewallReapplyResult{}
+
+	for _, projectName := range projectNames {
+		networkNames, err := s.DB.Cluster.GetCreatedNetworks(projectName)
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed loading networks for project %q: %w", projectName, err))
+		}
+
+		for _, networkName := range networkNames {
+			n, err := network.LoadByName(s, projectName, networkName)
+			if err != nil {
+				return response.SmartError(fmt.Errorf("Failed loading network %q in project %q: %w", networkName, projectName, err))
+			}
+
+			// Only some networks (bridges) have their rules managed by the firewall driver.
+			err = n.FirewallReapply()
+			if errors.Is(err, network.ErrNotImplemented) {
+				continue
+			} else if err != nil {
+				return response.SmartError(fmt.Errorf("Failed re-applying firewall rules for network %q in project %q: %w", networkName, projectName, err))
+			}
+
+			result.Networks++
+		}
+	}
+
+	instances, err := instance.LoadNodeAll(s, instancetype.Any)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed loading instances: %w", err))
+	}
+
+	for _, inst := range instances {
+		if !inst.IsRunning() {
+			continue
+		}
+
+		err = inst.FirewallReapply()
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed re-applying firewall rules for instance %q in project %q: %w", inst.Name(), inst.Project().Name, err))
+		}
+
+		result.Instances++
+	}
+
+	rules, err := s.Firewall.Dump()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	result.Rules = len(rules)
+
+	logger.Info("Re-applied firewall rules", logger.Ctx{"networks": result.Networks, "instances": result.Instances, "rules": result.Rules})
+
+	return response.SyncResponse(true, result)
+}
+
+// internalNetworkSelfTestGet runs connectivity probes against a network from the point of view of the host.
+func internalNetworkSelfTestGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName, reqProject, err := project.NetworkProject(s.DB.Cluster, projectParam(r))
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	networkName, err := url.PathUnescape(mux.Vars(r)["networkName"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	n, err := network.LoadByName(s, projectName, networkName)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed loading network: %w", err))
+	}
+
+	// Check if project allows access to network.
+	if !project.NetworkAllowed(reqProject.Config, networkName, n.IsManaged()) {
+		return response.SmartError(api.StatusErrorf(http.StatusNotFound, "Network not found"))
+	}
+
+	results, err := network.SelfTest(r.Context(), s, n)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, results)
+}
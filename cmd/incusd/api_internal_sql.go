@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/gorilla/mux"
+
+	"github.com/lxc/incus/internal/server/db"
+	"github.com/lxc/incus/internal/server/response"
+	internalSQL "github.com/lxc/incus/internal/sql"
+)
+
+var internalSQLQueriesCmd = APIEndpoint{
+	Path: "sql/queries",
+
+	Get: APIEndpointAction{Handler: internalSQLQueriesGet},
+}
+
+var internalSQLQueryCmd = APIEndpoint{
+	Path: "sql/queries/{name}",
+
+	Get: APIEndpointAction{Handler: internalSQLQueryGet},
+}
+
+// internalSQLReadQuery is a predefined read-only query against the global database.
+type internalSQLReadQuery struct {
+	description string
+
+	// Names of the supported filters. Each filter matches a pair of placeholders in the query, in the same order,
+	// which are set to the filter value so that an empty value can disable the condition.
+	filters []string
+
+	query string
+}
+
+// internalSQLReadQueries are the queries which can be run through the read-only query endpoint.
+var internalSQLReadQueries = map[string]internalSQLReadQuery{
+	"members": {
+		description: "Cluster members with their state, version and number of instances",
+		filters:     []string{"member"},
+		query: `
+SELECT nodes.id, nodes.name, nodes.address, nodes.state, nodes.heartbeat, nodes.schema, nodes.api_extensions,
+  (SELECT COUNT(*) FROM instances WHERE instances.node_id = nodes.id) AS instances
+  FROM nodes
+  WHERE (? = '' OR nodes.name = ?)
+  ORDER BY nodes.name`,
+	},
+
+	"instances": {
+		description: "Instances with their project, type and location",
+		filters:     []string{"project", "member"},
+		query: `
+SELECT projects.name AS project, instances.name, instances.type, nodes.name AS member,
+  instances.creation_date, instances.last_use_date
+  FROM instances
+  JOIN projects ON projects.id = instances.project_id
+  JOIN nodes ON nodes.id = instances.node_id
+  WHERE (? = '' OR projects.name = ?) AND (? = '' OR nodes.name = ?)
+  ORDER BY projects.name, instances.name`,
+	},
+
+	"instances-per-member": {
+		description: "Number of instances on each cluster member",
+		filters:     []string{"project"},
+		query: `
+SELECT nodes.name AS member, COUNT(instances.id) AS instances
+  FROM nodes
+  LEFT JOIN instances ON instances.node_id = nodes.id
+    AND (? = '' OR instances.project_id = (SELECT id FROM projects WHERE name = ?))
+  GROUP BY nodes.id
+  ORDER BY nodes.name`,
+	},
+
+	"volumes": {
+		description: "Storage volumes with their project, pool, type and location",
+		filters:     []string{"project", "pool", "member"},
+		query: `
+SELECT projects.name AS project, storage_pools.name AS pool, storage_volumes.name, storage_volumes.type,
+  storage_volumes.content_type, nodes.name AS member
+  FROM storage_volumes
+  JOIN projects ON projects.id = storage_volumes.project_id
+  JOIN storage_pools ON storage_pools.id = storage_volumes.storage_pool_id
+  LEFT JOIN nodes ON nodes.id = storage_volumes.node_id
+  WHERE (? = '' OR projects.name = ?) AND (? = '' OR storage_pools.name = ?) AND (? = '' OR nodes.name = ?)
+  ORDER BY projects.name, storage_pools.name, storage_volumes.name`,
+	},
+
+	"images-per-member": {
+		description: "Images with the number of cluster members holding a copy",
+		filters:     []string{"project"},
+		query: `
+SELECT projects.name AS project, images.fingerprint, images.size, COUNT(images_nodes.node_id) AS members
+  FROM images
+  JOIN projects ON projects.id = images.project_id
+  LEFT JOIN images_nodes ON images_nodes.image_id = images.id
+  WHERE (? = '' OR projects.name = ?)
+  GROUP BY images.id
+  ORDER BY projects.name, images.fingerprint`,
+	},
+
+	"operations": {
+		description: "Operations with their type, project and location",
+		filters:     []string{"project", "member"},
+		query: `
+SELECT operations.uuid, operations.type, projects.name AS project, nodes.name AS member
+  FROM operations
+  LEFT JOIN projects ON projects.id = operations.project_id
+  JOIN nodes ON nodes.id = operations.node_id
+  WHERE (? = '' OR projects.name = ?) AND (? = '' OR nodes.name = ?)
+  ORDER BY nodes.name, operations.uuid`,
+	},
+}
+
+// List the predefined read-only queries.
+func internalSQLQueriesGet(d *Daemon, r *http.Request) response.Response {
+	queries := make([]internalSQL.SQLReadQuery, 0, len(internalSQLReadQueries))
+	for name, q := range internalSQLReadQueries {
+		queries = append(queries, internalSQL.SQLReadQuery{
+			Name:        name,
+			Description: q.description,
+			Filters:     q.filters,
+		})
+	}
+
+	sort.Slice(queries, func(i, j int) bool { return queries[i].Name < queries[j].Name })
+
+	return response.SyncResponse(true, queries)
+}
+
+// Run a predefined read-only query against the global database.
+func internalSQLQueryGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	q, ok := internalSQLReadQueries[name]
+	if !ok {
+		return response.NotFound(fmt.Errorf("Unknown query %q", name))
+	}
+
+	args := make([]any, 0, len(q.filters)*2)
+	for _, filter := range q.filters {
+		value := r.FormValue(filter)
+		args = append(args, value, value)
+	}
+
+	result := internalSQL.SQLResult{}
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return internalSQLSelect(tx.Tx(), q.query, &result, args...)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, result)
+}
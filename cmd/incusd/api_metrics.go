@@ -7,19 +7,26 @@ import (
 	"net"
 	"net/http"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/lxc/incus/internal/server/db"
 	dbCluster "github.com/lxc/incus/internal/server/db/cluster"
+	"github.com/lxc/incus/internal/server/db/query"
+	"github.com/lxc/incus/internal/server/db/warningtype"
+	"github.com/lxc/incus/internal/server/events"
 	"github.com/lxc/incus/internal/server/instance"
 	instanceDrivers "github.com/lxc/incus/internal/server/instance/drivers"
 	"github.com/lxc/incus/internal/server/locking"
 	"github.com/lxc/incus/internal/server/metrics"
+	"github.com/lxc/incus/internal/server/node"
+	"github.com/lxc/incus/internal/server/operations"
 	"github.com/lxc/incus/internal/server/response"
 	"github.com/lxc/incus/shared/api"
 	"github.com/lxc/incus/shared/logger"
+	localtls "github.com/lxc/incus/shared/tls"
 	"github.com/lxc/incus/shared/util"
 )
 
@@ -31,6 +38,9 @@ type metricsCacheEntry struct {
 var metricsCache map[string]metricsCacheEntry
 var metricsCacheLock sync.Mutex
 
+// instanceCustomMetrics holds the metrics published by instances through the guest API.
+var instanceCustomMetrics = metrics.NewCustomMetricsStore()
+
 var metricsCmd = APIEndpoint{
 	Path: "metrics",
 
@@ -117,7 +127,7 @@ func metricsGet(d *Daemon, r *http.Request) response.Response {
 		}
 
 		// Add internal metrics.
-		metricSet.Merge(internalMetrics(ctx, s.StartTime, tx))
+		metricSet.Merge(internalMetrics(ctx, s.StartTime, s.Events, tx))
 
 		return nil
 	})
@@ -238,6 +248,14 @@ func metricsGet(d *Daemon, r *http.Request) response.Response {
 
 					newMetrics[projectName].Merge(instanceMetrics)
 
+					// Add the metrics published by the instance through the guest API.
+					customMetrics := instanceCustomMetrics.Get(projectName, inst.Name())
+					if len(customMetrics) > 0 {
+						customSet := metrics.NewMetricSet(map[string]string{"project": projectName, "name": inst.Name(), "type": inst.Type().String()})
+						customSet.AddCustomMetrics(customMetrics)
+						newMetrics[projectName].Merge(customSet)
+					}
+
 					newMetricsLock.Unlock()
 				}
 
@@ -288,7 +306,7 @@ func metricsGet(d *Daemon, r *http.Request) response.Response {
 	return response.SyncResponsePlain(true, compress, metricSet.String())
 }
 
-func internalMetrics(ctx context.Context, daemonStartTime time.Time, tx *db.ClusterTx) *metrics.MetricSet {
+func internalMetrics(ctx context.Context, daemonStartTime time.Time, eventsServer *events.Server, tx *db.ClusterTx) *metrics.MetricSet {
 	out := metrics.NewMetricSet(nil)
 
 	warnings, err := dbCluster.GetWarnings(ctx, tx.Tx())
@@ -297,16 +315,55 @@ func internalMetrics(ctx context.Context, daemonStartTime time.Time, tx *db.Clus
 	} else {
 		// Total number of warnings
 		out.AddSamples(metrics.WarningsTotal, metrics.Sample{Value: float64(len(warnings))})
+
+		// Number of unresolved warnings by type
+		out.AddSamples(metrics.Warnings, warningsSamples(warnings)...)
 	}
 
-	operations, err := dbCluster.GetOperations(ctx, tx.Tx())
+	dbOperations, err := dbCluster.GetOperations(ctx, tx.Tx())
 	if err != nil {
 		logger.Warn("Failed to get operations", logger.Ctx{"err": err})
 	} else {
 		// Total number of operations
-		out.AddSamples(metrics.OperationsTotal, metrics.Sample{Value: float64(len(operations))})
+		out.AddSamples(metrics.OperationsTotal, metrics.Sample{Value: float64(len(dbOperations))})
+	}
+
+	// Background operations running and queued past core.max_concurrent_operations
+	tasksRunning, tasksQueued := operations.TasksCount()
+	out.AddSamples(metrics.OperationsTasksRunning, metrics.Sample{Value: float64(tasksRunning)})
+	out.AddSamples(metrics.OperationsTasksQueued, metrics.Sample{Value: float64(tasksQueued)})
+
+	// Periodic image synchronization
+	out.AddSamples(metrics.ImagesSyncTotal, metrics.Sample{Value: float64(autoSyncImagesTotal.Load())})
+	out.AddSamples(metrics.ImagesSyncFailuresTotal, metrics.Sample{Value: float64(autoSyncImagesFailuresTotal.Load())})
+
+	// Database transaction retries and slow transactions
+	out.AddSamples(metrics.DatabaseRetriesTotal, metrics.Sample{Value: float64(query.RetryCount())})
+	out.AddSamples(metrics.DatabaseSlowQueriesTotal, metrics.Sample{Value: float64(db.SlowTransactionCount())})
+
+	// Event listeners delivery, in total and for each listener
+	eventsInfo := eventsServer.Debug()
+	var eventsPending int64
+	for _, listener := range eventsInfo.Listeners {
+		eventsPending += listener.Pending
+
+		labels := map[string]string{
+			"listener":       listener.ID,
+			"remote":         listener.Remote,
+			"project":        listener.Project,
+			"cluster_member": strconv.FormatBool(listener.ClusterMember),
+		}
+
+		out.AddSamples(metrics.EventsListenerPending, metrics.Sample{Value: float64(listener.Pending), Labels: labels})
+		out.AddSamples(metrics.EventsListenerSentTotal, metrics.Sample{Value: float64(listener.Sent), Labels: labels})
+		out.AddSamples(metrics.EventsListenerDroppedTotal, metrics.Sample{Value: float64(listener.Dropped), Labels: labels})
 	}
 
+	out.AddSamples(metrics.EventsListeners, metrics.Sample{Value: float64(len(eventsInfo.Listeners))})
+	out.AddSamples(metrics.EventsPending, metrics.Sample{Value: float64(eventsPending)})
+	out.AddSamples(metrics.EventsSentTotal, metrics.Sample{Value: float64(eventsInfo.Sent)})
+	out.AddSamples(metrics.EventsDroppedTotal, metrics.Sample{Value: float64(eventsInfo.Dropped)})
+
 	// Daemon uptime
 	out.AddSamples(metrics.UptimeSeconds, metrics.Sample{Value: time.Since(daemonStartTime).Seconds()})
 
@@ -343,3 +400,52 @@ func internalMetrics(ctx context.Context, daemonStartTime time.Time, tx *db.Clus
 
 	return out
 }
+
+// metricsCertificate returns the dedicated certificate of the metrics endpoint.
+// Returns nil unless both the certificate and key are set, in which case the network certificate should be used.
+func metricsCertificate(nodeConfig *node.Config) (*localtls.CertInfo, error) {
+	cert, key := nodeConfig.MetricsCertificate()
+	if cert == "" || key == "" {
+		return nil, nil
+	}
+
+	certInfo, err := localtls.KeyPairFromRaw([]byte(cert), []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid metrics certificate: %w", err)
+	}
+
+	return certInfo, nil
+}
+
+// warningsSamples counts the unresolved warnings with the same type, severity, status, project and location.
+func warningsSamples(warnings []dbCluster.Warning) []metrics.Sample {
+	samples := []metrics.Sample{}
+	indexes := map[string]int{}
+
+	for _, w := range warnings {
+		if w.Status == warningtype.StatusResolved {
+			continue
+		}
+
+		labels := map[string]string{
+			"type":     warningtype.TypeNames[w.TypeCode],
+			"severity": warningtype.Severities[w.TypeCode.Severity()],
+			"status":   warningtype.Statuses[w.Status],
+			"project":  w.Project,
+			"location": w.Node,
+		}
+
+		key := fmt.Sprintf("%s|%s|%s|%s", labels["type"], labels["status"], labels["project"], labels["location"])
+
+		i, ok := indexes[key]
+		if !ok {
+			i = len(samples)
+			indexes[key] = i
+			samples = append(samples, metrics.Sample{Labels: labels})
+		}
+
+		samples[i].Value++
+	}
+
+	return samples
+}
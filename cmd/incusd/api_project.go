@@ -9,21 +9,28 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/gorilla/mux"
 
+	internalInstance "github.com/lxc/incus/internal/instance"
 	"github.com/lxc/incus/internal/jmap"
 	"github.com/lxc/incus/internal/server/db"
 	"github.com/lxc/incus/internal/server/db/cluster"
 	"github.com/lxc/incus/internal/server/db/operationtype"
+	"github.com/lxc/incus/internal/server/instance"
+	"github.com/lxc/incus/internal/server/instance/instancetype"
 	"github.com/lxc/incus/internal/server/lifecycle"
 	"github.com/lxc/incus/internal/server/network"
+	"github.com/lxc/incus/internal/server/network/zone"
 	"github.com/lxc/incus/internal/server/operations"
 	projecthelpers "github.com/lxc/incus/internal/server/project"
 	"github.com/lxc/incus/internal/server/request"
 	"github.com/lxc/incus/internal/server/response"
 	"github.com/lxc/incus/internal/server/state"
+	storagePools "github.com/lxc/incus/internal/server/storage"
+	storageDrivers "github.com/lxc/incus/internal/server/storage/drivers"
 	localUtil "github.com/lxc/incus/internal/server/util"
 	"github.com/lxc/incus/internal/version"
 	"github.com/lxc/incus/shared/api"
@@ -49,6 +56,24 @@ var projectCmd = APIEndpoint{
 	Put:    APIEndpointAction{Handler: projectPut, AccessHandler: allowAuthenticated},
 }
 
+var projectDependenciesCmd = APIEndpoint{
+	Path: "projects/{name}/dependencies",
+
+	Get: APIEndpointAction{Handler: projectDependenciesGet, AccessHandler: allowAuthenticated},
+}
+
+var projectDNSRecordsCmd = APIEndpoint{
+	Path: "projects/{name}/dns-records",
+
+	Get: APIEndpointAction{Handler: projectDNSRecordsGet, AccessHandler: allowAuthenticated},
+}
+
+var projectStorageVolumeAttributionCmd = APIEndpoint{
+	Path: "projects/{name}/storage-volume-attribution",
+
+	Get: APIEndpointAction{Handler: projectStorageVolumeAttributionGet, AccessHandler: allowAuthenticated},
+}
+
 var projectStateCmd = APIEndpoint{
 	Path: "projects/{name}/state",
 
@@ -141,7 +166,7 @@ func projectsGet(d *Daemon, r *http.Request) response.Response {
 	recursion := localUtil.IsRecursionRequest(r)
 
 	var result any
-	err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+	err := s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		projects, err := cluster.GetProjects(ctx, tx.Tx())
 		if err != nil {
 			return err
@@ -306,31 +331,9 @@ func projectsPost(d *Daemon, r *http.Request) response.Response {
 
 	var id int64
 	err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-		id, err = cluster.CreateProject(ctx, tx.Tx(), cluster.Project{Description: project.Description, Name: project.Name})
-		if err != nil {
-			return fmt.Errorf("Failed adding database record: %w", err)
-		}
+		id, err = projectCreate(ctx, tx, project)
 
-		err = cluster.CreateProjectConfig(ctx, tx.Tx(), id, project.Config)
-		if err != nil {
-			return fmt.Errorf("Unable to create project config for project %q: %w", project.Name, err)
-		}
-
-		if util.IsTrue(project.Config["features.profiles"]) {
-			err = projectCreateDefaultProfile(tx, project.Name)
-			if err != nil {
-				return err
-			}
-
-			if project.Config["features.images"] == "false" {
-				err = cluster.InitProjectWithoutImages(ctx, tx.Tx(), project.Name)
-				if err != nil {
-					return err
-				}
-			}
-		}
-
-		return nil
+		return err
 	})
 	if err != nil {
 		return response.SmartError(fmt.Errorf("Failed creating project %q: %w", project.Name, err))
@@ -348,6 +351,40 @@ func projectsPost(d *Daemon, r *http.Request) response.Response {
 	return response.SyncResponseLocation(true, nil, lc.Source)
 }
 
+// projectCreate adds the database records of a new project (including its default profile) and returns its ID.
+func projectCreate(ctx context.Context, tx *db.ClusterTx, project api.ProjectsPost) (int64, error) {
+	err := projectValidateAddressPools(ctx, tx, project.Name, project.Config)
+	if err != nil {
+		return -1, err
+	}
+
+	id, err := cluster.CreateProject(ctx, tx.Tx(), cluster.Project{Description: project.Description, Name: project.Name})
+	if err != nil {
+		return -1, fmt.Errorf("Failed adding database record: %w", err)
+	}
+
+	err = cluster.CreateProjectConfig(ctx, tx.Tx(), id, project.Config)
+	if err != nil {
+		return -1, fmt.Errorf("Unable to create project config for project %q: %w", project.Name, err)
+	}
+
+	if util.IsTrue(project.Config["features.profiles"]) {
+		err = projectCreateDefaultProfile(tx, project.Name)
+		if err != nil {
+			return -1, err
+		}
+
+		if project.Config["features.images"] == "false" {
+			err = cluster.InitProjectWithoutImages(ctx, tx.Tx(), project.Name)
+			if err != nil {
+				return -1, err
+			}
+		}
+	}
+
+	return id, nil
+}
+
 // Create the default profile of a project.
 func projectCreateDefaultProfile(tx *db.ClusterTx, project string) error {
 	// Create a default profile
@@ -413,7 +450,7 @@ func projectGet(d *Daemon, r *http.Request) response.Response {
 
 	// Get the database entry
 	var project *api.Project
-	err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		dbProject, err := cluster.GetProject(ctx, tx.Tx(), name)
 		if err != nil {
 			return err
@@ -483,7 +520,7 @@ func projectPut(d *Daemon, r *http.Request) response.Response {
 
 	// Get the current data
 	var project *api.Project
-	err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		dbProject, err := cluster.GetProject(ctx, tx.Tx(), name)
 		if err != nil {
 			return err
@@ -524,6 +561,11 @@ func projectPut(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
+	// Only server administrators can change whether the project is read-only.
+	if util.IsTrue(req.Config["read_only"]) != util.IsTrue(project.Config["read_only"]) && !s.Authorizer.UserIsAdmin(r) {
+		return response.Forbidden(fmt.Errorf("Only server administrators can change the read_only option"))
+	}
+
 	requestor := request.CreateRequestor(r)
 	s.Events.SendLifecycle(project.Name, lifecycle.ProjectUpdated.Event(project.Name, requestor, nil))
 
@@ -574,7 +616,7 @@ func projectPatch(d *Daemon, r *http.Request) response.Response {
 
 	// Get the current data
 	var project *api.Project
-	err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		dbProject, err := cluster.GetProject(ctx, tx.Tx(), name)
 		if err != nil {
 			return err
@@ -645,6 +687,11 @@ func projectPatch(d *Daemon, r *http.Request) response.Response {
 		}
 	}
 
+	// Only server administrators can change whether the project is read-only.
+	if util.IsTrue(req.Config["read_only"]) != util.IsTrue(project.Config["read_only"]) && !s.Authorizer.UserIsAdmin(r) {
+		return response.Forbidden(fmt.Errorf("Only server administrators can change the read_only option"))
+	}
+
 	requestor := request.CreateRequestor(r)
 	s.Events.SendLifecycle(project.Name, lifecycle.ProjectUpdated.Event(project.Name, requestor, nil))
 
@@ -717,6 +764,13 @@ func projectChange(s *state.State, project *api.Project, req api.ProjectPut) res
 			return err
 		}
 
+		if util.ValueInSlice("networks.address_pools", configChanged) || util.ValueInSlice("features.networks", configChanged) {
+			err = projectValidateAddressPools(ctx, tx, project.Name, req.Config)
+			if err != nil {
+				return err
+			}
+		}
+
 		err = cluster.UpdateProject(ctx, tx.Tx(), project.Name, req)
 		if err != nil {
 			return fmt.Errorf("Persist profile changes: %w", err)
@@ -850,6 +904,8 @@ func projectPost(d *Daemon, r *http.Request) response.Response {
 			return err
 		}
 
+		d.projectRequests.Forget(name)
+
 		requestor := request.CreateRequestor(r)
 		s.Events.SendLifecycle(req.Name, lifecycle.ProjectRenamed.Event(req.Name, requestor, logger.Ctx{"old_name": name}))
 
@@ -901,22 +957,37 @@ func projectDelete(d *Daemon, r *http.Request) response.Response {
 			return fmt.Errorf("Fetch project %q: %w", name, err)
 		}
 
-		empty, err := projectIsEmpty(ctx, project, tx)
+		dependencies, err := projectDependencies(ctx, tx, project)
 		if err != nil {
 			return err
 		}
 
-		if !empty {
-			return fmt.Errorf("Only empty projects can be removed")
+		summary := projectDependenciesSummary(dependencies)
+		if summary != "" {
+			return fmt.Errorf("Only empty projects can be removed (still contains %s)", summary)
 		}
 
-		return cluster.DeleteProject(ctx, tx.Tx(), name)
+		// Remove the project from the authorizer before committing, so that a failure leaves the project in place.
+		err = s.Authorizer.DeleteProject(int64(project.ID))
+		if err != nil {
+			return err
+		}
+
+		err = cluster.DeleteProject(ctx, tx.Tx(), name)
+		if err != nil {
+			_ = s.Authorizer.AddProject(int64(project.ID), name)
+			return err
+		}
+
+		return nil
 	})
 
 	if err != nil {
 		return response.SmartError(err)
 	}
 
+	d.projectRequests.Forget(name)
+
 	requestor := request.CreateRequestor(r)
 	s.Events.SendLifecycle(name, lifecycle.ProjectDeleted.Event(name, requestor, nil))
 
@@ -974,7 +1045,7 @@ func projectStateGet(d *Daemon, r *http.Request) response.Response {
 	state := api.ProjectState{}
 
 	// Get current limits and usage.
-	err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
 		result, err := projecthelpers.GetCurrentAllocations(ctx, tx, name)
 		if err != nil {
 			return err
@@ -988,71 +1059,499 @@ func projectStateGet(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	// Get the API request quota usage.
+	limit, err := projectRequestsLimit(s, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	state.Resources["requests"] = api.ProjectStateResource{
+		Limit: limit,
+		Usage: d.projectRequests.Usage(name),
+	}
+
 	return response.SyncResponse(true, &state)
 }
 
-// Check if a project is empty.
-func projectIsEmpty(ctx context.Context, project *cluster.Project, tx *db.ClusterTx) (bool, error) {
-	instances, err := cluster.GetInstances(ctx, tx.Tx(), cluster.InstanceFilter{Project: &project.Name})
+// swagger:operation GET /1.0/projects/{name}/dependencies projects project_dependencies_get
+//
+//	Get the project dependencies
+//
+//	Lists the entities still attributed to the project which must be removed before the project can be deleted.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: Project dependencies
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/ProjectDependencies"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func projectDependenciesGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
 	if err != nil {
-		return false, err
+		return response.SmartError(err)
 	}
 
-	if len(instances) > 0 {
-		return false, nil
+	// Check user permissions.
+	if !s.Authorizer.UserHasPermission(r, name, "") {
+		return response.Forbidden(nil)
 	}
 
-	images, err := cluster.GetImages(ctx, tx.Tx(), cluster.ImageFilter{Project: &project.Name})
+	var dependencies *api.ProjectDependencies
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		project, err := cluster.GetProject(ctx, tx.Tx(), name)
+		if err != nil {
+			return fmt.Errorf("Failed loading project %q: %w", name, err)
+		}
+
+		dependencies, err = projectDependencies(ctx, tx, project)
+
+		return err
+	})
 	if err != nil {
-		return false, err
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, dependencies)
+}
+
+// swagger:operation GET /1.0/projects/{name}/dns-records projects project_dns_records_get
+//
+//	Get the project DNS records
+//
+//	Computes the DNS records that the network zones generate for the instances of the project, without serving them.
+//	The zones are taken from the project used for network zones (the default project unless `features.networks.zones` is enabled).
+//	Records of other projects and manual zone records aren't included.
+//	When the zones don't serve the project yet, the records are a preview of those that would be served.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: Project DNS records
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/ProjectDNSRecords"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func projectDNSRecordsGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
 	}
 
-	if len(images) > 0 {
-		return false, nil
+	// Check user permissions.
+	if !s.Authorizer.UserHasPermission(r, name, "") {
+		return response.Forbidden(nil)
 	}
 
-	profiles, err := cluster.GetProfiles(ctx, tx.Tx(), cluster.ProfileFilter{Project: &project.Name})
+	// Get the project used for the network zones.
+	var p *api.Project
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		dbProject, err := cluster.GetProject(ctx, tx.Tx(), name)
+		if err != nil {
+			return fmt.Errorf("Failed loading project %q: %w", name, err)
+		}
+
+		p, err = dbProject.ToAPI(ctx, tx.Tx())
+
+		return err
+	})
 	if err != nil {
-		return false, err
+		return response.SmartError(err)
 	}
 
-	if len(profiles) > 0 {
-		// Consider the project empty if it is only used by the default profile.
-		if len(profiles) == 1 && profiles[0].Name == "default" {
-			return true, nil
+	zoneProjectName := projecthelpers.NetworkZoneProjectFromRecord(p)
+
+	zoneNames, err := s.DB.Cluster.GetNetworkZonesByProject(zoneProjectName)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed loading network zones: %w", err))
+	}
+
+	// Zones from the default project only serve the instances of the default project.
+	result := api.ProjectDNSRecords{
+		ZoneProject: zoneProjectName,
+		Served:      zoneProjectName == name,
+		Forward:     []api.ProjectDNSZone{},
+		Reverse:     []api.ProjectDNSZone{},
+	}
+
+	for _, zoneName := range zoneNames {
+		netzone, err := zone.LoadByNameAndProject(s, zoneProjectName, zoneName)
+		if err != nil {
+			return response.SmartError(err)
 		}
 
-		return false, nil
+		// Only generate the records of the project's instances as the zone may belong to another project.
+		records, err := netzone.ProjectRecords(name)
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed generating records for network zone %q: %w", zoneName, err))
+		}
+
+		dnsZone := api.ProjectDNSZone{Name: zoneName, Records: records}
+		if zone.IsReverse(zoneName) {
+			result.Reverse = append(result.Reverse, dnsZone)
+		} else {
+			result.Forward = append(result.Forward, dnsZone)
+		}
 	}
 
-	volumes, err := tx.GetStorageVolumeURIs(ctx, project.Name)
+	return response.SyncResponse(true, result)
+}
+
+// swagger:operation GET /1.0/projects/{name}/storage-volume-attribution projects project_storage_volume_attribution_get
+//
+//	Get the storage volume attribution
+//
+//	Returns the project that the storage volumes of the given type are attributed to when requested in the project,
+//	along with the project prefixed name used by the storage drivers for the given volume.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: type
+//	    description: Storage volume type
+//	    type: string
+//	    example: custom
+//	    required: true
+//	  - in: query
+//	    name: volume
+//	    description: Storage volume name
+//	    type: string
+//	    example: vol1
+//	  - in: query
+//	    name: prefixed_name
+//	    description: Project prefixed storage volume name to check (instead of volume)
+//	    type: string
+//	    example: default_vol1
+//	responses:
+//	  "200":
+//	    description: Storage volume attribution
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/ProjectStorageVolumeAttribution"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func projectStorageVolumeAttributionGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
 	if err != nil {
-		return false, err
+		return response.SmartError(err)
 	}
 
-	if len(volumes) > 0 {
-		return false, nil
+	// Check user permissions.
+	if !s.Authorizer.UserHasPermission(r, name, "") {
+		return response.Forbidden(nil)
 	}
 
-	networks, err := tx.GetNetworkURIs(ctx, project.ID, project.Name)
+	volumeTypeName := queryParam(r, "type")
+	volumeType, err := storagePools.VolumeTypeNameToDBType(volumeTypeName)
 	if err != nil {
-		return false, err
+		return response.BadRequest(fmt.Errorf("Invalid storage volume type %q", volumeTypeName))
 	}
 
-	if len(networks) > 0 {
-		return false, nil
+	volumeName := queryParam(r, "volume")
+	prefixedName := queryParam(r, "prefixed_name")
+	if volumeName != "" && prefixedName != "" {
+		return response.BadRequest(fmt.Errorf("Only one of volume and prefixed_name can be provided"))
 	}
 
-	acls, err := tx.GetNetworkACLURIs(ctx, project.ID, project.Name)
+	var p *api.Project
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		dbProject, err := cluster.GetProject(ctx, tx.Tx(), name)
+		if err != nil {
+			return fmt.Errorf("Failed loading project %q: %w", name, err)
+		}
+
+		p, err = dbProject.ToAPI(ctx, tx.Tx())
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	attribution := api.ProjectStorageVolumeAttribution{
+		Project:          name,
+		Type:             volumeTypeName,
+		EffectiveProject: projecthelpers.StorageVolumeProjectFromRecord(p, volumeType),
+		Volume:           volumeName,
+	}
+
+	if prefixedName != "" {
+		if !strings.Contains(prefixedName, "_") {
+			return response.BadRequest(fmt.Errorf("Storage volume name %q isn't project prefixed", prefixedName))
+		}
+
+		var prefixProject string
+		prefixProject, attribution.Volume = projecthelpers.StorageVolumeParts(prefixedName)
+		if prefixProject != attribution.EffectiveProject {
+			return response.BadRequest(fmt.Errorf("Storage volume %q is prefixed with project %q but %s volumes of project %q are attributed to project %q", prefixedName, prefixProject, volumeTypeName, name, attribution.EffectiveProject))
+		}
+	}
+
+	if attribution.Volume != "" {
+		attribution.PrefixedName = projecthelpers.StorageVolume(attribution.EffectiveProject, attribution.Volume)
+	}
+
+	return response.SyncResponse(true, attribution)
+}
+
+// projectConfig returns the configuration of the project (nil if the project doesn't exist).
+func projectConfig(s *state.State, projectName string) (map[string]string, error) {
+	var config map[string]string
+
+	err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		dbProject, err := cluster.GetProject(ctx, tx.Tx(), projectName)
+		if err != nil {
+			return err
+		}
+
+		config, err = cluster.GetProjectConfig(ctx, tx.Tx(), dbProject.ID)
+
+		return err
+	})
+	if err != nil {
+		if response.IsNotFoundError(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// projectRequestsLimit returns the maximum number of API requests per minute allowed in the project (-1 if none).
+func projectRequestsLimit(s *state.State, projectName string) (int64, error) {
+	config, err := projectConfig(s, projectName)
+	if err != nil {
+		return -1, err
+	}
+
+	return projectRequestsLimitFromConfig(config)
+}
+
+// projectRequestsLimitFromConfig returns the maximum number of API requests per minute allowed by the project
+// configuration (-1 if none).
+func projectRequestsLimitFromConfig(config map[string]string) (int64, error) {
+	value := config["limits.requests"]
+	if value == "" {
+		return -1, nil
+	}
+
+	limit, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return -1, fmt.Errorf("Invalid value %q for limits.requests: %w", value, err)
+	}
+
+	return limit, nil
+}
+
+// Check if a project is empty.
+func projectIsEmpty(ctx context.Context, project *cluster.Project, tx *db.ClusterTx) (bool, error) {
+	dependencies, err := projectDependencies(ctx, tx, project)
 	if err != nil {
 		return false, err
 	}
 
-	if len(acls) > 0 {
-		return false, nil
+	return projectDependenciesSummary(dependencies) == "", nil
+}
+
+// projectDependencies returns the URLs of the entities still attributed to the project.
+// The entity types which the project inherits from the default project (based on its features) are skipped as
+// those entities live in the default project. The default profile is skipped as it's removed with the project.
+func projectDependencies(ctx context.Context, tx *db.ClusterTx, project *cluster.Project) (*api.ProjectDependencies, error) {
+	p, err := project.ToAPI(ctx, tx.Tx())
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies := api.ProjectDependencies{
+		Instances:      []string{},
+		Images:         []string{},
+		Profiles:       []string{},
+		StorageVolumes: []string{},
+		StorageBuckets: []string{},
+		Networks:       []string{},
+		NetworkACLs:    []string{},
+		NetworkZones:   []string{},
+	}
+
+	instances, err := cluster.GetInstances(ctx, tx.Tx(), cluster.InstanceFilter{Project: &project.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, instance := range instances {
+		apiInstance := api.Instance{Name: instance.Name}
+		dependencies.Instances = append(dependencies.Instances, apiInstance.URL(version.APIVersion, project.Name).String())
+	}
+
+	if projecthelpers.ImageProjectFromRecord(p) == project.Name {
+		images, err := cluster.GetImages(ctx, tx.Tx(), cluster.ImageFilter{Project: &project.Name})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, image := range images {
+			apiImage := api.Image{Fingerprint: image.Fingerprint}
+			dependencies.Images = append(dependencies.Images, apiImage.URL(version.APIVersion, project.Name).String())
+		}
+	}
+
+	if projecthelpers.ProfileProjectFromRecord(p) == project.Name {
+		profiles, err := cluster.GetProfiles(ctx, tx.Tx(), cluster.ProfileFilter{Project: &project.Name})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, profile := range profiles {
+			if profile.Name == "default" {
+				continue
+			}
+
+			apiProfile := api.Profile{Name: profile.Name}
+			dependencies.Profiles = append(dependencies.Profiles, apiProfile.URL(version.APIVersion, project.Name).String())
+		}
+	}
+
+	if projecthelpers.StorageVolumeProjectFromRecord(p, db.StoragePoolVolumeTypeCustom) == project.Name {
+		dependencies.StorageVolumes, err = tx.GetStorageVolumeURIs(ctx, project.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if projecthelpers.StorageBucketProjectFromRecord(p) == project.Name {
+		buckets, err := tx.GetStoragePoolBuckets(ctx, false, db.StorageBucketFilter{Project: &project.Name})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, bucket := range buckets {
+			dependencies.StorageBuckets = append(dependencies.StorageBuckets, bucket.URL(version.APIVersion, bucket.PoolName, project.Name).String())
+		}
 	}
 
-	return true, nil
+	if projecthelpers.NetworkProjectFromRecord(p) == project.Name {
+		dependencies.Networks, err = tx.GetNetworkURIs(ctx, project.ID, project.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		dependencies.NetworkACLs, err = tx.GetNetworkACLURIs(ctx, project.ID, project.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if projecthelpers.NetworkZoneProjectFromRecord(p) == project.Name {
+		dependencies.NetworkZones, err = tx.GetNetworkZoneURIs(ctx, project.ID, project.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &dependencies, nil
+}
+
+// projectDependenciesSummary returns a short description of the entities still attributed to the project, or an
+// empty string if there are none.
+func projectDependenciesSummary(dependencies *api.ProjectDependencies) string {
+	counts := []struct {
+		name string
+		urls []string
+	}{
+		{"instances", dependencies.Instances},
+		{"images", dependencies.Images},
+		{"profiles", dependencies.Profiles},
+		{"storage volumes", dependencies.StorageVolumes},
+		{"storage buckets", dependencies.StorageBuckets},
+		{"networks", dependencies.Networks},
+		{"network ACLs", dependencies.NetworkACLs},
+		{"network zones", dependencies.NetworkZones},
+	}
+
+	parts := []string{}
+	for _, count := range counts {
+		if len(count.urls) > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d", count.name, len(count.urls)))
+		}
+	}
+
+	return strings.Join(parts, ", ")
 }
 
 func isEitherAllowOrBlock(value string) error {
@@ -1152,6 +1651,22 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		//  type: integer
 		//  shortdesc: When an unused cached remote image is flushed in the project
 		"images.remote_cache_expiry": validate.Optional(validate.IsInt64),
+		// gendoc:generate(entity=project, group=specific, key=images.servers)
+		// Specify a comma-separated list of image server URLs that images can be downloaded from in this project.
+		// If {config:option}`project-features:features.images` is disabled, the value of the `default` project applies.
+		// The image remotes of the project are always allowed.
+		// ---
+		//  type: string
+		//  defaultdesc: all servers
+		//  shortdesc: Image servers that images can be downloaded from
+		"images.servers": validate.Optional(validate.IsListOf(validate.IsRequestURL)),
+		// gendoc:generate(entity=project, group=specific, key=instances.name.pattern)
+		// Regular expression that the names of new or renamed instances in the project must fully match, for example `web-[0-9]+`.
+		// It applies on top of the built-in rules and of the server's {config:option}`server-miscellaneous:instances.name.pattern`.
+		// ---
+		//  type: string
+		//  shortdesc: Pattern that instance names must match
+		"instances.name.pattern": validate.Optional(internalInstance.ValidNamePattern),
 		// gendoc:generate(entity=project, group=limits, key=limits.instances)
 		//
 		// ---
@@ -1200,6 +1715,35 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		//  type: integer
 		//  shortdesc: Maximum number of networks that the project can have
 		"limits.networks": validate.Optional(validate.IsUint32),
+		// gendoc:generate(entity=project, group=limits, key=limits.requests)
+		// This limit applies to API requests that modify the project (anything other than `GET` and `HEAD`) made by restricted clients.
+		// Requests above the limit are rejected with `429 Too Many Requests`.
+		// The limit is enforced separately by each cluster member.
+		// ---
+		//  type: integer
+		//  shortdesc: Maximum number of API requests per minute for the project
+		"limits.requests": validate.Optional(validate.IsUint32),
+		// gendoc:generate(entity=project, group=specific, key=networks.address_pools)
+		// Specify a comma-separated list of IPv4 and IPv6 subnets that the managed networks of the project take their subnets from.
+		// New networks that don't specify their `ipv4.address` or `ipv6.address` (or set it to `auto`) get an unused `/24` (IPv4) or `/64` (IPv6) subnet from the pools, and the subnets set explicitly must be within the pools.
+		// The same applies when updating the networks, and the existing networks must use subnets within the pools when changing them.
+		// Only the IP families included in the pools are affected.
+		// The pools of a project can't overlap with the pools of other projects, and the networks of other projects can't use subnets within the pools.
+		// This requires {config:option}`project-features:features.networks` to be enabled.
+		// ---
+		//  type: string
+		//  shortdesc: Subnets that the networks of the project use
+		"networks.address_pools": validate.Optional(validate.IsListOf(validate.IsNetwork)),
+		// gendoc:generate(entity=project, group=specific, key=read_only)
+		// When enabled, all requests modifying the entities of the project (instances, profiles, images, volumes...) are rejected, while they can still be retrieved.
+		// Server administrators aren't affected, so they can still modify a read-only project.
+		// The project itself can still be updated, so the option can be disabled again.
+		// Only server administrators can change this option.
+		// ---
+		//  type: bool
+		//  defaultdesc: `false`
+		//  shortdesc: Whether the entities of the project are read-only
+		"read_only": validate.Optional(validate.IsBool),
 		// gendoc:generate(entity=project, group=restricted, key=restricted)
 		// This option must be enabled to allow the `restricted.*` keys to take effect.
 		// To temporarily remove the restrictions, you can disable this option instead of clearing the related keys.
@@ -1411,6 +1955,14 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		//  defaultdesc: `block`
 		//  shortdesc: Whether to prevent creating instance or volume snapshots
 		"restricted.snapshots": isEitherAllowOrBlock,
+		// gendoc:generate(entity=project, group=restricted, key=restricted.storage.drivers)
+		// Specify a comma-delimited list of storage drivers (for example, `ceph,lvm`) whose storage pools can be used to create instances and custom storage volumes in this project.
+		// This also applies when copying or moving instances and custom storage volumes, and when changing the storage pool of the root disk of instances and profiles.
+		// If this option is not set, all storage pools can be used.
+		// ---
+		//  type: string
+		//  shortdesc: Which storage drivers are allowed for use in this project
+		"restricted.storage.drivers": validate.Optional(validate.IsListOf(validate.IsOneOf(storageDrivers.AllDriverNames()...))),
 	}
 
 	for k, v := range config {
@@ -1427,6 +1979,38 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 			continue
 		}
 
+		// gendoc:generate(entity=project, group=specific, key=instances.defaults.*)
+		// Instance configuration keys set on the new instances of the project, unless set on the instance or in one of its profiles.
+		// Devices can't be set this way, add them to the profiles of the project instead.
+		// See {ref}`projects-instance-defaults`.
+		// ---
+		//  type: string
+		//  shortdesc: Default instance configuration
+		if strings.HasPrefix(key, projecthelpers.InstanceDefaultsPrefix) {
+			if strings.HasPrefix(key, projecthelpers.InstanceDefaultsPrefix+"devices.") {
+				return fmt.Errorf("Invalid project configuration key %q: Default devices aren't supported, add them to the profiles of the project instead", key)
+			}
+
+			continue
+		}
+
+		// gendoc:generate(entity=project, group=specific, key=images.remotes.*)
+		// Image remotes of the project, set as `images.remotes.<name>.url` and `images.remotes.<name>.protocol`
+		// (`simplestreams` or `incus`, defaults to `simplestreams`).
+		// The name of a remote can be used as the image server when creating instances or images in the project.
+		// See {ref}`images-remotes`.
+		// ---
+		//  type: string
+		//  shortdesc: Image remotes of the project
+		if strings.HasPrefix(key, projecthelpers.ImageRemotesPrefix) {
+			err := projectValidateImageRemote(key, v)
+			if err != nil {
+				return fmt.Errorf("Invalid project configuration key %q value: %w", k, err)
+			}
+
+			continue
+		}
+
 		// Then validate.
 		validator, ok := projectConfigKeys[key]
 		if !ok {
@@ -1439,6 +2023,12 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		}
 	}
 
+	// Validate the default instance configuration against the instance configuration schema.
+	err := instance.ValidConfig(s.OS, projecthelpers.InstanceDefaults(&api.Project{Config: config}), false, instancetype.Any)
+	if err != nil {
+		return fmt.Errorf("Invalid default instance configuration: %w", err)
+	}
+
 	// Ensure that restricted projects have their own profiles. Otherwise restrictions in this project could
 	// be bypassed by settings from the default project's profiles that are not checked against this project's
 	// restrictions when they are configured.
@@ -1449,6 +2039,93 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 	return nil
 }
 
+// projectValidateAddressPools checks that the network address pools of the project don't overlap with the pools
+// of the other projects, that the networks of the other projects don't use subnets from the pools and that the
+// existing networks of the project use subnets from the pools.
+func projectValidateAddressPools(ctx context.Context, tx *db.ClusterTx, projectName string, config map[string]string) error {
+	if config["networks.address_pools"] == "" {
+		return nil
+	}
+
+	// Without features.networks, the networks of the project are created in the default project.
+	if projectName != projecthelpers.Default && util.IsFalseOrEmpty(config["features.networks"]) {
+		return api.StatusErrorf(http.StatusBadRequest, "Projects without their own networks can't have networks.address_pools")
+	}
+
+	pools, err := network.SubnetParseAppend(nil, util.SplitNTrimSpace(config["networks.address_pools"], ",", -1, false)...)
+	if err != nil {
+		return api.StatusErrorf(http.StatusBadRequest, "Invalid networks.address_pools: %w", err)
+	}
+
+	projectsPools, err := networkProjectsAddressPools(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	for otherProject, otherPools := range projectsPools {
+		if otherProject == projectName {
+			continue
+		}
+
+		for _, pool := range pools {
+			otherPool := networkSubnetOverlappingPool(pool, otherPools)
+			if otherPool != nil {
+				return api.StatusErrorf(http.StatusBadRequest, "Address pool %q overlaps with address pool %q of project %q", pool.String(), otherPool.String(), otherProject)
+			}
+		}
+	}
+
+	// Check the subnets of the existing networks.
+	networks, err := tx.GetCreatedNetworks(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed loading networks: %w", err)
+	}
+
+	for networksProject, projectNetworks := range networks {
+		for _, n := range projectNetworks {
+			if networksProject == projectName {
+				err = networkCheckProjectSubnets(pools, projectName, n.Config)
+				if err != nil {
+					return api.StatusErrorf(http.StatusBadRequest, "Network %q doesn't match the address pools: %w", n.Name, err)
+				}
+
+				continue
+			}
+
+			for _, key := range []string{"ipv4.address", "ipv6.address"} {
+				_, subnet, err := net.ParseCIDR(n.Config[key])
+				if err != nil {
+					continue
+				}
+
+				pool := networkSubnetOverlappingPool(subnet, pools)
+				if pool != nil {
+					return api.StatusErrorf(http.StatusBadRequest, "Address pool %q overlaps with network %q of project %q", pool.String(), n.Name, networksProject)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// projectValidateImageRemote validates an images.remotes.<name>.<setting> project configuration key and its value.
+func projectValidateImageRemote(key string, value string) error {
+	fields := strings.Split(strings.TrimPrefix(key, projecthelpers.ImageRemotesPrefix), ".")
+	if len(fields) != 2 || fields[0] == "" {
+		return fmt.Errorf("Image remote keys must be of the form %s<name>.url or %s<name>.protocol", projecthelpers.ImageRemotesPrefix, projecthelpers.ImageRemotesPrefix)
+	}
+
+	switch fields[1] {
+	case "url":
+		return validate.IsRequestURL(value)
+	case "protocol":
+		return validate.Optional(validate.IsOneOf("simplestreams", "incus"))(value)
+	}
+
+	return fmt.Errorf("Unknown image remote setting %q", fields[1])
+}
+
 func projectValidateName(name string) error {
 	if name == "" {
 		return fmt.Errorf("No name provided")
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/lxc/incus/internal/server/db"
+	"github.com/lxc/incus/internal/server/db/cluster"
+	"github.com/lxc/incus/internal/server/lifecycle"
+	"github.com/lxc/incus/internal/server/request"
+	"github.com/lxc/incus/internal/server/response"
+	"github.com/lxc/incus/shared/api"
+)
+
+var projectsBulkCmd = APIEndpoint{
+	Path: "projects-bulk",
+
+	Post: APIEndpointAction{Handler: projectsBulkPost},
+}
+
+// swagger:operation POST /1.0/projects-bulk projects projects_bulk_post
+//
+//	Add several projects
+//
+//	Creates several projects sharing the same description and configuration.
+//	In all-or-nothing mode, the projects are created in a single transaction and either all or none of them are created.
+//	Otherwise each project is created separately and the projects which couldn't be created are reported.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: projects
+//	    description: Projects to create
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ProjectsBulkPost"
+//	responses:
+//	  "200":
+//	    description: Created projects
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/ProjectsBulkResult"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func projectsBulkPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// Parse the request.
+	req := api.ProjectsBulkPost{}
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if len(req.Names) == 0 {
+		return response.BadRequest(fmt.Errorf("No project names provided"))
+	}
+
+	seen := map[string]bool{}
+	for _, name := range req.Names {
+		if seen[name] {
+			return response.BadRequest(fmt.Errorf("Project %q is listed more than once", name))
+		}
+
+		seen[name] = true
+	}
+
+	// Set default features.
+	if req.Template.Config == nil {
+		req.Template.Config = map[string]string{}
+	}
+
+	for featureName, featureInfo := range cluster.ProjectFeatures {
+		_, ok := req.Template.Config[featureName]
+		if !ok && featureInfo.DefaultEnabled {
+			req.Template.Config[featureName] = "true"
+		}
+	}
+
+	// Validate the configuration.
+	err = projectValidateConfig(s, req.Template.Config)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// The address pools of different projects can't overlap.
+	if req.Template.Config["networks.address_pools"] != "" && len(req.Names) > 1 {
+		return response.BadRequest(fmt.Errorf("The networks.address_pools configuration can't be shared by several projects"))
+	}
+
+	result := api.ProjectsBulkResult{
+		Created: []string{},
+		Failed:  map[string]string{},
+	}
+
+	projects := make([]api.ProjectsPost, 0, len(req.Names))
+	for _, name := range req.Names {
+		err = projectValidateName(name)
+		if err != nil {
+			if req.AllOrNothing {
+				return response.BadRequest(fmt.Errorf("Invalid project %q: %w", name, err))
+			}
+
+			result.Failed[name] = err.Error()
+			continue
+		}
+
+		projects = append(projects, api.ProjectsPost{Name: name, ProjectPut: req.Template})
+	}
+
+	// Create the projects.
+	ids := map[string]int64{}
+	if req.AllOrNothing {
+		err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			for _, project := range projects {
+				id, err := projectCreate(ctx, tx, project)
+				if err != nil {
+					return fmt.Errorf("Failed creating project %q: %w", project.Name, err)
+				}
+
+				ids[project.Name] = id
+			}
+
+			return nil
+		})
+		if err != nil {
+			return response.SmartError(err)
+		}
+	} else {
+		for _, project := range projects {
+			err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+				id, err := projectCreate(ctx, tx, project)
+				if err != nil {
+					return err
+				}
+
+				ids[project.Name] = id
+
+				return nil
+			})
+			if err != nil {
+				result.Failed[project.Name] = err.Error()
+			}
+		}
+	}
+
+	requestor := request.CreateRequestor(r)
+	for _, project := range projects {
+		id, ok := ids[project.Name]
+		if !ok {
+			continue
+		}
+
+		err = s.Authorizer.AddProject(id, project.Name)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		lc := lifecycle.ProjectCreated.Event(project.Name, requestor, nil)
+		s.Events.SendLifecycle(project.Name, lc)
+
+		result.Created = append(result.Created, lc.Source)
+	}
+
+	return response.SyncResponse(true, result)
+}
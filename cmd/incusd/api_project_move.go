@@ -0,0 +1,394 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/lxc/incus/internal/server/db"
+	"github.com/lxc/incus/internal/server/db/cluster"
+	deviceConfig "github.com/lxc/incus/internal/server/device/config"
+	"github.com/lxc/incus/internal/server/instance"
+	"github.com/lxc/incus/internal/server/instance/instancetype"
+	"github.com/lxc/incus/internal/server/lifecycle"
+	"github.com/lxc/incus/internal/server/network"
+	projecthelpers "github.com/lxc/incus/internal/server/project"
+	"github.com/lxc/incus/internal/server/request"
+	"github.com/lxc/incus/internal/server/response"
+	"github.com/lxc/incus/shared/api"
+)
+
+var projectMoveCmd = APIEndpoint{
+	Path: "projects/{name}/move",
+
+	Post: APIEndpointAction{Handler: projectMovePost},
+}
+
+// swagger:operation POST /1.0/projects/{name}/move projects project_move_post
+//
+//	Move entities to another project
+//
+//	Moves (or copies) profiles and networks from the project to another project.
+//	The entities are all moved or none are.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: move
+//	    description: Entities to move
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ProjectMovePost"
+//	responses:
+//	  "200":
+//	    description: Moved entities
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/ProjectMoveResult"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "409":
+//	    $ref: "#/responses/Conflict"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func projectMovePost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	req := api.ProjectMovePost{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// Quick checks.
+	if req.Project == "" {
+		return response.BadRequest(fmt.Errorf("No target project provided"))
+	}
+
+	if req.Project == name {
+		return response.BadRequest(fmt.Errorf("Project and target project are the same"))
+	}
+
+	if len(req.Profiles) == 0 && len(req.Networks) == 0 {
+		return response.BadRequest(fmt.Errorf("No profile or network provided"))
+	}
+
+	if req.Copy && len(req.Networks) > 0 {
+		return response.BadRequest(fmt.Errorf("Networks can only be moved"))
+	}
+
+	// Load the projects.
+	var sourceProject *api.Project
+	var targetProject *api.Project
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		dbProject, err := cluster.GetProject(ctx, tx.Tx(), name)
+		if err != nil {
+			return fmt.Errorf("Failed loading project %q: %w", name, err)
+		}
+
+		sourceProject, err = dbProject.ToAPI(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		dbProject, err = cluster.GetProject(ctx, tx.Tx(), req.Project)
+		if err != nil {
+			return fmt.Errorf("Failed loading project %q: %w", req.Project, err)
+		}
+
+		targetProject, err = dbProject.ToAPI(ctx, tx.Tx())
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Check the entities before moving them.
+	profiles, err := projectMoveCheckProfiles(s.DB.Cluster, sourceProject, targetProject, req)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	for _, profile := range profiles {
+		// Profiles can be applied to any instance type, so just use instancetype.Any type for validation.
+		err = instance.ValidDevices(s, *targetProject, instancetype.Any, deviceConfig.NewDevices(profile.Devices), nil)
+		if err != nil {
+			return response.BadRequest(fmt.Errorf("Invalid devices for profile %q in project %q: %w", profile.Name, targetProject.Name, err))
+		}
+	}
+
+	networks, err := projectMoveCheckNetworks(s.DB.Cluster, sourceProject, targetProject, req, func(name string) (network.Network, error) {
+		return network.LoadByName(s, sourceProject.Name, name)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// The networks validate their configuration (uplink, subnets, ACLs...) against their project, so validate
+	// them as if they were already part of the target project.
+	movedNetworks := make([]network.Network, 0, len(networks))
+	for _, n := range networks {
+		moved, err := network.LoadByNameInProject(s, sourceProject.Name, n.Name(), targetProject.Name)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		err = moved.Validate(moved.Config())
+		if err != nil {
+			return response.BadRequest(fmt.Errorf("Invalid network %q in project %q: %w", n.Name(), targetProject.Name, err))
+		}
+
+		movedNetworks = append(movedNetworks, moved)
+	}
+
+	// Move all the entities at once.
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		for _, profile := range profiles {
+			_, err := cluster.GetProfileID(ctx, tx.Tx(), targetProject.Name, profile.Name)
+			if err == nil {
+				return api.StatusErrorf(http.StatusConflict, "Profile %q already exists in project %q", profile.Name, targetProject.Name)
+			}
+
+			if req.Copy {
+				err = projectMoveCopyProfile(ctx, tx, targetProject.Name, profile)
+			} else {
+				err = tx.MoveProfile(ctx, sourceProject.Name, profile.Name, targetProject.Name)
+			}
+
+			if err != nil {
+				return fmt.Errorf("Failed moving profile %q: %w", profile.Name, err)
+			}
+
+			// Check the restrictions and limits of the target project now that the profile is part of it.
+			err = projecthelpers.AllowProfileUpdate(tx, targetProject.Name, profile.Name, profile.Writable())
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, n := range networks {
+			_, err := tx.GetNetworkID(ctx, targetProject.Name, n.Name())
+			if err == nil {
+				return api.StatusErrorf(http.StatusConflict, "Network %q already exists in project %q", n.Name(), targetProject.Name)
+			}
+
+			err = tx.MoveNetwork(ctx, sourceProject.Name, n.Name(), targetProject.Name)
+			if err != nil {
+				return fmt.Errorf("Failed moving network %q: %w", n.Name(), err)
+			}
+		}
+
+		return projectMoveCheckNetworksLimit(ctx, tx, targetProject, len(networks))
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	result := api.ProjectMoveResult{Profiles: []string{}, Networks: []string{}}
+	requestor := request.CreateRequestor(r)
+
+	for _, profile := range profiles {
+		if !req.Copy {
+			s.Events.SendLifecycle(sourceProject.Name, lifecycle.ProfileDeleted.Event(profile.Name, sourceProject.Name, requestor, nil))
+		}
+
+		lc := lifecycle.ProfileCreated.Event(profile.Name, targetProject.Name, requestor, nil)
+		s.Events.SendLifecycle(targetProject.Name, lc)
+		result.Profiles = append(result.Profiles, lc.Source)
+	}
+
+	for i, n := range networks {
+		s.Events.SendLifecycle(sourceProject.Name, lifecycle.NetworkDeleted.Event(n, requestor, nil))
+
+		lc := lifecycle.NetworkCreated.Event(movedNetworks[i], requestor, nil)
+		s.Events.SendLifecycle(targetProject.Name, lc)
+		result.Networks = append(result.Networks, lc.Source)
+	}
+
+	return response.SyncResponse(true, result)
+}
+
+// projectMoveCheckProfiles checks that the profiles can be moved (or copied) between the projects and returns them.
+func projectMoveCheckProfiles(c *db.Cluster, sourceProject *api.Project, targetProject *api.Project, req api.ProjectMovePost) ([]api.Profile, error) {
+	if len(req.Profiles) == 0 {
+		return nil, nil
+	}
+
+	// Without features.profiles, the profiles of a project are those of the default project.
+	for _, p := range []*api.Project{sourceProject, targetProject} {
+		if projecthelpers.ProfileProjectFromRecord(p) != p.Name {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "Project %q doesn't have features.profiles enabled", p.Name)
+		}
+	}
+
+	profiles := make([]api.Profile, 0, len(req.Profiles))
+	for _, name := range req.Profiles {
+		if name == "default" && !req.Copy {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "The default profile can't be moved")
+		}
+
+		_, profile, err := c.GetProfile(sourceProject.Name, name)
+		if err != nil {
+			return nil, api.StatusErrorf(http.StatusNotFound, "Profile %q not found in project %q", name, sourceProject.Name)
+		}
+
+		if !req.Copy {
+			instances, err := c.GetInstancesWithProfile(sourceProject.Name, name)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(instances) > 0 {
+				return nil, api.StatusErrorf(http.StatusBadRequest, "Profile %q is currently in use", name)
+			}
+		}
+
+		profiles = append(profiles, *profile)
+	}
+
+	return profiles, nil
+}
+
+// projectMoveCheckNetworks checks that the networks can be moved between the projects and returns them.
+func projectMoveCheckNetworks(c *db.Cluster, sourceProject *api.Project, targetProject *api.Project, req api.ProjectMovePost, load func(name string) (network.Network, error)) ([]network.Network, error) {
+	if len(req.Networks) == 0 {
+		return nil, nil
+	}
+
+	// Without features.networks, the networks of a project are those of the default project.
+	for _, p := range []*api.Project{sourceProject, targetProject} {
+		if projecthelpers.NetworkProjectFromRecord(p) != p.Name {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "Project %q doesn't have features.networks enabled", p.Name)
+		}
+	}
+
+	networks := make([]network.Network, 0, len(req.Networks))
+	for _, name := range req.Networks {
+		n, err := load(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if n.Status() != api.NetworkStatusCreated {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "Network %q isn't fully created", name)
+		}
+
+		if targetProject.Name != projecthelpers.Default && !n.Info().Projects {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "Network type %q does not support non-default projects", n.Type())
+		}
+
+		if !projecthelpers.NetworkAllowed(targetProject.Config, name, true) {
+			return nil, api.StatusErrorf(http.StatusForbidden, "Network %q not allowed in project %q", name, targetProject.Name)
+		}
+
+		inUse, err := n.IsUsed()
+		if err != nil {
+			return nil, err
+		}
+
+		if inUse {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "Network %q is currently in use", name)
+		}
+
+		// Peerings are established with a network in a given project.
+		peers, err := c.GetNetworkPeerNames(n.ID())
+		if err != nil {
+			return nil, err
+		}
+
+		if len(peers) > 0 {
+			return nil, api.StatusErrorf(http.StatusBadRequest, "Network %q has peers", name)
+		}
+
+		networks = append(networks, n)
+	}
+
+	return networks, nil
+}
+
+// projectMoveCheckNetworksLimit checks that the target project doesn't exceed its limits.networks once the
+// networks were moved to it.
+func projectMoveCheckNetworksLimit(ctx context.Context, tx *db.ClusterTx, targetProject *api.Project, moved int) error {
+	if moved == 0 || targetProject.Config["limits.networks"] == "" {
+		return nil
+	}
+
+	limit, err := strconv.Atoi(targetProject.Config["limits.networks"])
+	if err != nil {
+		return fmt.Errorf("Invalid project limits.networks value: %w", err)
+	}
+
+	projectID, err := cluster.GetProjectID(ctx, tx.Tx(), targetProject.Name)
+	if err != nil {
+		return err
+	}
+
+	networks, err := tx.GetNetworkURIs(ctx, int(projectID), targetProject.Name)
+	if err != nil {
+		return fmt.Errorf("Failed loading project's networks for limits check: %w", err)
+	}
+
+	if len(networks) > limit {
+		return api.StatusErrorf(http.StatusBadRequest, "Networks limit has been reached for project %q", targetProject.Name)
+	}
+
+	return nil
+}
+
+// projectMoveCopyProfile creates a copy of the profile in the target project.
+func projectMoveCopyProfile(ctx context.Context, tx *db.ClusterTx, targetProjectName string, profile api.Profile) error {
+	devices, err := cluster.APIToDevices(profile.Devices)
+	if err != nil {
+		return err
+	}
+
+	id, err := cluster.CreateProfile(ctx, tx.Tx(), cluster.Profile{
+		Project:     targetProjectName,
+		Name:        profile.Name,
+		Description: profile.Description,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = cluster.CreateProfileConfig(ctx, tx.Tx(), id, profile.Config)
+	if err != nil {
+		return err
+	}
+
+	return cluster.CreateProfileDevices(ctx, tx.Tx(), id, devices)
+}
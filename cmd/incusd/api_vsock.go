@@ -46,7 +46,7 @@ func authenticateAgentCert(s *state.State, r *http.Request) (bool, instance.Inst
 	agentCert := inst.(instance.VM).AgentCertificate()
 
 	for _, cert := range r.TLS.PeerCertificates {
-		trusted, _ = localUtil.CheckTrustState(*cert, map[string]x509.Certificate{"0": *agentCert}, nil, false)
+		trusted, _ = localUtil.CheckTrustState(*cert, map[string]x509.Certificate{"0": *agentCert}, nil, false, nil)
 		if trusted {
 			return true, inst, nil
 		}
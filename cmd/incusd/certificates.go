@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
@@ -11,6 +12,8 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -25,6 +28,7 @@ import (
 	"github.com/lxc/incus/internal/server/db/operationtype"
 	"github.com/lxc/incus/internal/server/lifecycle"
 	"github.com/lxc/incus/internal/server/operations"
+	"github.com/lxc/incus/internal/server/pkcs11"
 	"github.com/lxc/incus/internal/server/project"
 	"github.com/lxc/incus/internal/server/request"
 	"github.com/lxc/incus/internal/server/response"
@@ -35,6 +39,7 @@ import (
 	"github.com/lxc/incus/shared/api"
 	"github.com/lxc/incus/shared/logger"
 	localtls "github.com/lxc/incus/shared/tls"
+	"github.com/lxc/incus/shared/util"
 )
 
 var certificatesCmd = APIEndpoint{
@@ -44,6 +49,18 @@ var certificatesCmd = APIEndpoint{
 	Post: APIEndpointAction{Handler: certificatesPost, AllowUntrusted: true},
 }
 
+var certificatesImportCmd = APIEndpoint{
+	Path: "certificates/import",
+
+	Post: APIEndpointAction{Handler: certificatesImportPost},
+}
+
+var certificatesTrustStoreCmd = APIEndpoint{
+	Path: "certificates/trust-store",
+
+	Get: APIEndpointAction{Handler: certificatesTrustStoreGet},
+}
+
 var certificateCmd = APIEndpoint{
 	Path: "certificates/{fingerprint}",
 
@@ -297,7 +314,9 @@ func updateCertificateCacheFromLocal(d *Daemon) error {
 }
 
 // clusterMemberJoinTokenValid searches for cluster join token that matches the join token provided.
-// Returns matching operation if found and cancels the operation, otherwise returns nil.
+// Each use is recorded in the cluster database so that single-use tokens can't be used twice, even through
+// different members. Returns matching operation if found and cancels the operation (unless the token is
+// reusable), otherwise returns nil.
 func clusterMemberJoinTokenValid(s *state.State, r *http.Request, projectName string, joinToken *api.ClusterMemberJoinToken) (*api.Operation, error) {
 	ops, err := operationsGetByType(s, r, projectName, operationtype.ClusterJoinToken)
 	if err != nil {
@@ -305,6 +324,7 @@ func clusterMemberJoinTokenValid(s *state.State, r *http.Request, projectName st
 	}
 
 	var foundOp *api.Operation
+	var reusable bool
 	for _, op := range ops {
 		if op.StatusCode != api.Running {
 			continue // Tokens are single use, so if cancelled but not deleted yet its not available.
@@ -326,39 +346,59 @@ func clusterMemberJoinTokenValid(s *state.State, r *http.Request, projectName st
 
 		if opServerName == joinToken.ServerName && opSecret == joinToken.Secret {
 			foundOp = op
+			reusable, _ = op.Metadata["reusable"].(bool)
 			break
 		}
 	}
 
-	if foundOp != nil {
-		// Token is single-use, so cancel it now.
-		err = operationCancel(s, r, projectName, foundOp)
-		if err != nil {
-			return nil, fmt.Errorf("Failed to cancel operation %q: %w", foundOp.ID, err)
+	if foundOp == nil {
+		// No operation found.
+		return nil, nil
+	}
+
+	var expiry time.Time
+	expiresAt, ok := foundOp.Metadata["expiresAt"]
+	if ok {
+		// Depending on whether it's a local operation or not, expiry will either be a time.Time or a string.
+		if s.ServerName == foundOp.Location {
+			expiry, _ = expiresAt.(time.Time)
+		} else {
+			expiry, _ = time.Parse(time.RFC3339Nano, expiresAt.(string))
 		}
 
-		expiresAt, ok := foundOp.Metadata["expiresAt"]
-		if ok {
-			var expiry time.Time
+		// Check if token has expired.
+		if !expiry.IsZero() && time.Now().After(expiry) {
+			return nil, api.StatusErrorf(http.StatusForbidden, "Token has expired")
+		}
+	}
 
-			// Depending on whether it's a local operation or not, expiry will either be a time.Time or a string.
-			if s.ServerName == foundOp.Location {
-				expiry, _ = expiresAt.(time.Time)
-			} else {
-				expiry, _ = time.Parse(time.RFC3339Nano, expiresAt.(string))
-			}
+	// Record the use of the token, refusing a second use of a single-use token.
+	secretHash := fmt.Sprintf("%x", sha256.Sum256([]byte(joinToken.Secret)))
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		uses, err := tx.GetClusterJoinTokenUses(ctx, secretHash)
+		if err != nil {
+			return err
+		}
 
-			// Check if token has expired.
-			if time.Now().After(expiry) {
-				return nil, api.StatusErrorf(http.StatusForbidden, "Token has expired")
-			}
+		if !reusable && len(uses) > 0 {
+			return api.StatusErrorf(http.StatusForbidden, "Token has already been used")
 		}
 
-		return foundOp, nil
+		return tx.CreateClusterJoinTokenUse(ctx, secretHash, joinToken.ServerName, expiry)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// No operation found.
-	return nil, nil
+	// Tokens are single-use unless created as reusable, so cancel it now.
+	if !reusable {
+		err = operationCancel(s, r, projectName, foundOp)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to cancel operation %q: %w", foundOp.ID, err)
+		}
+	}
+
+	return foundOp, nil
 }
 
 // certificateTokenValid searches for certificate token that matches the add token provided.
@@ -531,7 +571,7 @@ func certificatesPost(d *Daemon, r *http.Request) response.Response {
 			// If so then check there is a matching join operation.
 			joinOp, err := clusterMemberJoinTokenValid(s, r, project.Default, joinToken)
 			if err != nil {
-				return response.InternalError(fmt.Errorf("Failed during search for join token operation: %w", err))
+				return response.SmartError(fmt.Errorf("Failed during search for join token operation: %w", err))
 			}
 
 			if joinOp == nil {
@@ -736,6 +776,336 @@ func certificatesPost(d *Daemon, r *http.Request) response.Response {
 	return response.SyncResponseLocation(true, nil, lc.Source)
 }
 
+// swagger:operation POST /1.0/certificates/import certificates certificates_import_post
+//
+//	Add trusted certificates in bulk
+//
+//	Adds a batch of certificates to the trust store in a single transaction.
+//	Certificates which fail validation are reported back and not added.
+//	When `atomic` is set, nothing gets added if any certificate fails.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: certificates
+//	    description: Certificates
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/CertificatesImportPost"
+//	responses:
+//	  "200":
+//	    description: Import result
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/CertificatesImportResult"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func certificatesImportPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// Parse the request.
+	req := api.CertificatesImportPost{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// Other members only need to refresh their cache.
+	if isClusterNotification(r) {
+		s.UpdateCertificateCache()
+		return response.EmptySyncResponse
+	}
+
+	if len(req.Certificates) == 0 {
+		return response.BadRequest(fmt.Errorf("No certificates provided"))
+	}
+
+	result := api.CertificatesImportResult{
+		Imported: []string{},
+		Failed:   []api.CertificatesImportFailure{},
+	}
+
+	fail := func(index int, name string, fingerprint string, err error) {
+		result.Failed = append(result.Failed, api.CertificatesImportFailure{
+			Index:       index,
+			Name:        name,
+			Fingerprint: fingerprint,
+			Error:       err.Error(),
+		})
+	}
+
+	// Validate the certificates.
+	type importEntry struct {
+		index    int
+		dbCert   dbCluster.Certificate
+		projects []string
+	}
+
+	entries := make([]importEntry, 0, len(req.Certificates))
+	seen := map[string]bool{}
+	for i, entry := range req.Certificates {
+		if entry.Certificate == "" {
+			fail(i, entry.Name, "", fmt.Errorf("Missing certificate"))
+			continue
+		}
+
+		dbType, err := certificate.FromAPIType(entry.Type)
+		if err != nil {
+			fail(i, entry.Name, "", err)
+			continue
+		}
+
+		data, err := base64.StdEncoding.DecodeString(entry.Certificate)
+		if err != nil {
+			fail(i, entry.Name, "", err)
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(data)
+		if err != nil {
+			fail(i, entry.Name, "", fmt.Errorf("Invalid certificate material: %w", err))
+			continue
+		}
+
+		fingerprint := localtls.CertFingerprint(cert)
+
+		err = certificateValidate(cert)
+		if err != nil {
+			fail(i, entry.Name, fingerprint, err)
+			continue
+		}
+
+		if seen[fingerprint] {
+			fail(i, entry.Name, fingerprint, fmt.Errorf("Certificate listed more than once"))
+			continue
+		}
+
+		seen[fingerprint] = true
+
+		// Figure out a name.
+		name := entry.Name
+		if name == "" {
+			name = cert.Subject.CommonName
+		}
+
+		if name == "" {
+			fail(i, entry.Name, fingerprint, fmt.Errorf("Certificate name is required when it has no common name"))
+			continue
+		}
+
+		entries = append(entries, importEntry{
+			index: i,
+			dbCert: dbCluster.Certificate{
+				Fingerprint: fingerprint,
+				Type:        dbType,
+				Name:        name,
+				Certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})),
+				Restricted:  entry.Restricted,
+			},
+			projects: entry.Projects,
+		})
+	}
+
+	if req.Atomic && len(result.Failed) > 0 {
+		return response.SyncResponse(true, result)
+	}
+
+	validationFailed := result.Failed
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		// Start over from the validation results as the transaction may be retried.
+		result.Imported = []string{}
+		result.Failed = append([]api.CertificatesImportFailure{}, validationFailed...)
+
+		projectNames, err := dbCluster.GetProjectNames(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		// Check the entries against the database before adding anything.
+		valid := make([]importEntry, 0, len(entries))
+		for _, entry := range entries {
+			_, err := dbCluster.GetCertificateByFingerprintPrefix(ctx, tx.Tx(), entry.dbCert.Fingerprint)
+			if err != nil && !api.StatusErrorCheck(err, http.StatusNotFound) {
+				return fmt.Errorf("Failed checking for existing certificate %q: %w", entry.dbCert.Fingerprint, err)
+			}
+
+			if err == nil {
+				fail(entry.index, entry.dbCert.Name, entry.dbCert.Fingerprint, fmt.Errorf("Certificate already in trust store"))
+				continue
+			}
+
+			missing := ""
+			for _, projectName := range entry.projects {
+				if !util.ValueInSlice(projectName, projectNames) {
+					missing = projectName
+					break
+				}
+			}
+
+			if missing != "" {
+				fail(entry.index, entry.dbCert.Name, entry.dbCert.Fingerprint, fmt.Errorf("Project %q not found", missing))
+				continue
+			}
+
+			valid = append(valid, entry)
+		}
+
+		if req.Atomic && len(result.Failed) > 0 {
+			return nil
+		}
+
+		for _, entry := range valid {
+			_, err := dbCluster.CreateCertificateWithProjects(ctx, tx.Tx(), entry.dbCert, entry.projects)
+			if err != nil {
+				return fmt.Errorf("Failed adding certificate %q: %w", entry.dbCert.Name, err)
+			}
+
+			result.Imported = append(result.Imported, entry.dbCert.Fingerprint)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Keep the failures in request order.
+	sort.Slice(result.Failed, func(i, j int) bool { return result.Failed[i].Index < result.Failed[j].Index })
+
+	if len(result.Imported) == 0 {
+		return response.SyncResponse(true, result)
+	}
+
+	// Notify other nodes about the new certificates.
+	notifier, err := cluster.NewNotifier(s, s.Endpoints.NetworkCert(), s.ServerCert(), cluster.NotifyAlive)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = notifier(func(client incus.InstanceServer) error {
+		_, err := client.ImportCertificates(api.CertificatesImportPost{})
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Reload the cache once for the whole batch.
+	s.UpdateCertificateCache()
+
+	requestor := request.CreateRequestor(r)
+	for _, fingerprint := range result.Imported {
+		s.Events.SendLifecycle(project.Default, lifecycle.CertificateCreated.Event(fingerprint, requestor, nil))
+	}
+
+	return response.SyncResponse(true, result)
+}
+
+// swagger:operation GET /1.0/certificates/trust-store certificates certificates_trust_store_get
+//
+//	Get the effective trust store
+//
+//	Returns the certificates currently loaded in the trust store of the server,
+//	grouped by type, along with their project restrictions.
+//	This reflects what's used to authenticate clients, not what's in the database.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	responses:
+//	  "200":
+//	    description: Trust store
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/CertificatesTrustStore"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func certificatesTrustStoreGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// The trust store is loaded separately by each member.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	certificates, projects := d.clientCerts.GetCertificatesAndProjects()
+
+	trustStore := api.CertificatesTrustStore{Certificates: map[string][]api.CertificatesTrustStoreEntry{}}
+	for certType, certs := range certificates {
+		entries := make([]api.CertificatesTrustStoreEntry, 0, len(certs))
+		for fingerprint, cert := range certs {
+			certProjects, restricted := projects[fingerprint]
+			if certProjects == nil {
+				certProjects = []string{}
+			}
+
+			entries = append(entries, api.CertificatesTrustStoreEntry{
+				Fingerprint: fingerprint,
+				Subject:     cert.Subject.String(),
+				Issuer:      cert.Issuer.String(),
+				NotBefore:   cert.NotBefore,
+				NotAfter:    cert.NotAfter,
+				Restricted:  restricted,
+				Projects:    certProjects,
+			})
+		}
+
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Fingerprint < entries[j].Fingerprint })
+
+		trustStore.Certificates[certType.ToAPIType()] = entries
+	}
+
+	return response.SyncResponse(true, trustStore)
+}
+
 // swagger:operation GET /1.0/certificates/{fingerprint} certificates certificate_get
 //
 //	Get the trusted certificate
@@ -994,7 +1364,7 @@ func doCertificateUpdate(d *Daemon, dbInfo api.Certificate, req api.CertificateP
 
 				trusted := false
 				for _, i := range r.TLS.PeerCertificates {
-					trusted, _ = localUtil.CheckTrustState(*i, trustedCerts, s.Endpoints.NetworkCert(), false)
+					trusted, _ = localUtil.CheckTrustState(*i, trustedCerts, s.Endpoints.NetworkCert(), false, nil)
 
 					if trusted {
 						break
@@ -1116,7 +1486,7 @@ func certificateDelete(d *Daemon, r *http.Request) response.Response {
 
 			trusted := false
 			for _, i := range r.TLS.PeerCertificates {
-				trusted, _ = localUtil.CheckTrustState(*i, trustedCerts, s.Endpoints.NetworkCert(), false)
+				trusted, _ = localUtil.CheckTrustState(*i, trustedCerts, s.Endpoints.NetworkCert(), false, nil)
 
 				if trusted {
 					break
@@ -1181,3 +1551,34 @@ func certificateValidate(cert *x509.Certificate) error {
 
 	return nil
 }
+
+// loadNetworkCert loads the network certificate (the cluster certificate once clustered), taking its private key
+// from the PKCS#11 token referenced by the URI if set. A missing certificate is generated with the given key.
+func loadNetworkCert(dir string, keyParams localtls.KeyParams, pkcs11Key string) (*localtls.CertInfo, error) {
+	if pkcs11Key == "" {
+		return internalUtil.LoadCertWithKey(dir, keyParams)
+	}
+
+	prefix := "server"
+	if util.PathExists(filepath.Join(dir, "cluster.crt")) {
+		prefix = "cluster"
+	}
+
+	return loadCertWithPKCS11Key(dir, prefix, pkcs11Key)
+}
+
+// loadCertWithPKCS11Key loads the certificate with the given prefix from the var dir, using the private key
+// referenced by the PKCS#11 URI instead of the key file.
+func loadCertWithPKCS11Key(dir string, prefix string, uri string) (*localtls.CertInfo, error) {
+	signer, err := pkcs11.NewSigner(uri)
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading %q private key from PKCS#11 token: %w", prefix, err)
+	}
+
+	cert, err := localtls.KeyPairAndCAWithSigner(dir, prefix, signer)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load TLS certificate: %w", err)
+	}
+
+	return cert, nil
+}
@@ -16,6 +16,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	dqliteClient "github.com/cowsql/go-cowsql/client"
@@ -30,6 +31,7 @@ import (
 	"github.com/lxc/incus/internal/rsync"
 	"github.com/lxc/incus/internal/server/acme"
 	"github.com/lxc/incus/internal/server/apparmor"
+	"github.com/lxc/incus/internal/server/auditlog"
 	"github.com/lxc/incus/internal/server/auth"
 	"github.com/lxc/incus/internal/server/auth/oidc"
 	"github.com/lxc/incus/internal/server/bgp"
@@ -39,6 +41,7 @@ import (
 	"github.com/lxc/incus/internal/server/daemon"
 	"github.com/lxc/incus/internal/server/db"
 	dbCluster "github.com/lxc/incus/internal/server/db/cluster"
+	"github.com/lxc/incus/internal/server/db/query"
 	"github.com/lxc/incus/internal/server/db/warningtype"
 	"github.com/lxc/incus/internal/server/dns"
 	"github.com/lxc/incus/internal/server/endpoints"
@@ -48,9 +51,12 @@ import (
 	"github.com/lxc/incus/internal/server/instance"
 	instanceDrivers "github.com/lxc/incus/internal/server/instance/drivers"
 	"github.com/lxc/incus/internal/server/instance/instancetype"
+	"github.com/lxc/incus/internal/server/lifecyclehook"
 	"github.com/lxc/incus/internal/server/loki"
 	networkZone "github.com/lxc/incus/internal/server/network/zone"
 	"github.com/lxc/incus/internal/server/node"
+	"github.com/lxc/incus/internal/server/operations"
+	"github.com/lxc/incus/internal/server/project"
 	"github.com/lxc/incus/internal/server/request"
 	"github.com/lxc/incus/internal/server/response"
 	scriptletLoad "github.com/lxc/incus/internal/server/scriptlet/load"
@@ -67,6 +73,7 @@ import (
 	"github.com/lxc/incus/internal/server/warnings"
 	internalUtil "github.com/lxc/incus/internal/util"
 	"github.com/lxc/incus/internal/version"
+	"github.com/lxc/incus/shared/api"
 	"github.com/lxc/incus/shared/archive"
 	"github.com/lxc/incus/shared/cancel"
 	"github.com/lxc/incus/shared/logger"
@@ -104,6 +111,9 @@ type Daemon struct {
 	// Whether daemon was started by systemd socket activation.
 	systemdSocketActivated bool
 
+	// Interval at which the service manager expects watchdog notifications (0 if disabled).
+	systemdWatchdogInterval time.Duration
+
 	config    *DaemonConfig
 	endpoints *endpoints.Endpoints
 	gateway   *cluster.Gateway
@@ -113,8 +123,19 @@ type Daemon struct {
 
 	oidcVerifier *oidc.Verifier
 
+	// Revocation status of CA-signed client certificates.
+	revocationChecker *localUtil.RevocationChecker
+
 	// Stores last heartbeat node information to detect node changes.
-	lastNodeList *cluster.APIHeartbeat
+	// It's accessed from the heartbeat refresh task and API handlers, so lastNodeListMu must be held.
+	lastNodeList   *cluster.APIHeartbeat
+	lastNodeListMu sync.Mutex
+
+	// Smoothed online state of the cluster members, used to ignore brief outages.
+	memberState *cluster.MemberStateTracker
+
+	// Whether the cluster database lost quorum.
+	quorumLost atomic.Bool
 
 	// Serialize changes to cluster membership (joins, leaves, role
 	// changes).
@@ -136,6 +157,12 @@ type Daemon struct {
 	// Keep track of skews.
 	timeSkew bool
 
+	// Keep track of cluster configuration drift (number of consecutive heartbeats with a different configuration)
+	// and of whether the configuration is being reloaded because of it.
+	configDrift          int
+	configDriftReloading bool
+	configDriftMu        sync.Mutex
+
 	// Configuration.
 	globalConfig   *clusterConfig.Config
 	localConfig    *node.Config
@@ -146,19 +173,33 @@ type Daemon struct {
 
 	lokiClient *loki.Client
 
+	// Audit log.
+	auditLog *auditlog.Writer
+
+	// Hook run on instance lifecycle events.
+	lifecycleHook *lifecyclehook.Hook
+
 	// HTTP-01 challenge provider for ACME
 	http01Provider acme.HTTP01Provider
 
 	// Authorization.
-	authorizer auth.Authorizer
+	authorizer   auth.Authorizer
+	authorizerMu sync.Mutex
 
 	// Syslog listener cancel function.
 	syslogSocketCancel context.CancelFunc
+
+	// Per-project API request accounting.
+	projectRequests *project.RequestQuota
+
+	// Authentication failures accounting.
+	authThrottle *auth.Throttle
 }
 
 // DaemonConfig holds configuration values for Daemon.
 type DaemonConfig struct {
 	Group              string        // Group name the local unix socket should be chown'ed to
+	ExtraGroups        []string      // Additional group names the local unix socket should be accessible to
 	Trace              []string      // List of sub-systems to trace
 	RaftLatency        float64       // Coarse grain measure of the cluster latency
 	DqliteSetupTimeout time.Duration // How long to wait for the cluster database to be up
@@ -183,6 +224,12 @@ func newDaemon(config *DaemonConfig, os *sys.OS) *Daemon {
 		shutdownCtx:    shutdownCtx,
 		shutdownCancel: shutdownCancel,
 		shutdownDoneCh: make(chan error),
+
+		projectRequests: project.NewRequestQuota(),
+		authThrottle:    auth.NewThrottle(),
+
+		revocationChecker: localUtil.NewRevocationChecker(),
+		memberState:       cluster.NewMemberStateTracker(),
 	}
 
 	d.serverCert = func() *localtls.CertInfo { return d.serverCertInt }
@@ -257,6 +304,27 @@ func allowProjectPermission() func(d *Daemon, r *http.Request) response.Response
 			return response.Forbidden(nil)
 		}
 
+		// Enforce the project read-only flag and request quota on mutating requests.
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			config, err := projectConfig(s, projectName)
+			if err != nil {
+				return response.SmartError(err)
+			}
+
+			if util.IsTrue(config["read_only"]) {
+				return response.Forbidden(fmt.Errorf("Project %q is read-only", projectName))
+			}
+
+			limit, err := projectRequestsLimitFromConfig(config)
+			if err != nil {
+				return response.SmartError(err)
+			}
+
+			if limit >= 0 && !d.projectRequests.Allow(projectName, limit) {
+				return response.TooManyRequests(fmt.Errorf("Project %q has reached its limit of %d requests per minute", projectName, limit))
+			}
+		}
+
 		return response.EmptySyncResponse
 	}
 }
@@ -269,7 +337,7 @@ func (d *Daemon) checkTrustedClient(r *http.Request) error {
 			return err
 		}
 
-		return fmt.Errorf("Not authorized")
+		return api.StatusReasonErrorf(http.StatusForbidden, api.ErrorReasonNotAuthorized, "Not authorized")
 	}
 
 	return nil
@@ -280,6 +348,51 @@ func (d *Daemon) getTrustedCertificates() map[certificate.Type]map[string]x509.C
 	return d.clientCerts.GetCertificates()
 }
 
+// revocationCheck returns a function checking whether a CA-signed client certificate was revoked through OCSP
+// or a CRL, or nil if revocation checking is disabled.
+func (d *Daemon) revocationCheck() func(cert *x509.Certificate, issuer *x509.Certificate) bool {
+	methods, failClosed, cacheTTL := d.globalConfig.TrustCARevocation()
+	if len(methods) == 0 {
+		return nil
+	}
+
+	config := localUtil.RevocationConfig{
+		OCSP:       util.ValueInSlice("ocsp", methods),
+		CRL:        util.ValueInSlice("crl", methods),
+		FailClosed: failClosed,
+		CacheTTL:   time.Duration(cacheTTL) * time.Second,
+	}
+
+	return func(cert *x509.Certificate, issuer *x509.Certificate) bool {
+		return d.revocationChecker.Revoked(cert, issuer, config)
+	}
+}
+
+// authThrottleSource returns the address against which the authentication failures of the request are tracked,
+// or an empty string if the request is exempt from throttling (local and cluster traffic).
+func (d *Daemon) authThrottleSource(r *http.Request) string {
+	// Local unix socket queries.
+	if r.TLS == nil {
+		return ""
+	}
+
+	// Internal cluster traffic.
+	trustedCerts := d.getTrustedCertificates()
+	for _, i := range r.TLS.PeerCertificates {
+		trusted, _ := localUtil.CheckTrustState(*i, trustedCerts[certificate.TypeServer], d.endpoints.NetworkCert(), false, nil)
+		if trusted {
+			return ""
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
 // Authenticate validates an incoming http Request
 // It will check over what protocol it came, what type of request it is and
 // will validate the TLS certificate.
@@ -293,7 +406,7 @@ func (d *Daemon) Authenticate(w http.ResponseWriter, r *http.Request) (bool, str
 	// Allow internal cluster traffic by checking against the trusted certfificates.
 	if r.TLS != nil {
 		for _, i := range r.TLS.PeerCertificates {
-			trusted, fingerprint := localUtil.CheckTrustState(*i, trustedCerts[certificate.TypeServer], d.endpoints.NetworkCert(), false)
+			trusted, fingerprint := localUtil.CheckTrustState(*i, trustedCerts[certificate.TypeServer], d.endpoints.NetworkCert(), false, nil)
 			if trusted {
 				return true, fingerprint, "cluster", nil
 			}
@@ -345,11 +458,12 @@ func (d *Daemon) Authenticate(w http.ResponseWriter, r *http.Request) (bool, str
 
 	// Validate normal TLS access.
 	trustCACertificates := d.globalConfig.TrustCACertificates()
+	revoked := d.revocationCheck()
 
 	// Validate metrics certificates.
 	if r.URL.Path == "/1.0/metrics" {
 		for _, i := range r.TLS.PeerCertificates {
-			trusted, username := localUtil.CheckTrustState(*i, trustedCerts[certificate.TypeMetrics], d.endpoints.NetworkCert(), trustCACertificates)
+			trusted, username := localUtil.CheckTrustState(*i, trustedCerts[certificate.TypeMetrics], d.endpoints.NetworkCert(), trustCACertificates, revoked)
 			if trusted {
 				return true, username, "tls", nil
 			}
@@ -357,7 +471,7 @@ func (d *Daemon) Authenticate(w http.ResponseWriter, r *http.Request) (bool, str
 	}
 
 	for _, i := range r.TLS.PeerCertificates {
-		trusted, username := localUtil.CheckTrustState(*i, trustedCerts[certificate.TypeClient], d.endpoints.NetworkCert(), trustCACertificates)
+		trusted, username := localUtil.CheckTrustState(*i, trustedCerts[certificate.TypeClient], d.endpoints.NetworkCert(), trustCACertificates, revoked)
 		if trusted {
 			return true, username, "tls", nil
 		}
@@ -385,6 +499,10 @@ func (d *Daemon) State() *state.State {
 	localConfig := d.localConfig
 	d.globalConfigMu.Unlock()
 
+	d.authorizerMu.Lock()
+	authorizer := d.authorizer
+	d.authorizerMu.Unlock()
+
 	return &state.State{
 		ShutdownCtx:            d.shutdownCtx,
 		DB:                     d.db,
@@ -404,7 +522,7 @@ func (d *Daemon) State() *state.State {
 		LocalConfig:            localConfig,
 		ServerName:             d.serverName,
 		StartTime:              d.startTime,
-		Authorizer:             d.authorizer,
+		Authorizer:             authorizer,
 	}
 }
 
@@ -438,14 +556,52 @@ func (d *Daemon) createCmd(restAPI *mux.Router, version string, c APIEndpoint) {
 			select {
 			case <-d.setupChan:
 			default:
-				response := response.Unavailable(fmt.Errorf("Daemon setup in progress"))
+				response := response.Unavailable(api.StatusReasonErrorf(http.StatusServiceUnavailable, api.ErrorReasonSetupInProgress, "Daemon setup in progress"))
 				_ = response.Render(w)
 				return
 			}
 		}
 
+		// Bound the request context by the client's deadline (if provided) so that the authentication and the
+		// handlers can abort early.
+		clientTimeout := r.Header.Get(request.HeaderTimeout)
+		if clientTimeout != "" {
+			timeout, err := time.ParseDuration(clientTimeout)
+			if err != nil || timeout <= 0 {
+				_ = response.BadRequest(fmt.Errorf("Invalid %s header %q", request.HeaderTimeout, clientTimeout)).Render(w)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			r = r.WithContext(ctx)
+		}
+
+		// Reject sources which recently failed to authenticate too many times.
+		throttleSource := d.authThrottleSource(r)
+		if throttleSource != "" {
+			blocked := d.authThrottle.Blocked(throttleSource)
+			if blocked > 0 {
+				w.Header().Set("Retry-After", fmt.Sprintf("%d", int(blocked.Seconds())+1))
+				_ = response.TooManyRequests(fmt.Errorf("Too many authentication failures")).Render(w)
+				return
+			}
+		}
+
 		// Authentication
+		untrustedOk := (r.Method == "GET" && c.Get.AllowUntrusted) || (r.Method == "POST" && c.Post.AllowUntrusted)
 		trusted, username, protocol, err := d.Authenticate(w, r)
+		if throttleSource != "" {
+			// Untrusted requests are only failures if the endpoint requires authentication.
+			if trusted {
+				d.authThrottle.Success(throttleSource)
+			} else if err != nil || !untrustedOk {
+				threshold, blockTime := d.globalConfig.AuthFailureThrottle()
+				d.authThrottle.Failure(throttleSource, threshold, time.Duration(blockTime)*time.Second)
+			}
+		}
+
 		if err != nil {
 			_, ok := err.(*oidc.AuthError)
 			if ok {
@@ -454,15 +610,15 @@ func (d *Daemon) createCmd(restAPI *mux.Router, version string, c APIEndpoint) {
 					_ = d.oidcVerifier.WriteHeaders(w)
 				}
 
-				_ = response.Unauthorized(err).Render(w)
+				_ = response.Unauthorized(api.StatusReasonErrorf(http.StatusUnauthorized, api.ErrorReasonAuthenticationFailed, "%v", err)).Render(w)
 				return
 			}
 		}
 
 		// Reject internal queries to remote, non-cluster, clients
 		if version == "internal" && !util.ValueInSlice(protocol, []string{"unix", "cluster"}) {
-			// Except for the initial cluster accept request (done over trusted TLS)
-			if !trusted || c.Path != "cluster/accept" || protocol != "tls" {
+			// Except for the initial cluster accept request and its validation (done over trusted TLS)
+			if !trusted || !util.ValueInSlice(c.Path, []string{"cluster/accept", "cluster/accept/validate"}) || protocol != "tls" {
 				logger.Warn("Rejecting remote internal API request", logger.Ctx{"ip": r.RemoteAddr})
 				_ = response.Forbidden(nil).Render(w)
 				return
@@ -476,9 +632,26 @@ func (d *Daemon) createCmd(restAPI *mux.Router, version string, c APIEndpoint) {
 			logCtx["username"] = username
 		}
 
-		untrustedOk := (r.Method == "GET" && c.Get.AllowUntrusted) || (r.Method == "POST" && c.Post.AllowUntrusted)
+		// Apply the logging override of the route (unnamed routes are identified by their URL).
+		logRouteName := c.Name
+		if logRouteName == "" {
+			logRouteName = uri
+		}
+
+		logRequest := logger.Debug
+		logBody := daemon.Debug
+		logRoute, ok := d.apiLogRoute(logRouteName)
+		if ok {
+			logRequest = apiLogFunc(logger.Log, logRoute.Level)
+			logBody = logRoute.Body
+		}
+
 		if trusted {
-			logger.Debug("Handling API request", logCtx)
+			if logRequest != nil {
+				logRequest("Handling API request", logCtx)
+			}
+
+			d.setMessageHeaders(w)
 
 			// Get user access data.
 			userAccess, err := func() (*auth.UserAccess, error) {
@@ -534,8 +707,16 @@ func (d *Daemon) createCmd(restAPI *mux.Router, version string, c APIEndpoint) {
 			}
 
 			r = r.WithContext(ctx)
+
+			// Record who's using the connection.
+			conn, ok := r.Context().Value(request.CtxConn).(net.Conn)
+			if ok && protocol != "unix" {
+				d.endpoints.ConnectionSetIdentity(conn, username, protocol)
+			}
 		} else if untrustedOk && r.Header.Get("X-Incus-authenticated") == "" {
-			logger.Debug(fmt.Sprintf("Allowing untrusted %s", r.Method), logger.Ctx{"url": r.URL.RequestURI(), "ip": r.RemoteAddr})
+			if logRequest != nil {
+				logRequest(fmt.Sprintf("Allowing untrusted %s", r.Method), logger.Ctx{"url": r.URL.RequestURI(), "ip": r.RemoteAddr})
+			}
 		} else {
 			if d.oidcVerifier != nil {
 				_ = d.oidcVerifier.WriteHeaders(w)
@@ -546,8 +727,16 @@ func (d *Daemon) createCmd(restAPI *mux.Router, version string, c APIEndpoint) {
 			return
 		}
 
-		// Dump full request JSON when in debug mode
-		if daemon.Debug && r.Method != "GET" && localUtil.IsJSONRequest(r) {
+		// Refuse changes which can't be committed while the cluster database lost quorum.
+		// This includes exec, console and file transfers which, as the database can't be read either without a
+		// leader, would otherwise only fail once they time out loading the instance.
+		if version != "internal" && !util.ValueInSlice(r.Method, []string{"GET", "HEAD", "OPTIONS"}) && d.clusterQuorumFrozen() {
+			_ = response.Unavailable(api.StatusReasonErrorf(http.StatusServiceUnavailable, api.ErrorReasonQuorumLost, "Cluster database quorum lost")).Render(w)
+			return
+		}
+
+		// Dump full request JSON when in debug mode or when enabled for the route
+		if logBody && r.Method != "GET" && localUtil.IsJSONRequest(r) {
 			newBody := &bytes.Buffer{}
 			captured := &bytes.Buffer{}
 			multiW := io.MultiWriter(newBody, captured)
@@ -558,7 +747,11 @@ func (d *Daemon) createCmd(restAPI *mux.Router, version string, c APIEndpoint) {
 			}
 
 			r.Body = internalIO.BytesReadCloser{Buf: newBody}
-			localUtil.DebugJSON("API Request", captured, logger.AddContext(logCtx))
+			if logRoute.Body {
+				localUtil.LogJSON("API Request", captured, apiLogFunc(logger.AddContext(logCtx), logRoute.Level))
+			} else {
+				localUtil.DebugJSON("API Request", captured, logger.AddContext(logCtx))
+			}
 		}
 
 		// Actually process the request
@@ -605,7 +798,11 @@ func (d *Daemon) createCmd(restAPI *mux.Router, version string, c APIEndpoint) {
 				}
 			} else if !action.AllowUntrusted {
 				// Require admin privileges
-				if !d.authorizer.UserIsAdmin(r) {
+				d.authorizerMu.Lock()
+				authorizer := d.authorizer
+				d.authorizerMu.Unlock()
+
+				if !authorizer.UserIsAdmin(r) {
 					return response.Forbidden(nil)
 				}
 			}
@@ -613,6 +810,12 @@ func (d *Daemon) createCmd(restAPI *mux.Router, version string, c APIEndpoint) {
 			return action.Handler(d, r)
 		}
 
+		// Don't start handling requests which the client already gave up on.
+		if errors.Is(r.Context().Err(), context.DeadlineExceeded) {
+			_ = response.SmartError(api.StatusErrorf(http.StatusGatewayTimeout, "Request deadline exceeded before handling it")).Render(w)
+			return
+		}
+
 		switch r.Method {
 		case "GET":
 			resp = handleRequest(c.Get)
@@ -647,6 +850,53 @@ func (d *Daemon) createCmd(restAPI *mux.Router, version string, c APIEndpoint) {
 	}
 }
 
+// apiLogRoute returns the logging override configured for the API route, if any.
+func (d *Daemon) apiLogRoute(name string) (clusterConfig.LogRoute, bool) {
+	d.globalConfigMu.Lock()
+	globalConfig := d.globalConfig
+	d.globalConfigMu.Unlock()
+
+	if globalConfig == nil {
+		return clusterConfig.LogRoute{}, false
+	}
+
+	route, ok := globalConfig.LogRoutes()[name]
+	return route, ok
+}
+
+// setMessageHeaders adds the server message to the response headers if enabled.
+func (d *Daemon) setMessageHeaders(w http.ResponseWriter) {
+	d.globalConfigMu.Lock()
+	globalConfig := d.globalConfig
+	d.globalConfigMu.Unlock()
+
+	if globalConfig == nil {
+		return
+	}
+
+	message, severity, _, header := globalConfig.Message()
+	if message == "" || !header {
+		return
+	}
+
+	w.Header().Set("X-Incus-Message", message)
+	w.Header().Set("X-Incus-Message-Severity", severity)
+}
+
+// apiLogFunc returns the function logging at the level of a route logging override, nil if it isn't logged.
+func apiLogFunc(l logger.Logger, level string) func(msg string, ctx ...logger.Ctx) {
+	switch level {
+	case "debug":
+		return l.Debug
+	case "info":
+		return l.Info
+	case "warn":
+		return l.Warn
+	}
+
+	return nil
+}
+
 // have we setup shared mounts?
 var sharedMountsLock sync.Mutex
 
@@ -696,13 +946,51 @@ func (d *Daemon) Init() error {
 	// ignored.
 	if err != nil {
 		logger.Error("Failed to start the daemon", logger.Ctx{"err": err})
-		_ = d.Stop(context.Background(), unix.SIGINT)
+		_ = d.Stop(context.Background(), daemonStopModeExit)
 		return err
 	}
 
 	return nil
 }
 
+// userAgentFeatures returns the list of features advertised in the user-agent, based on the enabled subsystems.
+func userAgentFeatures(clustered bool, nodeConfig *node.Config, clusterConfig *clusterConfig.Config) []string {
+	features := []string{}
+
+	if clustered {
+		features = append(features, "cluster")
+	}
+
+	if nodeConfig.BGPAddress() != "" && nodeConfig.BGPRouterID() != "" && clusterConfig.BGPASN() != 0 {
+		features = append(features, "bgp")
+	}
+
+	if nodeConfig.DNSAddress() != "" {
+		features = append(features, "dns")
+	}
+
+	lokiURL, _, _, _, _, _, _ := clusterConfig.LokiServer()
+	if lokiURL != "" {
+		features = append(features, "loki")
+	}
+
+	if nodeConfig.MetricsAddress() != "" {
+		features = append(features, "metrics")
+	}
+
+	oidcIssuer, oidcClientID, _ := clusterConfig.OIDCServer()
+	if oidcIssuer != "" && oidcClientID != "" {
+		features = append(features, "oidc")
+	}
+
+	openfgaAPIURL, _, openfgaStoreID, _, _, _, _ := clusterConfig.OpenFGA()
+	if openfgaAPIURL != "" && openfgaStoreID != "" {
+		features = append(features, "openfga")
+	}
+
+	return features
+}
+
 func (d *Daemon) setupLoki(URL string, cert string, key string, caCert string, labels []string, logLevel string, types []string) error {
 	if d.lokiClient != nil {
 		d.lokiClient.Stop()
@@ -724,6 +1012,96 @@ func (d *Daemon) setupLoki(URL string, cert string, key string, caCert string, l
 	return nil
 }
 
+func (d *Daemon) setupAuditLog(path string, format string, rotateSize int64, rotateInterval time.Duration, maxFiles int64) error {
+	if d.auditLog != nil {
+		d.internalListener.RemoveHandler("audit")
+		d.auditLog.Stop()
+		d.auditLog = nil
+	}
+
+	if path == "" {
+		return nil
+	}
+
+	auditLog, err := auditlog.NewWriter(auditlog.Config{
+		Path:           path,
+		Format:         format,
+		RotateSize:     rotateSize,
+		RotateInterval: rotateInterval,
+		MaxFiles:       maxFiles,
+	})
+	if err != nil {
+		return err
+	}
+
+	d.auditLog = auditLog
+	d.internalListener.AddHandler("audit", d.auditLog.HandleEvent)
+
+	return nil
+}
+
+func (d *Daemon) setupLifecycleHook(target string, actions []string, timeout time.Duration) {
+	d.internalListener.RemoveHandler("lifecycle-hook")
+	d.lifecycleHook = nil
+
+	if target == "" {
+		return
+	}
+
+	d.lifecycleHook = lifecyclehook.NewHook(d.shutdownCtx, target, actions, timeout)
+	d.internalListener.AddHandler("lifecycle-hook", d.lifecycleHook.HandleEvent)
+}
+
+// setupOpenFGA replaces the authorizer with one using the given OpenFGA server, or with the TLS authorizer if
+// no OpenFGA server is configured. The current authorizer is kept if the new one can't be loaded.
+func (d *Daemon) setupOpenFGA(apiURL string, apiToken string, storeID string, modelID string, cacheTTL int64, failClosed bool, tlsRestrictions bool) error {
+	var authorizer auth.Authorizer
+	var err error
+
+	if apiURL == "" || storeID == "" {
+		authorizer, err = auth.LoadAuthorizer("tls", nil, logger.Log, nil)
+	} else {
+		config := map[string]any{
+			"openfga.api.url":          apiURL,
+			"openfga.api.token":        apiToken,
+			"openfga.store.id":         storeID,
+			"openfga.store.model_id":   modelID,
+			"openfga.cache.ttl":        cacheTTL,
+			"openfga.fail_closed":      failClosed,
+			"openfga.tls_restrictions": tlsRestrictions,
+		}
+
+		projectsGetFunc := func() (map[int64]string, error) {
+			var projects map[int64]string
+
+			err := d.db.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+				var err error
+				projects, err = dbCluster.GetProjectIDsToNames(ctx, tx.Tx())
+				return err
+			})
+
+			return projects, err
+		}
+
+		authorizer, err = auth.LoadAuthorizer("openfga", config, logger.Log, projectsGetFunc)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.authorizerMu.Lock()
+	oldAuthorizer := d.authorizer
+	d.authorizer = authorizer
+	d.authorizerMu.Unlock()
+
+	if oldAuthorizer != nil {
+		oldAuthorizer.StopStatusCheck()
+	}
+
+	return nil
+}
+
 func (d *Daemon) init() error {
 	var err error
 
@@ -948,6 +1326,10 @@ func (d *Daemon) init() error {
 		if err != nil && os.IsPermission(err) {
 			logger.Warn("Unable to access device nodes, likely running on a nodev mount")
 			d.os.Nodev = true
+			dbWarnings = append(dbWarnings, dbCluster.Warning{
+				TypeCode:    warningtype.NodevMount,
+				LastMessage: "likely running on a nodev mount, instances relying on device nodes won't work",
+			})
 		}
 
 		_ = fd.Close()
@@ -960,14 +1342,32 @@ func (d *Daemon) init() error {
 		return err
 	}
 
+	logger.Info("Loading daemon configuration")
+	err = d.db.Node.Transaction(context.TODO(), func(ctx context.Context, tx *db.NodeTx) error {
+		d.localConfig, err = node.ConfigLoad(ctx, tx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	pkcs11NetworkKey, pkcs11ServerKey := d.localConfig.PKCS11Keys()
+	certificateKey := d.localConfig.CertificateKey()
+
 	/* Setup network endpoint certificate */
-	networkCert, err := internalUtil.LoadCert(d.os.VarDir)
+	networkCert, err := loadNetworkCert(d.os.VarDir, certificateKey, pkcs11NetworkKey)
 	if err != nil {
 		return err
 	}
 
 	/* Setup server certificate */
-	serverCert, err := internalUtil.LoadServerCert(d.os.VarDir)
+	var serverCert *localtls.CertInfo
+	if pkcs11ServerKey != "" {
+		serverCert, err = loadCertWithPKCS11Key(d.os.VarDir, "server", pkcs11ServerKey)
+	} else {
+		serverCert, err = internalUtil.LoadServerCertWithKey(d.os.VarDir, certificateKey)
+	}
+
 	if err != nil {
 		return err
 	}
@@ -1006,13 +1406,16 @@ func (d *Daemon) init() error {
 		clusterLogLevel = "TRACE"
 	}
 
+	snapshotThreshold, snapshotTrailing := d.localConfig.RaftSnapshotParams()
+
 	d.gateway, err = cluster.NewGateway(
 		d.shutdownCtx,
 		d.db.Node,
 		networkCert,
 		d.State,
 		cluster.Latency(d.config.RaftLatency),
-		cluster.LogLevel(clusterLogLevel))
+		cluster.LogLevel(clusterLogLevel),
+		cluster.SnapshotParams(snapshotThreshold, snapshotTrailing))
 	if err != nil {
 		return err
 	}
@@ -1037,15 +1440,6 @@ func (d *Daemon) init() error {
 		}
 	}
 
-	logger.Info("Loading daemon configuration")
-	err = d.db.Node.Transaction(context.TODO(), func(ctx context.Context, tx *db.NodeTx) error {
-		d.localConfig, err = node.ConfigLoad(ctx, tx)
-		return err
-	})
-	if err != nil {
-		return err
-	}
-
 	localHTTPAddress := d.localConfig.HTTPSAddress()
 	localClusterAddress := d.localConfig.ClusterAddress()
 	debugAddress := d.localConfig.DebugAddress()
@@ -1054,21 +1448,30 @@ func (d *Daemon) init() error {
 		d.systemdSocketActivated = true
 	}
 
+	d.systemdWatchdogInterval = linux.SystemdWatchdogInterval()
+
+	metricsCert, err := metricsCertificate(d.localConfig)
+	if err != nil {
+		logger.Warn("Falling back to the server certificate for the metrics server", logger.Ctx{"err": err})
+	}
+
 	/* Setup the web server */
 	config := &endpoints.Config{
-		Dir:                  d.os.VarDir,
-		UnixSocket:           d.UnixSocket(),
-		Cert:                 networkCert,
-		RestServer:           restServer(d),
-		DevIncusServer:       devIncusServer(d),
-		LocalUnixSocketGroup: d.config.Group,
-		NetworkAddress:       localHTTPAddress,
-		ClusterAddress:       localClusterAddress,
-		DebugAddress:         debugAddress,
-		MetricsServer:        metricsServer(d),
-		StorageBucketsServer: storageBucketsServer(d),
-		VsockServer:          vSockServer(d),
-		VsockSupport:         false,
+		Dir:                        d.os.VarDir,
+		UnixSocket:                 d.UnixSocket(),
+		Cert:                       networkCert,
+		RestServer:                 restServer(d),
+		DevIncusServer:             devIncusServer(d),
+		LocalUnixSocketGroup:       d.config.Group,
+		LocalUnixSocketExtraGroups: d.config.ExtraGroups,
+		NetworkAddress:             localHTTPAddress,
+		ClusterAddress:             localClusterAddress,
+		DebugAddress:               debugAddress,
+		MetricsServer:              metricsServer(d),
+		MetricsCert:                metricsCert,
+		StorageBucketsServer:       storageBucketsServer(d),
+		VsockServer:                vSockServer(d),
+		VsockSupport:               false,
 	}
 
 	// Enable vsock server support if VM instances supported.
@@ -1127,6 +1530,25 @@ func (d *Daemon) init() error {
 			// to run the heartbeat task, in case we are the raft
 			// leader.
 			d.gateway.Cluster = d.db.Cluster
+
+			// Use the configured heartbeat timings if they can be loaded.
+			configErr := d.db.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+				config, err := clusterConfig.Load(ctx, tx)
+				if err != nil {
+					return err
+				}
+
+				d.gateway.HeartbeatOfflineThreshold = config.OfflineThreshold()
+				d.gateway.HeartbeatInterval = config.HeartbeatInterval()
+				d.gateway.HeartbeatPowerSave = config.HeartbeatPowerSave()
+				d.gateway.HeartbeatStateDelay = config.MemberStateDelay()
+
+				return nil
+			})
+			if configErr != nil {
+				logger.Warn("Failed loading heartbeat configuration, using defaults", logger.Ctx{"err": configErr})
+			}
+
 			taskFunc, taskSchedule := cluster.HeartbeatTask(d.gateway)
 			hbGroup := task.Group{}
 			d.taskClusterHeartbeat = hbGroup.Add(taskFunc, taskSchedule)
@@ -1143,7 +1565,11 @@ func (d *Daemon) init() error {
 		return fmt.Errorf("Failed to initialize global database: %w", err)
 	}
 
-	d.firewall = firewall.New()
+	d.firewall, err = firewall.Load(d.localConfig.FirewallDriver())
+	if err != nil {
+		return err
+	}
+
 	logger.Info("Firewall loaded driver", logger.Ctx{"driver": d.firewall})
 
 	err = cluster.NotifyUpgradeCompleted(d.State(), networkCert, d.serverCert())
@@ -1176,11 +1602,6 @@ func (d *Daemon) init() error {
 		instancesStart(s, instances)
 	}
 
-	// Setup the user-agent.
-	if clustered {
-		version.UserAgentFeatures([]string{"cluster"})
-	}
-
 	// Load server name and config before patches run (so they can access them from d.State()).
 	err = d.db.Cluster.Transaction(d.shutdownCtx, func(ctx context.Context, tx *db.ClusterTx) error {
 		config, err := clusterConfig.Load(ctx, tx)
@@ -1274,12 +1695,28 @@ func (d *Daemon) init() error {
 	d.proxy = proxy.FromConfig(d.globalConfig.ProxyHTTPS(), d.globalConfig.ProxyHTTP(), d.globalConfig.ProxyIgnoreHosts())
 
 	d.gateway.HeartbeatOfflineThreshold = d.globalConfig.OfflineThreshold()
+	d.gateway.HeartbeatInterval = d.globalConfig.HeartbeatInterval()
+	d.gateway.HeartbeatPowerSave = d.globalConfig.HeartbeatPowerSave()
+	d.gateway.HeartbeatStateDelay = d.globalConfig.MemberStateDelay()
 	lokiURL, lokiUsername, lokiPassword, lokiCACert, lokiLabels, lokiLoglevel, lokiTypes := d.globalConfig.LokiServer()
+	auditLogPath := d.localConfig.AuditLogPath()
+	auditLogFormat, auditLogRotateSize, auditLogRotateInterval, auditLogMaxFiles := d.globalConfig.AuditLog()
+	lifecycleHook, lifecycleHookActions, lifecycleHookTimeout := d.globalConfig.InstancesHook()
 	oidcIssuer, oidcClientID, oidcAudience := d.globalConfig.OIDCServer()
+	openfgaAPIURL, openfgaAPIToken, openfgaStoreID, openfgaModelID, openfgaCacheTTL, openfgaFailClosed, openfgaTLSRestrictions := d.globalConfig.OpenFGA()
 	syslogSocketEnabled := d.localConfig.SyslogSocket()
 	instancePlacementScriptlet := d.globalConfig.InstancesPlacementScriptlet()
 
 	d.endpoints.NetworkUpdateTrustedProxy(d.globalConfig.HTTPSTrustedProxy())
+	events.LoggingSampler.Configure(d.globalConfig.LogSampling())
+	d.events.SetLocalOnlyTypes(d.globalConfig.LocalEventTypes())
+	dbRetryAttempts, dbRetryBackoff := d.globalConfig.DatabaseRetry()
+	query.SetRetryPolicy(int(dbRetryAttempts), dbRetryBackoff)
+	db.SetSlowTransactionThreshold(d.globalConfig.DatabaseSlowThreshold())
+	operations.SetTasksLimit(int(d.globalConfig.MaxConcurrentOperations()))
+
+	// Advertise the enabled subsystems in the user-agent, before any of them starts making requests.
+	version.UserAgentFeatures(userAgentFeatures(clustered, d.localConfig, d.globalConfig))
 	d.globalConfigMu.Unlock()
 
 	// Setup Loki logger.
@@ -1290,6 +1727,19 @@ func (d *Daemon) init() error {
 		}
 	}
 
+	// Setup audit log.
+	if auditLogPath != "" {
+		err = d.setupAuditLog(auditLogPath, auditLogFormat, auditLogRotateSize, auditLogRotateInterval, auditLogMaxFiles)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Setup instance lifecycle hook.
+	if lifecycleHook != "" {
+		d.setupLifecycleHook(lifecycleHook, lifecycleHookActions, lifecycleHookTimeout)
+	}
+
 	// Setup syslog listener.
 	if syslogSocketEnabled {
 		err = d.setupSyslogSocket(true)
@@ -1303,6 +1753,14 @@ func (d *Daemon) init() error {
 		d.oidcVerifier = oidc.NewVerifier(oidcIssuer, oidcClientID, oidcAudience)
 	}
 
+	// Setup OpenFGA authorization.
+	if openfgaAPIURL != "" && openfgaStoreID != "" {
+		err = d.setupOpenFGA(openfgaAPIURL, openfgaAPIToken, openfgaStoreID, openfgaModelID, openfgaCacheTTL, openfgaFailClosed, openfgaTLSRestrictions)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Setup BGP listener.
 	d.bgp = bgp.NewServer()
 	if bgpAddress != "" && bgpASN != 0 && bgpRouterID != "" {
@@ -1366,6 +1824,12 @@ func (d *Daemon) init() error {
 		return err
 	}
 
+	// Check that the networks agree with their parent interfaces and uplinks on the MTU.
+	err = networkMTUCheck(d.State())
+	if err != nil {
+		logger.Warn("Failed checking network MTUs", logger.Ctx{"err": err})
+	}
+
 	// Setup tertiary listeners that may use managed network addresses and must be started after networks.
 	metricsAddress := d.localConfig.MetricsAddress()
 	if metricsAddress != "" {
@@ -1413,7 +1877,10 @@ func (d *Daemon) init() error {
 
 		logger.Info("Starting device monitor")
 
-		d.devmonitor, err = fsmonitor.New(d.State().ShutdownCtx, prefixPath)
+		// Watch the main device path along with any additional configured paths.
+		devMonitorPaths := append([]string{prefixPath}, d.localConfig.DevMonitorPaths()...)
+
+		d.devmonitor, err = fsmonitor.New(d.State().ShutdownCtx, devMonitorPaths)
 		if err != nil {
 			return err
 		}
@@ -1508,6 +1975,14 @@ func (d *Daemon) init() error {
 
 		// Remove expired tokens (hourly)
 		d.tasks.Add(autoRemoveExpiredTokensTask(d))
+
+		// Prune the operations history (hourly)
+		d.tasks.Add(pruneOperationsHistoryTask(d))
+	}
+
+	// Notify the service manager watchdog (half the watchdog interval)
+	if d.systemdWatchdogInterval > 0 {
+		d.tasks.Add(systemdWatchdogTask(d))
 	}
 
 	// Start all background tasks
@@ -1524,6 +1999,12 @@ func (d *Daemon) init() error {
 	// Unblock incoming requests
 	d.waitReady.Cancel()
 
+	// Let the service manager know that we're ready.
+	err = linux.SystemdNotify("READY=1")
+	if err != nil {
+		logger.Warn("Failed notifying service manager of readiness", logger.Ctx{"err": err})
+	}
+
 	logger.Info("Daemon started")
 
 	return nil
@@ -1546,6 +2027,9 @@ func (d *Daemon) startClusterTasks() {
 	// Perform automatic evacuation for offline cluster members
 	d.clusterTasks.Add(autoHealClusterTask(d))
 
+	// Detect loss of the cluster database quorum
+	d.clusterTasks.Add(clusterQuorumTask(d))
+
 	// Start all background tasks
 	d.clusterTasks.Start(d.shutdownCtx)
 }
@@ -1567,9 +2051,47 @@ func (d *Daemon) numRunningInstances(instances []instance.Instance) int {
 	return count
 }
 
+// daemonStopMode indicates what the daemon is being stopped for.
+type daemonStopMode int
+
+const (
+	// daemonStopModeExit stops the daemon without waiting for operations, leaving instances running.
+	daemonStopModeExit daemonStopMode = iota
+
+	// daemonStopModeRestart stops the daemon for it to be restarted, leaving instances running.
+	daemonStopModeRestart
+
+	// daemonStopModeShutdown stops the daemon along with all the instances as the host is going down.
+	daemonStopModeShutdown
+)
+
+// String returns the name of the stop mode.
+func (m daemonStopMode) String() string {
+	switch m {
+	case daemonStopModeRestart:
+		return "restart"
+	case daemonStopModeShutdown:
+		return "shutdown"
+	}
+
+	return "exit"
+}
+
+// daemonStopModeFromSignal returns the stop mode matching the signal received by the daemon.
+func daemonStopModeFromSignal(sig os.Signal) daemonStopMode {
+	switch sig {
+	case unix.SIGPWR:
+		return daemonStopModeShutdown
+	case unix.SIGTERM:
+		return daemonStopModeRestart
+	}
+
+	return daemonStopModeExit
+}
+
 // Stop stops the shared daemon.
-func (d *Daemon) Stop(ctx context.Context, sig os.Signal) error {
-	logger.Info("Starting shutdown sequence", logger.Ctx{"signal": sig})
+func (d *Daemon) Stop(ctx context.Context, mode daemonStopMode) error {
+	logger.Info("Starting shutdown sequence", logger.Ctx{"mode": mode})
 
 	// Cancelling the context will make everyone aware that we're shutting down.
 	d.shutdownCancel()
@@ -1613,8 +2135,8 @@ func (d *Daemon) Stop(ctx context.Context, sig os.Signal) error {
 		}
 	}
 
-	// Handle shutdown (unix.SIGPWR) and reload (unix.SIGTERM) signals.
-	if sig == unix.SIGPWR || sig == unix.SIGTERM {
+	// Handle shutdown and restart.
+	if mode == daemonStopModeShutdown || mode == daemonStopModeRestart {
 		if d.db.Cluster != nil {
 			// waitForOperations will block until all operations are done, or it's forced to shut down.
 			// For the latter case, we re-use the shutdown channel which is filled when a shutdown is
@@ -1624,25 +2146,43 @@ func (d *Daemon) Stop(ctx context.Context, sig os.Signal) error {
 
 		// Unmount daemon image and backup volumes if set.
 		logger.Info("Stopping daemon storage volumes")
-		done := make(chan struct{})
+		done := make(chan error, 1)
 		go func() {
 			err := daemonStorageVolumesUnmount(s)
 			if err != nil {
 				logger.Error("Failed to unmount image and backup volumes", logger.Ctx{"err": err})
 			}
 
-			done <- struct{}{}
+			done <- err
 		}()
 
 		// Only wait 60 seconds in case the storage backend is unreachable.
 		select {
 		case <-time.After(time.Minute):
 			logger.Error("Timed out waiting for image and backup volume")
-		case <-done:
+
+			// Record the failure and detach the volumes so that they aren't left half-mounted.
+			if d.db.Cluster != nil {
+				err := d.db.Cluster.UpsertWarningLocalNode("", -1, -1, warningtype.DaemonStorageUnmountTimeout, "Timed out unmounting the image and backup volumes on shutdown, detached them instead")
+				if err != nil {
+					logger.Warn("Failed to create warning", logger.Ctx{"err": err})
+				}
+			}
+
+			daemonStorageVolumesDetach(s)
+		case err := <-done:
+			// The volumes were cleanly unmounted, clear any warning left by a previous shutdown.
+			if err == nil && d.db.Cluster != nil {
+				err = warnings.ResolveWarningsByLocalNodeAndType(d.db.Cluster, warningtype.DaemonStorageUnmountTimeout)
+				if err != nil {
+					logger.Warn("Failed to resolve warning", logger.Ctx{"type": warningtype.DaemonStorageUnmountTimeout, "err": err})
+				}
+			}
 		}
 
 		// Full shutdown requested.
-		if sig == unix.SIGPWR {
+		if mode == daemonStopModeShutdown {
+			logger.Info("Shutting down instances")
 			instancesShutdown(s, instances)
 
 			logger.Info("Stopping networks")
@@ -1655,7 +2195,10 @@ func (d *Daemon) Stop(ctx context.Context, sig os.Signal) error {
 				logger.Error("Failed to get storage pools", logger.Ctx{"err": err})
 			}
 
-			for _, poolName := range pools {
+			// Unmount pools backed by other pools first.
+			pools = storagePoolsMountOrder(s, pools)
+			for i := len(pools) - 1; i >= 0; i-- {
+				poolName := pools[i]
 				pool, err := storagePools.LoadByName(s, poolName)
 				if err != nil {
 					logger.Error("Failed to get storage pool", logger.Ctx{"pool": poolName, "err": err})
@@ -1668,6 +2211,8 @@ func (d *Daemon) Stop(ctx context.Context, sig os.Signal) error {
 					continue
 				}
 			}
+		} else {
+			logger.Info("Keeping instances running across the restart", logger.Ctx{"instances": d.numRunningInstances(instances)})
 		}
 	}
 
@@ -1791,25 +2336,49 @@ func initializeDbObject(d *Daemon) error {
 	return nil
 }
 
-// hasMemberStateChanged returns true if the number of members, their addresses or state has changed.
-func (d *Daemon) hasMemberStateChanged(heartbeatData *cluster.APIHeartbeat) bool {
+// hasMemberStateChanged returns true if the number of members, their addresses, state, database role or version has changed.
+// Changes to the online state of a member are only considered once they lasted for the configured delay.
+func (d *Daemon) hasMemberStateChanged(lastNodeList *cluster.APIHeartbeat, heartbeatData *cluster.APIHeartbeat) bool {
+	// Record the member states on every heartbeat so that the time spent in each state is tracked.
+	onlineChanged := false
+	memberIDs := make([]int64, 0, len(heartbeatData.Members))
+	for memberID, member := range heartbeatData.Members {
+		memberIDs = append(memberIDs, memberID)
+
+		_, changed := d.memberState.Observe(memberID, member.Online, d.gateway.HeartbeatStateDelay)
+		if changed {
+			onlineChanged = true
+		}
+	}
+
+	d.memberState.Retain(memberIDs...)
+
 	// No previous heartbeat data.
-	if d.lastNodeList == nil {
+	if lastNodeList == nil {
+		return true
+	}
+
+	// Member online state has changed for long enough.
+	if onlineChanged {
 		return true
 	}
 
 	// Member count has changed.
-	if len(d.lastNodeList.Members) != len(heartbeatData.Members) {
+	if len(lastNodeList.Members) != len(heartbeatData.Members) {
 		return true
 	}
 
 	// Check for member address or state changes.
-	for lastMemberID, lastMember := range d.lastNodeList.Members {
+	for lastMemberID, lastMember := range lastNodeList.Members {
 		if heartbeatData.Members[lastMemberID].Address != lastMember.Address {
 			return true
 		}
 
-		if heartbeatData.Members[lastMemberID].Online != lastMember.Online {
+		if heartbeatData.Members[lastMemberID].RaftRole != lastMember.RaftRole {
+			return true
+		}
+
+		if heartbeatData.Members[lastMemberID].Schema != lastMember.Schema || heartbeatData.Members[lastMemberID].APIExtensions != lastMember.APIExtensions {
 			return true
 		}
 	}
@@ -1912,6 +2481,103 @@ func (d *Daemon) heartbeatHandler(w http.ResponseWriter, r *http.Request, isLead
 	}
 }
 
+// checkConfigDrift compares the hash of the loaded cluster-wide configuration with the leader's one.
+// On drift, a warning is raised and the configuration is reloaded from the database in the background so that
+// the heartbeat handling isn't delayed.
+func (d *Daemon) checkConfigDrift(s *state.State, leaderHash string) {
+	d.configDriftMu.Lock()
+	defer d.configDriftMu.Unlock()
+
+	if s.GlobalConfig.Hash() == leaderHash {
+		if d.configDrift > 1 {
+			logger.Warn("Cluster configuration drift resolved")
+
+			err := warnings.ResolveWarningsByLocalNodeAndType(d.db.Cluster, warningtype.ClusterConfigDrift)
+			if err != nil {
+				logger.Warn("Failed to resolve cluster configuration drift warning", logger.Ctx{"err": err})
+			}
+		}
+
+		d.configDrift = 0
+		return
+	}
+
+	d.configDrift++
+
+	// A single mismatch can be caused by a configuration change being propagated, so wait for the next heartbeat.
+	if d.configDrift < 2 {
+		return
+	}
+
+	if d.configDrift == 2 {
+		logger.Warn("Cluster configuration drift detected between leader and local, reloading")
+
+		err := d.db.Cluster.UpsertWarningLocalNode("", -1, -1, warningtype.ClusterConfigDrift, fmt.Sprintf("leaderHash: %s, localHash: %s", leaderHash, s.GlobalConfig.Hash()))
+		if err != nil {
+			logger.Warn("Failed to create cluster configuration drift warning", logger.Ctx{"err": err})
+		}
+	}
+
+	// Don't start another reload while the previous one is still running.
+	if d.configDriftReloading {
+		return
+	}
+
+	d.configDriftReloading = true
+
+	go func() {
+		err := d.reloadGlobalConfig()
+		if err != nil {
+			logger.Error("Failed reloading cluster configuration", logger.Ctx{"err": err})
+		}
+
+		d.configDriftMu.Lock()
+		d.configDriftReloading = false
+		d.configDriftMu.Unlock()
+	}()
+}
+
+// reloadGlobalConfig reloads the cluster-wide configuration from the database and runs the update
+// triggers for any key whose value differs from the previously loaded configuration.
+func (d *Daemon) reloadGlobalConfig() error {
+	var newConfig *clusterConfig.Config
+	err := d.db.Cluster.Transaction(d.shutdownCtx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		newConfig, err = clusterConfig.Load(ctx, tx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	d.globalConfigMu.Lock()
+	oldValues := d.globalConfig.Dump()
+	d.globalConfig = newConfig
+	d.globalConfigMu.Unlock()
+
+	newValues := newConfig.Dump()
+	changed := map[string]string{}
+	for key, value := range newValues {
+		if oldValues[key] != value {
+			changed[key] = value
+		}
+	}
+
+	for key := range oldValues {
+		_, ok := newValues[key]
+		if !ok {
+			changed[key] = ""
+		}
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	return doApi10UpdateTriggers(d, nil, changed, d.State().LocalConfig, newConfig)
+}
+
 // nodeRefreshTask is run when a full state heartbeat is sent (on the leader) or received (by a non-leader member).
 // Is is used to check for member state changes and trigger refreshes of the certificate cache.
 // It also triggers member role promotion when run on the isLeader is true.
@@ -1933,8 +2599,12 @@ func (d *Daemon) nodeRefreshTask(heartbeatData *cluster.APIHeartbeat, isLeader b
 		return
 	}
 
+	d.lastNodeListMu.Lock()
+	lastNodeList := d.lastNodeList
+	d.lastNodeListMu.Unlock()
+
 	// If the max version of the cluster has changed, check whether we need to upgrade.
-	if d.lastNodeList == nil || d.lastNodeList.Version.APIExtensions != heartbeatData.Version.APIExtensions || d.lastNodeList.Version.Schema != heartbeatData.Version.Schema {
+	if lastNodeList == nil || lastNodeList.Version.APIExtensions != heartbeatData.Version.APIExtensions || lastNodeList.Version.Schema != heartbeatData.Version.Schema {
 		err := cluster.MaybeUpdate(s)
 		if err != nil {
 			logger.Error("Error updating", logger.Ctx{"err": err})
@@ -1942,6 +2612,12 @@ func (d *Daemon) nodeRefreshTask(heartbeatData *cluster.APIHeartbeat, isLeader b
 		}
 	}
 
+	// If the leader's cluster-wide configuration differs from ours, reload it.
+	// This is skipped while members run different versions as they may not know about the same keys.
+	if !isLeader && heartbeatData.ConfigHash != "" && s.GlobalConfig != nil && heartbeatData.Version.APIExtensions == version.APIExtensionsCount() {
+		d.checkConfigDrift(s, heartbeatData.ConfigHash)
+	}
+
 	stateChangeTaskFailure := false // Records whether any of the state change tasks failed.
 
 	// Handle potential OVN chassis changes.
@@ -1951,7 +2627,7 @@ func (d *Daemon) nodeRefreshTask(heartbeatData *cluster.APIHeartbeat, isLeader b
 		logger.Error("Error restarting OVN networks", logger.Ctx{"err": err})
 	}
 
-	if d.hasMemberStateChanged(heartbeatData) {
+	if d.hasMemberStateChanged(lastNodeList, heartbeatData) {
 		logger.Info("Cluster member state has changed", logger.Ctx{"local": localClusterAddress})
 
 		// Refresh cluster certificates cached.
@@ -1971,7 +2647,9 @@ func (d *Daemon) nodeRefreshTask(heartbeatData *cluster.APIHeartbeat, isLeader b
 	// Only update the node list if there are no state change task failures.
 	// If there are failures, then we leave the old state so that we can re-try the tasks again next heartbeat.
 	if !stateChangeTaskFailure {
+		d.lastNodeListMu.Lock()
 		d.lastNodeList = heartbeatData
+		d.lastNodeListMu.Unlock()
 	}
 
 	// If we are leader and called from the leader heartbeat send function (unavailbleMembers != nil) and there
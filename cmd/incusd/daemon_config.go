@@ -8,16 +8,51 @@ import (
 	"github.com/lxc/incus/internal/server/node"
 	"github.com/lxc/incus/internal/server/state"
 	"github.com/lxc/incus/shared/proxy"
+	"github.com/lxc/incus/shared/util"
 )
 
-func daemonConfigRender(state *state.State) (map[string]string, error) {
-	config := map[string]string{}
+// daemonConfigHiddenKeys are the server configuration keys holding secrets, which aren't returned by the API.
+var daemonConfigHiddenKeys = []string{"core.metrics_key", "openfga.api.token"}
 
-	// Turn the config into a JSON-compatible map.
-	for key, value := range state.GlobalConfig.Dump() {
-		config[key] = value
+// daemonConfigHiddenValue is returned in place of the value of the hidden keys.
+// Sending it back leaves the key unchanged.
+const daemonConfigHiddenValue = "********"
+
+// daemonConfigHide returns a copy of the configuration with the values of the hidden keys masked.
+func daemonConfigHide(config map[string]string) map[string]string {
+	hidden := make(map[string]string, len(config))
+	for key, value := range config {
+		if util.ValueInSlice(key, daemonConfigHiddenKeys) {
+			value = daemonConfigHiddenValue
+		}
+
+		hidden[key] = value
 	}
 
+	return hidden
+}
+
+// daemonConfigUnhide replaces the masked values of the hidden keys with their current value.
+// Hidden keys which currently aren't set are removed.
+func daemonConfigUnhide(config map[string]string, current map[string]string) {
+	for _, key := range daemonConfigHiddenKeys {
+		if config[key] != daemonConfigHiddenValue {
+			continue
+		}
+
+		value, ok := current[key]
+		if ok {
+			config[key] = value
+		} else {
+			delete(config, key)
+		}
+	}
+}
+
+func daemonConfigRender(state *state.State) (map[string]string, error) {
+	// Turn the config into a JSON-compatible map.
+	config := daemonConfigHide(state.GlobalConfig.Dump())
+
 	// Apply the local config.
 	err := state.DB.Node.Transaction(context.TODO(), func(ctx context.Context, tx *db.NodeTx) error {
 		nodeConfig, err := node.ConfigLoad(ctx, tx)
@@ -25,7 +60,7 @@ func daemonConfigRender(state *state.State) (map[string]string, error) {
 			return err
 		}
 
-		for key, value := range nodeConfig.Dump() {
+		for key, value := range daemonConfigHide(nodeConfig.Dump()) {
 			config[key] = value
 		}
 
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/lxc/incus/client"
@@ -17,6 +18,7 @@ import (
 	"github.com/lxc/incus/internal/server/lifecycle"
 	"github.com/lxc/incus/internal/server/locking"
 	"github.com/lxc/incus/internal/server/operations"
+	"github.com/lxc/incus/internal/server/project"
 	"github.com/lxc/incus/internal/server/request"
 	"github.com/lxc/incus/internal/server/response"
 	"github.com/lxc/incus/internal/server/state"
@@ -47,6 +49,7 @@ type ImageDownloadArgs struct {
 	StoragePool       string
 	Budget            int64
 	SourceProjectName string
+	BandwidthLimit    int64
 }
 
 // imageOperationLock acquires a lock for operating on an image and returns the unlock function.
@@ -58,6 +61,26 @@ func imageOperationLock(fingerprint string) (locking.UnlockFunc, error) {
 	return locking.Lock(context.TODO(), fmt.Sprintf("ImageOperation_%s", fingerprint))
 }
 
+// imageRemoteResolve returns the server URL and protocol to use for an image source, resolving the name of an
+// image remote of the project into its URL and protocol.
+func imageRemoteResolve(ctx context.Context, s *state.State, projectName string, server string, protocol string) (string, string, error) {
+	if server == "" || strings.Contains(server, "://") {
+		return server, protocol, nil
+	}
+
+	imageProject, err := project.ImageProject(ctx, s.DB.Cluster, projectName)
+	if err != nil {
+		return "", "", err
+	}
+
+	remoteURL, remoteProtocol, found := project.ImageRemote(imageProject, server)
+	if !found {
+		return server, protocol, nil
+	}
+
+	return remoteURL, remoteProtocol, nil
+}
+
 // ImageDownload resolves the image fingerprint and if not in the database, downloads it.
 func ImageDownload(r *http.Request, s *state.State, op *operations.Operation, args *ImageDownloadArgs) (*api.Image, error) {
 	var err error
@@ -66,6 +89,16 @@ func ImageDownload(r *http.Request, s *state.State, op *operations.Operation, ar
 	var remote incus.ImageServer
 	var info *api.Image
 
+	// Check that the project allows downloading images from the server.
+	imageProject, err := project.ImageProject(context.TODO(), s.DB.Cluster, args.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !project.ImageServerAllowed(imageProject, args.Server) {
+		return nil, api.StatusErrorf(http.StatusForbidden, "Image server %q isn't allowed in project %q", args.Server, imageProject.Name)
+	}
+
 	// Default protocol is Incus. Copy so that local modifications aren't propgated to args.
 	protocol := args.Protocol
 	if protocol == "" {
@@ -312,6 +345,11 @@ func ImageDownload(r *http.Request, s *state.State, op *operations.Operation, ar
 	if op != nil {
 		canceler = cancel.NewHTTPRequestCanceller()
 		op.SetCanceler(canceler)
+
+		// Don't start downloading if the operation was cancelled in the meantime.
+		if op.Context().Err() != nil {
+			return nil, fmt.Errorf("Image download cancelled")
+		}
 	}
 
 	if util.ValueInSlice(protocol, []string{"incus", "lxd", "simplestreams"}) {
@@ -361,8 +399,8 @@ func ImageDownload(r *http.Request, s *state.State, op *operations.Operation, ar
 		// Download the image
 		var resp *incus.ImageFileResponse
 		request := incus.ImageFileRequest{
-			MetaFile:        io.WriteSeeker(dest),
-			RootfsFile:      io.WriteSeeker(destRootfs),
+			MetaFile:        internalIO.NewRateLimitWriter(dest, args.BandwidthLimit),
+			RootfsFile:      internalIO.NewRateLimitWriter(destRootfs, args.BandwidthLimit),
 			ProgressHandler: progress,
 			Canceler:        canceler,
 			DeltaSourceRetriever: func(fingerprint string, file string) string {
@@ -513,6 +551,11 @@ func ImageDownload(r *http.Request, s *state.State, op *operations.Operation, ar
 		info.AutoUpdate = args.AutoUpdate
 	}
 
+	// Don't record the image if the operation was cancelled in the meantime.
+	if op != nil && op.Context().Err() != nil {
+		return nil, fmt.Errorf("Image download cancelled")
+	}
+
 	// Create the database entry
 	err = s.DB.Cluster.CreateImage(args.ProjectName, info.Fingerprint, info.Filename, info.Size, info.Public, info.AutoUpdate, info.Architecture, info.CreatedAt, info.ExpiresAt, info.Properties, info.Type, nil)
 	if err != nil {
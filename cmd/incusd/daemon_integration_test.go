@@ -7,7 +7,6 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"golang.org/x/sys/unix"
 
 	"github.com/lxc/incus/client"
 	"github.com/lxc/incus/internal/server/sys"
@@ -39,7 +38,7 @@ func newTestDaemon(t *testing.T) (*Daemon, func()) {
 	require.NoError(t, daemon.Init())
 
 	cleanup := func() {
-		assert.NoError(t, daemon.Stop(context.Background(), unix.SIGQUIT))
+		assert.NoError(t, daemon.Stop(context.Background(), daemonStopModeExit))
 		osCleanup()
 	}
 
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lxc/incus/internal/server/cluster"
+	"github.com/lxc/incus/internal/server/db"
+	"github.com/lxc/incus/internal/server/db/warningtype"
+	"github.com/lxc/incus/internal/server/lifecycle"
+	"github.com/lxc/incus/internal/server/project"
+	"github.com/lxc/incus/internal/server/state"
+	"github.com/lxc/incus/internal/server/task"
+	"github.com/lxc/incus/shared/logger"
+)
+
+// clusterQuorumCheckInterval is the interval at which the availability of the cluster database leader is checked.
+const clusterQuorumCheckInterval = 10 * time.Second
+
+// clusterQuorumTask detects when the cluster database loses quorum, which is the case when no raft leader can be
+// found for longer than the offline threshold.
+// Loss and recovery of quorum are logged and reported through lifecycle events. As the database can't be written
+// to without quorum, the warning is only recorded once quorum is restored.
+// With the recover action, the leader rebalances the database roles as soon as quorum is restored so that the voters
+// which are still unreachable get replaced by online members.
+func clusterQuorumTask(d *Daemon) (task.Func, task.Schedule) {
+	// Time since which no leader could be found.
+	var noLeaderSince time.Time
+
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		_, err := d.gateway.LeaderAddress()
+		if err != nil {
+			if errors.Is(err, cluster.ErrNodeIsNotClustered) || ctx.Err() != nil {
+				return
+			}
+
+			if noLeaderSince.IsZero() {
+				noLeaderSince = time.Now()
+			}
+
+			if d.quorumLost.Load() || time.Since(noLeaderSince) < s.GlobalConfig.OfflineThreshold() {
+				return
+			}
+
+			d.quorumLost.Store(true)
+
+			action := s.GlobalConfig.QuorumLossAction()
+			unreachable := clusterQuorumUnreachableVoters(s)
+			logger.Error("Cluster database quorum lost", logger.Ctx{"since": noLeaderSince, "action": action, "unreachable": unreachable, "err": err})
+			s.Events.SendLifecycle(project.Default, lifecycle.ClusterQuorumLost.Event(s.ServerName, nil, map[string]any{"since": noLeaderSince, "action": action, "unreachable": unreachable}))
+
+			return
+		}
+
+		if d.quorumLost.Load() {
+			d.quorumLost.Store(false)
+
+			logger.Warn("Cluster database quorum restored", logger.Ctx{"since": noLeaderSince})
+			s.Events.SendLifecycle(project.Default, lifecycle.ClusterQuorumRestored.Event(s.ServerName, nil, map[string]any{"since": noLeaderSince}))
+
+			err = s.DB.Cluster.UpsertWarningLocalNode("", -1, -1, warningtype.ClusterQuorumLost, fmt.Sprintf("Quorum lost from %s to %s", noLeaderSince.UTC().Format(time.RFC3339), time.Now().UTC().Format(time.RFC3339)))
+			if err != nil {
+				logger.Warn("Failed to create cluster quorum warning", logger.Ctx{"err": err})
+			}
+
+			// Replace the voters which are still unreachable.
+			if s.GlobalConfig.QuorumLossAction() == "recover" {
+				unreachable := clusterQuorumUnreachableVoters(s)
+				if len(unreachable) > 0 {
+					d.clusterMembershipMutex.Lock()
+					err = rebalanceMemberRoles(s, d.gateway, nil, unreachable)
+					d.clusterMembershipMutex.Unlock()
+					if err != nil && !errors.Is(err, cluster.ErrNotLeader) {
+						logger.Warn("Failed replacing the unreachable database voters", logger.Ctx{"unreachable": unreachable, "err": err})
+					}
+				}
+			}
+
+			// Refresh the member states straight away rather than waiting for the next heartbeat.
+			if d.taskClusterHeartbeat != nil {
+				d.taskClusterHeartbeat.Reset()
+			}
+		}
+
+		noLeaderSince = time.Time{}
+	}
+
+	return f, task.Every(clusterQuorumCheckInterval)
+}
+
+// clusterQuorumFrozen returns whether changes must be refused as the cluster database lost quorum.
+func (d *Daemon) clusterQuorumFrozen() bool {
+	if !d.quorumLost.Load() {
+		return false
+	}
+
+	d.globalConfigMu.Lock()
+	defer d.globalConfigMu.Unlock()
+
+	if d.globalConfig == nil {
+		return false
+	}
+
+	action := d.globalConfig.QuorumLossAction()
+
+	return action == "freeze" || action == "recover"
+}
+
+// clusterQuorumUnreachableVoters returns the addresses of the database voters which can't be reached.
+func clusterQuorumUnreachableVoters(s *state.State) []string {
+	var nodes []db.RaftNode
+	err := s.DB.Node.Transaction(context.TODO(), func(ctx context.Context, tx *db.NodeTx) error {
+		var err error
+		nodes, err = tx.GetRaftNodes(ctx)
+		return err
+	})
+	if err != nil {
+		logger.Warn("Failed loading the database members", logger.Ctx{"err": err})
+		return nil
+	}
+
+	unreachable := []string{}
+	for _, node := range nodes {
+		if node.Role != db.RaftVoter || cluster.HasConnectivity(s.Endpoints.NetworkCert(), s.ServerCert(), node.Address) {
+			continue
+		}
+
+		unreachable = append(unreachable, node.Address)
+	}
+
+	return unreachable
+}
@@ -7,6 +7,9 @@ import (
 	"path/filepath"
 	"strings"
 
+	"golang.org/x/sys/unix"
+
+	"github.com/lxc/incus/internal/linux"
 	"github.com/lxc/incus/internal/rsync"
 	"github.com/lxc/incus/internal/server/db"
 	"github.com/lxc/incus/internal/server/node"
@@ -15,6 +18,7 @@ import (
 	storagePools "github.com/lxc/incus/internal/server/storage"
 	storageDrivers "github.com/lxc/incus/internal/server/storage/drivers"
 	internalUtil "github.com/lxc/incus/internal/util"
+	"github.com/lxc/incus/shared/logger"
 )
 
 func daemonStorageVolumesUnmount(s *state.State) error {
@@ -74,6 +78,42 @@ func daemonStorageVolumesUnmount(s *state.State) error {
 	return nil
 }
 
+// daemonStorageVolumesDetach lazily unmounts the daemon storage volumes.
+// This is used as a fallback when the regular unmount didn't complete in time, so that the volumes don't
+// remain mounted once the daemon is gone.
+func daemonStorageVolumesDetach(s *state.State) {
+	for _, source := range []string{s.LocalConfig.StorageBackupsVolume(), s.LocalConfig.StorageImagesVolume()} {
+		if source == "" {
+			continue
+		}
+
+		mountpoint, err := daemonStorageVolumeMountPath(source)
+		if err != nil || !linux.IsMountPoint(mountpoint) {
+			continue
+		}
+
+		err = unix.Unmount(mountpoint, unix.MNT_DETACH)
+		if err != nil {
+			logger.Error("Failed to detach storage volume", logger.Ctx{"volume": source, "err": err})
+			continue
+		}
+
+		logger.Info("Detached storage volume", logger.Ctx{"volume": source})
+	}
+}
+
+// daemonStorageVolumeMountPath returns the mount path of a daemon storage volume.
+func daemonStorageVolumeMountPath(source string) (string, error) {
+	poolName, volumeName, err := daemonStorageSplitVolume(source)
+	if err != nil {
+		return "", err
+	}
+
+	volStorageName := project.StorageVolume(project.Default, volumeName)
+
+	return storageDrivers.GetVolumeMountPath(poolName, storageDrivers.VolumeTypeCustom, volStorageName), nil
+}
+
 func daemonStorageMount(s *state.State) error {
 	var storageBackups string
 	var storageImages string
@@ -104,6 +144,24 @@ func daemonStorageMount(s *state.State) error {
 			return err
 		}
 
+		// Clear any stale mount left behind by a previous shutdown which didn't complete.
+		mountpoint, err := daemonStorageVolumeMountPath(source)
+		if err != nil {
+			return err
+		}
+
+		if linux.IsMountPoint(mountpoint) {
+			_, err = os.ReadDir(mountpoint)
+			if err != nil {
+				logger.Warn("Clearing stale mount of storage volume", logger.Ctx{"volume": source, "err": err})
+
+				err = unix.Unmount(mountpoint, unix.MNT_DETACH)
+				if err != nil {
+					return fmt.Errorf("Failed to clear stale mount of storage volume %q: %w", source, err)
+				}
+			}
+		}
+
 		// Mount volume.
 		_, err = pool.MountCustomVolume(project.Default, volumeName, nil)
 		if err != nil {
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lxc/incus/internal/linux"
+	"github.com/lxc/incus/internal/server/db"
+	"github.com/lxc/incus/internal/server/task"
+	"github.com/lxc/incus/shared/logger"
+)
+
+// systemdWatchdogCheck checks that the core subsystems of the daemon are responsive.
+func systemdWatchdogCheck(ctx context.Context, d *Daemon) error {
+	err := d.db.Node.Transaction(ctx, func(ctx context.Context, tx *db.NodeTx) error {
+		_, err := tx.GetRaftNodes(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Local database unresponsive: %w", err)
+	}
+
+	err = d.db.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		_, err := tx.GetLocalNodeAddress(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Cluster database unresponsive: %w", err)
+	}
+
+	return nil
+}
+
+// systemdWatchdogTask notifies the service manager that the daemon is alive at half the watchdog interval.
+// The notification is skipped when the daemon is unhealthy so that the service manager restarts it.
+func systemdWatchdogTask(d *Daemon) (task.Func, task.Schedule) {
+	interval := d.systemdWatchdogInterval
+
+	f := func(ctx context.Context) {
+		ctx, cancel := context.WithTimeout(ctx, interval/2)
+		defer cancel()
+
+		err := systemdWatchdogCheck(ctx, d)
+		if err != nil {
+			logger.Error("Skipping watchdog notification", logger.Ctx{"err": err})
+			return
+		}
+
+		err = linux.SystemdNotify("WATCHDOG=1")
+		if err != nil {
+			logger.Warn("Failed sending watchdog notification", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Every(interval / 2)
+}
@@ -15,12 +15,14 @@ import (
 	"github.com/gorilla/mux"
 	"golang.org/x/sys/unix"
 
+	internalInstance "github.com/lxc/incus/internal/instance"
 	"github.com/lxc/incus/internal/linux"
 	"github.com/lxc/incus/internal/server/cluster"
 	"github.com/lxc/incus/internal/server/events"
 	"github.com/lxc/incus/internal/server/instance"
 	"github.com/lxc/incus/internal/server/instance/instancetype"
 	"github.com/lxc/incus/internal/server/lifecycle"
+	"github.com/lxc/incus/internal/server/metrics"
 	"github.com/lxc/incus/internal/server/project"
 	"github.com/lxc/incus/internal/server/request"
 	"github.com/lxc/incus/internal/server/response"
@@ -63,9 +65,21 @@ var devIncusConfigGet = devIncusHandler{"/1.0/config", func(d *Daemon, c instanc
 		return response.DevIncusErrorResponse(api.StatusErrorf(http.StatusForbidden, "not authorized"), c.Type() == instancetype.VM)
 	}
 
+	// With recursion, return the keys along with their values.
+	if r.URL.Query().Get("recursion") == "1" {
+		filtered := map[string]string{}
+		for k, v := range c.ExpandedConfig() {
+			if internalInstance.IsGuestAPIConfig(k) {
+				filtered[k] = v
+			}
+		}
+
+		return response.DevIncusResponse(http.StatusOK, filtered, "json", c.Type() == instancetype.VM)
+	}
+
 	filtered := []string{}
 	for k := range c.ExpandedConfig() {
-		if strings.HasPrefix(k, "user.") || strings.HasPrefix(k, "cloud-init.") {
+		if internalInstance.IsGuestAPIConfig(k) {
 			filtered = append(filtered, fmt.Sprintf("/1.0/config/%s", k))
 		}
 	}
@@ -83,7 +97,7 @@ var devIncusConfigKeyGet = devIncusHandler{"/1.0/config/{key}", func(d *Daemon,
 		return response.DevIncusErrorResponse(api.StatusErrorf(http.StatusBadRequest, "bad request"), c.Type() == instancetype.VM)
 	}
 
-	if !strings.HasPrefix(key, "user.") && !strings.HasPrefix(key, "cloud-init.") {
+	if !internalInstance.IsGuestAPIConfig(key) {
 		return response.DevIncusErrorResponse(api.StatusErrorf(http.StatusForbidden, "not authorized"), c.Type() == instancetype.VM)
 	}
 
@@ -265,6 +279,42 @@ var devIncusDevicesGet = devIncusHandler{"/1.0/devices", func(d *Daemon, c insta
 	return response.DevIncusResponse(http.StatusOK, c.ExpandedDevices(), "json", c.Type() == instancetype.VM)
 }}
 
+var devIncusMetrics = devIncusHandler{"/1.0/metrics", func(d *Daemon, c instance.Instance, w http.ResponseWriter, r *http.Request) response.Response {
+	if util.IsFalse(c.ExpandedConfig()["security.guestapi"]) {
+		return response.DevIncusErrorResponse(api.StatusErrorf(http.StatusForbidden, "not authorized"), c.Type() == instancetype.VM)
+	}
+
+	if r.Method == "GET" {
+		resp := map[string]apiGuest.DevIncusMetric{}
+		for name, metric := range instanceCustomMetrics.Get(c.Project().Name, c.Name()) {
+			resp[name] = apiGuest.DevIncusMetric{Type: metric.Type, Value: metric.Value}
+		}
+
+		return response.DevIncusResponse(http.StatusOK, resp, "json", c.Type() == instancetype.VM)
+	} else if r.Method == "PUT" {
+		req := map[string]apiGuest.DevIncusMetric{}
+
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			return response.DevIncusErrorResponse(api.StatusErrorf(http.StatusBadRequest, err.Error()), c.Type() == instancetype.VM)
+		}
+
+		values := make(map[string]metrics.CustomMetric, len(req))
+		for name, metric := range req {
+			values[name] = metrics.CustomMetric{Type: metric.Type, Value: metric.Value}
+		}
+
+		err = instanceCustomMetrics.Set(c.Project().Name, c.Name(), values)
+		if err != nil {
+			return response.DevIncusErrorResponse(api.StatusErrorf(http.StatusBadRequest, err.Error()), c.Type() == instancetype.VM)
+		}
+
+		return response.DevIncusResponse(http.StatusOK, "", "raw", c.Type() == instancetype.VM)
+	}
+
+	return response.DevIncusErrorResponse(api.StatusErrorf(http.StatusMethodNotAllowed, fmt.Sprintf("method %q not allowed", r.Method)), c.Type() == instancetype.VM)
+}}
+
 var handlers = []devIncusHandler{
 	{"/", func(d *Daemon, c instance.Instance, w http.ResponseWriter, r *http.Request) response.Response {
 		return response.DevIncusResponse(http.StatusOK, []string{"/1.0"}, "json", c.Type() == instancetype.VM)
@@ -276,6 +326,7 @@ var handlers = []devIncusHandler{
 	devIncusEventsGet,
 	devIncusImageExport,
 	devIncusDevicesGet,
+	devIncusMetrics,
 }
 
 func hoistReq(f func(*Daemon, instance.Instance, http.ResponseWriter, *http.Request) response.Response, d *Daemon) func(http.ResponseWriter, *http.Request) {
@@ -11,8 +11,6 @@ import (
 	"strings"
 	"testing"
 
-	"golang.org/x/sys/unix"
-
 	"github.com/lxc/incus/internal/linux"
 	"github.com/lxc/incus/internal/server/sys"
 )
@@ -151,7 +149,7 @@ func TestHttpRequest(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	defer func() { _ = d.Stop(context.Background(), unix.SIGQUIT) }()
+	defer func() { _ = d.Stop(context.Background(), daemonStopModeExit) }()
 
 	c := http.Client{Transport: &http.Transport{DialContext: DevIncusDialer{Path: fmt.Sprintf("%s/guestapi/sock", testDir)}.DevIncusDial}}
 
@@ -40,6 +40,8 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"golang.org/x/sys/unix"
 
@@ -54,6 +56,10 @@ import (
 	"github.com/lxc/incus/shared/util"
 )
 
+// deviceTaskPinning holds the CPU pinning last applied by deviceTaskBalance, keyed by project and instance name.
+var deviceTaskPinning = map[string]map[string][]int64{}
+var deviceTaskPinningMu sync.Mutex
+
 type deviceTaskCPU struct {
 	id    int64
 	strId string
@@ -599,6 +605,7 @@ func deviceTaskBalance(s *state.State) {
 	}
 
 	// Set the new pinning
+	appliedPinning := map[string]map[string][]int64{}
 	for ctn, set := range pinning {
 		// Confirm the container didn't just stop
 		if ctn.InitPID() <= 0 {
@@ -615,8 +622,27 @@ func deviceTaskBalance(s *state.State) {
 		err = cg.SetCpuset(strings.Join(set, ","))
 		if err != nil {
 			logger.Error("balance: Unable to set cpuset", logger.Ctx{"name": ctn.Name(), "err": err, "value": strings.Join(set, ",")})
+			continue
 		}
+
+		cpuIDs, err := resources.ParseCpuset(strings.Join(set, ","))
+		if err != nil {
+			continue
+		}
+
+		sort.Slice(cpuIDs, func(i, j int) bool { return cpuIDs[i] < cpuIDs[j] })
+
+		projectName := ctn.Project().Name
+		if appliedPinning[projectName] == nil {
+			appliedPinning[projectName] = map[string][]int64{}
+		}
+
+		appliedPinning[projectName][ctn.Name()] = cpuIDs
 	}
+
+	deviceTaskPinningMu.Lock()
+	deviceTaskPinning = appliedPinning
+	deviceTaskPinningMu.Unlock()
 }
 
 // deviceEventListener starts the event listener for resource scheduling.
@@ -628,6 +654,27 @@ func deviceEventListener(stateFunc func() *state.State) {
 		return
 	}
 
+	// Re-balancing is debounced so that bursts of events only lead to a single run once things settle.
+	var rebalanceTimer *time.Timer
+	var rebalanceCh <-chan time.Time
+	coalesced := 0
+
+	scheduleRebalance := func(s *state.State) {
+		quietPeriod := s.LocalConfig.DevMonitorQuietPeriod()
+		if quietPeriod <= 0 {
+			deviceTaskBalance(s)
+			return
+		}
+
+		if rebalanceTimer != nil {
+			rebalanceTimer.Stop()
+			coalesced++
+		}
+
+		rebalanceTimer = time.NewTimer(quietPeriod)
+		rebalanceCh = rebalanceTimer.C
+	}
+
 	for {
 		select {
 		case e := <-chNetlinkCPU:
@@ -643,7 +690,7 @@ func deviceEventListener(stateFunc func() *state.State) {
 			}
 
 			logger.Debugf("Scheduler: cpu: %s is now %s: re-balancing", e[0], e[1])
-			deviceTaskBalance(s)
+			scheduleRebalance(s)
 
 		case e := <-chUSB:
 			device.USBRunHandlers(stateFunc(), &e)
@@ -662,7 +709,18 @@ func deviceEventListener(stateFunc func() *state.State) {
 			}
 
 			logger.Debugf("Scheduler: %s %s %s: re-balancing", e[0], e[1], e[2])
-			deviceTaskBalance(s)
+			scheduleRebalance(s)
+
+		case <-rebalanceCh:
+			if coalesced > 0 {
+				logger.Debugf("Scheduler: coalesced %d re-balancing requests", coalesced)
+			}
+
+			rebalanceTimer = nil
+			rebalanceCh = nil
+			coalesced = 0
+
+			deviceTaskBalance(stateFunc())
 		}
 	}
 }
@@ -6,6 +6,9 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+
 	"github.com/lxc/incus/internal/server/db"
 	"github.com/lxc/incus/internal/server/db/cluster"
 	"github.com/lxc/incus/internal/server/events"
@@ -19,7 +22,7 @@ import (
 	"github.com/lxc/incus/shared/ws"
 )
 
-var eventTypes = []string{api.EventTypeLogging, api.EventTypeOperation, api.EventTypeLifecycle, api.EventTypeNetworkACL}
+var eventTypes = []string{api.EventTypeLogging, api.EventTypeOperation, api.EventTypeLifecycle, api.EventTypeNetworkACL, api.EventTypeSyscall}
 var privilegedEventTypes = []string{api.EventTypeLogging}
 
 var eventsCmd = APIEndpoint{
@@ -28,6 +31,12 @@ var eventsCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: eventsGet, AccessHandler: allowAuthenticated},
 }
 
+var eventsLoggingCmd = APIEndpoint{
+	Path: "events/logging",
+
+	Get: APIEndpointAction{Handler: eventsLoggingGet},
+}
+
 type eventsServe struct {
 	req *http.Request
 	s   *state.State
@@ -140,7 +149,7 @@ func eventsSocket(s *state.State, r *http.Request, w http.ResponseWriter) error
 
 	listenerConnection := events.NewWebsocketListenerConnection(conn)
 
-	listener, err := s.Events.AddListener(projectName, allProjects, listenerConnection, types, excludeSources, recvFunc, excludeLocations)
+	listener, err := s.Events.AddListener(projectName, allProjects, listenerConnection, types, excludeSources, recvFunc, excludeLocations, isClusterNotification(r))
 	if err != nil {
 		l.Warn("Failed to add event listener", logger.Ctx{"err": err})
 		return nil
@@ -187,3 +196,91 @@ func eventsSocket(s *state.State, r *http.Request, w http.ResponseWriter) error
 func eventsGet(d *Daemon, r *http.Request) response.Response {
 	return &eventsServe{req: r, s: d.State()}
 }
+
+type eventsLoggingServe struct {
+	req *http.Request
+	s   *state.State
+}
+
+func (r *eventsLoggingServe) Render(w http.ResponseWriter) error {
+	return eventsLoggingSocket(r.s, r.req, w)
+}
+
+func (r *eventsLoggingServe) String() string {
+	return "log stream handler"
+}
+
+func eventsLoggingSocket(s *state.State, r *http.Request, w http.ResponseWriter) error {
+	level := queryParam(r, "level")
+	if level == "" {
+		level = "debug"
+	}
+
+	l := logger.AddContext(logger.Ctx{"remote": r.RemoteAddr})
+
+	// Upgrade the connection to websocket
+	conn, err := ws.Upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		l.Warn("Failed upgrading log stream connection", logger.Ctx{"err": err})
+		return nil
+	}
+
+	defer func() { _ = conn.Close() }() // Ensure listener below ends when this function ends.
+
+	listenerConnection, err := events.NewLogStreamConnection(events.NewWebsocketListenerConnection(conn), level)
+	if err != nil {
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseUnsupportedData, err.Error()))
+		return nil
+	}
+
+	s.Events.SetLocalLocation(s.ServerName)
+
+	// Only stream the local log, not the one forwarded from other cluster members.
+	listener, err := s.Events.AddListener("", true, listenerConnection, []string{api.EventTypeLogging}, []events.EventSource{events.EventSourcePull, events.EventSourcePush}, nil, nil, false)
+	if err != nil {
+		l.Warn("Failed to add log stream listener", logger.Ctx{"err": err})
+		return nil
+	}
+
+	listener.Wait(r.Context())
+
+	return nil
+}
+
+// swagger:operation GET /1.0/events/logging server events_logging_get
+//
+//	Get the daemon log stream
+//
+//	Connects to the daemon log stream using websocket.
+//	Log entries are dropped rather than queued indefinitely if the client doesn't keep up,
+//	in which case a warning entry reports how many entries were dropped.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: level
+//	    description: Minimum log level (debug, info, warning or error)
+//	    type: string
+//	    example: info
+//	responses:
+//	  "200":
+//	    description: Websocket message (JSON)
+//	    schema:
+//	      $ref: "#/definitions/Event"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func eventsLoggingGet(d *Daemon, r *http.Request) response.Response {
+	level := queryParam(r, "level")
+	if level != "" {
+		_, err := logrus.ParseLevel(level)
+		if err != nil {
+			return response.BadRequest(fmt.Errorf("Invalid log level %q", level))
+		}
+	}
+
+	return &eventsLoggingServe{req: r, s: d.State()}
+}
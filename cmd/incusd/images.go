@@ -21,6 +21,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -411,6 +412,18 @@ func imgPostRemoteInfo(s *state.State, r *http.Request, req api.ImagesPost, op *
 		return nil, fmt.Errorf("must specify one of alias or fingerprint for init from image")
 	}
 
+	// Resolve the image remotes of the project.
+	req.Source.Server, req.Source.Protocol, err = imageRemoteResolve(r.Context(), s, project, req.Source.Server, req.Source.Protocol)
+	if err != nil {
+		return nil, err
+	}
+
+	// Throttle the copies of images between cluster members.
+	var bandwidthLimit int64
+	if isClusterNotification(r) {
+		bandwidthLimit = s.GlobalConfig.ImagesSyncBandwidth()
+	}
+
 	info, err := ImageDownload(r, s, op, &ImageDownloadArgs{
 		Server:            req.Source.Server,
 		Protocol:          req.Source.Protocol,
@@ -423,6 +436,7 @@ func imgPostRemoteInfo(s *state.State, r *http.Request, req api.ImagesPost, op *
 		ProjectName:       project,
 		Budget:            budget,
 		SourceProjectName: req.Source.Project,
+		BandwidthLimit:    bandwidthLimit,
 	})
 	if err != nil {
 		return nil, err
@@ -553,8 +567,6 @@ func getImgPostInfo(s *state.State, r *http.Request, builddir string, project st
 	l := logger.AddContext(logger.Ctx{"function": "getImgPostInfo"})
 
 	info.Public = util.IsTrue(r.Header.Get("X-Incus-public"))
-	propHeaders := r.Header[http.CanonicalHeaderKey("X-Incus-properties")]
-	profilesHeaders := r.Header.Get("X-Incus-profiles")
 	ctype, ctypeParams, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
 	if err != nil {
 		ctype = "application/octet-stream"
@@ -712,6 +724,15 @@ func getImgPostInfo(s *state.State, r *http.Request, builddir string, project st
 		}
 	}
 
+	return imgPostInfoRecord(s, r, project, &info, imageMeta, metadata)
+}
+
+// imgPostInfoRecord fills the image info from the uploaded image's metadata, the request headers and the
+// upload metadata and creates the image database record (unless it already exists).
+func imgPostInfoRecord(s *state.State, r *http.Request, project string, info *api.Image, imageMeta *api.ImageMetadata, metadata map[string]any) (*api.Image, error) {
+	propHeaders := r.Header[http.CanonicalHeaderKey("X-Incus-properties")]
+	profilesHeaders := r.Header.Get("X-Incus-profiles")
+
 	info.Architecture = imageMeta.Architecture
 	info.CreatedAt = time.Unix(imageMeta.CreationDate, 0)
 
@@ -770,7 +791,7 @@ func getImgPostInfo(s *state.State, r *http.Request, builddir string, project st
 				return nil, err
 			}
 		} else {
-			return &info, fmt.Errorf("Image with same fingerprint already exists")
+			return info, fmt.Errorf("Image with same fingerprint already exists")
 		}
 	} else {
 		public, ok := metadata["public"]
@@ -785,7 +806,7 @@ func getImgPostInfo(s *state.State, r *http.Request, builddir string, project st
 		}
 	}
 
-	return &info, nil
+	return info, nil
 }
 
 // imageCreateInPool() creates a new storage volume in a given storage pool for
@@ -1269,16 +1290,26 @@ func getImageMetadata(fname string) (*api.ImageMetadata, string, error) {
 		return nil, "unknown", fmt.Errorf("Metadata tarball is missing metadata.yaml")
 	}
 
-	_, err = osarch.ArchitectureId(result.Architecture)
+	err = imageMetadataValidate(&result)
 	if err != nil {
 		return nil, "unknown", err
 	}
 
-	if result.CreationDate == 0 {
-		return nil, "unknown", fmt.Errorf("Missing creation date")
+	return &result, imageType, nil
+}
+
+// imageMetadataValidate checks the metadata.yaml content of an image.
+func imageMetadataValidate(meta *api.ImageMetadata) error {
+	_, err := osarch.ArchitectureId(meta.Architecture)
+	if err != nil {
+		return err
 	}
 
-	return &result, imageType, nil
+	if meta.CreationDate == 0 {
+		return fmt.Errorf("Missing creation date")
+	}
+
+	return nil
 }
 
 func doImagesGet(ctx context.Context, tx *db.ClusterTx, recursion bool, projectName string, public bool, clauses *filter.ClauseSet) (any, error) {
@@ -4167,7 +4198,7 @@ func autoSyncImagesTask(d *Daemon) (task.Func, task.Schedule) {
 		}
 
 		opRun := func(op *operations.Operation) error {
-			return autoSyncImages(ctx, s)
+			return autoSyncImages(ctx, s, op)
 		}
 
 		op, err := operations.OperationCreate(s, "", operations.OperationClassTask, operationtype.ImagesSynchronize, nil, nil, opRun, nil, nil, nil)
@@ -4200,29 +4231,83 @@ func autoSyncImagesTask(d *Daemon) (task.Func, task.Schedule) {
 	return f, task.Hourly()
 }
 
-func autoSyncImages(ctx context.Context, s *state.State) error {
+// Number of images synchronized across the cluster by this member, and the number of failed synchronizations.
+var autoSyncImagesTotal atomic.Int64
+var autoSyncImagesFailuresTotal atomic.Int64
+
+// autoSyncImages synchronizes all images across the cluster, reporting the progress in the operation if one is given.
+func autoSyncImages(ctx context.Context, s *state.State, op *operations.Operation) error {
 	// Get all images.
 	imageProjectInfo, err := s.DB.Cluster.GetImages()
 	if err != nil {
 		return fmt.Errorf("Failed to query image fingerprints: %w", err)
 	}
 
+	// Limit the number of images being synchronized at the same time.
+	concurrency := s.GlobalConfig.ImagesSyncConcurrency()
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var progressLock sync.Mutex
+	var synced, failed int
+
+	updateProgress := func(err error) {
+		progressLock.Lock()
+		defer progressLock.Unlock()
+
+		if err != nil {
+			failed++
+			autoSyncImagesFailuresTotal.Add(1)
+		} else {
+			synced++
+			autoSyncImagesTotal.Add(1)
+		}
+
+		if op == nil {
+			return
+		}
+
+		_ = op.UpdateMetadata(map[string]any{
+			"images_total":  len(imageProjectInfo),
+			"images_synced": synced,
+			"images_failed": failed,
+		})
+	}
+
+	slots := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+
 	for fingerprint, projects := range imageProjectInfo {
-		ch := make(chan error)
+		select {
+		case <-ctx.Done():
+			return nil
+		case slots <- struct{}{}:
+		}
+
+		wg.Add(1)
 		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
 			err := imageSyncBetweenNodes(s, nil, projects[0], fingerprint)
 			if err != nil {
 				logger.Error("Failed to synchronize images", logger.Ctx{"err": err, "fingerprint": fingerprint})
 			}
 
-			ch <- nil
+			updateProgress(err)
 		}()
+	}
 
-		select {
-		case <-ctx.Done():
-			return nil
-		case <-ch:
-		}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+	case <-done:
 	}
 
 	return nil
@@ -4301,6 +4386,12 @@ func imageSyncBetweenNodes(s *state.State, r *http.Request, project string, fing
 			return fmt.Errorf("Failed to get nodes for the image synchronization: %w", err)
 		}
 
+		// Skip the members which are being evacuated or which don't want the image.
+		addresses, err = imageSyncTargets(s, addresses, fingerprint)
+		if err != nil {
+			return err
+		}
+
 		if len(addresses) <= 0 {
 			logger.Info("All members have image", logger.Ctx{"fingerprint": fingerprint, "project": project})
 			return nil
@@ -4333,6 +4424,120 @@ func imageSyncBetweenNodes(s *state.State, r *http.Request, project string, fing
 	return nil
 }
 
+// imageSyncTargets filters out the addresses of the cluster members which are evacuated or whose
+// images.sync_filter configuration doesn't select the image.
+func imageSyncTargets(s *state.State, addresses []string, fingerprint string) ([]string, error) {
+	skipped := map[string]bool{}
+	err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		members, err := tx.GetNodes(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed getting cluster members: %w", err)
+		}
+
+		var projects []string
+		var aliases []string
+		loaded := false
+
+		for _, member := range members {
+			if member.State == db.ClusterMemberStateEvacuated {
+				skipped[member.Address] = true
+				continue
+			}
+
+			syncFilter := member.Config["images.sync_filter"]
+			if syncFilter == "" {
+				continue
+			}
+
+			// Only load the projects and aliases of the image when a member filters images.
+			if !loaded {
+				projects, err = tx.GetProjectsUsingImage(ctx, fingerprint)
+				if err != nil {
+					return fmt.Errorf("Failed getting projects using image %q: %w", fingerprint, err)
+				}
+
+				for _, projectName := range projects {
+					_, image, err := tx.GetImageByFingerprintPrefix(ctx, fingerprint, dbCluster.ImageFilter{Project: &projectName})
+					if err != nil {
+						return fmt.Errorf("Failed getting image %q in project %q: %w", fingerprint, projectName, err)
+					}
+
+					for _, alias := range image.Aliases {
+						aliases = append(aliases, alias.Name)
+					}
+				}
+
+				loaded = true
+			}
+
+			if !imageSyncFilterMatch(syncFilter, fingerprint, projects, aliases) {
+				skipped[member.Address] = true
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		if !skipped[address] {
+			targets = append(targets, address)
+		}
+	}
+
+	return targets, nil
+}
+
+// imageSyncFilterMatch checks whether an image stored in the given projects and with the given aliases is
+// selected by an images.sync_filter value. An empty filter selects all images.
+func imageSyncFilterMatch(syncFilter string, fingerprint string, projects []string, aliases []string) bool {
+	if syncFilter == "" {
+		return true
+	}
+
+	for _, entry := range util.SplitNTrimSpace(syncFilter, ",", -1, true) {
+		key, value, _ := strings.Cut(entry, "=")
+
+		switch key {
+		case "project":
+			if util.ValueInSlice(value, projects) {
+				return true
+			}
+
+		case "alias":
+			if util.ValueInSlice(value, aliases) {
+				return true
+			}
+
+		case "fingerprint":
+			if strings.HasPrefix(fingerprint, value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// imageSyncFilterValidate validates an images.sync_filter value.
+func imageSyncFilterValidate(value string) error {
+	for _, entry := range util.SplitNTrimSpace(value, ",", -1, true) {
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok || value == "" {
+			return fmt.Errorf("Invalid image synchronization filter %q, expected <key>=<value>", entry)
+		}
+
+		if !util.ValueInSlice(key, []string{"project", "alias", "fingerprint"}) {
+			return fmt.Errorf("Invalid image synchronization filter key %q", key)
+		}
+	}
+
+	return nil
+}
+
 func createTokenResponse(s *state.State, r *http.Request, projectName string, fingerprint string, metadata jmap.Map) response.Response {
 	secret, err := internalUtil.RandomHexString(32)
 	if err != nil {
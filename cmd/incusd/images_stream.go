@@ -0,0 +1,423 @@
+package main
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	internalIO "github.com/lxc/incus/internal/io"
+	"github.com/lxc/incus/internal/server/db"
+	"github.com/lxc/incus/internal/server/db/operationtype"
+	"github.com/lxc/incus/internal/server/lifecycle"
+	"github.com/lxc/incus/internal/server/operations"
+	projectutils "github.com/lxc/incus/internal/server/project"
+	"github.com/lxc/incus/internal/server/response"
+	internalUtil "github.com/lxc/incus/internal/util"
+	"github.com/lxc/incus/shared/api"
+	"github.com/lxc/incus/shared/archive"
+	"github.com/lxc/incus/shared/ioprogress"
+	"github.com/lxc/incus/shared/logger"
+	"github.com/lxc/incus/shared/util"
+)
+
+var imagesStreamCmd = APIEndpoint{
+	Path: "images/stream",
+
+	Post: APIEndpointAction{Handler: imagesStreamPost, AccessHandler: allowProjectPermission()},
+}
+
+// swagger:operation POST /1.0/images/stream images images_stream_post
+//
+//	Stream a new image
+//
+//	Streams a unified image tarball directly into the image store.
+//	The upload is hashed, checked and written to disk as it's received rather than being buffered first.
+//	Progress is reported through the operation, which is returned once the upload has been received.
+//	Cancelling the operation aborts the upload.
+//
+//	---
+//	consumes:
+//	  - application/octet-stream
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: query
+//	    name: pool
+//	    description: Storage pool to create the image volume on
+//	    type: string
+//	    example: local
+//	  - in: body
+//	    name: raw_image
+//	    description: Raw image file
+//	    required: true
+//	  - in: header
+//	    name: X-Incus-fingerprint
+//	    description: Expected fingerprint of the image
+//	    schema:
+//	      type: string
+//	  - in: header
+//	    name: X-Incus-properties
+//	    description: Descriptive properties
+//	    schema:
+//	      type: object
+//	      additionalProperties:
+//	        type: string
+//	  - in: header
+//	    name: X-Incus-public
+//	    description: Whether the image is available to unauthenticated users
+//	    schema:
+//	      type: boolean
+//	  - in: header
+//	    name: X-Incus-filename
+//	    description: Original filename of the image
+//	    schema:
+//	      type: string
+//	  - in: header
+//	    name: X-Incus-profiles
+//	    description: List of profiles to use
+//	    schema:
+//	      type: array
+//	      items:
+//	        type: string
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func imagesStreamPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName := projectParam(r)
+	poolName := queryParam(r, "pool")
+
+	// Split images are sent as multipart forms which can't be processed as a stream.
+	ctype, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err == nil && ctype == "multipart/form-data" {
+		return response.BadRequest(fmt.Errorf("Only unified images can be streamed"))
+	}
+
+	if poolName != "" {
+		_, err = s.DB.Cluster.GetStoragePoolID(poolName)
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed loading storage pool %q: %w", poolName, err))
+		}
+	}
+
+	// Possibly set a quota on the amount of disk space this project is allowed to use.
+	var budget int64
+	err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		budget, err = projectutils.GetImageSpaceBudget(tx, projectName)
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Image volumes are a cache shared by all projects, work out where the volume will land.
+	volumeProject, err := projectutils.StorageVolumeProject(s.DB.Cluster, projectName, db.StoragePoolVolumeTypeImage)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	target, err := os.CreateTemp(internalUtil.VarPath("images"), "incus_stream_")
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	pr, pw := io.Pipe()
+
+	run := func(op *operations.Operation) error {
+		l := logger.AddContext(logger.Ctx{"project": projectName, "operation": op.ID()})
+
+		revert := true
+		defer func() {
+			_ = target.Close()
+
+			if revert {
+				_ = os.Remove(target.Name())
+			}
+		}()
+
+		// Stop reading on failure so that the handler doesn't block on the upload.
+		defer func() { _ = pr.CloseWithError(fmt.Errorf("Image upload aborted")) }()
+
+		// Validate the image header before accepting the rest of the upload.
+		body := bufio.NewReader(pr)
+		header, err := body.Peek(263)
+		if err != nil {
+			return fmt.Errorf("Failed reading image header: %w", err)
+		}
+
+		_, algo, unpacker, err := archive.DetectCompressionFile(bytes.NewReader(header))
+		if err != nil {
+			return fmt.Errorf("Invalid image: %w", err)
+		}
+
+		// Check the image metadata as it's received so that an invalid image is rejected early.
+		checker := newImageStreamChecker(op.Context(), algo, unpacker)
+		defer func() { _ = checker.Close() }()
+
+		metadata := map[string]any{"volume_project": volumeProject}
+		progress := &ioprogress.ProgressReader{
+			ReadCloser: io.NopCloser(body),
+			Tracker: &ioprogress.ProgressTracker{
+				Length: r.ContentLength,
+				Handler: func(value, speed int64) {
+					percent := int64(0)
+					var processed int64
+
+					if r.ContentLength > 0 {
+						percent = value
+					} else {
+						processed = value
+					}
+
+					operations.SetProgressMetadata(metadata, "upload", "Upload", percent, processed, speed)
+					_ = op.UpdateMetadata(metadata)
+				},
+			},
+		}
+
+		sha256 := sha256.New()
+		size, err := io.Copy(io.MultiWriter(internalIO.NewQuotaWriter(target, budget), sha256, checker), progress)
+		if err != nil {
+			return fmt.Errorf("Failed receiving image: %w", err)
+		}
+
+		err = checker.Close()
+		if err != nil {
+			return fmt.Errorf("Invalid image: %w", err)
+		}
+
+		err = target.Close()
+		if err != nil {
+			return err
+		}
+
+		info := api.Image{}
+		info.Size = size
+		info.Public = util.IsTrue(r.Header.Get("X-Incus-public"))
+		info.Filename = r.Header.Get("X-Incus-filename")
+		info.Fingerprint = fmt.Sprintf("%x", sha256.Sum(nil))
+
+		expectedFingerprint := r.Header.Get("X-Incus-fingerprint")
+		if expectedFingerprint != "" && info.Fingerprint != expectedFingerprint {
+			return fmt.Errorf("Fingerprints don't match, got %s expected %s", info.Fingerprint, expectedFingerprint)
+		}
+
+		imageMeta, imageType, err := getImageMetadata(target.Name())
+		if err != nil {
+			return err
+		}
+
+		info.Type = imageType
+
+		imgfname := internalUtil.VarPath("images", info.Fingerprint)
+		err = internalUtil.FileMove(target.Name(), imgfname)
+		if err != nil {
+			return err
+		}
+
+		revert = false
+
+		_, err = imgPostInfoRecord(s, r, projectName, &info, imageMeta, nil)
+		if err != nil {
+			return err
+		}
+
+		metadata["fingerprint"] = info.Fingerprint
+		metadata["size"] = strconv.FormatInt(info.Size, 10)
+		_ = op.UpdateMetadata(metadata)
+
+		// Sync the images between each node in the cluster on demand.
+		err = imageSyncBetweenNodes(s, r, projectName, info.Fingerprint)
+		if err != nil {
+			return fmt.Errorf("Failed syncing image between nodes: %w", err)
+		}
+
+		if poolName != "" {
+			err = imageCreateInPool(s, &info, poolName)
+			if err != nil {
+				return fmt.Errorf("Failed creating image volume on storage pool %q: %w", poolName, err)
+			}
+		}
+
+		l.Debug("Streamed image", logger.Ctx{"fingerprint": info.Fingerprint, "size": info.Size})
+		s.Events.SendLifecycle(projectName, lifecycle.ImageCreated.Event(info.Fingerprint, projectName, op.Requestor(), logger.Ctx{"type": info.Type}))
+
+		return nil
+	}
+
+	// Cancelling the operation closes the upload stream which then makes it fail.
+	onCancel := func(op *operations.Operation) error { return nil }
+
+	op, err := operations.OperationCreate(s, projectName, operations.OperationClassTask, operationtype.ImageUpload, nil, map[string]any{"volume_project": volumeProject}, run, onCancel, nil, r)
+	if err != nil {
+		_ = target.Close()
+		_ = os.Remove(target.Name())
+		return response.InternalError(err)
+	}
+
+	err = op.Start()
+	if err != nil {
+		_ = target.Close()
+		_ = os.Remove(target.Name())
+		return response.InternalError(err)
+	}
+
+	go func() {
+		<-op.Context().Done()
+		_ = pr.CloseWithError(fmt.Errorf("Image upload operation %q ended", op.ID()))
+	}()
+
+	// Feed the upload to the operation as it's received.
+	_, err = io.Copy(pw, r.Body)
+	_ = pw.CloseWithError(err)
+	if err != nil {
+		logger.Debug("Image upload stream interrupted", logger.Ctx{"err": err, "operation": op.ID()})
+	}
+
+	return operations.StartedOperationResponse(op)
+}
+
+// imageStreamChecker checks the metadata of an image while it's being uploaded, so that an invalid image is rejected
+// without waiting for the whole upload. Once the check is done, what's written to it is discarded.
+type imageStreamChecker struct {
+	pw   *io.PipeWriter
+	done chan struct{}
+	err  error
+}
+
+// newImageStreamChecker starts checking the image compressed with the given algorithm as it's written.
+func newImageStreamChecker(ctx context.Context, algo string, unpacker []string) *imageStreamChecker {
+	pr, pw := io.Pipe()
+
+	c := &imageStreamChecker{
+		pw:   pw,
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		c.err = imageStreamMetadataCheck(ctx, pr, algo, unpacker)
+		close(c.done)
+	}()
+
+	return c
+}
+
+// Write passes the upload to the check until it's done, and fails if the check did.
+func (c *imageStreamChecker) Write(p []byte) (int, error) {
+	select {
+	case <-c.done:
+	default:
+		_, err := c.pw.Write(p)
+		if err == nil {
+			return len(p), nil
+		}
+
+		// The check stopped reading, wait for its result.
+		<-c.done
+	}
+
+	if c.err != nil {
+		return 0, fmt.Errorf("Invalid image: %w", c.err)
+	}
+
+	return len(p), nil
+}
+
+// Close signals the end of the upload and returns the result of the check.
+func (c *imageStreamChecker) Close() error {
+	_ = c.pw.Close()
+	<-c.done
+
+	return c.err
+}
+
+// imageStreamMetadataCheck reads the unified image tarball from r until its metadata and checks it.
+// Images which can't be read as a stream, or whose root filesystem comes before the metadata, are left to the check
+// done once the upload is complete.
+func imageStreamMetadataCheck(ctx context.Context, r *io.PipeReader, algo string, unpacker []string) error {
+	ctx, cancel := context.WithCancel(ctx)
+
+	var tr *tar.Reader
+	if algo == ".squashfs" || algo == ".qcow2" {
+		_ = r.Close()
+		cancel()
+		return nil
+	} else if len(unpacker) > 0 {
+		cmd := exec.CommandContext(ctx, unpacker[0], unpacker[1:]...)
+		cmd.Stdin = r
+
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			_ = r.Close()
+			cancel()
+			return err
+		}
+
+		err = cmd.Start()
+		if err != nil {
+			_ = r.Close()
+			cancel()
+			return err
+		}
+
+		// Stop feeding and kill the decompressor before waiting for it.
+		defer func() { _ = cmd.Wait() }()
+
+		tr = tar.NewReader(stdout)
+	} else {
+		tr = tar.NewReader(r)
+	}
+
+	defer cancel()
+	defer func() { _ = r.Close() }()
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return fmt.Errorf("Metadata tarball is missing metadata.yaml")
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if hdr.Name == "metadata.yaml" || hdr.Name == "./metadata.yaml" {
+			meta := api.ImageMetadata{}
+
+			err = yaml.NewDecoder(tr).Decode(&meta)
+			if err != nil {
+				return err
+			}
+
+			return imageMetadataValidate(&meta)
+		}
+
+		if strings.HasPrefix(hdr.Name, "rootfs/") || strings.HasPrefix(hdr.Name, "./rootfs/") || hdr.Name == "rootfs.img" || hdr.Name == "./rootfs.img" {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageSyncFilterMatch(t *testing.T) {
+	fingerprint := "a8d8b2d1f1a34dbe3f6a0e1bbcd2fd7c7d2d1b2e6f3c1d4e5a6b7c8d9e0f1a2b"
+	projects := []string{"default", "web"}
+	aliases := []string{"debian/12", "webserver"}
+
+	cases := []struct {
+		name   string
+		filter string
+		match  bool
+	}{
+		{"empty", "", true},
+		{"project", "project=web", true},
+		{"other project", "project=db", false},
+		{"alias", "alias=debian/12", true},
+		{"other alias", "alias=debian/11", false},
+		{"fingerprint", "fingerprint=" + fingerprint, true},
+		{"fingerprint prefix", "fingerprint=a8d8b2", true},
+		{"other fingerprint", "fingerprint=b8d8b2", false},
+		{"any entry", "project=db, alias=webserver", true},
+		{"no entry", "project=db, alias=debian/11, fingerprint=b8d8b2", false},
+		{"alias isn't a project", "project=webserver", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.match, imageSyncFilterMatch(c.filter, fingerprint, projects, aliases))
+		})
+	}
+}
+
+func TestImageSyncFilterValidate(t *testing.T) {
+	cases := []struct {
+		value string
+		valid bool
+	}{
+		{"", true},
+		{"project=default", true},
+		{"alias=debian/12", true},
+		{"fingerprint=a8d8b2", true},
+		{"project=default, alias=debian/12,fingerprint=a8d8b2", true},
+		{"project", false},
+		{"project=", false},
+		{"=default", false},
+		{"name=default", false},
+		{"project=default, profile=default", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.value, func(t *testing.T) {
+			err := imageSyncFilterValidate(c.value)
+			if c.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+// imageTarball returns an uncompressed unified image with the given metadata.yaml content followed by a root
+// filesystem of the given size.
+func imageTarball(t *testing.T, metadata string, rootfsSize int) []byte {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "metadata.yaml", Mode: 0644, Size: int64(len(metadata))}))
+	_, err := tw.Write([]byte(metadata))
+	require.NoError(t, err)
+
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "rootfs/", Mode: 0755, Typeflag: tar.TypeDir}))
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "rootfs/data", Mode: 0644, Size: int64(rootfsSize)}))
+	_, err = tw.Write(make([]byte, rootfsSize))
+	require.NoError(t, err)
+
+	require.NoError(t, tw.Close())
+
+	return buf.Bytes()
+}
+
+func TestImageStreamChecker(t *testing.T) {
+	cases := []struct {
+		name     string
+		metadata string
+		valid    bool
+	}{
+		{"valid", "architecture: x86_64\ncreation_date: 1700000000\n", true},
+		{"bad architecture", "architecture: abacus\ncreation_date: 1700000000\n", false},
+		{"no creation date", "architecture: x86_64\n", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			image := imageTarball(t, c.metadata, 1024*1024)
+			checker := newImageStreamChecker(context.Background(), ".tar", []string{})
+
+			// Feed the image in chunks, an invalid image is rejected before reaching the root filesystem.
+			var writeErr error
+			written := 0
+			for written < len(image) && writeErr == nil {
+				end := written + 4096
+				if end > len(image) {
+					end = len(image)
+				}
+
+				_, writeErr = checker.Write(image[written:end])
+				written = end
+			}
+
+			err := checker.Close()
+			if c.valid {
+				assert.NoError(t, writeErr)
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, writeErr)
+				assert.Error(t, err)
+				assert.Less(t, written, len(image)/2)
+			}
+		})
+	}
+}
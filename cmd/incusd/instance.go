@@ -33,12 +33,13 @@ import (
 // Helper functions
 
 // instanceCreateAsEmpty creates an empty instance.
-func instanceCreateAsEmpty(s *state.State, args db.InstanceArgs) (instance.Instance, error) {
+// If checkProjectLimits is true, the project limits are checked again when recording the instance.
+func instanceCreateAsEmpty(s *state.State, args db.InstanceArgs, checkProjectLimits bool) (instance.Instance, error) {
 	revert := revert.New()
 	defer revert.Fail()
 
 	// Create the instance record.
-	inst, instOp, cleanup, err := instance.CreateInternal(s, args, true)
+	inst, instOp, cleanup, err := instance.CreateInternal(s, args, true, checkProjectLimits)
 	if err != nil {
 		return nil, fmt.Errorf("Failed creating instance record: %w", err)
 	}
@@ -121,7 +122,8 @@ func ensureImageIsLocallyAvailable(s *state.State, r *http.Request, img *api.Ima
 }
 
 // instanceCreateFromImage creates an instance from a rootfs image.
-func instanceCreateFromImage(s *state.State, r *http.Request, img *api.Image, args db.InstanceArgs, op *operations.Operation) error {
+// If checkProjectLimits is true, the project limits are checked again when recording the instance.
+func instanceCreateFromImage(s *state.State, r *http.Request, img *api.Image, args db.InstanceArgs, checkProjectLimits bool, op *operations.Operation) error {
 	revert := revert.New()
 	defer revert.Fail()
 
@@ -146,7 +148,7 @@ func instanceCreateFromImage(s *state.State, r *http.Request, img *api.Image, ar
 	args.BaseImage = img.Fingerprint
 
 	// Create the instance.
-	inst, instOp, cleanup, err := instance.CreateInternal(s, args, true)
+	inst, instOp, cleanup, err := instance.CreateInternal(s, args, true, checkProjectLimits)
 	if err != nil {
 		return fmt.Errorf("Failed creating instance record: %w", err)
 	}
@@ -228,6 +230,7 @@ type instanceCreateAsCopyOpts struct {
 	refresh              bool              // Refresh an existing target instance.
 	applyTemplateTrigger bool              // Apply deferred TemplateTriggerCopy.
 	allowInconsistent    bool              // Ignore some copy errors
+	checkProjectLimits   bool              // Check the project limits again when recording the new instance.
 }
 
 // instanceCreateAsCopy create a new instance by copying from an existing instance.
@@ -251,7 +254,7 @@ func instanceCreateAsCopy(s *state.State, opts instanceCreateAsCopyOpts, op *ope
 	// If we are not in refresh mode, then create a new instance as we are in copy mode.
 	if !opts.refresh {
 		// Create the instance.
-		inst, instOp, cleanup, err = instance.CreateInternal(s, opts.targetInstance, true)
+		inst, instOp, cleanup, err = instance.CreateInternal(s, opts.targetInstance, true, opts.checkProjectLimits)
 		if err != nil {
 			return nil, fmt.Errorf("Failed creating instance record: %w", err)
 		}
@@ -386,7 +389,7 @@ func instanceCreateAsCopy(s *state.State, opts instanceCreateAsCopyOpts, op *ope
 			}
 
 			// Create the snapshots.
-			_, snapInstOp, cleanup, err := instance.CreateInternal(s, snapInstArgs, true)
+			_, snapInstOp, cleanup, err := instance.CreateInternal(s, snapInstArgs, true, false)
 			if err != nil {
 				return nil, fmt.Errorf("Failed creating instance snapshot record %q: %w", newSnapName, err)
 			}
@@ -737,6 +740,22 @@ func getSourceImageFromInstanceSource(ctx context.Context, s *state.State, tx *d
 	return sourceImage, nil
 }
 
+// instanceValidName validates the name of a new or renamed instance in the project, checking both the built-in
+// naming rules and the naming patterns set in the server and project configuration.
+func instanceValidName(s *state.State, projectName string, instanceName string) error {
+	config, err := projectConfig(s, projectName)
+	if err != nil {
+		return err
+	}
+
+	err = internalInstance.ValidName(instanceName, s.GlobalConfig.InstancesNamePattern(), config["instances.name.pattern"])
+	if err != nil {
+		return api.StatusErrorf(http.StatusBadRequest, "%v", err)
+	}
+
+	return nil
+}
+
 // instanceOperationLock acquires a lock for operating on an instance and returns the unlock function.
 func instanceOperationLock(ctx context.Context, projectName string, instanceName string) (locking.UnlockFunc, error) {
 	l := logger.AddContext(logger.Ctx{"project": projectName, "instance": instanceName})
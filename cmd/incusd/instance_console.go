@@ -533,6 +533,9 @@ func instanceConsolePost(d *Daemon, r *http.Request) response.Response {
 //	Get console log
 //
 //	Gets the console log for the instance.
+//	For containers, this is the content of the console ring buffer. For virtual machines,
+//	this is the recorded output of the serial console.
+//	The amount of output kept is set by the `console.log_size` configuration key.
 //
 //	---
 //	produces:
@@ -587,21 +590,38 @@ func instanceConsoleLogGet(d *Daemon, r *http.Request) response.Response {
 		return resp
 	}
 
-	if !liblxc.RuntimeLiblxcVersionAtLeast(liblxc.Version(), 3, 0, 0) {
-		return response.BadRequest(fmt.Errorf("Querying the console buffer requires liblxc >= 3.0"))
-	}
-
 	inst, err := instance.LoadByProjectAndName(s, projectName, name)
 	if err != nil {
 		return response.SmartError(err)
 	}
 
+	ent := response.FileResponseEntry{}
+
+	// Virtual machines record their serial console output in the console log file.
+	if inst.Type() == instancetype.VM {
+		v := inst.(instance.VM)
+
+		logContents, err := v.ConsoleLog()
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		ent.File = bytes.NewReader([]byte(logContents))
+		ent.FileModified = time.Now()
+		ent.FileSize = int64(len(logContents))
+
+		return response.FileResponse(r, []response.FileResponseEntry{ent}, nil)
+	}
+
+	if !liblxc.RuntimeLiblxcVersionAtLeast(liblxc.Version(), 3, 0, 0) {
+		return response.BadRequest(fmt.Errorf("Querying the console buffer requires liblxc >= 3.0"))
+	}
+
 	if inst.Type() != instancetype.Container {
 		return response.SmartError(fmt.Errorf("Instance is not container type"))
 	}
 
 	c := inst.(instance.Container)
-	ent := response.FileResponseEntry{}
 	if !c.IsRunning() {
 		// Hand back the contents of the console ringbuffer logfile.
 		consoleBufferLogPath := c.ConsoleBufferLogPath()
@@ -667,10 +687,6 @@ func instanceConsoleLogGet(d *Daemon, r *http.Request) response.Response {
 //	  "500":
 //	    $ref: "#/responses/InternalServerError"
 func instanceConsoleLogDelete(d *Daemon, r *http.Request) response.Response {
-	if !liblxc.RuntimeLiblxcVersionAtLeast(liblxc.Version(), 3, 0, 0) {
-		return response.BadRequest(fmt.Errorf("Clearing the console buffer requires liblxc >= 3.0"))
-	}
-
 	name, err := url.PathUnescape(mux.Vars(r)["name"])
 	if err != nil {
 		return response.SmartError(err)
@@ -687,12 +703,6 @@ func instanceConsoleLogDelete(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
-	if inst.Type() != instancetype.Container {
-		return response.SmartError(fmt.Errorf("Instance is not container type"))
-	}
-
-	c := inst.(instance.Container)
-
 	truncateConsoleLogFile := func(path string) error {
 		// Check that this is a regular file. We don't want to try and unlink
 		// /dev/stderr or /dev/null or something.
@@ -712,6 +722,23 @@ func instanceConsoleLogDelete(d *Daemon, r *http.Request) response.Response {
 		return os.Truncate(path, 0)
 	}
 
+	// Virtual machines keep their console output in the console ring buffer and log file.
+	if inst.Type() == instancetype.VM {
+		v := inst.(instance.VM)
+
+		return response.SmartError(v.ClearConsoleLog())
+	}
+
+	if !liblxc.RuntimeLiblxcVersionAtLeast(liblxc.Version(), 3, 0, 0) {
+		return response.BadRequest(fmt.Errorf("Clearing the console buffer requires liblxc >= 3.0"))
+	}
+
+	if inst.Type() != instancetype.Container {
+		return response.SmartError(fmt.Errorf("Instance is not container type"))
+	}
+
+	c := inst.(instance.Container)
+
 	if !inst.IsRunning() {
 		consoleLogpath := c.ConsoleBufferLogPath()
 		return response.SmartError(truncateConsoleLogFile(consoleLogpath))
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+
+	internalInstance "github.com/lxc/incus/internal/instance"
+	"github.com/lxc/incus/internal/server/db"
+	"github.com/lxc/incus/internal/server/instance"
+	"github.com/lxc/incus/internal/server/response"
+	"github.com/lxc/incus/shared/util"
+)
+
+var instanceDeviceCmd = APIEndpoint{
+	Name: "instanceDevice",
+	Path: "instances/{name}/devices/{device}",
+
+	Delete: APIEndpointAction{Handler: instanceDeviceDelete, AccessHandler: allowProjectPermission()},
+}
+
+// swagger:operation DELETE /1.0/instances/{name}/devices/{device} instances instance_device_delete
+//
+//	Hot-unplug a host device
+//
+//	Removes a GPU, USB or PCI device from a running instance without restarting it.
+//	The device is also removed from the instance configuration.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceDeviceDelete(d *Daemon, r *http.Request) response.Response {
+	// Don't mess with instance while in setup mode.
+	<-d.waitReady.Done()
+
+	s := d.State()
+
+	instanceType, err := urlInstanceTypeDetect(r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	projectName := projectParam(r)
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	deviceName, err := url.PathUnescape(mux.Vars(r)["device"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if internalInstance.IsSnapshot(name) {
+		return response.BadRequest(fmt.Errorf("Invalid instance name"))
+	}
+
+	// Handle requests targeted to an instance on a different node.
+	resp, err := forwardedResponseIfInstanceIsRemote(s, r, projectName, name, instanceType)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if resp != nil {
+		return resp
+	}
+
+	unlock, err := instanceOperationLock(s.ShutdownCtx, projectName, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	defer unlock()
+
+	inst, err := instance.LoadByProjectAndName(s, projectName, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if !inst.IsRunning() {
+		return response.BadRequest(fmt.Errorf("Devices can only be hot-unplugged from running instances"))
+	}
+
+	dev, ok := inst.LocalDevices()[deviceName]
+	if !ok {
+		_, ok = inst.ExpandedDevices()[deviceName]
+		if ok {
+			return response.BadRequest(fmt.Errorf("Device %q comes from a profile and can't be hot-unplugged", deviceName))
+		}
+
+		return response.NotFound(fmt.Errorf("Device %q not found", deviceName))
+	}
+
+	if !util.ValueInSlice(dev["type"], resourcesAssignableDeviceTypes) {
+		return response.BadRequest(fmt.Errorf("Device %q of type %q isn't a host device", deviceName, dev["type"]))
+	}
+
+	devices := inst.LocalDevices().Clone()
+	delete(devices, deviceName)
+
+	args := db.InstanceArgs{
+		Architecture: inst.Architecture(),
+		Config:       inst.LocalConfig(),
+		Description:  inst.Description(),
+		Devices:      devices,
+		Ephemeral:    inst.IsEphemeral(),
+		Profiles:     inst.Profiles(),
+		Project:      projectName,
+	}
+
+	// The instance driver refuses to stop devices which don't support hot-unplug while running.
+	err = inst.Update(args, true)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Failed to hot-unplug device %q: %w", deviceName, err))
+	}
+
+	return response.EmptySyncResponse
+}
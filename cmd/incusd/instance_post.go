@@ -322,9 +322,27 @@ func instancePost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
+	// Check the naming patterns when renaming or moving to another project.
+	if req.Name != inst.Name() || (req.Project != "" && req.Project != projectName) {
+		targetProjectName := projectName
+		if req.Project != "" {
+			targetProjectName = req.Project
+		}
+
+		err = instanceValidName(s, targetProjectName, req.Name)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
 	if req.Migration {
 		// Server-side pool migration.
 		if req.Pool != "" {
+			err = instanceCheckStoragePool(s, projectName, req.Pool)
+			if err != nil {
+				return response.SmartError(err)
+			}
+
 			// Setup the instance move operation.
 			run := func(op *operations.Operation) error {
 				return instancePostPoolMigration(s, inst, req.Name, req.InstanceOnly, req.Pool, req.Live, req.AllowInconsistent, op)
@@ -347,6 +365,15 @@ func instancePost(d *Daemon, r *http.Request) response.Response {
 				return response.Forbidden(nil)
 			}
 
+			// Check that the target project can use the instance's storage pool.
+			_, rootDiskDevice, err := internalInstance.GetRootDiskDevice(inst.ExpandedDevices().CloneNative())
+			if err == nil {
+				err = instanceCheckStoragePool(s, req.Project, rootDiskDevice["pool"])
+				if err != nil {
+					return response.SmartError(err)
+				}
+			}
+
 			// Setup the instance move operation.
 			run := func(op *operations.Operation) error {
 				return instancePostProjectMigration(s, inst, req.Name, req.Project, req.InstanceOnly, req.Live, req.AllowInconsistent, op)
@@ -417,7 +444,7 @@ func instancePost(d *Daemon, r *http.Request) response.Response {
 
 		if req.Target != nil {
 			// Push mode.
-			op, err := operations.OperationCreate(s, projectName, operations.OperationClassTask, operationtype.InstanceMigrate, resources, nil, run, nil, nil, r)
+			op, err := operations.OperationCreate(s, projectName, operations.OperationClassTask, operationtype.InstanceMigrate, resources, nil, run, cancel, nil, r)
 			if err != nil {
 				return response.InternalError(err)
 			}
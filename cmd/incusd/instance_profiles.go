@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+
+	internalInstance "github.com/lxc/incus/internal/instance"
+	"github.com/lxc/incus/internal/server/db"
+	"github.com/lxc/incus/internal/server/db/cluster"
+	"github.com/lxc/incus/internal/server/project"
+	"github.com/lxc/incus/internal/server/response"
+	"github.com/lxc/incus/internal/version"
+	"github.com/lxc/incus/shared/api"
+)
+
+// swagger:operation GET /1.0/instances/{name}/profiles instances instance_profiles_get
+//
+//	Get the effective profiles
+//
+//	Gets the profiles applied to the instance, in the order they're applied, along with the project
+//	each profile was resolved from.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: Effective profiles
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/InstanceEffectiveProfiles"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceProfilesGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName := projectParam(r)
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if internalInstance.IsSnapshot(name) {
+		return response.BadRequest(fmt.Errorf("Invalid instance name"))
+	}
+
+	result := api.InstanceEffectiveProfiles{
+		Profiles: []api.InstanceEffectiveProfile{},
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		dbProject, err := cluster.GetProject(ctx, tx.Tx(), projectName)
+		if err != nil {
+			return fmt.Errorf("Failed loading project %q: %w", projectName, err)
+		}
+
+		p, err := dbProject.ToAPI(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		result.ProfilesProject = project.ProfileProjectFromRecord(p)
+
+		inst, err := cluster.GetInstance(ctx, tx.Tx(), projectName, name)
+		if err != nil {
+			return err
+		}
+
+		profiles, err := cluster.GetInstanceProfiles(ctx, tx.Tx(), inst.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, profile := range profiles {
+			result.Profiles = append(result.Profiles, api.InstanceEffectiveProfile{
+				Name:    profile.Name,
+				Project: profile.Project,
+				URL:     api.NewURL().Path(version.APIVersion, "profiles", profile.Name).Project(profile.Project).String(),
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, result)
+}
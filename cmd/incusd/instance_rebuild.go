@@ -92,6 +92,12 @@ func instanceRebuildPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
+	// Resolve the image remotes of the project.
+	req.Source.Server, req.Source.Protocol, err = imageRemoteResolve(r.Context(), s, targetProjectName, req.Source.Server, req.Source.Protocol)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	var targetProject *api.Project
 	var sourceImage *api.Image
 	var inst instance.Instance
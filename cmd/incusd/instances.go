@@ -60,6 +60,13 @@ var instanceStateCmd = APIEndpoint{
 	Put: APIEndpointAction{Handler: instanceStatePut, AccessHandler: allowProjectPermission()},
 }
 
+var instanceProfilesCmd = APIEndpoint{
+	Name: "instanceProfiles",
+	Path: "instances/{name}/profiles",
+
+	Get: APIEndpointAction{Handler: instanceProfilesGet, AccessHandler: allowProjectPermission()},
+}
+
 var instanceSFTPCmd = APIEndpoint{
 	Name: "instanceFile",
 	Path: "instances/{name}/sftp",
@@ -189,15 +196,23 @@ func instanceShouldAutoStart(inst instance.Instance) bool {
 	return util.IsTrue(autoStart) || (autoStart == "" && lastState == instance.PowerStateRunning)
 }
 
+// instanceAutostartMaxRetryDelay is the longest delay between two attempts to automatically start an instance.
+const instanceAutostartMaxRetryDelay = time.Hour
+
 func instancesStart(s *state.State, instances []instance.Instance) {
 	instancesStartMu.Lock()
 	defer instancesStartMu.Unlock()
 
 	sort.Sort(instanceAutostartList(instances))
 
-	maxAttempts := 3
+	maxAttempts := int64(3)
+	retryDelay := 5 * time.Second
+	if s.GlobalConfig != nil {
+		maxAttempts, retryDelay = s.GlobalConfig.InstancesAutostartRetry()
+	}
 
 	// Start the instances
+	var failed []instance.Instance
 	for _, inst := range instances {
 		if !instanceShouldAutoStart(inst) {
 			continue
@@ -208,56 +223,101 @@ func instancesStart(s *state.State, instances []instance.Instance) {
 			continue
 		}
 
-		// Get the instance config.
-		config := inst.ExpandedConfig()
-		autoStartDelay := config["boot.autostart.delay"]
-
-		instLogger := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
-
-		// Try to start the instance.
-		var attempt = 0
-		for {
-			attempt++
-			err := inst.Start(false)
-			if err != nil {
-				if api.StatusErrorCheck(err, http.StatusServiceUnavailable) {
-					break // Don't log or retry instances that are not ready to start yet.
-				}
+		if instanceAutostart(s, inst, 1, maxAttempts) {
+			failed = append(failed, inst)
+		}
+	}
 
-				instLogger.Warn("Failed auto start instance attempt", logger.Ctx{"attempt": attempt, "maxAttempts": maxAttempts, "err": err})
+	// Retry the instances which failed to start in the background so that the caller isn't delayed.
+	if len(failed) > 0 {
+		go instancesStartRetry(s, failed, maxAttempts, retryDelay)
+	}
+}
 
-				if attempt >= maxAttempts {
-					// If unable to start after 3 tries, record a warning.
-					warnErr := s.DB.Cluster.UpsertWarningLocalNode(inst.Project().Name, cluster.TypeInstance, inst.ID(), warningtype.InstanceAutostartFailure, fmt.Sprintf("%v", err))
-					if warnErr != nil {
-						instLogger.Warn("Failed to create instance autostart failure warning", logger.Ctx{"err": warnErr})
-					}
+// instancesStartRetry retries starting the instances which failed to start, doubling the delay between attempts.
+func instancesStartRetry(s *state.State, instances []instance.Instance, maxAttempts int64, retryDelay time.Duration) {
+	delay := retryDelay
 
-					instLogger.Error("Failed to auto start instance", logger.Ctx{"err": err})
+	for attempt := int64(2); attempt <= maxAttempts && len(instances) > 0; attempt++ {
+		select {
+		case <-time.After(delay):
+		case <-s.ShutdownCtx.Done():
+			return
+		}
 
-					break
-				}
+		delay *= 2
+		if delay > instanceAutostartMaxRetryDelay {
+			delay = instanceAutostartMaxRetryDelay
+		}
 
-				time.Sleep(5 * time.Second)
+		instancesStartMu.Lock()
 
+		var failed []instance.Instance
+		for _, inst := range instances {
+			// Reload the instance as it may have been modified, started or deleted in the meantime.
+			inst, err := instance.LoadByProjectAndName(s, inst.Project().Name, inst.Name())
+			if err != nil {
 				continue
 			}
 
-			// Resolve any previous warning.
-			warnErr := warnings.ResolveWarningsByLocalNodeAndProjectAndTypeAndEntity(s.DB.Cluster, inst.Project().Name, warningtype.InstanceAutostartFailure, cluster.TypeInstance, inst.ID())
-			if warnErr != nil {
-				instLogger.Warn("Failed to resolve instance autostart failure warning", logger.Ctx{"err": warnErr})
+			if !instanceShouldAutoStart(inst) || inst.IsRunning() {
+				continue
 			}
 
-			// Wait the auto-start delay if set.
-			autoStartDelayInt, err := strconv.Atoi(autoStartDelay)
-			if err == nil {
-				time.Sleep(time.Duration(autoStartDelayInt) * time.Second)
+			if instanceAutostart(s, inst, attempt, maxAttempts) {
+				failed = append(failed, inst)
 			}
+		}
+
+		instancesStartMu.Unlock()
+
+		instances = failed
+	}
+}
+
+// instanceAutostart tries to automatically start the instance and returns whether the start should be retried.
+// A warning is raised if the instance still fails to start on the last attempt.
+func instanceAutostart(s *state.State, inst instance.Instance, attempt int64, maxAttempts int64) bool {
+	instLogger := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+
+	instLogger.Debug("Auto starting instance", logger.Ctx{"attempt": attempt, "maxAttempts": maxAttempts})
+
+	err := inst.Start(false)
+	if err != nil {
+		if api.StatusErrorCheck(err, http.StatusServiceUnavailable) {
+			return false // Don't log or retry instances that are not ready to start yet.
+		}
+
+		instLogger.Warn("Failed auto start instance attempt", logger.Ctx{"attempt": attempt, "maxAttempts": maxAttempts, "err": err})
 
-			break
+		if attempt < maxAttempts {
+			return true
 		}
+
+		// If unable to start after all the attempts, record a warning.
+		warnErr := s.DB.Cluster.UpsertWarningLocalNode(inst.Project().Name, cluster.TypeInstance, inst.ID(), warningtype.InstanceAutostartFailure, fmt.Sprintf("%v", err))
+		if warnErr != nil {
+			instLogger.Warn("Failed to create instance autostart failure warning", logger.Ctx{"err": warnErr})
+		}
+
+		instLogger.Error("Failed to auto start instance", logger.Ctx{"err": err})
+
+		return false
 	}
+
+	// Resolve any previous warning.
+	warnErr := warnings.ResolveWarningsByLocalNodeAndProjectAndTypeAndEntity(s.DB.Cluster, inst.Project().Name, warningtype.InstanceAutostartFailure, cluster.TypeInstance, inst.ID())
+	if warnErr != nil {
+		instLogger.Warn("Failed to resolve instance autostart failure warning", logger.Ctx{"err": warnErr})
+	}
+
+	// Wait the auto-start delay if set.
+	autoStartDelayInt, err := strconv.Atoi(inst.ExpandedConfig()["boot.autostart.delay"])
+	if err == nil {
+		time.Sleep(time.Duration(autoStartDelayInt) * time.Second)
+	}
+
+	return false
 }
 
 type instanceStopList []instance.Instance
@@ -122,7 +122,7 @@ func createFromImage(s *state.State, r *http.Request, p api.Project, profiles []
 			return err
 		}
 
-		return instanceCreateFromImage(s, r, img, args, op)
+		return instanceCreateFromImage(s, r, img, args, !isClusterNotification(r), op)
 	}
 
 	resources := map[string][]api.URL{}
@@ -173,7 +173,7 @@ func createFromNone(s *state.State, r *http.Request, projectName string, profile
 	}
 
 	run := func(op *operations.Operation) error {
-		_, err := instanceCreateAsEmpty(s, args)
+		_, err := instanceCreateAsEmpty(s, args, !isClusterNotification(r))
 		return err
 	}
 
@@ -241,6 +241,14 @@ func createFromMigration(s *state.State, r *http.Request, projectName string, pr
 		return response.BadRequest(fmt.Errorf("Can't find a storage pool for the instance to use"))
 	}
 
+	// Internal moves between cluster members keep the instance on the same pool.
+	if r == nil || !isClusterNotification(r) {
+		err = instanceCheckStoragePool(s, projectName, storagePool)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
 	if localRootDiskDeviceKey == "" && storagePoolProfile == "" {
 		// Give the container it's own local root disk device with a pool property.
 		rootDev := map[string]string{}
@@ -314,7 +322,7 @@ func createFromMigration(s *state.State, r *http.Request, projectName string, pr
 		// Note: At this stage we do not yet know if snapshots are going to be received and so we cannot
 		// create their DB records. This will be done if needed in the migrationSink.Do() function called
 		// as part of the operation below.
-		inst, instOp, cleanup, err = instance.CreateInternal(s, args, true)
+		inst, instOp, cleanup, err = instance.CreateInternal(s, args, true, r == nil || !isClusterNotification(r))
 		if err != nil {
 			return response.InternalError(fmt.Errorf("Failed creating instance record: %w", err))
 		}
@@ -499,6 +507,19 @@ func createFromCopy(s *state.State, r *http.Request, projectName string, profile
 		req.Devices[key] = value
 	}
 
+	// Check that the project can use the storage pool of the copy (which may be the one of the source).
+	if !isClusterNotification(r) {
+		storagePool, _, _, _, resp := instanceFindStoragePool(s, targetProject, req)
+		if resp != nil {
+			return resp
+		}
+
+		err = instanceCheckStoragePool(s, targetProject, storagePool)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
 	if req.Stateful {
 		sourceName, _, _ := api.GetParentAndSnapshotName(source.Name())
 		if sourceName != req.Name {
@@ -542,6 +563,7 @@ func createFromCopy(s *state.State, r *http.Request, projectName string, profile
 			refresh:              req.Source.Refresh,
 			applyTemplateTrigger: true,
 			allowInconsistent:    req.Source.AllowInconsistent,
+			checkProjectLimits:   !isClusterNotification(r),
 		}, op)
 		if err != nil {
 			return err
@@ -660,6 +682,11 @@ func createFromBackup(s *state.State, r *http.Request, projectName string, data
 		bInfo.Name = instanceName
 	}
 
+	err = instanceValidName(s, projectName, bInfo.Name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	logger.Debug("Backup file info loaded", logger.Ctx{
 		"type":      bInfo.Type,
 		"name":      bInfo.Name,
@@ -726,7 +753,7 @@ func createFromBackup(s *state.State, r *http.Request, projectName string, data
 
 		runRevert.Add(revertHook)
 
-		err = internalImportFromBackup(s, bInfo.Project, bInfo.Name, instanceName != "")
+		err = internalImportFromBackup(s, bInfo.Project, bInfo.Name, instanceName != "", !isClusterNotification(r))
 		if err != nil {
 			return fmt.Errorf("Failed importing backup: %w", err)
 		}
@@ -863,6 +890,14 @@ func instancesPost(d *Daemon, r *http.Request) response.Response {
 		}
 	}
 
+	// Resolve the image remotes of the project.
+	if req.Source.Type == "image" {
+		req.Source.Server, req.Source.Protocol, err = imageRemoteResolve(r.Context(), s, targetProjectName, req.Source.Server, req.Source.Protocol)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
 	// Check if clustered.
 	clustered, err := cluster.Enabled(s.DB.Node)
 	if err != nil {
@@ -1002,6 +1037,25 @@ func instancesPost(d *Daemon, r *http.Request) response.Response {
 			}
 		}
 
+		// Apply the default instance configuration of the project, below the requested and profile values.
+		// Copies keep the configuration of their source and migrated instances already have their own.
+		if util.ValueInSlice(req.Source.Type, []string{"image", "none"}) {
+			defaultsProject := targetProject
+			if profileProject != targetProject.Name {
+				dbProject, err := dbCluster.GetProject(ctx, tx.Tx(), profileProject)
+				if err != nil {
+					return fmt.Errorf("Failed loading project %q: %w", profileProject, err)
+				}
+
+				defaultsProject, err = dbProject.ToAPI(ctx, tx.Tx())
+				if err != nil {
+					return err
+				}
+			}
+
+			req.Config = project.ApplyInstanceDefaults(req.Config, project.InstanceDefaults(defaultsProject), profiles)
+		}
+
 		// Generate automatic instance name if not specified.
 		if req.Name == "" {
 			names, err := tx.GetInstanceNames(ctx, targetProjectName)
@@ -1076,9 +1130,18 @@ func instancesPost(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
-	err = instance.ValidName(req.Name, false)
-	if err != nil {
-		return response.BadRequest(err)
+	// The naming patterns don't apply to the internal requests between cluster members (such as the migration
+	// sinks used when moving an instance to another member) as those keep the name of an existing instance.
+	if clusterNotification {
+		err = internalInstance.ValidName(req.Name)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+	} else {
+		err = instanceValidName(s, targetProjectName, req.Name)
+		if err != nil {
+			return response.SmartError(err)
+		}
 	}
 
 	if clustered && !clusterNotification && targetMemberInfo == nil {
@@ -1150,6 +1213,13 @@ func instancesPost(d *Daemon, r *http.Request) response.Response {
 	}
 }
 
+// instanceCheckStoragePool checks that the project's restrictions allow placing an instance on the storage pool.
+func instanceCheckStoragePool(s *state.State, projectName string, poolName string) error {
+	return s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return project.AllowStoragePoolUse(tx, projectName, poolName)
+	})
+}
+
 func instanceFindStoragePool(s *state.State, projectName string, req *api.InstancesPost) (string, string, string, map[string]string, response.Response) {
 	// Grab the container's root device if one is specified
 	storagePool := ""
@@ -3,6 +3,8 @@ package main
 import (
 	"context"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -51,6 +53,17 @@ func expireLogsTask(state *state.State) (task.Func, task.Schedule) {
 	return f, task.Daily()
 }
 
+// logsExpiryTypes are the types of logs expiring on their own schedule, see the core.logs_expiry.* server
+// configuration keys:
+//   - console: the console logs of instances.
+//   - instance: the other log files of instances and their snapshots.
+//   - deleted: the logs of deleted instances.
+//   - lifecycle: the rotated files of the audit log, which records the lifecycle events.
+//
+// Operations don't write log files, the history of completed operations is pruned on its own through the
+// core.operations_history.* keys.
+var logsExpiryTypes = []string{"console", "instance", "deleted", "lifecycle"}
+
 func expireLogs(ctx context.Context, state *state.State) error {
 	// List the instances.
 	instances, err := instance.LoadNodeAll(state, instancetype.Any)
@@ -70,6 +83,48 @@ func expireLogs(ctx context.Context, state *state.State) error {
 		names = append(names, project.Instance(inst.Project().Name, inst.Name()))
 	}
 
+	// The console logs of running virtual machines are only written when retrieved, they mustn't be expired.
+	runningVMs := map[string]bool{}
+	for _, inst := range instances {
+		if inst.Type() != instancetype.VM || !inst.IsRunning() {
+			continue
+		}
+
+		runningVMs[project.Instance(inst.Project().Name, inst.Name())] = true
+	}
+
+	// Load the expiry of each log type and report what was removed once done.
+	expiry := make(map[string]time.Duration, len(logsExpiryTypes))
+	pruned := make(map[string]int, len(logsExpiryTypes))
+	for _, logType := range logsExpiryTypes {
+		expiry[logType] = state.GlobalConfig.LogsExpiry(logType)
+	}
+
+	defer func() {
+		for _, logType := range logsExpiryTypes {
+			if pruned[logType] > 0 {
+				logger.Info("Expired log files", logger.Ctx{"type": logType, "count": pruned[logType], "expiry": expiry[logType]})
+			}
+		}
+	}()
+
+	// remove removes the log file or directory at path if it's of a type which expires and wasn't modified
+	// since the expiry of its type.
+	remove := func(logType string, path string, modTime time.Time) error {
+		if expiry[logType] <= 0 || time.Since(modTime) < expiry[logType] {
+			return nil
+		}
+
+		err := os.RemoveAll(path)
+		if err != nil {
+			return err
+		}
+
+		pruned[logType]++
+
+		return nil
+	}
+
 	newestFile := func(path string, dir os.FileInfo) time.Time {
 		newest := dir.ModTime()
 
@@ -129,38 +184,72 @@ func expireLogs(ctx context.Context, state *state.State) error {
 
 				// Deal with directories (snapshots).
 				if instInfo.IsDir() {
-					newest := newestFile(path, instInfo)
-					if time.Since(newest).Hours() >= 48 {
-						err := os.RemoveAll(path)
-						if err != nil {
-							return err
-						}
+					err := remove("instance", path, newestFile(path, instInfo))
+					if err != nil {
+						return err
 					}
 
 					continue
 				}
 
 				// Only remove old log files (keep other files, such as conf, pid, monitor etc).
-				if strings.HasSuffix(instInfo.Name(), ".log") || strings.HasSuffix(instInfo.Name(), ".log.old") {
-					// Remove any log file which wasn't modified in the past 48 hours.
-					if time.Since(instInfo.ModTime()).Hours() >= 48 {
-						err := os.Remove(path)
-						if err != nil {
-							return err
-						}
+				if !strings.HasSuffix(instInfo.Name(), ".log") && !strings.HasSuffix(instInfo.Name(), ".log.old") {
+					continue
+				}
+
+				logType := "instance"
+				if instInfo.Name() == "console.log" {
+					if runningVMs[fi.Name()] {
+						continue
 					}
+
+					logType = "console"
 				}
-			}
-		} else {
-			// Empty directory if unchanged in the past 24 hours.
-			path := internalUtil.LogPath(fi.Name())
-			newest := newestFile(path, fi)
-			if time.Since(newest).Hours() >= 24 {
-				err := os.RemoveAll(path)
+
+				// Remove any log file which wasn't modified since the expiry of its type.
+				err = remove(logType, path, instInfo.ModTime())
 				if err != nil {
 					return err
 				}
 			}
+		} else {
+			// Empty directory if unchanged since the expiry of the logs of deleted instances.
+			path := internalUtil.LogPath(fi.Name())
+			err := remove("deleted", path, newestFile(path, fi))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Expire the rotated audit log files, the active one is never removed.
+	auditLogPath := state.LocalConfig.AuditLogPath()
+	if auditLogPath != "" {
+		auditEntries, err := os.ReadDir(filepath.Dir(auditLogPath))
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range auditEntries {
+			suffix, ok := strings.CutPrefix(entry.Name(), filepath.Base(auditLogPath)+".")
+			if !ok || entry.IsDir() {
+				continue
+			}
+
+			_, err := strconv.ParseUint(suffix, 10, 64)
+			if err != nil {
+				continue
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				continue
+			}
+
+			err = remove("lifecycle", filepath.Join(filepath.Dir(auditLogPath), entry.Name()), info.ModTime())
+			if err != nil {
+				return err
+			}
 		}
 	}
 
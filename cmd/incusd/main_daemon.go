@@ -18,7 +18,8 @@ type cmdDaemon struct {
 	global *cmdGlobal
 
 	// Common options
-	flagGroup string
+	flagGroup       string
+	flagExtraGroups []string
 }
 
 func (c *cmdDaemon) Command() *cobra.Command {
@@ -33,6 +34,7 @@ func (c *cmdDaemon) Command() *cobra.Command {
 `
 	cmd.RunE = c.Run
 	cmd.Flags().StringVar(&c.flagGroup, "group", "", "The group of users that will be allowed to talk to Incus"+"``")
+	cmd.Flags().StringArrayVar(&c.flagExtraGroups, "extra-group", nil, "Additional group of users that will be allowed to talk to Incus (can be repeated)"+"``")
 
 	return cmd
 }
@@ -59,6 +61,7 @@ func (c *cmdDaemon) Run(cmd *cobra.Command, args []string) error {
 
 	conf := defaultDaemonConfig()
 	conf.Group = c.flagGroup
+	conf.ExtraGroups = c.flagExtraGroups
 	conf.Trace = c.global.flagLogTrace
 	d := newDaemon(conf, sys.DefaultOS())
 
@@ -84,7 +87,7 @@ func (c *cmdDaemon) Run(cmd *cobra.Command, args []string) error {
 				logger.Warn("Ignoring signal, shutdown already in progress", logger.Ctx{"signal": sig})
 			} else {
 				go func() {
-					d.shutdownDoneCh <- d.Stop(context.Background(), sig)
+					d.shutdownDoneCh <- d.Stop(context.Background(), daemonStopModeFromSignal(sig))
 				}()
 			}
 
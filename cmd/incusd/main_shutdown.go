@@ -16,6 +16,7 @@ type cmdShutdown struct {
 	global *cmdGlobal
 
 	flagForce   bool
+	flagRestart bool
 	flagTimeout int
 }
 
@@ -31,10 +32,14 @@ func (c *cmdShutdown) Command() *cobra.Command {
 
   This can take quite a while as instances can take a long time to
   shutdown, especially if a non-standard timeout was configured for them.
+
+  With --restart, the instances are left running so that the daemon
+  can be restarted without disrupting them.
 `
 	cmd.RunE = c.Run
 	cmd.Flags().IntVarP(&c.flagTimeout, "timeout", "t", 0, "Number of seconds to wait before giving up"+"``")
 	cmd.Flags().BoolVarP(&c.flagForce, "force", "f", false, "Force shutdown instead of waiting for running operations to finish"+"``")
+	cmd.Flags().BoolVar(&c.flagRestart, "restart", false, "Leave instances running as the daemon is being restarted"+"``")
 	cmd.Hidden = true
 
 	return cmd
@@ -52,6 +57,7 @@ func (c *cmdShutdown) Run(cmd *cobra.Command, args []string) error {
 
 	v := url.Values{}
 	v.Set("force", strconv.FormatBool(c.flagForce))
+	v.Set("restart", strconv.FormatBool(c.flagRestart))
 
 	chResult := make(chan error, 1)
 	go func() {
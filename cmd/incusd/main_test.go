@@ -7,7 +7,6 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
-	"golang.org/x/sys/unix"
 
 	"github.com/lxc/incus/internal/idmap"
 	"github.com/lxc/incus/internal/server/db"
@@ -104,7 +103,7 @@ func (suite *daemonTestSuite) SetupTest() {
 }
 
 func (suite *daemonTestSuite) TearDownTest() {
-	err := suite.d.Stop(context.Background(), unix.SIGQUIT)
+	err := suite.d.Stop(context.Background(), daemonStopModeExit)
 	if err != nil {
 		suite.T().Errorf("failed to stop daemon: %v", err)
 	}
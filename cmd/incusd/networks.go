@@ -450,15 +450,28 @@ func networksPost(d *Daemon, r *http.Request) response.Response {
 	defer revert.Fail()
 
 	// Populate default config.
+	requestedConfig := localUtil.CopyConfig(req.Config)
 	err = netType.FillConfig(req.Config)
 	if err != nil {
 		return response.SmartError(err)
 	}
 
-	// Create the database entry.
-	_, err = s.DB.Cluster.CreateNetwork(projectName, req.Name, req.Description, netType.DBType(), req.Config)
+	// Allocate the subnets and create the database entry at once, so that they can't be allocated twice.
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		err := networkAllocateProjectSubnets(ctx, tx, projectName, req.Name, requestedConfig, req.Config)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CreateNetwork(ctx, projectName, req.Name, req.Description, netType.DBType(), req.Config)
+		if err != nil {
+			return fmt.Errorf("Error inserting %q into database: %w", req.Name, err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return response.SmartError(fmt.Errorf("Error inserting %q into database: %w", req.Name, err))
+		return response.SmartError(err)
 	}
 
 	revert.Add(func() { _ = s.DB.Cluster.DeleteNetwork(projectName, req.Name) })
@@ -480,6 +493,212 @@ func networksPost(d *Daemon, r *http.Request) response.Response {
 	return resp
 }
 
+// networkAllocateProjectSubnets makes the network use subnets from the project's networks.address_pools and keeps
+// it out of the address pools of the other projects.
+// The subnets which were generated rather than requested are replaced with unused subnets allocated from the
+// pools (avoiding the subnets of all the existing networks), while the requested subnets must be within the pools.
+// Only the IP families which the pools include are affected. For the other IP families, generated subnets within
+// the pools of other projects are replaced with unused ones, while requested ones are rejected.
+func networkAllocateProjectSubnets(ctx context.Context, tx *db.ClusterTx, projectName string, networkName string, requestedConfig map[string]string, config map[string]string) error {
+	projectsPools, err := networkProjectsAddressPools(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	pools := projectsPools[projectName]
+
+	var otherPools []*net.IPNet
+	for poolsProject, projectPools := range projectsPools {
+		if poolsProject != projectName {
+			otherPools = append(otherPools, projectPools...)
+		}
+	}
+
+	if len(pools) == 0 && len(otherPools) == 0 {
+		return nil
+	}
+
+	// Get the subnets used by the networks of all projects, along with the pools of the other projects.
+	networks, err := tx.GetCreatedNetworks(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed loading networks: %w", err)
+	}
+
+	used := append([]*net.IPNet{}, otherPools...)
+	for networksProject, projectNetworks := range networks {
+		for _, n := range projectNetworks {
+			if networksProject == projectName && n.Name == networkName {
+				continue
+			}
+
+			for _, key := range []string{"ipv4.address", "ipv6.address"} {
+				_, subnet, err := net.ParseCIDR(n.Config[key])
+				if err == nil {
+					used = append(used, subnet)
+				}
+			}
+		}
+	}
+
+	for _, family := range networkAddressPoolFamilies {
+		// Only replace the generated subnets.
+		if util.ValueInSlice(config[family.key], []string{"", "none"}) || !util.ValueInSlice(requestedConfig[family.key], []string{"", "auto"}) {
+			continue
+		}
+
+		familyPools := networkAddressPoolsFamily(pools, family.ipv4)
+		if len(familyPools) == 0 {
+			// Keep the generated subnet unless it's within the pools of another project.
+			_, subnet, err := net.ParseCIDR(config[family.key])
+			if err != nil || networkSubnetOverlappingPool(subnet, otherPools) == nil {
+				continue
+			}
+
+			_, fallback, err := net.ParseCIDR(family.fallback)
+			if err != nil {
+				return err
+			}
+
+			familyPools = []*net.IPNet{fallback}
+		}
+
+		subnet, err := network.SubnetAllocate(familyPools, used, family.prefix)
+		if err != nil {
+			return api.StatusErrorf(http.StatusBadRequest, "Failed allocating %q for project %q: %w", family.key, projectName, err)
+		}
+
+		// Use the first address of the subnet as the gateway.
+		gateway := make(net.IP, len(subnet.IP))
+		copy(gateway, subnet.IP)
+		gateway[len(gateway)-1]++
+
+		ones, _ := subnet.Mask.Size()
+		config[family.key] = fmt.Sprintf("%s/%d", gateway.String(), ones)
+		used = append(used, subnet)
+	}
+
+	err = networkCheckProjectSubnets(pools, projectName, config)
+	if err != nil {
+		return err
+	}
+
+	// Check that the subnets don't encroach on the pools of the other projects.
+	for poolsProject, projectPools := range projectsPools {
+		if poolsProject == projectName {
+			continue
+		}
+
+		for _, family := range networkAddressPoolFamilies {
+			_, subnet, err := net.ParseCIDR(config[family.key])
+			if err != nil {
+				continue
+			}
+
+			pool := networkSubnetOverlappingPool(subnet, projectPools)
+			if pool != nil {
+				return api.StatusErrorf(http.StatusForbidden, "Subnet %q overlaps with address pool %q of project %q", subnet.String(), pool.String(), poolsProject)
+			}
+		}
+	}
+
+	return nil
+}
+
+// networkProjectsAddressPools returns the networks.address_pools of the projects which have some, by project name.
+func networkProjectsAddressPools(ctx context.Context, tx *db.ClusterTx) (map[string][]*net.IPNet, error) {
+	projects, err := dbCluster.GetProjects(ctx, tx.Tx())
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading projects: %w", err)
+	}
+
+	projectsPools := map[string][]*net.IPNet{}
+	for _, p := range projects {
+		projectConfig, err := dbCluster.GetProjectConfig(ctx, tx.Tx(), p.ID)
+		if err != nil {
+			return nil, fmt.Errorf("Failed loading config of project %q: %w", p.Name, err)
+		}
+
+		if projectConfig["networks.address_pools"] == "" {
+			continue
+		}
+
+		pools, err := network.SubnetParseAppend(nil, util.SplitNTrimSpace(projectConfig["networks.address_pools"], ",", -1, false)...)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid networks.address_pools of project %q: %w", p.Name, err)
+		}
+
+		projectsPools[p.Name] = pools
+	}
+
+	return projectsPools, nil
+}
+
+// networkSubnetOverlappingPool returns the first of the pools which the subnet overlaps with, if any.
+func networkSubnetOverlappingPool(subnet *net.IPNet, pools []*net.IPNet) *net.IPNet {
+	for _, pool := range pools {
+		if network.SubnetsOverlap(subnet, pool) {
+			return pool
+		}
+	}
+
+	return nil
+}
+
+// networkAddressPoolFamilies are the network configuration keys which the project address pools apply to, along
+// with the size of the subnets allocated from the pools and the range which subnets generated within the pools of
+// other projects are replaced from.
+var networkAddressPoolFamilies = []struct {
+	key      string
+	ipv4     bool
+	prefix   int
+	fallback string
+}{
+	{key: "ipv4.address", ipv4: true, prefix: 24, fallback: "10.0.0.0/8"},
+	{key: "ipv6.address", ipv4: false, prefix: 64, fallback: "fd42::/16"},
+}
+
+// networkAddressPoolsFamily returns the address pools of the given IP family.
+func networkAddressPoolsFamily(pools []*net.IPNet, ipv4 bool) []*net.IPNet {
+	familyPools := []*net.IPNet{}
+	for _, pool := range pools {
+		if (pool.IP.To4() != nil) == ipv4 {
+			familyPools = append(familyPools, pool)
+		}
+	}
+
+	return familyPools
+}
+
+// networkCheckProjectSubnets checks that the subnets set in the network configuration are within the project's
+// address pools. Only the IP families which the pools include are checked.
+func networkCheckProjectSubnets(pools []*net.IPNet, projectName string, config map[string]string) error {
+	for _, family := range networkAddressPoolFamilies {
+		familyPools := networkAddressPoolsFamily(pools, family.ipv4)
+		if len(familyPools) == 0 || util.ValueInSlice(config[family.key], []string{"", "none"}) {
+			continue
+		}
+
+		_, subnet, err := net.ParseCIDR(config[family.key])
+		if err != nil {
+			return api.StatusErrorf(http.StatusBadRequest, "Invalid %q: %w", family.key, err)
+		}
+
+		allowed := false
+		for _, pool := range familyPools {
+			if network.SubnetContains(pool, subnet) {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			return api.StatusErrorf(http.StatusForbidden, "Subnet %q isn't within the address pools of project %q", subnet.String(), projectName)
+		}
+	}
+
+	return nil
+}
+
 // networkPartiallyCreated returns true of supplied network has properties that indicate it has had previous
 // create attempts run on it but failed on one or more nodes.
 func networkPartiallyCreated(netInfo *api.Network) bool {
@@ -550,11 +769,17 @@ func networksPostCluster(s *state.State, projectName string, netInfo *api.Networ
 		}
 
 		// Add default values if we are inserting global config for first time.
+		requestedConfig := localUtil.CopyConfig(req.Config)
 		err = netType.FillConfig(req.Config)
 		if err != nil {
 			return err
 		}
 
+		err = networkAllocateProjectSubnets(ctx, tx, projectName, req.Name, requestedConfig, req.Config)
+		if err != nil {
+			return err
+		}
+
 		// Insert the global config keys.
 		err = tx.CreateNetworkConfig(networkID, 0, req.Config)
 		if err != nil {
@@ -1258,7 +1483,7 @@ func networkPut(d *Daemon, r *http.Request) response.Response {
 
 	clientType := clusterRequest.UserAgentClientType(r.Header.Get("User-Agent"))
 
-	response := doNetworkUpdate(projectName, n, req, targetNode, clientType, r.Method, clustered)
+	response := doNetworkUpdate(s, projectName, n, req, targetNode, clientType, r.Method, clustered)
 
 	requestor := request.CreateRequestor(r)
 	s.Events.SendLifecycle(projectName, lifecycle.NetworkUpdated.Event(n, requestor, nil))
@@ -1311,7 +1536,7 @@ func networkPatch(d *Daemon, r *http.Request) response.Response {
 
 // doNetworkUpdate loads the current local network config, merges with the requested network config, validates
 // and applies the changes. Will also notify other cluster nodes of non-node specific config if needed.
-func doNetworkUpdate(projectName string, n network.Network, req api.NetworkPut, targetNode string, clientType clusterRequest.ClientType, httpMethod string, clustered bool) response.Response {
+func doNetworkUpdate(s *state.State, projectName string, n network.Network, req api.NetworkPut, targetNode string, clientType clusterRequest.ClientType, httpMethod string, clustered bool) response.Response {
 	if req.Config == nil {
 		req.Config = map[string]string{}
 	}
@@ -1338,6 +1563,16 @@ func doNetworkUpdate(projectName string, n network.Network, req api.NetworkPut,
 		}
 	}
 
+	// Make the network use subnets from the project's address pools (already done when notified by another member).
+	if clientType == clusterRequest.ClientTypeNormal {
+		err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			return networkAllocateProjectSubnets(ctx, tx, projectName, n.Name(), localUtil.CopyConfig(req.Config), req.Config)
+		})
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
 	// Validate the merged configuration.
 	err := n.Validate(req.Config)
 	if err != nil {
@@ -1636,6 +1871,47 @@ func networkStartup(s *state.State) error {
 	return nil
 }
 
+// networkMTUCheck checks the MTU of all managed networks against their parent interfaces or uplink
+// and raises a warning for any mismatch.
+func networkMTUCheck(s *state.State) error {
+	var projectNames []string
+
+	err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+		projectNames, err = dbCluster.GetProjectNames(ctx, tx.Tx())
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to load projects: %w", err)
+	}
+
+	for _, projectName := range projectNames {
+		networkNames, err := s.DB.Cluster.GetCreatedNetworks(projectName)
+		if err != nil {
+			return fmt.Errorf("Failed to load networks for project %q: %w", projectName, err)
+		}
+
+		for _, networkName := range networkNames {
+			n, err := network.LoadByName(s, projectName, networkName)
+			if err != nil {
+				logger.Warn("Failed loading network for MTU check", logger.Ctx{"project": projectName, "network": networkName, "err": err})
+				continue
+			}
+
+			err = network.MTUCheck(s, n)
+			if err != nil {
+				logger.Warn("Network MTU mismatch", logger.Ctx{"project": projectName, "network": networkName, "err": err})
+				_ = s.DB.Cluster.UpsertWarningLocalNode(n.Project(), dbCluster.TypeNetwork, int(n.ID()), warningtype.NetworkMTUMismatch, err.Error())
+				continue
+			}
+
+			_ = warnings.ResolveWarningsByLocalNodeAndProjectAndTypeAndEntity(s.DB.Cluster, n.Project(), warningtype.NetworkMTUMismatch, dbCluster.TypeNetwork, int(n.ID()))
+		}
+	}
+
+	return nil
+}
+
 func networkShutdown(s *state.State) {
 	var err error
 
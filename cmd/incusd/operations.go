@@ -55,6 +55,11 @@ var operationWebsocket = APIEndpoint{
 	Get: APIEndpointAction{Handler: operationWebsocketGet, AllowUntrusted: true},
 }
 
+// operationBlocksShutdown returns whether the daemon waits for the operation to finish before shutting down.
+func operationBlocksShutdown(op *operations.Operation) bool {
+	return op.Status() == api.Running && op.Class() != operations.OperationClassToken
+}
+
 // waitForOperations waits for operations to finish.
 // There's a timeout for console/exec operations that when reached will shut down the instances forcefully.
 func waitForOperations(ctx context.Context, cluster *db.Cluster, consoleShutdownTimeout time.Duration) {
@@ -82,7 +87,7 @@ func waitForOperations(ctx context.Context, cluster *db.Cluster, consoleShutdown
 
 		var runningOps, execConsoleOps int
 		for _, op := range ops {
-			if op.Status() != api.Running || op.Class() == operations.OperationClassToken {
+			if !operationBlocksShutdown(op) {
 				continue
 			}
 
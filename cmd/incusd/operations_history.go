@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lxc/incus/internal/server/cluster"
+	"github.com/lxc/incus/internal/server/db"
+	"github.com/lxc/incus/internal/server/db/operationtype"
+	"github.com/lxc/incus/internal/server/project"
+	"github.com/lxc/incus/internal/server/response"
+	"github.com/lxc/incus/internal/server/state"
+	"github.com/lxc/incus/internal/server/task"
+	"github.com/lxc/incus/shared/api"
+	"github.com/lxc/incus/shared/logger"
+	"github.com/lxc/incus/shared/util"
+)
+
+var operationsHistoryCmd = APIEndpoint{
+	Path: "operations/history",
+
+	Get: APIEndpointAction{Handler: operationsHistoryGet, AccessHandler: allowProjectPermission()},
+}
+
+// swagger:operation GET /1.0/operations/history operations operations_history_get
+//
+//	Get the operations history
+//
+//	Returns the completed operations kept in the operations history, most recent first.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: query
+//	    name: all-projects
+//	    description: Retrieve operations from all projects
+//	    type: boolean
+//	  - in: query
+//	    name: type
+//	    description: Only return operations of this type
+//	    type: string
+//	    example: instance-create
+//	  - in: query
+//	    name: status
+//	    description: Only return operations with this final status
+//	    type: string
+//	    example: Failure
+//	  - in: query
+//	    name: since
+//	    description: Only return operations completed at or after this time (RFC3339)
+//	    type: string
+//	    example: 2021-03-23T17:00:00Z
+//	  - in: query
+//	    name: until
+//	    description: Only return operations completed at or before this time (RFC3339)
+//	    type: string
+//	    example: 2021-03-23T18:00:00Z
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of completed operations
+//	          items:
+//	            $ref: "#/definitions/OperationHistory"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func operationsHistoryGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName := queryParam(r, "project")
+	allProjects := util.IsTrue(queryParam(r, "all-projects"))
+
+	if allProjects && projectName != "" {
+		return response.SmartError(
+			api.StatusErrorf(http.StatusBadRequest, "Cannot specify a project when requesting all projects"),
+		)
+	} else if !allProjects && projectName == "" {
+		projectName = project.Default
+	}
+
+	if allProjects && !s.Authorizer.UserIsAdmin(r) {
+		return response.Forbidden(nil)
+	}
+
+	filter := db.OperationHistoryFilter{}
+	if !allProjects {
+		filter.Project = &projectName
+	}
+
+	typeName := queryParam(r, "type")
+	if typeName != "" {
+		opType, err := operationtype.FromName(typeName)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+
+		filter.Type = &opType
+	}
+
+	status := queryParam(r, "status")
+	if status != "" {
+		statusCode := api.StatusCodeFromString(status)
+		if !statusCode.IsFinal() {
+			return response.BadRequest(fmt.Errorf("Invalid operation status %q", status))
+		}
+
+		filter.StatusCode = &statusCode
+	}
+
+	for key, dest := range map[string]**time.Time{"since": &filter.Since, "until": &filter.Until} {
+		value := queryParam(r, key)
+		if value == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return response.BadRequest(fmt.Errorf("Invalid %q time %q: %w", key, value, err))
+		}
+
+		*dest = &t
+	}
+
+	var entries []db.OperationHistory
+	err := s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		entries, err = tx.GetOperationsHistory(ctx, filter)
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	result := make([]api.OperationHistory, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, api.OperationHistory{
+			ID:          entry.UUID,
+			Type:        entry.Type.Name(),
+			Description: entry.Description,
+			Status:      entry.StatusCode.String(),
+			StatusCode:  entry.StatusCode,
+			Err:         entry.Err,
+			Project:     entry.Project,
+			Location:    entry.Location,
+			Requestor:   entry.Requestor,
+			CreatedAt:   entry.CreatedAt,
+			FinishedAt:  entry.FinishedAt,
+		})
+	}
+
+	return response.SyncResponse(true, result)
+}
+
+// pruneOperationsHistoryTask removes the entries of the operations history which are past the configured age or
+// count. This is independent from the removal of orphaned operations and only runs on the leader when clustered.
+func pruneOperationsHistoryTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		leader, err := d.gateway.LeaderAddress()
+		if err != nil && !errors.Is(err, cluster.ErrNodeIsNotClustered) {
+			logger.Error("Failed to get leader cluster member address", logger.Ctx{"err": err})
+			return
+		}
+
+		if err == nil && s.LocalConfig.ClusterAddress() != leader {
+			logger.Debug("Skipping operations history pruning task since we're not leader")
+			return
+		}
+
+		err = pruneOperationsHistory(ctx, s)
+		if err != nil {
+			logger.Error("Failed pruning operations history", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Hourly()
+}
+
+// pruneOperationsHistory removes the operations history entries past the configured age or count.
+// When the history is disabled, all entries are removed.
+func pruneOperationsHistory(ctx context.Context, s *state.State) error {
+	maxAge, maxCount := s.GlobalConfig.OperationsHistory()
+
+	var before time.Time
+	if maxAge > 0 {
+		before = time.Now().Add(-maxAge)
+	} else if maxCount <= 0 {
+		before = time.Now()
+	}
+
+	var removed int64
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		removed, err = tx.PruneOperationsHistory(ctx, before, maxCount)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if removed > 0 {
+		logger.Debug("Pruned operations history", logger.Ctx{"removed": removed})
+	}
+
+	return nil
+}
@@ -1,15 +1,22 @@
 package main
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 
+	"github.com/lxc/incus/internal/server/instance"
+	"github.com/lxc/incus/internal/server/instance/instancetype"
 	"github.com/lxc/incus/internal/server/resources"
 	"github.com/lxc/incus/internal/server/response"
 	storagePools "github.com/lxc/incus/internal/server/storage"
 	"github.com/lxc/incus/shared/api"
+	"github.com/lxc/incus/shared/util"
 )
 
 var api10ResourcesCmd = APIEndpoint{
@@ -18,6 +25,24 @@ var api10ResourcesCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: api10ResourcesGet, AccessHandler: allowAuthenticated},
 }
 
+var api10ResourcesAssignmentsCmd = APIEndpoint{
+	Path: "resources/assignments",
+
+	Get: APIEndpointAction{Handler: api10ResourcesAssignmentsGet},
+}
+
+var api10ResourcesFeaturesCmd = APIEndpoint{
+	Path: "resources/features",
+
+	Get: APIEndpointAction{Handler: api10ResourcesFeaturesGet, AccessHandler: allowAuthenticated},
+}
+
+var api10ResourcesUsageCmd = APIEndpoint{
+	Path: "resources/usage",
+
+	Get: APIEndpointAction{Handler: api10ResourcesUsageGet, AccessHandler: allowAuthenticated},
+}
+
 var storagePoolResourcesCmd = APIEndpoint{
 	Path: "storage-pools/{name}/resources",
 
@@ -82,6 +107,320 @@ func api10ResourcesGet(d *Daemon, r *http.Request) response.Response {
 	return response.SyncResponse(true, res)
 }
 
+// resourcesAssignableDeviceTypes are the device types which pass a host device through to an instance.
+var resourcesAssignableDeviceTypes = []string{"gpu", "usb", "pci"}
+
+// swagger:operation GET /1.0/resources/assignments server resources_assignments_get
+//
+//	Get the host resources assigned to instances
+//
+//	Returns the CPU pinning and host devices (GPU, USB and PCI) of the running instances of the server.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	responses:
+//	  "200":
+//	    description: Resource assignments
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of assignments
+//	          items:
+//	            $ref: "#/definitions/ResourcesAssignment"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func api10ResourcesAssignmentsGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// If a target was specified, forward the request to the relevant node.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	instances, err := instance.LoadNodeAll(s, instancetype.Any)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	deviceTaskPinningMu.Lock()
+	pinning := deviceTaskPinning
+	deviceTaskPinningMu.Unlock()
+
+	assignments := []api.ResourcesAssignment{}
+	for _, inst := range instances {
+		if !inst.IsRunning() {
+			continue
+		}
+
+		assignment := api.ResourcesAssignment{
+			Project:  inst.Project().Name,
+			Instance: inst.Name(),
+			CPUs:     pinning[inst.Project().Name][inst.Name()],
+			Devices:  map[string]map[string]string{},
+		}
+
+		for name, dev := range inst.ExpandedDevices() {
+			if !util.ValueInSlice(dev["type"], resourcesAssignableDeviceTypes) {
+				continue
+			}
+
+			assignment.Devices[name] = dev.Clone()
+		}
+
+		if len(assignment.CPUs) == 0 && len(assignment.Devices) == 0 {
+			continue
+		}
+
+		assignments = append(assignments, assignment)
+	}
+
+	sort.Slice(assignments, func(i, j int) bool {
+		if assignments[i].Project != assignments[j].Project {
+			return assignments[i].Project < assignments[j].Project
+		}
+
+		return assignments[i].Instance < assignments[j].Instance
+	})
+
+	return response.SyncResponse(true, assignments)
+}
+
+// swagger:operation GET /1.0/resources/features server resources_features_get
+//
+//	Get the detected kernel and LXC features
+//
+//	Returns the kernel, LXC and AppArmor features detected on the server at startup.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	responses:
+//	  "200":
+//	    description: Detected features
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/ResourcesFeatures"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func api10ResourcesFeaturesGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// If a target was specified, forward the request to the relevant node.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	features := s.OS.Features()
+	features.IdmappedMountsPolicy = s.LocalConfig.IdmappedMounts()
+
+	return response.SyncResponse(true, features)
+}
+
+// resourcesUsageClasses are the classes of limited host resources reported by the usage endpoint.
+var resourcesUsageClasses = []string{"cpu", "gpu", "usb", "pci", "nic", "disk"}
+
+// resourcesUsageDevice returns the class and host resources consumed by an instance device.
+// An empty class is returned for devices which don't consume a limited host resource.
+func resourcesUsageDevice(dev map[string]string) (string, []string) {
+	switch dev["type"] {
+	case "gpu", "pci":
+		for _, key := range []string{"pci", "address", "id"} {
+			if dev[key] != "" {
+				return dev["type"], []string{dev[key]}
+			}
+		}
+
+		return dev["type"], []string{}
+	case "usb":
+		return "usb", []string{fmt.Sprintf("%s:%s", dev["vendorid"], dev["productid"])}
+	case "nic":
+		// Only NICs passing through a host interface or virtual function are limited.
+		if dev["nictype"] == "physical" || dev["nictype"] == "sriov" {
+			return "nic", []string{dev["parent"]}
+		}
+	case "disk":
+		// Only disks passing through a host block device are limited.
+		if strings.HasPrefix(dev["source"], "/dev/") {
+			return "disk", []string{dev["source"]}
+		}
+	}
+
+	return "", nil
+}
+
+// swagger:operation GET /1.0/resources/usage server resources_usage_get
+//
+//	Get the host resources used by instances
+//
+//	Returns the instances consuming limited host resources (CPU threads pinned by the scheduler,
+//	GPUs, USB and PCI devices, NICs and block devices) grouped by class of resource.
+//	Only instances of projects the user has access to are included.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	responses:
+//	  "200":
+//	    description: Resource usage
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: Usage per class of resource
+//	          items:
+//	            $ref: "#/definitions/ResourcesUsage"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func api10ResourcesUsageGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// If a target was specified, forward the request to the relevant node.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	instances, err := instance.LoadNodeAll(s, instancetype.Any)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	deviceTaskPinningMu.Lock()
+	pinning := deviceTaskPinning
+	deviceTaskPinningMu.Unlock()
+
+	usage := make(map[string]*api.ResourcesUsage, len(resourcesUsageClasses))
+	for _, class := range resourcesUsageClasses {
+		usage[class] = &api.ResourcesUsage{Class: class, Consumers: []api.ResourcesUsageConsumer{}}
+	}
+
+	addConsumer := func(class string, consumer api.ResourcesUsageConsumer) {
+		usage[class].Total += consumer.Amount
+		usage[class].Consumers = append(usage[class].Consumers, consumer)
+	}
+
+	for _, inst := range instances {
+		if !inst.IsRunning() {
+			continue
+		}
+
+		projectName := inst.Project().Name
+		if !s.Authorizer.UserHasPermission(r, projectName, "") {
+			continue
+		}
+
+		cpus := pinning[projectName][inst.Name()]
+		if len(cpus) > 0 {
+			resources := make([]string, 0, len(cpus))
+			for _, cpu := range cpus {
+				resources = append(resources, strconv.FormatInt(cpu, 10))
+			}
+
+			addConsumer("cpu", api.ResourcesUsageConsumer{Project: projectName, Instance: inst.Name(), Amount: int64(len(cpus)), Resources: resources})
+		}
+
+		for name, dev := range inst.ExpandedDevices() {
+			class, resources := resourcesUsageDevice(dev)
+			if class == "" {
+				continue
+			}
+
+			addConsumer(class, api.ResourcesUsageConsumer{Project: projectName, Instance: inst.Name(), Device: name, Amount: 1, Resources: resources})
+		}
+	}
+
+	result := make([]api.ResourcesUsage, 0, len(resourcesUsageClasses))
+	for _, class := range resourcesUsageClasses {
+		consumers := usage[class].Consumers
+		sort.Slice(consumers, func(i, j int) bool {
+			if consumers[i].Project != consumers[j].Project {
+				return consumers[i].Project < consumers[j].Project
+			}
+
+			if consumers[i].Instance != consumers[j].Instance {
+				return consumers[i].Instance < consumers[j].Instance
+			}
+
+			return consumers[i].Device < consumers[j].Device
+		})
+
+		result = append(result, *usage[class])
+	}
+
+	return response.SyncResponse(true, result)
+}
+
 // swagger:operation GET /1.0/storage-pools/{name}/resources storage storage_pool_resources
 //
 //	Get storage pool resources information
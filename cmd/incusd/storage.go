@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -56,6 +58,9 @@ func storageStartup(s *state.State, forceCheck bool) error {
 		return fmt.Errorf("Failed loading existing storage pools: %w", err)
 	}
 
+	// Mount pools backing other pools first.
+	poolNames = storagePoolsMountOrder(s, poolNames)
+
 	initPools := make(map[string]struct{}, len(poolNames))
 	for _, poolName := range poolNames {
 		initPools[poolName] = struct{}{}
@@ -89,8 +94,8 @@ func storageStartup(s *state.State, forceCheck bool) error {
 		return true
 	}
 
-	// Try initializing storage pools in random order.
-	for poolName := range initPools {
+	// Try initializing storage pools in mount order.
+	for _, poolName := range poolNames {
 		if initPool(poolName) {
 			// Storage pool initialized successfully so remove it from the list so its not retried.
 			delete(initPools, poolName)
@@ -111,9 +116,14 @@ func storageStartup(s *state.State, forceCheck bool) error {
 				case <-t.C:
 					t.Stop()
 
-					// Try initializing remaining storage pools in random order.
+					// Try initializing remaining storage pools in mount order.
 					tryInstancesStart := false
-					for poolName := range initPools {
+					for _, poolName := range poolNames {
+						_, found := initPools[poolName]
+						if !found {
+							continue
+						}
+
 						if initPool(poolName) {
 							// Storage pool initialized successfully or deleted so
 							// remove it from the list so its not retried.
@@ -150,6 +160,74 @@ func storageStartup(s *state.State, forceCheck bool) error {
 	return nil
 }
 
+// storagePoolsMountOrder returns the given storage pools ordered so that pools backing other pools come first.
+// A pool is considered to depend on another one when its source is located within the mount path or the source
+// directory of that other pool. Pools without dependencies keep their original order.
+func storagePoolsMountOrder(s *state.State, poolNames []string) []string {
+	isWithin := func(path string, parent string) bool {
+		if path == "" || parent == "" || !filepath.IsAbs(path) || !filepath.IsAbs(parent) {
+			return false
+		}
+
+		path = filepath.Clean(path)
+		parent = filepath.Clean(parent)
+
+		return path != parent && strings.HasPrefix(path, parent+"/")
+	}
+
+	sources := make(map[string]string, len(poolNames))
+	for _, poolName := range poolNames {
+		pool, err := storagePools.LoadByName(s, poolName)
+		if err != nil {
+			continue
+		}
+
+		sources[poolName] = pool.Driver().Config()["source"]
+	}
+
+	// Find the pools each pool depends on.
+	dependencies := make(map[string][]string, len(poolNames))
+	for _, poolName := range poolNames {
+		for _, backerName := range poolNames {
+			if backerName == poolName {
+				continue
+			}
+
+			if isWithin(sources[poolName], storageDrivers.GetPoolMountPath(backerName)) || isWithin(sources[poolName], sources[backerName]) {
+				dependencies[poolName] = append(dependencies[poolName], backerName)
+			}
+		}
+	}
+
+	ordered := make([]string, 0, len(poolNames))
+	visited := make(map[string]bool, len(poolNames))
+
+	var visit func(poolName string, path []string)
+	visit = func(poolName string, path []string) {
+		if visited[poolName] {
+			return
+		}
+
+		// Ignore dependency loops, the pools involved are then ordered as listed.
+		if util.ValueInSlice(poolName, path) {
+			return
+		}
+
+		for _, backerName := range dependencies[poolName] {
+			visit(backerName, append(path, poolName))
+		}
+
+		visited[poolName] = true
+		ordered = append(ordered, poolName)
+	}
+
+	for _, poolName := range poolNames {
+		visit(poolName, nil)
+	}
+
+	return ordered
+}
+
 func storagePoolDriversCacheUpdate(s *state.State) {
 	// Get a list of all storage drivers currently in use
 	// on this server. Only do this when we do not already have done
@@ -549,6 +549,14 @@ func storagePoolVolumesTypePost(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	// Check that the requesting project can place volumes on the pool.
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return project.AllowStoragePoolUse(tx, projectParam(r), poolName)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	resp := forwardedResponseIfTargetIsRemote(s, r)
 	if resp != nil {
 		return resp
@@ -668,7 +676,10 @@ func doCustomVolumeRefresh(s *state.State, r *http.Request, requestProjectName s
 		return nil
 	}
 
-	op, err := operations.OperationCreate(s, requestProjectName, operations.OperationClassTask, operationtype.VolumeCopy, nil, nil, run, nil, nil, r)
+	// Transfers between pools stop by themselves once the operation is cancelled.
+	cancel := func(op *operations.Operation) error { return nil }
+
+	op, err := operations.OperationCreate(s, requestProjectName, operations.OperationClassTask, operationtype.VolumeCopy, nil, nil, run, cancel, nil, r)
 	if err != nil {
 		return response.InternalError(err)
 	}
@@ -716,7 +727,10 @@ func doVolumeCreateOrCopy(s *state.State, r *http.Request, requestProjectName st
 	}
 
 	// Volume copy operations potentially take a long time, so run as an async operation.
-	op, err := operations.OperationCreate(s, requestProjectName, operations.OperationClassTask, operationtype.VolumeCopy, nil, nil, run, nil, nil, r)
+	// Transfers between pools stop by themselves once the operation is cancelled.
+	cancel := func(op *operations.Operation) error { return nil }
+
+	op, err := operations.OperationCreate(s, requestProjectName, operations.OperationClassTask, operationtype.VolumeCopy, nil, nil, run, cancel, nil, r)
 	if err != nil {
 		return response.InternalError(err)
 	}
@@ -831,7 +845,8 @@ func storagePoolVolumesPost(d *Daemon, r *http.Request) response.Response {
 			return api.StatusErrorf(http.StatusConflict, "Volume by that name already exists")
 		}
 
-		return nil
+		// Check that the requesting project can place volumes on the pool.
+		return project.AllowStoragePoolUse(tx, projectParam(r), poolName)
 	})
 	if err != nil {
 		return response.SmartError(err)
@@ -1159,6 +1174,24 @@ func storagePoolVolumePost(d *Daemon, r *http.Request) response.Response {
 		return storagePoolVolumeTypePostRename(s, r, srcPoolName, projectName, &dbVolume.StorageVolume, req)
 	}
 
+	// Check that the requesting target project can place volumes on the target pool.
+	targetPoolName := srcPoolName
+	if req.Pool != "" {
+		targetPoolName = req.Pool
+	}
+
+	targetRequestProjectName := projectParam(r)
+	if req.Project != "" {
+		targetRequestProjectName = req.Project
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return project.AllowStoragePoolUse(tx, targetRequestProjectName, targetPoolName)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	// Otherwise this is a move request.
 	return storagePoolVolumeTypePostMove(s, r, srcPoolName, projectName, targetProjectName, &dbVolume.StorageVolume, req)
 }
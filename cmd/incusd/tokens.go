@@ -2,8 +2,11 @@ package main
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/lxc/incus/internal/server/cluster"
+	"github.com/lxc/incus/internal/server/db"
 	"github.com/lxc/incus/internal/server/db/operationtype"
 	"github.com/lxc/incus/internal/server/operations"
 	"github.com/lxc/incus/internal/server/state"
@@ -66,9 +69,44 @@ func autoRemoveExpiredTokens(ctx context.Context, s *state.State) {
 	logger.Debug("Done removing expired tokens")
 }
 
+// autoRemoveExpiredJoinTokenUses removes the recorded uses of the expired cluster join tokens.
+// This only runs on the leader as the records are shared by the whole cluster.
+func autoRemoveExpiredJoinTokenUses(ctx context.Context, d *Daemon) {
+	s := d.State()
+
+	leader, err := d.gateway.LeaderAddress()
+	if err != nil {
+		if !errors.Is(err, cluster.ErrNodeIsNotClustered) {
+			logger.Error("Failed to get leader cluster member address", logger.Ctx{"err": err})
+		}
+
+		return
+	}
+
+	if s.LocalConfig.ClusterAddress() != leader {
+		return
+	}
+
+	var removed int64
+	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		removed, err = tx.DeleteExpiredClusterJoinTokenUses(ctx, time.Now())
+
+		return err
+	})
+	if err != nil {
+		logger.Error("Failed removing expired cluster join token uses", logger.Ctx{"err": err})
+		return
+	}
+
+	if removed > 0 {
+		logger.Debug("Removed expired cluster join token uses", logger.Ctx{"removed": removed})
+	}
+}
+
 func autoRemoveExpiredTokensTask(d *Daemon) (task.Func, task.Schedule) {
 	f := func(ctx context.Context) {
 		autoRemoveExpiredTokens(ctx, d.State())
+		autoRemoveExpiredJoinTokenUses(ctx, d)
 	}
 
 	return f, task.Every(time.Minute)
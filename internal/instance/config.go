@@ -17,6 +17,11 @@ func IsUserConfig(key string) bool {
 	return strings.HasPrefix(key, "user.")
 }
 
+// IsGuestAPIConfig returns true if the config key is exposed to the instance through the guest API.
+func IsGuestAPIConfig(key string) bool {
+	return strings.HasPrefix(key, "user.") || strings.HasPrefix(key, "cloud-init.")
+}
+
 // ConfigVolatilePrefix indicates the prefix used for volatile config keys.
 const ConfigVolatilePrefix = "volatile."
 
@@ -154,6 +159,17 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: What to do when evacuating the instance
 	"cluster.evacuate": validate.Optional(validate.IsOneOf("auto", "migrate", "live-migrate", "stop")),
 
+	// gendoc:generate(entity=instance, group=miscellaneous, key=console.log_size)
+	// Amount of console output kept for the instance, the oldest output being discarded first.
+	// For containers, the value is rounded up to the next power of two.
+	// See {ref}`instances-console-log`.
+	// ---
+	//  type: string
+	//  defaultdesc: {config:option}`server-miscellaneous:instances.console.log_size`
+	//  liveupdate: no
+	//  shortdesc: Size of the console log
+	"console.log_size": validate.Optional(validate.IsSize),
+
 	// gendoc:generate(entity=instance, group=resource-limits, key=limits.cpu)
 	// A number or a specific range of CPUs to expose to the instance.
 	//
@@ -719,6 +735,27 @@ var InstanceConfigKeysContainer = map[string]func(value string) error{
 	//  shortdesc: List of syscalls to deny
 	"security.syscalls.deny": validate.IsAny,
 
+	// gendoc:generate(entity=instance, group=security, key=security.syscalls.intercept.audit)
+	// When enabled, each intercepted system call is reported through the `syscall` event type, along with its arguments and the decision taken on it.
+	// See {ref}`syscall-audit`.
+	// ---
+	//  type: bool
+	//  defaultdesc: `false`
+	//  liveupdate: yes
+	//  condition: container
+	//  shortdesc: Whether to report intercepted system calls as events
+	"security.syscalls.intercept.audit": validate.Optional(validate.IsBool),
+
+	// gendoc:generate(entity=instance, group=security, key=security.syscalls.intercept.audit.limit)
+	// Audit events above this limit are dropped, the next event sent reports how many were dropped.
+	// ---
+	//  type: integer
+	//  defaultdesc: `60`
+	//  liveupdate: yes
+	//  condition: container
+	//  shortdesc: Maximum number of audit events per minute
+	"security.syscalls.intercept.audit.limit": validate.Optional(validate.IsUint32),
+
 	// gendoc:generate(entity=instance, group=security, key=security.syscalls.intercept.bpf)
 	//
 	// ---
@@ -787,6 +824,17 @@ var InstanceConfigKeysContainer = map[string]func(value string) error{
 	//  shortdesc: Whether to mount `shiftfs` on top of file systems handled through mount syscall interception
 	"security.syscalls.intercept.mount.shift": validate.Optional(validate.IsBool),
 
+	// gendoc:generate(entity=instance, group=security, key=security.syscalls.intercept.profiles)
+	// Specify a comma-separated list of system call interception profiles to apply to the instance.
+	// Each profile enables a set of `security.syscalls.intercept.*` options, see {ref}`syscall-handling-profiles`.
+	// Options set directly on the instance take precedence over the profiles.
+	// ---
+	//  type: string
+	//  liveupdate: no
+	//  condition: container
+	//  shortdesc: System call interception profiles to apply
+	"security.syscalls.intercept.profiles": validate.IsAny,
+
 	// gendoc:generate(entity=instance, group=security, key=security.syscalls.intercept.sched_setcheduler)
 	// This system call allows increasing process priority.
 	// ---
@@ -0,0 +1,62 @@
+package instance
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/lxc/incus/shared/validate"
+)
+
+// ValidName validates a (non-snapshot) instance name.
+// The name must be a valid DNS label (as it's used in the DNS records of the instance) and can't contain the
+// snapshot delimiter nor the "_" separator used in project prefixed names. It must also fully match each of
+// the non-empty name patterns supplied (as set in the server and project configuration).
+func ValidName(name string, patterns ...string) error {
+	if strings.Contains(name, SnapshotDelimiter) {
+		return fmt.Errorf("The character %q is reserved for snapshots", SnapshotDelimiter)
+	}
+
+	if strings.Contains(name, "_") {
+		return fmt.Errorf("The character %q is reserved for project prefixed names", "_")
+	}
+
+	err := validate.IsHostname(name)
+	if err != nil {
+		return fmt.Errorf("Invalid instance name: %w", err)
+	}
+
+	for _, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+
+		re, err := compileNamePattern(pattern)
+		if err != nil {
+			return err
+		}
+
+		if !re.MatchString(name) {
+			return fmt.Errorf("Invalid instance name: %q doesn't match the naming pattern %q", name, pattern)
+		}
+	}
+
+	return nil
+}
+
+// ValidNamePattern validates an instance name pattern.
+func ValidNamePattern(pattern string) error {
+	_, err := compileNamePattern(pattern)
+
+	return err
+}
+
+// compileNamePattern compiles an instance name pattern, anchored so that it has to match the whole name.
+func compileNamePattern(pattern string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("Invalid instance name pattern %q: %w", pattern, err)
+	}
+
+	return re, nil
+}
@@ -0,0 +1,68 @@
+package instance_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/internal/instance"
+)
+
+func TestValidName(t *testing.T) {
+	cases := []struct {
+		name     string
+		patterns []string
+		valid    bool
+	}{
+		{name: "c1", valid: true},
+		{name: "web-01", valid: true},
+		{name: "c1", patterns: []string{""}, valid: true},
+		{name: "web-01", patterns: []string{"web-[0-9]+"}, valid: true},
+		{name: "web-01", patterns: []string{"[a-z]+-[0-9]+", "web-.*"}, valid: true},
+		{name: "", valid: false},
+		{name: "c1/snap0", valid: false},
+		{name: "default_c1", valid: false},
+		{name: "-c1", valid: false},
+		{name: "123", valid: false},
+		{name: "c1-", valid: false},
+		{name: "c1.example", valid: false},
+		{name: "web-01", patterns: []string{"db-[0-9]+"}, valid: false},
+		{name: "web-01a", patterns: []string{"web-[0-9]+"}, valid: false},
+		{name: "xweb-01", patterns: []string{"web-[0-9]+"}, valid: false},
+		{name: "web-01", patterns: []string{"web-[0-9]+", "db-.*"}, valid: false},
+		{name: "web-01", patterns: []string{"web-("}, valid: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := instance.ValidName(c.name, c.patterns...)
+			if c.valid {
+				assert.NoError(t, err, "patterns %v", c.patterns)
+			} else {
+				assert.Error(t, err, "patterns %v", c.patterns)
+			}
+		})
+	}
+}
+
+func TestValidNamePattern(t *testing.T) {
+	cases := map[string]bool{
+		"web-[0-9]+":      true,
+		"(web|db)-[a-z]+": true,
+		".*":              true,
+		"web-(":           false,
+		"[a-z":            false,
+		"a{2,1}":          false,
+	}
+
+	for pattern, valid := range cases {
+		t.Run(pattern, func(t *testing.T) {
+			err := instance.ValidNamePattern(pattern)
+			if valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package io
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// RateLimitWriter limits the rate at which data gets written to the wrapped writer.
+type RateLimitWriter struct {
+	writer io.Writer
+	limit  int64
+	start  time.Time
+	n      int64
+}
+
+// NewRateLimitWriter returns a new RateLimitWriter wrapping the given writer.
+//
+// The limit is in bytes per second. If the given limit isn't positive, then no limit is applied.
+func NewRateLimitWriter(writer io.Writer, limit int64) *RateLimitWriter {
+	return &RateLimitWriter{
+		writer: writer,
+		limit:  limit,
+	}
+}
+
+// Write implements the Writer interface.
+func (w *RateLimitWriter) Write(p []byte) (int, error) {
+	if w.limit <= 0 {
+		return w.writer.Write(p)
+	}
+
+	if w.start.IsZero() {
+		w.start = time.Now()
+	}
+
+	n, err := w.writer.Write(p)
+	w.n += int64(n)
+
+	// Wait until the average rate since the first write is back under the limit.
+	expected := time.Duration(float64(w.n) / float64(w.limit) * float64(time.Second))
+	elapsed := time.Since(w.start)
+	if expected > elapsed {
+		time.Sleep(expected - elapsed)
+	}
+
+	return n, err
+}
+
+// Seek implements the Seeker interface, provided that the wrapped writer supports it.
+func (w *RateLimitWriter) Seek(offset int64, whence int) (int64, error) {
+	seeker, ok := w.writer.(io.Seeker)
+	if !ok {
+		return 0, fmt.Errorf("Writer doesn't support seeking")
+	}
+
+	return seeker.Seek(offset, whence)
+}
@@ -5,6 +5,7 @@ import (
 	"net"
 	"os"
 	"strconv"
+	"time"
 
 	"golang.org/x/sys/unix"
 )
@@ -50,6 +51,54 @@ func GetSystemdListeners(start int) []net.Listener {
 	return listeners
 }
 
+// SystemdNotify sends a state notification (such as "READY=1") to the service manager.
+// Does nothing if the process wasn't started with a notification socket.
+func SystemdNotify(state string) error {
+	socketPath := os.Getenv("NOTIFY_SOCKET")
+	if socketPath == "" {
+		return nil
+	}
+
+	// Abstract sockets are advertised with a leading '@'.
+	if socketPath[0] == '@' {
+		socketPath = "\x00" + socketPath[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	if err != nil {
+		return fmt.Errorf("Failed connecting to notification socket: %w", err)
+	}
+
+	defer func() { _ = conn.Close() }()
+
+	_, err = conn.Write([]byte(state))
+	if err != nil {
+		return fmt.Errorf("Failed sending notification %q: %w", state, err)
+	}
+
+	return nil
+}
+
+// SystemdWatchdogInterval returns the watchdog interval requested by the service manager.
+// Returns 0 if the watchdog isn't enabled for this process.
+func SystemdWatchdogInterval() time.Duration {
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0
+	}
+
+	// The watchdog may be meant for another process of the service.
+	watchdogPID := os.Getenv("WATCHDOG_PID")
+	if watchdogPID != "" {
+		pid, err := strconv.Atoi(watchdogPID)
+		if err != nil || pid != os.Getpid() {
+			return 0
+		}
+	}
+
+	return time.Duration(usec) * time.Microsecond
+}
+
 // SystemdListenFDsStart is the number of the first file descriptor that might
 // have been opened by systemd when socket activation is enabled. It's always 3
 // in real-world usage (i.e. the first file descriptor opened after stdin,
@@ -19,6 +19,7 @@ import (
 	"github.com/lxc/incus/internal/server/state"
 	internalUtil "github.com/lxc/incus/internal/util"
 	"github.com/lxc/incus/shared/logger"
+	localtls "github.com/lxc/incus/shared/tls"
 	"github.com/lxc/incus/shared/util"
 )
 
@@ -38,6 +39,29 @@ func certificateNeedsUpdate(domain string, cert *x509.Certificate) bool {
 	return !util.ValueInSlice(domain, cert.DNSNames) || time.Now().After(cert.NotAfter.Add(-30*24*time.Hour))
 }
 
+// certificateKeyType returns the ACME key type matching the configured key parameters.
+// Without any configured key parameter, RSA 2048 keys are used.
+func certificateKeyType(keyParams localtls.KeyParams) certcrypto.KeyType {
+	if keyParams == (localtls.KeyParams{}) {
+		return certcrypto.RSA2048
+	}
+
+	keyParams = keyParams.Resolved()
+
+	switch {
+	case keyParams.Type == localtls.KeyTypeECDSA && keyParams.Size == 256:
+		return certcrypto.EC256
+	case keyParams.Type == localtls.KeyTypeECDSA:
+		return certcrypto.EC384
+	case keyParams.Size == 2048:
+		return certcrypto.RSA2048
+	case keyParams.Size == 4096:
+		return certcrypto.RSA4096
+	default:
+		return certcrypto.RSA3072
+	}
+}
+
 // UpdateCertificate updates the certificate.
 func UpdateCertificate(s *state.State, provider HTTP01Provider, clustered bool, domain string, email string, caURL string, force bool) (*certificate.Resource, error) {
 	clusterCertFilename := internalUtil.VarPath(ClusterCertFilename)
@@ -118,7 +142,7 @@ func UpdateCertificate(s *state.State, provider HTTP01Provider, clustered bool,
 		config.CADirURL = "https://acme-v02.api.letsencrypt.org/directory"
 	}
 
-	config.Certificate.KeyType = certcrypto.RSA2048
+	config.Certificate.KeyType = certificateKeyType(s.LocalConfig.CertificateKey())
 
 	client, err := lego.NewClient(config)
 	if err != nil {
@@ -5,7 +5,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-acme/lego/v4/certcrypto"
 	"github.com/stretchr/testify/require"
+
+	localtls "github.com/lxc/incus/shared/tls"
 )
 
 func Test_certificateNeedsUpdate(t *testing.T) {
@@ -72,3 +75,20 @@ func Test_certificateNeedsUpdate(t *testing.T) {
 		})
 	}
 }
+
+func Test_certificateKeyType(t *testing.T) {
+	tests := []struct {
+		keyParams localtls.KeyParams
+		want      certcrypto.KeyType
+	}{
+		{localtls.KeyParams{}, certcrypto.RSA2048},
+		{localtls.KeyParams{Type: localtls.KeyTypeECDSA}, certcrypto.EC384},
+		{localtls.KeyParams{Size: 256}, certcrypto.EC256},
+		{localtls.KeyParams{Type: localtls.KeyTypeRSA}, certcrypto.RSA3072},
+		{localtls.KeyParams{Type: localtls.KeyTypeRSA, Size: 4096}, certcrypto.RSA4096},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, certificateKeyType(tt.keyParams))
+	}
+}
@@ -0,0 +1,92 @@
+package acme
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/shared/subprocess"
+	localtls "github.com/lxc/incus/shared/tls"
+)
+
+// Stages of the certificate renewal at which the renewal hook is run.
+const (
+	// HookStagePre is when the new certificate was issued but isn't in use yet.
+	HookStagePre = "pre"
+
+	// HookStagePost is when the new certificate is in use.
+	HookStagePost = "post"
+)
+
+// Failure policies of the renewal hook.
+const (
+	// HookFailureDefer postpones the use of the new certificate to the next renewal check if the pre-renewal hook fails.
+	HookFailureDefer = "defer"
+
+	// HookFailureRollback also restores the previous certificate if the post-renewal hook fails.
+	HookFailureRollback = "rollback"
+
+	// HookFailureIgnore only logs hook failures.
+	HookFailureIgnore = "ignore"
+)
+
+// hookTimeout is the maximum time given to the renewal hook to complete.
+const hookTimeout = 5 * time.Minute
+
+// hookRequest is the body sent to a renewal webhook.
+type hookRequest struct {
+	Stage       string `json:"stage"`
+	Fingerprint string `json:"fingerprint"`
+	Certificate string `json:"certificate"`
+}
+
+// IsWebhook returns whether the renewal hook is a webhook URL rather than a command.
+func IsWebhook(hook string) bool {
+	return strings.HasPrefix(hook, "http://") || strings.HasPrefix(hook, "https://")
+}
+
+// RunHook runs the renewal hook for the given stage of the renewal of a certificate.
+// Commands are passed the stage and the certificate fingerprint as arguments and the PEM encoded certificate on
+// stdin. Webhooks receive the same information as a JSON POST request and must reply with a 2xx status code.
+func RunHook(ctx context.Context, hook string, stage string, cert []byte) error {
+	fingerprint, err := localtls.CertFingerprintStr(string(cert))
+	if err != nil {
+		return fmt.Errorf("Failed getting certificate fingerprint: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, hookTimeout)
+	defer cancel()
+
+	if !IsWebhook(hook) {
+		return subprocess.RunCommandWithFds(ctx, bytes.NewReader(cert), nil, hook, stage, fingerprint)
+	}
+
+	body, err := json.Marshal(hookRequest{Stage: stage, Fingerprint: fingerprint, Certificate: string(cert)})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("Webhook returned %q", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,370 @@
+package auditlog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/lxc/incus/shared/api"
+	"github.com/lxc/incus/shared/logger"
+)
+
+// Supported audit log formats.
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Config is the configuration of an audit log.
+type Config struct {
+	// Path of the audit log file.
+	Path string
+
+	// Format of the entries (json or text).
+	Format string
+
+	// Size above which the file is rotated (0 to disable).
+	RotateSize int64
+
+	// Interval after which the file is rotated (0 to disable).
+	RotateInterval time.Duration
+
+	// Number of rotated files to keep (0 to keep them all).
+	MaxFiles int64
+}
+
+// queueSize is the number of lifecycle events which can wait to be written to the audit log.
+const queueSize = 1024
+
+// Writer appends lifecycle events to an audit log file.
+type Writer struct {
+	cfg Config
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	stopped  bool
+
+	// Events received from the event listener, written one at a time by a single goroutine.
+	// As the listener calls its handlers concurrently, events sent at nearly the same time may be written in a
+	// different order than their timestamps.
+	queue    chan api.Event
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewWriter returns a Writer appending to the audit log file described by cfg.
+func NewWriter(cfg Config) (*Writer, error) {
+	if !filepath.IsAbs(cfg.Path) {
+		return nil, fmt.Errorf("The audit log path must be absolute")
+	}
+
+	if cfg.Format == "" {
+		cfg.Format = FormatJSON
+	}
+
+	if cfg.Format != FormatJSON && cfg.Format != FormatText {
+		return nil, fmt.Errorf("Unsupported audit log format %q", cfg.Format)
+	}
+
+	w := &Writer{
+		cfg:   cfg,
+		queue: make(chan api.Event, queueSize),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+
+	err := w.open()
+	if err != nil {
+		return nil, err
+	}
+
+	go w.run()
+
+	return w, nil
+}
+
+// run writes the queued events until the writer is stopped, then writes those still queued.
+func (w *Writer) run() {
+	defer close(w.done)
+
+	for {
+		select {
+		case event := <-w.queue:
+			w.writeEvent(event)
+		case <-w.stop:
+			for {
+				select {
+				case event := <-w.queue:
+					w.writeEvent(event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// writeEvent writes the event to the audit log, logging any failure.
+func (w *Writer) writeEvent(event api.Event) {
+	err := w.Write(event)
+	if err != nil {
+		logger.Error("Failed recording event in the audit log", logger.Ctx{"path": w.cfg.Path, "err": err})
+	}
+}
+
+// checkFile returns an error if the file at path isn't a regular file owned by the current user, as such a file
+// mustn't be appended to or rotated. It returns fs.ErrNotExist if there's no file at path.
+func checkFile(path string) (fs.FileInfo, error) {
+	st, err := os.Lstat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !st.Mode().IsRegular() {
+		return nil, fmt.Errorf("%q isn't a regular file", path)
+	}
+
+	sys, ok := st.Sys().(*syscall.Stat_t)
+	if ok && int(sys.Uid) != os.Getuid() {
+		return nil, fmt.Errorf("%q is owned by another user", path)
+	}
+
+	return st, nil
+}
+
+// open opens the audit log file for appending.
+func (w *Writer) open() error {
+	err := os.MkdirAll(filepath.Dir(w.cfg.Path), 0700)
+	if err != nil {
+		return fmt.Errorf("Failed creating audit log directory: %w", err)
+	}
+
+	_, err = checkFile(w.cfg.Path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("Failed opening audit log: %w", err)
+	}
+
+	f, err := os.OpenFile(w.cfg.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE|syscall.O_NOFOLLOW, 0600)
+	if err != nil {
+		return fmt.Errorf("Failed opening audit log: %w", err)
+	}
+
+	st, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("Failed opening audit log: %w", err)
+	}
+
+	if !st.Mode().IsRegular() {
+		_ = f.Close()
+		return fmt.Errorf("Failed opening audit log: %q isn't a regular file", w.cfg.Path)
+	}
+
+	w.file = f
+	w.size = st.Size()
+	w.openedAt = time.Now()
+
+	return nil
+}
+
+// rotate renames the current file with a numerical suffix, shifting the previously rotated files, removes those
+// past the maximum number of files and opens a new file.
+// Only the file being written to and rotated files which are regular files owned by the current user are renamed
+// or removed, anything else found in their place stops the rotation.
+func (w *Writer) rotate() error {
+	current, err := w.file.Stat()
+	if err != nil {
+		return err
+	}
+
+	st, err := checkFile(w.cfg.Path)
+	if err != nil {
+		return err
+	}
+
+	if !os.SameFile(current, st) {
+		return fmt.Errorf("%q was replaced by another file", w.cfg.Path)
+	}
+
+	// Count the previously rotated files.
+	rotated := 0
+	for {
+		path := fmt.Sprintf("%s.%d", w.cfg.Path, rotated+1)
+
+		_, err := checkFile(path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				break
+			}
+
+			return err
+		}
+
+		rotated++
+	}
+
+	err = w.file.Close()
+	if err != nil {
+		return err
+	}
+
+	w.file = nil
+
+	// Shift the rotated files, starting from the oldest one.
+	for i := rotated; i > 0; i-- {
+		path := fmt.Sprintf("%s.%d", w.cfg.Path, i)
+
+		if w.cfg.MaxFiles > 0 && int64(i) >= w.cfg.MaxFiles {
+			err = os.Remove(path)
+		} else {
+			err = os.Rename(path, fmt.Sprintf("%s.%d", w.cfg.Path, i+1))
+		}
+
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	err = os.Rename(w.cfg.Path, w.cfg.Path+".1")
+	if err != nil {
+		return err
+	}
+
+	return w.open()
+}
+
+// format renders the lifecycle event as an audit log line.
+func (w *Writer) format(event api.Event, lifecycle api.EventLifecycle) ([]byte, error) {
+	if w.cfg.Format == FormatJSON {
+		line, err := json.Marshal(event)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(line, '\n'), nil
+	}
+
+	fields := []string{
+		event.Timestamp.UTC().Format(time.RFC3339Nano),
+		fmt.Sprintf("location=%q", event.Location),
+		fmt.Sprintf("project=%q", lifecycle.Project),
+		fmt.Sprintf("action=%q", lifecycle.Action),
+		fmt.Sprintf("source=%q", lifecycle.Source),
+	}
+
+	if lifecycle.Requestor != nil {
+		fields = append(fields,
+			fmt.Sprintf("requestor-username=%q", lifecycle.Requestor.Username),
+			fmt.Sprintf("requestor-protocol=%q", lifecycle.Requestor.Protocol),
+			fmt.Sprintf("requestor-address=%q", lifecycle.Requestor.Address),
+		)
+	}
+
+	if len(lifecycle.Context) > 0 {
+		context, err := json.Marshal(lifecycle.Context)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, fmt.Sprintf("context=%q", context))
+	}
+
+	return []byte(strings.Join(fields, " ") + "\n"), nil
+}
+
+// Write appends the lifecycle event to the audit log and syncs it to disk, rotating the file beforehand if needed.
+func (w *Writer) Write(event api.Event) error {
+	if event.Type != api.EventTypeLifecycle {
+		return nil
+	}
+
+	lifecycle := api.EventLifecycle{}
+	err := json.Unmarshal(event.Metadata, &lifecycle)
+	if err != nil {
+		return fmt.Errorf("Failed parsing lifecycle event: %w", err)
+	}
+
+	line, err := w.format(event, lifecycle)
+	if err != nil {
+		return fmt.Errorf("Failed formatting lifecycle event: %w", err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.stopped {
+		return nil
+	}
+
+	if w.file == nil {
+		err = w.open()
+		if err != nil {
+			return err
+		}
+	}
+
+	if w.size > 0 && ((w.cfg.RotateSize > 0 && w.size+int64(len(line)) > w.cfg.RotateSize) || (w.cfg.RotateInterval > 0 && time.Since(w.openedAt) >= w.cfg.RotateInterval)) {
+		err = w.rotate()
+		if err != nil {
+			return fmt.Errorf("Failed rotating audit log: %w", err)
+		}
+	}
+
+	n, err := w.file.Write(line)
+	w.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("Failed writing audit log: %w", err)
+	}
+
+	err = w.file.Sync()
+	if err != nil {
+		return fmt.Errorf("Failed syncing audit log: %w", err)
+	}
+
+	return nil
+}
+
+// HandleEvent queues the event received from the internal event listener, which calls its handlers concurrently,
+// so that the events are written one at a time.
+func (w *Writer) HandleEvent(event api.Event) {
+	if event.Type != api.EventTypeLifecycle {
+		return
+	}
+
+	select {
+	case <-w.stop:
+		return
+	default:
+	}
+
+	select {
+	case w.queue <- event:
+	case <-w.stop:
+	}
+}
+
+// Stop writes the queued events and closes the audit log file, events received afterwards are ignored.
+func (w *Writer) Stop() {
+	w.stopOnce.Do(func() { close(w.stop) })
+	<-w.done
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.stopped = true
+
+	if w.file != nil {
+		_ = w.file.Close()
+		w.file = nil
+	}
+}
@@ -0,0 +1,139 @@
+package auditlog
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/shared/api"
+)
+
+func lifecycleEvent(t *testing.T, action string) api.Event {
+	metadata, err := json.Marshal(api.EventLifecycle{Action: action, Source: "/1.0/instances/c1", Project: "default"})
+	require.NoError(t, err)
+
+	return api.Event{
+		Type:      api.EventTypeLifecycle,
+		Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Metadata:  metadata,
+		Location:  "server01",
+	}
+}
+
+func TestWriter_Text(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	w, err := NewWriter(Config{Path: path, Format: FormatText})
+	require.NoError(t, err)
+
+	require.NoError(t, w.Write(lifecycleEvent(t, "instance-started")))
+	require.NoError(t, w.Write(api.Event{Type: api.EventTypeLogging}))
+	w.Stop()
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `2024-01-02T03:04:05Z location="server01" project="default" action="instance-started" source="/1.0/instances/c1"`+"\n", string(content))
+}
+
+func TestWriter_Rotate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	w, err := NewWriter(Config{Path: path, RotateSize: 10, MaxFiles: 2})
+	require.NoError(t, err)
+
+	for _, action := range []string{"instance-created", "instance-started", "instance-stopped", "instance-deleted"} {
+		require.NoError(t, w.Write(lifecycleEvent(t, action)))
+	}
+
+	w.Stop()
+
+	// Each entry is bigger than the rotation size so is written to its own file, only two rotated files are kept.
+	for file, action := range map[string]string{path: "instance-deleted", path + ".1": "instance-stopped", path + ".2": "instance-started"} {
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, 1, strings.Count(string(content), "\n"))
+		assert.Contains(t, string(content), action)
+	}
+
+	assert.NoFileExists(t, path+".3")
+}
+
+func TestWriter_HandleEvent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	w, err := NewWriter(Config{Path: path})
+	require.NoError(t, err)
+
+	actions := []string{"instance-created", "instance-started", "instance-stopped", "instance-deleted"}
+	for _, action := range actions {
+		w.HandleEvent(lifecycleEvent(t, action))
+		w.HandleEvent(api.Event{Type: api.EventTypeLogging})
+	}
+
+	// Stopping the writer writes the queued events first, those received afterwards are ignored.
+	w.Stop()
+	w.HandleEvent(lifecycleEvent(t, "instance-restarted"))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	require.Len(t, lines, len(actions))
+
+	for i, line := range lines {
+		event := api.Event{}
+		require.NoError(t, json.Unmarshal([]byte(line), &event))
+
+		lifecycle := api.EventLifecycle{}
+		require.NoError(t, json.Unmarshal(event.Metadata, &lifecycle))
+		assert.Equal(t, actions[i], lifecycle.Action)
+	}
+}
+
+func TestNewWriter_NotRegular(t *testing.T) {
+	dir := t.TempDir()
+
+	// A directory or a symlink at the audit log path isn't written to.
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "dir.log"), 0700))
+	_, err := NewWriter(Config{Path: filepath.Join(dir, "dir.log")})
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "target"), nil, 0600))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "target"), filepath.Join(dir, "link.log")))
+	_, err = NewWriter(Config{Path: filepath.Join(dir, "link.log")})
+	assert.Error(t, err)
+}
+
+func TestWriter_RotateReplaced(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+
+	w, err := NewWriter(Config{Path: path, RotateSize: 10})
+	require.NoError(t, err)
+	defer w.Stop()
+
+	require.NoError(t, w.Write(lifecycleEvent(t, "instance-created")))
+
+	// Replace the audit log with a symlink to another file, which mustn't be rotated.
+	target := filepath.Join(dir, "target")
+	require.NoError(t, os.WriteFile(target, []byte("content"), 0600))
+	require.NoError(t, os.Remove(path))
+	require.NoError(t, os.Symlink(target, path))
+
+	assert.Error(t, w.Write(lifecycleEvent(t, "instance-started")))
+
+	st, err := os.Lstat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.ModeSymlink, st.Mode().Type())
+	assert.NoFileExists(t, path+".1")
+
+	content, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+}
@@ -0,0 +1,488 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lxc/incus/internal/server/request"
+	"github.com/lxc/incus/shared/logger"
+)
+
+// openfgaServerObject is the object used for server-wide relations (admin access).
+const openfgaServerObject = "server:incus"
+
+// openfgaCacheMaxEntries is the maximum number of cached decisions.
+const openfgaCacheMaxEntries = 10000
+
+// openfgaMaxTuplesPerWrite is the maximum number of tuples OpenFGA accepts in a single write request.
+const openfgaMaxTuplesPerWrite = 100
+
+type openfga struct {
+	commonAuthorizer
+
+	tls tls
+
+	apiURL     *url.URL
+	apiToken   string
+	storeID    string
+	modelID    string
+	failClosed bool
+	cacheTTL   time.Duration
+
+	// tlsRestrictions is whether the restrictions of TLS clients still apply to remote users on top of the
+	// OpenFGA decisions.
+	tlsRestrictions bool
+
+	client *http.Client
+
+	cache   map[string]openfgaCacheEntry
+	cacheMu sync.Mutex
+
+	projects   map[int64]string
+	projectsMu sync.Mutex
+}
+
+type openfgaCacheEntry struct {
+	allowed bool
+	expiry  time.Time
+}
+
+type openfgaTupleKey struct {
+	User     string `json:"user"`
+	Relation string `json:"relation"`
+	Object   string `json:"object"`
+}
+
+type openfgaCheckRequest struct {
+	TupleKey             openfgaTupleKey `json:"tuple_key"`
+	AuthorizationModelID string          `json:"authorization_model_id,omitempty"`
+	Context              map[string]any  `json:"context,omitempty"`
+}
+
+type openfgaCheckResponse struct {
+	Allowed bool `json:"allowed"`
+}
+
+type openfgaTupleKeys struct {
+	TupleKeys []openfgaTupleKey `json:"tuple_keys"`
+}
+
+type openfgaReadRequest struct {
+	TupleKey          openfgaTupleKey `json:"tuple_key"`
+	PageSize          int             `json:"page_size,omitempty"`
+	ContinuationToken string          `json:"continuation_token,omitempty"`
+}
+
+type openfgaTuple struct {
+	Key openfgaTupleKey `json:"key"`
+}
+
+type openfgaReadResponse struct {
+	Tuples            []openfgaTuple `json:"tuples"`
+	ContinuationToken string         `json:"continuation_token"`
+}
+
+type openfgaWriteRequest struct {
+	Writes               *openfgaTupleKeys `json:"writes,omitempty"`
+	Deletes              *openfgaTupleKeys `json:"deletes,omitempty"`
+	AuthorizationModelID string            `json:"authorization_model_id,omitempty"`
+}
+
+func (a *openfga) load() error {
+	getString := func(key string) string {
+		val, ok := a.config[key].(string)
+		if !ok {
+			return ""
+		}
+
+		return val
+	}
+
+	apiURL := getString("openfga.api.url")
+	if apiURL == "" {
+		return fmt.Errorf("Missing OpenFGA API URL")
+	}
+
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return fmt.Errorf("Invalid OpenFGA API URL: %w", err)
+	}
+
+	a.apiURL = u
+	a.apiToken = getString("openfga.api.token")
+	a.storeID = getString("openfga.store.id")
+	a.modelID = getString("openfga.store.model_id")
+
+	if a.storeID == "" {
+		return fmt.Errorf("Missing OpenFGA store ID")
+	}
+
+	a.failClosed = true
+	failClosed, ok := a.config["openfga.fail_closed"].(bool)
+	if ok {
+		a.failClosed = failClosed
+	}
+
+	a.tlsRestrictions = true
+	tlsRestrictions, ok := a.config["openfga.tls_restrictions"].(bool)
+	if ok {
+		a.tlsRestrictions = tlsRestrictions
+	}
+
+	cacheTTL, ok := a.config["openfga.cache.ttl"].(int64)
+	if ok {
+		a.cacheTTL = time.Duration(cacheTTL) * time.Second
+	}
+
+	a.client = &http.Client{Timeout: 10 * time.Second}
+	a.cache = map[string]openfgaCacheEntry{}
+	a.projects = map[int64]string{}
+
+	if a.projectsGetFunc != nil {
+		projects, err := a.projectsGetFunc()
+		if err != nil {
+			return fmt.Errorf("Failed to get projects: %w", err)
+		}
+
+		a.projects = projects
+
+		// Failing to reach OpenFGA shouldn't prevent the server from starting, the permission checks are
+		// subject to openfga.fail_closed anyway.
+		err = a.syncProjects()
+		if err != nil {
+			a.logger.Warn("Failed syncing projects with OpenFGA", logger.Ctx{"err": err})
+		}
+	}
+
+	return nil
+}
+
+// syncProjects makes the projects recorded in the OpenFGA store match the projects of this server.
+func (a *openfga) syncProjects() error {
+	existing := map[string]bool{}
+	readReq := openfgaReadRequest{
+		TupleKey: openfgaTupleKey{User: openfgaServerObject, Relation: "server", Object: "project:"},
+		PageSize: 100,
+	}
+
+	for {
+		resp := openfgaReadResponse{}
+		err := a.query(context.Background(), "read", readReq, &resp)
+		if err != nil {
+			return err
+		}
+
+		for _, tuple := range resp.Tuples {
+			existing[tuple.Key.Object] = true
+		}
+
+		if resp.ContinuationToken == "" {
+			break
+		}
+
+		readReq.ContinuationToken = resp.ContinuationToken
+	}
+
+	a.projectsMu.Lock()
+	var writes []openfgaTupleKey
+	for _, name := range a.projects {
+		tuple := a.projectTuple(name)
+		if existing[tuple.Object] {
+			delete(existing, tuple.Object)
+			continue
+		}
+
+		writes = append(writes, tuple)
+	}
+
+	a.projectsMu.Unlock()
+
+	// The remaining tuples are for projects which don't exist anymore.
+	var deletes []openfgaTupleKey
+	for object := range existing {
+		deletes = append(deletes, openfgaTupleKey{User: openfgaServerObject, Relation: "server", Object: object})
+	}
+
+	// Split the changes to stay within the write request limit.
+	for len(writes) > 0 || len(deletes) > 0 {
+		var batchWrites, batchDeletes []openfgaTupleKey
+
+		for len(batchWrites)+len(batchDeletes) < openfgaMaxTuplesPerWrite && (len(writes) > 0 || len(deletes) > 0) {
+			if len(writes) > 0 {
+				batchWrites = append(batchWrites, writes[0])
+				writes = writes[1:]
+			} else {
+				batchDeletes = append(batchDeletes, deletes[0])
+				deletes = deletes[1:]
+			}
+		}
+
+		err := a.writeTuples(batchWrites, batchDeletes)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddProject records the project as belonging to this server in the OpenFGA store.
+func (a *openfga) AddProject(projectID int64, name string) error {
+	err := a.writeTuples([]openfgaTupleKey{a.projectTuple(name)}, nil)
+	if err != nil {
+		return err
+	}
+
+	a.projectsMu.Lock()
+	a.projects[projectID] = name
+	a.projectsMu.Unlock()
+
+	return nil
+}
+
+// DeleteProject removes the project relationship from the OpenFGA store.
+func (a *openfga) DeleteProject(projectID int64) error {
+	a.projectsMu.Lock()
+	name, ok := a.projects[projectID]
+	a.projectsMu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	err := a.writeTuples(nil, []openfgaTupleKey{a.projectTuple(name)})
+	if err != nil {
+		return err
+	}
+
+	a.projectsMu.Lock()
+	delete(a.projects, projectID)
+	a.projectsMu.Unlock()
+
+	a.flushCache()
+
+	return nil
+}
+
+// RenameProject updates the project relationship in the OpenFGA store.
+func (a *openfga) RenameProject(projectID int64, newName string) error {
+	a.projectsMu.Lock()
+	oldName, ok := a.projects[projectID]
+	a.projectsMu.Unlock()
+
+	var deletes []openfgaTupleKey
+	if ok {
+		deletes = []openfgaTupleKey{a.projectTuple(oldName)}
+	}
+
+	err := a.writeTuples([]openfgaTupleKey{a.projectTuple(newName)}, deletes)
+	if err != nil {
+		return err
+	}
+
+	a.projectsMu.Lock()
+	a.projects[projectID] = newName
+	a.projectsMu.Unlock()
+
+	a.flushCache()
+
+	return nil
+}
+
+// StopStatusCheck clears the decision cache.
+func (a *openfga) StopStatusCheck() {
+	a.flushCache()
+}
+
+// UserAccess returns the server-wide access level of the user.
+func (a *openfga) UserAccess(username string) (*UserAccess, error) {
+	allowed, err := a.check(context.Background(), "user:"+username, "admin", openfgaServerObject, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserAccess{Admin: allowed}, nil
+}
+
+// UserIsAdmin checks whether the requestor is a global admin.
+func (a *openfga) UserIsAdmin(r *http.Request) bool {
+	tlsAllowed := a.tls.UserIsAdmin(r)
+	if !a.isRemote(r) || (a.tlsRestrictions && !tlsAllowed) {
+		return tlsAllowed
+	}
+
+	return a.userCheck(r, "", "admin", openfgaServerObject, tlsAllowed)
+}
+
+// UserHasPermission checks whether the requestor has a specific permission on a project.
+func (a *openfga) UserHasPermission(r *http.Request, projectName string, permission string) bool {
+	tlsAllowed := a.tls.UserHasPermission(r, projectName, permission)
+	if !a.isRemote(r) || (a.tlsRestrictions && !tlsAllowed) {
+		return tlsAllowed
+	}
+
+	relation := permission
+	if relation == "" {
+		relation = "user"
+	}
+
+	return a.userCheck(r, projectName, relation, "project:"+projectName, tlsAllowed)
+}
+
+// isRemote returns whether the request comes from a remote user (as opposed to local or cluster traffic).
+func (a *openfga) isRemote(r *http.Request) bool {
+	protocol, _ := r.Context().Value(request.CtxProtocol).(string)
+
+	return protocol != "unix" && protocol != "cluster"
+}
+
+// userCheck runs the check for the requestor, falling back to the TLS decision (tlsAllowed) on failure unless
+// fail-closed.
+func (a *openfga) userCheck(r *http.Request, projectName string, relation string, object string, tlsAllowed bool) bool {
+	username, _ := r.Context().Value(request.CtxUsername).(string)
+	protocol, _ := r.Context().Value(request.CtxProtocol).(string)
+
+	reqContext := map[string]any{
+		"username": username,
+		"protocol": protocol,
+		"project":  projectName,
+		"method":   r.Method,
+		"route":    r.URL.Path,
+	}
+
+	allowed, err := a.check(r.Context(), "user:"+username, relation, object, reqContext)
+	if err != nil {
+		a.logger.Warn("Failed OpenFGA permission check", logger.Ctx{"err": err, "username": username, "relation": relation, "object": object})
+
+		return tlsAllowed && !a.failClosed
+	}
+
+	return allowed
+}
+
+// check queries OpenFGA (or the local cache) for the given relation.
+func (a *openfga) check(ctx context.Context, user string, relation string, object string, reqContext map[string]any) (bool, error) {
+	cacheKey := strings.Join([]string{user, relation, object, fmt.Sprintf("%v", reqContext["route"]), fmt.Sprintf("%v", reqContext["method"])}, "|")
+
+	if a.cacheTTL > 0 {
+		a.cacheMu.Lock()
+		entry, ok := a.cache[cacheKey]
+		a.cacheMu.Unlock()
+
+		if ok && time.Now().Before(entry.expiry) {
+			return entry.allowed, nil
+		}
+	}
+
+	req := openfgaCheckRequest{
+		TupleKey:             openfgaTupleKey{User: user, Relation: relation, Object: object},
+		AuthorizationModelID: a.modelID,
+		Context:              reqContext,
+	}
+
+	resp := openfgaCheckResponse{}
+	err := a.query(ctx, "check", req, &resp)
+	if err != nil {
+		return false, err
+	}
+
+	if a.cacheTTL > 0 {
+		a.cacheMu.Lock()
+		if len(a.cache) >= openfgaCacheMaxEntries {
+			a.pruneCache()
+		}
+
+		a.cache[cacheKey] = openfgaCacheEntry{allowed: resp.Allowed, expiry: time.Now().Add(a.cacheTTL)}
+		a.cacheMu.Unlock()
+	}
+
+	return resp.Allowed, nil
+}
+
+// projectTuple returns the tuple linking a project to this server.
+func (a *openfga) projectTuple(name string) openfgaTupleKey {
+	return openfgaTupleKey{User: openfgaServerObject, Relation: "server", Object: "project:" + name}
+}
+
+// writeTuples adds and removes relationship tuples in the OpenFGA store.
+func (a *openfga) writeTuples(writes []openfgaTupleKey, deletes []openfgaTupleKey) error {
+	req := openfgaWriteRequest{AuthorizationModelID: a.modelID}
+
+	if len(writes) > 0 {
+		req.Writes = &openfgaTupleKeys{TupleKeys: writes}
+	}
+
+	if len(deletes) > 0 {
+		req.Deletes = &openfgaTupleKeys{TupleKeys: deletes}
+	}
+
+	return a.query(context.Background(), "write", req, nil)
+}
+
+// query sends a request to the OpenFGA store API.
+func (a *openfga) query(ctx context.Context, endpoint string, data any, target any) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	u := a.apiURL.JoinPath("stores", a.storeID, endpoint)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if a.apiToken != "" {
+		req.Header.Set("Authorization", "Bearer "+a.apiToken)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("Unexpected OpenFGA response (%d): %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	if target == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+// pruneCache removes the expired decisions, or all of them if the cache is still full.
+// It must be called with cacheMu held.
+func (a *openfga) pruneCache() {
+	now := time.Now()
+	for key, entry := range a.cache {
+		if now.After(entry.expiry) {
+			delete(a.cache, key)
+		}
+	}
+
+	if len(a.cache) >= openfgaCacheMaxEntries {
+		a.cache = map[string]openfgaCacheEntry{}
+	}
+}
+
+// flushCache removes all cached decisions.
+func (a *openfga) flushCache() {
+	a.cacheMu.Lock()
+	a.cache = map[string]openfgaCacheEntry{}
+	a.cacheMu.Unlock()
+}
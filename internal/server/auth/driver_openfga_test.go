@@ -0,0 +1,201 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/internal/server/request"
+	"github.com/lxc/incus/shared/logger"
+)
+
+func newOpenFGATestRequest(protocol string, access *UserAccess) *http.Request {
+	r := httptest.NewRequest("GET", "/1.0/instances?project=foo", nil)
+	ctx := context.WithValue(r.Context(), request.CtxUsername, "alice")
+	ctx = context.WithValue(ctx, request.CtxProtocol, protocol)
+	ctx = context.WithValue(ctx, request.CtxAccess, access)
+
+	return r.WithContext(ctx)
+}
+
+// Permission checks for remote users are sent to OpenFGA along with the request context.
+func TestOpenFGA_UserHasPermission(t *testing.T) {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		assert.Equal(t, "/stores/store1/check", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		req := openfgaCheckRequest{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "user:alice", req.TupleKey.User)
+		assert.Equal(t, "user", req.TupleKey.Relation)
+		assert.Equal(t, "tls", req.Context["protocol"])
+		assert.Equal(t, "/1.0/instances", req.Context["route"])
+
+		_ = json.NewEncoder(w).Encode(openfgaCheckResponse{Allowed: req.TupleKey.Object == "project:foo"})
+	}))
+	defer server.Close()
+
+	config := map[string]any{
+		"openfga.api.url":     server.URL,
+		"openfga.api.token":   "secret",
+		"openfga.store.id":    "store1",
+		"openfga.cache.ttl":   int64(60),
+		"openfga.fail_closed": true,
+	}
+
+	a, err := LoadAuthorizer("openfga", config, logger.Log, nil)
+	require.NoError(t, err)
+
+	r := newOpenFGATestRequest("tls", &UserAccess{Admin: true})
+	assert.True(t, a.UserHasPermission(r, "foo", ""))
+	assert.False(t, a.UserHasPermission(r, "bar", ""))
+
+	// The first decision is cached.
+	assert.True(t, a.UserHasPermission(r, "foo", ""))
+	assert.Equal(t, int32(2), calls.Load())
+
+	// Local requests don't hit OpenFGA.
+	r = newOpenFGATestRequest("unix", &UserAccess{Admin: true})
+	assert.True(t, a.UserHasPermission(r, "bar", ""))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+// When OpenFGA is unreachable, access is denied unless fail-closed is disabled.
+func TestOpenFGA_FailClosed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := map[string]any{
+		"openfga.api.url":     server.URL,
+		"openfga.store.id":    "store1",
+		"openfga.fail_closed": true,
+	}
+
+	a, err := LoadAuthorizer("openfga", config, logger.Log, nil)
+	require.NoError(t, err)
+
+	r := newOpenFGATestRequest("tls", &UserAccess{Admin: true})
+	assert.False(t, a.UserIsAdmin(r))
+
+	config["openfga.fail_closed"] = false
+	a, err = LoadAuthorizer("openfga", config, logger.Log, nil)
+	require.NoError(t, err)
+
+	assert.True(t, a.UserIsAdmin(r))
+
+	// Restricted clients remain restricted.
+	r = newOpenFGATestRequest("tls", &UserAccess{Projects: map[string][]string{"foo": nil}})
+	assert.False(t, a.UserHasPermission(r, "bar", ""))
+}
+
+// With the TLS restrictions disabled, OpenFGA alone decides for remote users.
+func TestOpenFGA_TLSRestrictions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := openfgaCheckRequest{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		_ = json.NewEncoder(w).Encode(openfgaCheckResponse{Allowed: req.TupleKey.Object == "project:bar"})
+	}))
+	defer server.Close()
+
+	config := map[string]any{
+		"openfga.api.url":  server.URL,
+		"openfga.store.id": "store1",
+	}
+
+	a, err := LoadAuthorizer("openfga", config, logger.Log, nil)
+	require.NoError(t, err)
+
+	// By default, a client restricted to another project isn't granted access by OpenFGA.
+	r := newOpenFGATestRequest("tls", &UserAccess{Projects: map[string][]string{"foo": nil}})
+	assert.False(t, a.UserHasPermission(r, "bar", ""))
+
+	config["openfga.tls_restrictions"] = false
+	a, err = LoadAuthorizer("openfga", config, logger.Log, nil)
+	require.NoError(t, err)
+
+	assert.True(t, a.UserHasPermission(r, "bar", ""))
+	assert.False(t, a.UserHasPermission(r, "foo", ""))
+}
+
+// The projects recorded in OpenFGA are synced with the server's projects on load.
+func TestOpenFGA_SyncProjects(t *testing.T) {
+	var writes []openfgaWriteRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/stores/store1/read":
+			resp := openfgaReadResponse{}
+			for _, object := range []string{"project:default", "project:gone"} {
+				resp.Tuples = append(resp.Tuples, openfgaTuple{Key: openfgaTupleKey{User: openfgaServerObject, Relation: "server", Object: object}})
+			}
+
+			_ = json.NewEncoder(w).Encode(resp)
+		case "/stores/store1/write":
+			req := openfgaWriteRequest{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			writes = append(writes, req)
+		default:
+			t.Errorf("Unexpected request to %q", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	config := map[string]any{
+		"openfga.api.url":  server.URL,
+		"openfga.store.id": "store1",
+	}
+
+	projectsGetFunc := func() (map[int64]string, error) {
+		return map[int64]string{1: "default", 2: "foo"}, nil
+	}
+
+	_, err := LoadAuthorizer("openfga", config, logger.Log, projectsGetFunc)
+	require.NoError(t, err)
+
+	require.Len(t, writes, 1)
+	require.NotNil(t, writes[0].Writes)
+	require.NotNil(t, writes[0].Deletes)
+	assert.Equal(t, []openfgaTupleKey{{User: openfgaServerObject, Relation: "server", Object: "project:foo"}}, writes[0].Writes.TupleKeys)
+	assert.Equal(t, []openfgaTupleKey{{User: openfgaServerObject, Relation: "server", Object: "project:gone"}}, writes[0].Deletes.TupleKeys)
+}
+
+// The decision cache doesn't grow past its maximum size.
+func TestOpenFGA_CacheBound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(openfgaCheckResponse{Allowed: true})
+	}))
+	defer server.Close()
+
+	config := map[string]any{
+		"openfga.api.url":   server.URL,
+		"openfga.store.id":  "store1",
+		"openfga.cache.ttl": int64(60),
+	}
+
+	a, err := LoadAuthorizer("openfga", config, logger.Log, nil)
+	require.NoError(t, err)
+
+	driver, ok := a.(*openfga)
+	require.True(t, ok)
+
+	for i := 0; i < openfgaCacheMaxEntries+10; i++ {
+		_, err := driver.check(context.Background(), fmt.Sprintf("user:u%d", i), "admin", openfgaServerObject, nil)
+		require.NoError(t, err)
+	}
+
+	assert.LessOrEqual(t, len(driver.cache), openfgaCacheMaxEntries)
+}
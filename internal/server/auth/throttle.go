@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+// ThrottleMaxBlockTime is the longest time a source can be blocked for.
+const ThrottleMaxBlockTime = time.Hour
+
+// Throttle keeps track of the authentication failures of each source and blocks the sources which keep failing.
+// Once a source reaches the failure threshold, it's blocked for the base block time, doubled for each further
+// failure (up to ThrottleMaxBlockTime). A successful authentication resets the source.
+type Throttle struct {
+	mu        sync.Mutex
+	sources   map[string]*throttleSource
+	lastPrune time.Time
+	now       func() time.Time
+}
+
+type throttleSource struct {
+	failures     int64
+	lastFailure  time.Time
+	blockedUntil time.Time
+}
+
+// NewThrottle returns a new Throttle.
+func NewThrottle() *Throttle {
+	return &Throttle{
+		sources: map[string]*throttleSource{},
+		now:     time.Now,
+	}
+}
+
+// Blocked returns how long the source remains blocked for, or 0 if it isn't blocked.
+func (t *Throttle) Blocked(source string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.sources[source]
+	if !ok {
+		return 0
+	}
+
+	remaining := s.blockedUntil.Sub(t.now())
+	if remaining < 0 {
+		return 0
+	}
+
+	return remaining
+}
+
+// Failure records an authentication failure for the source and blocks it if it reached the threshold.
+// A threshold of 0 disables blocking.
+func (t *Throttle) Failure(source string, threshold int64, blockTime time.Duration) {
+	if threshold <= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	t.prune(now)
+
+	s, ok := t.sources[source]
+	if !ok {
+		s = &throttleSource{}
+		t.sources[source] = s
+	}
+
+	s.failures++
+	s.lastFailure = now
+
+	if s.failures < threshold {
+		return
+	}
+
+	// Double the block time for each failure past the threshold.
+	block := blockTime
+	for i := threshold; i < s.failures && block < ThrottleMaxBlockTime; i++ {
+		block *= 2
+	}
+
+	if block > ThrottleMaxBlockTime {
+		block = ThrottleMaxBlockTime
+	}
+
+	s.blockedUntil = now.Add(block)
+}
+
+// Success resets the failures recorded for the source.
+func (t *Throttle) Success(source string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.sources, source)
+}
+
+// prune forgets the sources which haven't failed for longer than ThrottleMaxBlockTime and aren't blocked.
+// Runs at most once a minute. Must be called with the lock held.
+func (t *Throttle) prune(now time.Time) {
+	if now.Sub(t.lastPrune) < time.Minute {
+		return
+	}
+
+	t.lastPrune = now
+
+	for source, s := range t.sources {
+		if now.Sub(s.lastFailure) > ThrottleMaxBlockTime && now.After(s.blockedUntil) {
+			delete(t.sources, source)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottle(t *testing.T) {
+	now := time.Now()
+
+	throttle := NewThrottle()
+	throttle.now = func() time.Time { return now }
+
+	// Failures below the threshold don't block.
+	throttle.Failure("192.0.2.1", 3, time.Minute)
+	throttle.Failure("192.0.2.1", 3, time.Minute)
+	assert.Equal(t, time.Duration(0), throttle.Blocked("192.0.2.1"))
+
+	// Reaching the threshold blocks for the base block time.
+	throttle.Failure("192.0.2.1", 3, time.Minute)
+	assert.Equal(t, time.Minute, throttle.Blocked("192.0.2.1"))
+
+	// Other sources aren't affected.
+	assert.Equal(t, time.Duration(0), throttle.Blocked("192.0.2.2"))
+
+	// Further failures double the block time.
+	throttle.Failure("192.0.2.1", 3, time.Minute)
+	assert.Equal(t, 2*time.Minute, throttle.Blocked("192.0.2.1"))
+
+	// Up to the maximum.
+	for i := 0; i < 10; i++ {
+		throttle.Failure("192.0.2.1", 3, time.Minute)
+	}
+
+	assert.Equal(t, ThrottleMaxBlockTime, throttle.Blocked("192.0.2.1"))
+
+	// The block expires.
+	now = now.Add(ThrottleMaxBlockTime)
+	assert.Equal(t, time.Duration(0), throttle.Blocked("192.0.2.1"))
+
+	// Failing again blocks straight away as the failures are still counted.
+	throttle.Failure("192.0.2.1", 3, time.Minute)
+	assert.Equal(t, ThrottleMaxBlockTime, throttle.Blocked("192.0.2.1"))
+
+	// A success resets the failures.
+	throttle.Success("192.0.2.1")
+	assert.Equal(t, time.Duration(0), throttle.Blocked("192.0.2.1"))
+
+	throttle.Failure("192.0.2.1", 3, time.Minute)
+	assert.Equal(t, time.Duration(0), throttle.Blocked("192.0.2.1"))
+
+	// A threshold of 0 disables blocking.
+	for i := 0; i < 10; i++ {
+		throttle.Failure("192.0.2.3", 0, time.Minute)
+	}
+
+	assert.Equal(t, time.Duration(0), throttle.Blocked("192.0.2.3"))
+}
@@ -31,3 +31,17 @@ func FromAPIType(apiType string) (Type, error) {
 
 	return -1, fmt.Errorf("Invalid certificate type")
 }
+
+// ToAPIType returns the API equivalent type.
+func (t Type) ToAPIType() string {
+	switch t {
+	case TypeClient:
+		return api.CertificateTypeClient
+	case TypeServer:
+		return api.CertificateTypeServer
+	case TypeMetrics:
+		return api.CertificateTypeMetrics
+	}
+
+	return api.CertificateTypeUnknown
+}
@@ -2,10 +2,13 @@ package config
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/sirupsen/logrus"
 
@@ -13,6 +16,8 @@ import (
 	"github.com/lxc/incus/internal/server/config"
 	"github.com/lxc/incus/internal/server/db"
 	scriptletLoad "github.com/lxc/incus/internal/server/scriptlet/load"
+	"github.com/lxc/incus/shared/units"
+	"github.com/lxc/incus/shared/util"
 	"github.com/lxc/incus/shared/validate"
 )
 
@@ -20,6 +25,8 @@ import (
 type Config struct {
 	tx *db.ClusterTx // DB transaction the values in this config are bound to.
 	m  config.Map    // Low-level map holding the config values.
+
+	logRoutes map[string]LogRoute // Parsed value of core.log_routes, updated when the key changes.
 }
 
 // Load loads a new Config object with the current cluster configuration
@@ -36,7 +43,18 @@ func Load(ctx context.Context, tx *db.ClusterTx) (*Config, error) {
 		return nil, fmt.Errorf("failed to load node config: %w", err)
 	}
 
-	return &Config{tx: tx, m: m}, nil
+	c := &Config{tx: tx, m: m}
+	c.logRoutes = parseLogRoutes(m.GetString("core.log_routes"))
+
+	return c, nil
+}
+
+// AuditLog returns the format of the audit log entries, the size and interval at which the file is rotated and the
+// number of rotated files to keep.
+func (c *Config) AuditLog() (string, int64, time.Duration, int64) {
+	rotateSize, _ := units.ParseByteSizeString(c.m.GetString("audit.log.rotation.size"))
+
+	return c.m.GetString("audit.log.format"), rotateSize, time.Duration(c.m.GetInt64("audit.log.rotation.interval")) * time.Hour, c.m.GetInt64("audit.log.rotation.max_files")
 }
 
 // BackupsCompressionAlgorithm returns the compression algorithm to use for backups.
@@ -54,6 +72,12 @@ func (c *Config) BGPASN() int64 {
 	return c.m.GetInt64("core.bgp_asn")
 }
 
+// AuthFailureThrottle returns the number of authentication failures after which a source is blocked and the
+// number of seconds it's initially blocked for.
+func (c *Config) AuthFailureThrottle() (int64, int64) {
+	return c.m.GetInt64("core.auth_failure_threshold"), c.m.GetInt64("core.auth_failure_block_time")
+}
+
 // HTTPSAllowedHeaders returns the relevant CORS setting.
 func (c *Config) HTTPSAllowedHeaders() string {
 	return c.m.GetString("core.https_allowed_headers")
@@ -80,6 +104,12 @@ func (c *Config) TrustCACertificates() bool {
 	return c.m.GetBool("core.trust_ca_certificates")
 }
 
+// TrustCARevocation returns the methods used to check whether CA-signed client certificates were revoked,
+// whether to reject certificates whose status can't be determined and how many seconds to cache statuses for.
+func (c *Config) TrustCARevocation() ([]string, bool, int64) {
+	return util.SplitNTrimSpace(c.m.GetString("core.trust_ca_revocation"), ",", -1, true), c.m.GetBool("core.trust_ca_revocation.fail_closed"), c.m.GetInt64("core.trust_ca_revocation.cache_ttl")
+}
+
 // ProxyHTTPS returns the configured HTTPS proxy, if any.
 func (c *Config) ProxyHTTPS() string {
 	return c.m.GetString("core.proxy_https")
@@ -108,11 +138,63 @@ func (c *Config) OfflineThreshold() time.Duration {
 	return time.Duration(n) * time.Second
 }
 
+// HeartbeatInterval returns the interval between two heartbeats.
+// Unless configured, it is half of the offline threshold, or two thirds of it with the power_save heartbeat profile.
+func (c *Config) HeartbeatInterval() time.Duration {
+	n := c.m.GetInt64("cluster.heartbeat_interval")
+	if n == 0 {
+		if c.HeartbeatPowerSave() {
+			return c.OfflineThreshold() * 2 / 3
+		}
+
+		return c.OfflineThreshold() / 2
+	}
+
+	return time.Duration(n) * time.Second
+}
+
+// HeartbeatPowerSave returns whether heartbeats should be tuned to reduce wakeups.
+func (c *Config) HeartbeatPowerSave() bool {
+	return c.m.GetString("cluster.heartbeat_profile") == "power_save"
+}
+
+// MemberStateDelay returns how long a cluster member must remain in a new online state before the change is acted upon.
+func (c *Config) MemberStateDelay() time.Duration {
+	n := c.m.GetInt64("cluster.member_state_delay")
+	return time.Duration(n) * time.Second
+}
+
+// QuorumLossAction returns the action taken when the cluster database loses quorum.
+func (c *Config) QuorumLossAction() string {
+	return c.m.GetString("cluster.quorum_loss_action")
+}
+
 // ImagesMinimalReplica returns the numbers of nodes for cluster images replication.
 func (c *Config) ImagesMinimalReplica() int64 {
 	return c.m.GetInt64("cluster.images_minimal_replica")
 }
 
+// ImagesSyncConcurrency returns the number of images that can be synchronized across the cluster at the same time.
+func (c *Config) ImagesSyncConcurrency() int64 {
+	return c.m.GetInt64("cluster.images_sync_concurrency")
+}
+
+// ImagesSyncBandwidth returns the maximum number of bytes per second used by each image transfer between
+// cluster members, 0 meaning no limit.
+func (c *Config) ImagesSyncBandwidth() int64 {
+	value := c.m.GetString("cluster.images_sync_bandwidth")
+	if value == "" {
+		return 0
+	}
+
+	bandwidth, err := units.ParseByteSizeString(value)
+	if err != nil {
+		return 0
+	}
+
+	return bandwidth
+}
+
 // MaxVoters returns the maximum number of members in a cluster that will be
 // assigned the voter role.
 func (c *Config) MaxVoters() int64 {
@@ -167,11 +249,32 @@ func (c *Config) ImagesRemoteCacheExpiryDays() int64 {
 	return c.m.GetInt64("images.remote_cache_expiry")
 }
 
+// InstancesConsoleLogSize returns the default size of the instance console logs.
+func (c *Config) InstancesConsoleLogSize() string {
+	return c.m.GetString("instances.console.log_size")
+}
+
+// InstancesHook returns the hook run on instance lifecycle events, the actions triggering it (all of them if
+// empty) and its timeout.
+func (c *Config) InstancesHook() (string, []string, time.Duration) {
+	var actions []string
+	if c.m.GetString("instances.hook.actions") != "" {
+		actions = util.SplitNTrimSpace(c.m.GetString("instances.hook.actions"), ",", -1, true)
+	}
+
+	return c.m.GetString("instances.hook"), actions, time.Duration(c.m.GetInt64("instances.hook.timeout")) * time.Second
+}
+
 // InstancesNICHostname returns hostname mode to use for instance NICs.
 func (c *Config) InstancesNICHostname() string {
 	return c.m.GetString("instances.nic.host_name")
 }
 
+// InstancesNamePattern returns the pattern that new instance names must match.
+func (c *Config) InstancesNamePattern() string {
+	return c.m.GetString("instances.name.pattern")
+}
+
 // InstancesPlacementScriptlet returns the instances placement scriptlet source code.
 func (c *Config) InstancesPlacementScriptlet() string {
 	return c.m.GetString("instances.placement.scriptlet")
@@ -193,16 +296,108 @@ func (c *Config) LokiServer() (string, string, string, string, []string, string,
 	return c.m.GetString("loki.api.url"), c.m.GetString("loki.auth.username"), c.m.GetString("loki.auth.password"), c.m.GetString("loki.api.ca_cert"), labels, c.m.GetString("loki.loglevel"), types
 }
 
+// LocalEventTypes returns the event types which aren't forwarded to the other cluster members.
+func (c *Config) LocalEventTypes() []string {
+	return util.SplitNTrimSpace(c.m.GetString("cluster.local_event_types"), ",", -1, true)
+}
+
 // ACME returns all ACME settings needed for certificate renewal.
 func (c *Config) ACME() (string, string, string, bool) {
 	return c.m.GetString("acme.domain"), c.m.GetString("acme.email"), c.m.GetString("acme.ca_url"), c.m.GetBool("acme.agree_tos")
 }
 
+// ACMEHook returns the hook run around the renewal of the ACME certificate and its failure policy.
+func (c *Config) ACMEHook() (string, string) {
+	return c.m.GetString("acme.hook"), c.m.GetString("acme.hook_failure")
+}
+
 // ClusterJoinTokenExpiry returns the cluster join token expiry.
 func (c *Config) ClusterJoinTokenExpiry() string {
 	return c.m.GetString("cluster.join_token_expiry")
 }
 
+// InstancesAutostartRetry returns the number of attempts to automatically start an instance and the initial delay
+// between attempts.
+func (c *Config) InstancesAutostartRetry() (int64, time.Duration) {
+	return c.m.GetInt64("instances.autostart.attempts"), time.Duration(c.m.GetInt64("instances.autostart.retry_delay")) * time.Second
+}
+
+// DatabaseRetry returns the maximum number of attempts made for a database transaction failing with a transient
+// error and the delay between attempts.
+func (c *Config) DatabaseRetry() (int64, time.Duration) {
+	return c.m.GetInt64("core.database_retry.attempts"), time.Duration(c.m.GetInt64("core.database_retry.backoff")) * time.Millisecond
+}
+
+// DatabaseSlowThreshold returns the duration above which cluster database transactions are logged (0 if disabled).
+func (c *Config) DatabaseSlowThreshold() time.Duration {
+	return time.Duration(c.m.GetInt64("core.database_slow_threshold")) * time.Millisecond
+}
+
+// LogsExpiry returns how long the logs of the given type (console, deleted, instance or lifecycle) are kept, zero
+// if they never expire.
+func (c *Config) LogsExpiry(logType string) time.Duration {
+	return time.Duration(c.m.GetInt64("core.logs_expiry."+logType)) * time.Hour
+}
+
+// LogSampling returns the sampling policy for repeated log messages.
+func (c *Config) LogSampling() (int64, int64) {
+	return c.m.GetInt64("core.log_sampling_every"), c.m.GetInt64("core.log_sampling_limit")
+}
+
+// Message returns the message set for the users of the server, its severity, its expiry (zero if it doesn't
+// expire) and whether to return it in a response header. The message is empty if it expired.
+func (c *Config) Message() (string, string, time.Time, bool) {
+	message := c.m.GetString("core.message")
+
+	expiry, _ := time.Parse(time.RFC3339, c.m.GetString("core.message.expiry"))
+	if !expiry.IsZero() && time.Now().After(expiry) {
+		message = ""
+	}
+
+	return message, c.m.GetString("core.message.severity"), expiry, c.m.GetBool("core.message.header")
+}
+
+// LogRoute represents the logging override of an API route.
+type LogRoute struct {
+	// Level at which the requests are logged, empty if they aren't logged.
+	Level string
+
+	// Whether to log the JSON body of the requests.
+	Body bool
+}
+
+// LogRoutes returns the logging overrides of the API routes, indexed by route name.
+// The returned map mustn't be modified.
+func (c *Config) LogRoutes() map[string]LogRoute {
+	return c.logRoutes
+}
+
+// parseLogRoutes parses the value of core.log_routes, skipping invalid entries.
+func parseLogRoutes(value string) map[string]LogRoute {
+	routes := map[string]LogRoute{}
+	for _, entry := range util.SplitNTrimSpace(value, ",", -1, true) {
+		name, route, err := parseLogRoute(entry)
+		if err != nil {
+			continue
+		}
+
+		routes[name] = route
+	}
+
+	return routes
+}
+
+// MaxConcurrentOperations returns the maximum number of background operations running at once, 0 meaning no limit.
+func (c *Config) MaxConcurrentOperations() int64 {
+	return c.m.GetInt64("core.max_concurrent_operations")
+}
+
+// OperationsHistory returns how long completed operations are kept in the history and the maximum number of
+// operations kept (0 if unlimited). The history is disabled when both are zero.
+func (c *Config) OperationsHistory() (time.Duration, int64) {
+	return time.Duration(c.m.GetInt64("core.operations_history.max_age")) * 24 * time.Hour, c.m.GetInt64("core.operations_history.max_count")
+}
+
 // RemoteTokenExpiry returns the time after which a remote add token expires.
 func (c *Config) RemoteTokenExpiry() string {
 	return c.m.GetString("core.remote_token_expiry")
@@ -213,6 +408,11 @@ func (c *Config) OIDCServer() (string, string, string) {
 	return c.m.GetString("oidc.issuer"), c.m.GetString("oidc.client.id"), c.m.GetString("oidc.audience")
 }
 
+// OpenFGA returns all the OpenFGA settings needed to connect to a server.
+func (c *Config) OpenFGA() (string, string, string, string, int64, bool, bool) {
+	return c.m.GetString("openfga.api.url"), c.m.GetString("openfga.api.token"), c.m.GetString("openfga.store.id"), c.m.GetString("openfga.store.model_id"), c.m.GetInt64("openfga.cache.ttl"), c.m.GetBool("openfga.fail_closed"), c.m.GetBool("openfga.tls_restrictions")
+}
+
 // ClusterHealingThreshold returns the configured healing threshold, i.e. the
 // number of seconds after which an offline node will be evacuated automatically. If the config key
 // is set but its value is lower than cluster.offline_threshold it returns
@@ -233,6 +433,37 @@ func (c *Config) ClusterHealingThreshold() time.Duration {
 	return healingThreshold
 }
 
+// ClusterHealingPolicy returns the guardrails applied to the automatic evacuation of offline members,
+// i.e. whether a quorum of database voters must still be online, the maximum number of members which
+// may be evacuated within the healing window (0 meaning no limit) and the duration of that window.
+func (c *Config) ClusterHealingPolicy() (bool, int64, time.Duration) {
+	requireQuorum := c.m.GetBool("cluster.healing_require_quorum")
+	maxMembers := c.m.GetInt64("cluster.healing_max_members")
+	window := time.Duration(c.m.GetInt64("cluster.healing_window")) * time.Second
+
+	return requireQuorum, maxMembers, window
+}
+
+// Hash returns a hash of the current configuration keys and their values.
+// It's used to detect cluster members whose loaded configuration differs from the leader's.
+func (c *Config) Hash() string {
+	values := c.m.Dump()
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	for _, key := range keys {
+		_, _ = fmt.Fprintf(hash, "%s=%s\n", key, values[key])
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
+
 // Dump current configuration keys and their values. Keys with values matching
 // their defaults are omitted.
 func (c *Config) Dump() map[string]string {
@@ -264,11 +495,21 @@ func (c *Config) update(values map[string]string) (map[string]string, error) {
 		return nil, err
 	}
 
+	// Members must receive several heartbeats before being considered offline.
+	if c.m.GetInt64("cluster.heartbeat_interval") > 0 && c.HeartbeatInterval() > c.OfflineThreshold()/2 {
+		return nil, fmt.Errorf("cluster.heartbeat_interval must be at most half of cluster.offline_threshold")
+	}
+
 	err = c.tx.UpdateClusterConfig(changed)
 	if err != nil {
 		return nil, fmt.Errorf("cannot persist configuration changes: %w", err)
 	}
 
+	_, ok := changed["core.log_routes"]
+	if ok {
+		c.logRoutes = parseLogRoutes(c.m.GetString("core.log_routes"))
+	}
+
 	return changed, nil
 }
 
@@ -308,6 +549,63 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Agree to ACME terms of service
 	"acme.agree_tos": {Type: config.Bool, Default: "false"},
 
+	// gendoc:generate(entity=server, group=acme, key=acme.hook)
+	// Absolute path of a command or `http`/`https` URL of a webhook, run before and after the ACME certificate is renewed.
+	// See {ref}`authentication-server-certificate-hook`.
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: Hook run around the renewal of the certificate
+	"acme.hook": {Validator: validate.Optional(validateHook)},
+
+	// gendoc:generate(entity=server, group=acme, key=acme.hook_failure)
+	// Possible values are `defer`, `rollback` and `ignore`.
+	// With `defer`, the new certificate isn't used if the hook fails before the renewal and the renewal is retried later.
+	// With `rollback`, the previous certificate is also restored if the hook fails after the renewal.
+	// With `ignore`, hook failures are only logged.
+	// ---
+	//  type: string
+	//  scope: global
+	//  defaultdesc: `defer`
+	//  shortdesc: What to do when the renewal hook fails
+	"acme.hook_failure": {Default: "defer", Validator: validate.Optional(validate.IsOneOf("defer", "rollback", "ignore"))},
+
+	// gendoc:generate(entity=server, group=audit, key=audit.log.format)
+	// Possible values are `json` (one JSON encoded event per line) and `text`.
+	// ---
+	//  type: string
+	//  scope: global
+	//  defaultdesc: `json`
+	//  shortdesc: Format of the audit log entries
+	"audit.log.format": {Default: "json", Validator: validate.Optional(validate.IsOneOf("json", "text"))},
+
+	// gendoc:generate(entity=server, group=audit, key=audit.log.rotation.interval)
+	// Number of hours after which the audit log file is rotated. Set to `0` to disable time based rotation.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0`
+	//  shortdesc: Interval at which the audit log file is rotated
+	"audit.log.rotation.interval": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsInRange(0, 87600))},
+
+	// gendoc:generate(entity=server, group=audit, key=audit.log.rotation.max_files)
+	// Number of rotated audit log files to keep, the oldest ones being removed first. Set to `0` to keep them all.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `10`
+	//  shortdesc: Number of rotated audit log files to keep
+	"audit.log.rotation.max_files": {Type: config.Int64, Default: "10", Validator: validate.Optional(validate.IsInRange(0, 10000))},
+
+	// gendoc:generate(entity=server, group=audit, key=audit.log.rotation.size)
+	// Size above which the audit log file is rotated. Set to `0` to disable size based rotation.
+	// ---
+	//  type: string
+	//  scope: global
+	//  defaultdesc: `100MiB`
+	//  shortdesc: Size at which the audit log file is rotated
+	"audit.log.rotation.size": {Default: "100MiB", Validator: validate.Optional(validate.IsSize)},
+
 	// gendoc:generate(entity=server, group=miscellaneous, key=backups.compression_algorithm)
 	// Possible values are `bzip2`, `gzip`, `lzma`, `xz`, or `none`.
 	// ---
@@ -317,6 +615,30 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Compression algorithm to use for backups
 	"backups.compression_algorithm": {Default: "gzip", Validator: validate.IsCompressionAlgorithm},
 
+	// gendoc:generate(entity=server, group=cluster, key=cluster.heartbeat_interval)
+	// Specify the number of seconds between two heartbeats sent by the leader to the cluster members.
+	// It must be at most half of {config:option}`server-cluster:cluster.offline_threshold`.
+	// Set this option to `0` to derive the interval from the offline threshold and {config:option}`server-cluster:cluster.heartbeat_profile`.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0`
+	//  shortdesc: Interval between heartbeats
+	"cluster.heartbeat_interval": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsInRange(0, 3600))},
+
+	// gendoc:generate(entity=server, group=cluster, key=cluster.heartbeat_profile)
+	// Possible values are `default` and `power_save`.
+	// With `power_save`, the leader sends the heartbeats to all members at once rather than spreading them, aligns the
+	// heartbeat rounds on whole seconds and, unless {config:option}`server-cluster:cluster.heartbeat_interval` is set,
+	// sends them every two thirds of the offline threshold rather than every half.
+	// A member missing a single heartbeat may then be considered offline, so a higher {config:option}`server-cluster:cluster.offline_threshold` is recommended.
+	// ---
+	//  type: string
+	//  scope: global
+	//  defaultdesc: `default`
+	//  shortdesc: Heartbeat profile
+	"cluster.heartbeat_profile": {Default: "default", Validator: validate.Optional(validate.IsOneOf("default", "power_save"))},
+
 	// gendoc:generate(entity=server, group=cluster, key=cluster.offline_threshold)
 	// Specify the number of seconds after which an unresponsive member is considered offline.
 	// ---
@@ -326,6 +648,26 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Threshold when an unresponsive member is considered offline
 	"cluster.offline_threshold": {Type: config.Int64, Default: offlineThresholdDefault(), Validator: offlineThresholdValidator},
 
+	// gendoc:generate(entity=server, group=cluster, key=cluster.quorum_loss_action)
+	// Specify what to do when no database leader could be found for longer than the offline threshold, which happens when a majority of the database voters is unreachable.
+	// Possible values are `none`, which lets requests fail as the database can't be reached, `freeze`, which refuses any change with an explicit "quorum lost" error until quorum is restored, and `recover`, which also refuses changes and, once quorum is restored, replaces the database voters which are still unreachable.
+	// ---
+	//  type: string
+	//  scope: global
+	//  defaultdesc: `none`
+	//  shortdesc: Action taken when the cluster database lost quorum
+	"cluster.quorum_loss_action": {Default: "none", Validator: validate.Optional(validate.IsOneOf("none", "freeze", "recover"))},
+
+	// gendoc:generate(entity=server, group=cluster, key=cluster.member_state_delay)
+	// Specify the number of seconds a cluster member must remain offline before an offline warning is raised, and remain online before the warning is resolved.
+	// This avoids warnings and state changes being reported for members with an unstable connection.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0`
+	//  shortdesc: Time a member state change must last before being acted upon
+	"cluster.member_state_delay": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsInRange(0, 3600))},
+
 	// gendoc:generate(entity=server, group=cluster, key=cluster.images_minimal_replica)
 	// Specify the minimal number of cluster members that keep a copy of a particular image.
 	// Set this option to `1` for no replication, or to `-1` to replicate images on all members.
@@ -336,6 +678,24 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Number of cluster members that replicate an image
 	"cluster.images_minimal_replica": {Type: config.Int64, Default: "3", Validator: imageMinimalReplicaValidator},
 
+	// gendoc:generate(entity=server, group=cluster, key=cluster.images_sync_concurrency)
+	// Specify how many images the periodic image synchronization copies across the cluster at the same time.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `1`
+	//  shortdesc: Number of images synchronized across the cluster at the same time
+	"cluster.images_sync_concurrency": {Type: config.Int64, Default: "1", Validator: validate.Optional(validate.IsInRange(1, 64))},
+
+	// gendoc:generate(entity=server, group=cluster, key=cluster.images_sync_bandwidth)
+	// Specify the maximum transfer rate (in bytes per second, for example `10MiB`) of each copy of an image between cluster members.
+	// ---
+	//  type: string
+	//  scope: global
+	//  defaultdesc: no limit
+	//  shortdesc: Bandwidth limit of image copies between cluster members
+	"cluster.images_sync_bandwidth": {Validator: validate.Optional(validate.IsSize)},
+
 	// gendoc:generate(entity=server, group=cluster, key=cluster.healing_threshold)
 	// Specify the number of seconds after which an offline cluster member is to be evacuated.
 	// To disable evacuating offline members, set this option to `0`.
@@ -346,6 +706,36 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Threshold when to evacuate an offline cluster member
 	"cluster.healing_threshold": {Type: config.Int64, Default: "0"},
 
+	// gendoc:generate(entity=server, group=cluster, key=cluster.healing_require_quorum)
+	// When enabled, offline members are only evacuated if a majority of the database voters are still online.
+	// This prevents evacuations from being triggered on the wrong side of a network partition.
+	// ---
+	//  type: bool
+	//  scope: global
+	//  defaultdesc: `false`
+	//  shortdesc: Whether a quorum of voters is required to evacuate offline members
+	"cluster.healing_require_quorum": {Type: config.Bool, Default: "false"},
+
+	// gendoc:generate(entity=server, group=cluster, key=cluster.healing_max_members)
+	// Specify the maximum number of offline members that can be automatically evacuated within `cluster.healing_window`.
+	// Members beyond this limit are left untouched until the window allows for more evacuations.
+	// To not limit the number of evacuations, set this option to `0`.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0`
+	//  shortdesc: Maximum number of members evacuated within the healing window
+	"cluster.healing_max_members": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsInRange(0, 1000))},
+
+	// gendoc:generate(entity=server, group=cluster, key=cluster.healing_window)
+	// Specify the number of seconds over which `cluster.healing_max_members` is enforced.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `3600`
+	//  shortdesc: Window over which automatic evacuations are counted
+	"cluster.healing_window": {Type: config.Int64, Default: "3600", Validator: validate.Optional(validate.IsInRange(60, 31536000))},
+
 	// gendoc:generate(entity=server, group=cluster, key=cluster.join_token_expiry)
 	//
 	// ---
@@ -355,6 +745,17 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Time after which a cluster join token expires
 	"cluster.join_token_expiry": {Type: config.String, Default: "3H", Validator: expiryValidator},
 
+	// gendoc:generate(entity=server, group=cluster, key=cluster.local_event_types)
+	// Specify a comma-separated list of event types which are kept local to each cluster member rather than forwarded to the other members.
+	// The event types can be any combination of `lifecycle`, `logging`, `network-acl` and `syscall`.
+	// Clients only receive those events from the member they are connected to.
+	// ---
+	//  type: string
+	//  scope: global
+	//  defaultdesc: empty (all events are forwarded)
+	//  shortdesc: Event types which aren't forwarded to other cluster members
+	"cluster.local_event_types": {Validator: validate.Optional(validate.IsListOf(validate.IsOneOf("lifecycle", "logging", "network-acl", "syscall")))},
+
 	// gendoc:generate(entity=server, group=cluster, key=cluster.max_voters)
 	// Specify the maximum number of cluster members that are assigned the database voter role.
 	// This must be an odd number >= `3`.
@@ -384,6 +785,28 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Whether to enforce authentication on the metrics endpoint
 	"core.metrics_authentication": {Type: config.Bool, Default: "true"},
 
+	// gendoc:generate(entity=server, group=core, key=core.auth_failure_threshold)
+	// Specify the number of consecutive authentication failures from the same address after which the address
+	// is temporarily blocked. Requests from local clients and cluster members are never blocked.
+	// To disable blocking, set this option to `0`.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `10`
+	//  shortdesc: Authentication failures before blocking an address
+	"core.auth_failure_threshold": {Type: config.Int64, Default: "10", Validator: validate.Optional(validate.IsInRange(0, 1000))},
+
+	// gendoc:generate(entity=server, group=core, key=core.auth_failure_block_time)
+	// Specify the number of seconds for which an address is blocked after reaching
+	// {config:option}`server-core:core.auth_failure_threshold`.
+	// The time doubles with each further failure, up to one hour.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `60`
+	//  shortdesc: How long to block an address for
+	"core.auth_failure_block_time": {Type: config.Int64, Default: "60", Validator: validate.Optional(validate.IsInRange(1, 3600))},
+
 	// gendoc:generate(entity=server, group=core, key=core.bgp_asn)
 	//
 	// ---
@@ -430,7 +853,173 @@ var ConfigSchema = config.Schema{
 	//  type: string
 	//  scope: global
 	//  shortdesc: Trusted servers to provide the client's address
-	"core.https_trusted_proxy": {},
+	"core.https_trusted_proxy": {Validator: validate.Optional(validate.IsListOf(validate.IsNetworkAddress))},
+
+	// gendoc:generate(entity=server, group=core, key=core.database_retry.attempts)
+	// Maximum number of attempts made for a database transaction which fails with a transient error, for example because the database is busy, before the error is returned.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `250`
+	//  shortdesc: Number of attempts for database transactions
+	"core.database_retry.attempts": {Type: config.Int64, Default: "250", Validator: validate.Optional(validate.IsInRange(1, 10000))},
+
+	// gendoc:generate(entity=server, group=core, key=core.database_retry.backoff)
+	// Delay in milliseconds between two attempts of a database transaction which failed with a transient error.
+	// A random deviation is applied to the delay so that conflicting transactions don't retry at the same time.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `100`
+	//  shortdesc: Delay between attempts of database transactions
+	"core.database_retry.backoff": {Type: config.Int64, Default: "100", Validator: validate.Optional(validate.IsInRange(1, 60000))},
+
+	// gendoc:generate(entity=server, group=core, key=core.database_slow_threshold)
+	// Duration in milliseconds above which a cluster database transaction is logged as slow, along with the function which ran it.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0` (disabled)
+	//  shortdesc: Threshold for logging slow database transactions
+	"core.database_slow_threshold": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsInRange(0, 3600000))},
+
+	// gendoc:generate(entity=server, group=core, key=core.log_sampling_every)
+	// When set to a value greater than 1, only one in every N identical `debug` and `info` log messages is sent to the event stream and to Loki.
+	// Warnings and errors are never sampled.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0` (disabled)
+	//  shortdesc: Keep only one in every N identical log messages
+	"core.log_sampling_every": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsInRange(0, 1000000))},
+
+	// gendoc:generate(entity=server, group=core, key=core.log_sampling_limit)
+	// Maximum number of identical `debug` and `info` log messages sent to the event stream and to Loki per minute.
+	// Warnings and errors are never limited.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0` (unlimited)
+	//  shortdesc: Maximum number of identical log messages per minute
+	"core.log_sampling_limit": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsInRange(0, 1000000))},
+
+	// gendoc:generate(entity=server, group=core, key=core.log_routes)
+	// Specify a comma-separated list of `<route>=<level>` entries overriding how the requests to each API route are logged.
+	// The level can be `none`, `debug`, `info` or `warn`, optionally followed by `+body` to also log the JSON body of the requests.
+	// See {ref}`daemon-behavior-request-logging`.
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: Per-route API request logging
+	"core.log_routes": {Validator: validate.Optional(validate.IsListOf(logRouteValidator))},
+
+	// gendoc:generate(entity=server, group=core, key=core.logs_expiry.console)
+	// Number of hours after which the console logs of instances are removed if they weren't written to.
+	// Set to `0` to never remove them.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `48`
+	//  shortdesc: Expiry of the instance console logs
+	"core.logs_expiry.console": {Type: config.Int64, Default: "48", Validator: validate.Optional(validate.IsInRange(0, 87600))},
+
+	// gendoc:generate(entity=server, group=core, key=core.logs_expiry.deleted)
+	// Number of hours after which the logs of deleted instances are removed.
+	// Set to `0` to never remove them.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `24`
+	//  shortdesc: Expiry of the logs of deleted instances
+	"core.logs_expiry.deleted": {Type: config.Int64, Default: "24", Validator: validate.Optional(validate.IsInRange(0, 87600))},
+
+	// gendoc:generate(entity=server, group=core, key=core.logs_expiry.instance)
+	// Number of hours after which the other log files of instances and of their snapshots are removed if they
+	// weren't written to.
+	// Set to `0` to never remove them.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `48`
+	//  shortdesc: Expiry of the instance logs
+	"core.logs_expiry.instance": {Type: config.Int64, Default: "48", Validator: validate.Optional(validate.IsInRange(0, 87600))},
+
+	// gendoc:generate(entity=server, group=core, key=core.logs_expiry.lifecycle)
+	// Number of hours after which the rotated files of the audit log, which records the lifecycle events, are
+	// removed if they weren't written to. The file currently written to is never removed.
+	// Set to `0` to only limit them through {config:option}`server-audit:audit.log.rotation.max_files`.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0`
+	//  shortdesc: Expiry of the rotated audit log files
+	"core.logs_expiry.lifecycle": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsInRange(0, 87600))},
+
+	// gendoc:generate(entity=server, group=core, key=core.max_concurrent_operations)
+	// Limits the number of background operations (such as image downloads, instance creation or migration) running at once on each server.
+	// Additional operations are queued in the `Pending` state until a running one completes.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0` (unlimited)
+	//  shortdesc: Maximum number of background operations running at once
+	"core.max_concurrent_operations": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsInRange(0, 1000000))},
+
+	// gendoc:generate(entity=server, group=core, key=core.operations_history.max_age)
+	// Number of days completed operations are kept in the operations history.
+	// The history is disabled unless this or {config:option}`server-core:core.operations_history.max_count` is set.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0` (disabled)
+	//  shortdesc: Number of days completed operations are kept
+	"core.operations_history.max_age": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsInRange(0, 3650))},
+
+	// gendoc:generate(entity=server, group=core, key=core.operations_history.max_count)
+	// Maximum number of completed operations kept in the operations history, the oldest ones being removed first.
+	// The history is disabled unless this or {config:option}`server-core:core.operations_history.max_age` is set.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0` (unlimited)
+	//  shortdesc: Maximum number of completed operations kept
+	"core.operations_history.max_count": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsInRange(0, 10000000))},
+
+	// gendoc:generate(entity=server, group=core, key=core.message)
+	// Message shown to the users of the server, for example to announce upcoming maintenance.
+	// It's included in the server information returned by `GET /1.0`.
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: Message shown to the users of the server
+	"core.message": {Validator: validate.Optional(messageValidator)},
+
+	// gendoc:generate(entity=server, group=core, key=core.message.severity)
+	// Possible values are `info` and `warning`.
+	// ---
+	//  type: string
+	//  scope: global
+	//  defaultdesc: `info`
+	//  shortdesc: Severity of the server message
+	"core.message.severity": {Default: "info", Validator: validate.Optional(validate.IsOneOf("info", "warning"))},
+
+	// gendoc:generate(entity=server, group=core, key=core.message.expiry)
+	// Specify the time after which the message is no longer shown, as an RFC 3339 timestamp (for example `2024-03-16T10:00:00Z`).
+	// ---
+	//  type: string
+	//  scope: global
+	//  defaultdesc: no expiry
+	//  shortdesc: When the server message expires
+	"core.message.expiry": {Validator: validate.Optional(messageExpiryValidator)},
+
+	// gendoc:generate(entity=server, group=core, key=core.message.header)
+	// If enabled, the message is also returned in the `X-Incus-Message` header (and its severity in the `X-Incus-Message-Severity` header) of the responses to all API requests from trusted clients.
+	// ---
+	//  type: bool
+	//  scope: global
+	//  defaultdesc: `false`
+	//  shortdesc: Whether to return the server message in a response header
+	"core.message.header": {Type: config.Bool},
 
 	// gendoc:generate(entity=server, group=core, key=core.proxy_http)
 	// If this option is not specified, the daemon falls back to the `HTTP_PROXY` environment variable (if set).
@@ -484,6 +1073,37 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Whether to automatically trust clients signed by the CA
 	"core.trust_ca_certificates": {Type: config.Bool},
 
+	// gendoc:generate(entity=server, group=core, key=core.trust_ca_revocation)
+	// Specify a comma-separated list of methods (`ocsp` or `crl`) used to check whether a client certificate
+	// signed by the CA was revoked.
+	// They're tried in order, using the OCSP responders and CRL distribution points listed in the certificate.
+	// This only applies when {config:option}`server-core:core.trust_ca_certificates` is enabled.
+	// ---
+	//  type: string
+	//  scope: global
+	//  defaultdesc: no revocation checks
+	//  shortdesc: How to check CA-signed client certificates for revocation
+	"core.trust_ca_revocation": {Validator: validate.Optional(validate.IsListOf(validate.IsOneOf("ocsp", "crl")))},
+
+	// gendoc:generate(entity=server, group=core, key=core.trust_ca_revocation.fail_closed)
+	// If disabled, client certificates are trusted when none of the OCSP responders or CRLs can be reached.
+	// ---
+	//  type: bool
+	//  scope: global
+	//  defaultdesc: `true`
+	//  shortdesc: Whether to reject certificates whose revocation status is unknown
+	"core.trust_ca_revocation.fail_closed": {Type: config.Bool, Default: "true"},
+
+	// gendoc:generate(entity=server, group=core, key=core.trust_ca_revocation.cache_ttl)
+	// Specify the number of seconds for which the revocation status of a certificate is cached.
+	// To disable caching, set this option to `0`.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `3600`
+	//  shortdesc: How long to cache revocation statuses
+	"core.trust_ca_revocation.cache_ttl": {Type: config.Int64, Default: "3600", Validator: validate.Optional(validate.IsInRange(0, 86400))},
+
 	// gendoc:generate(entity=server, group=images, key=images.auto_update_cached)
 	//
 	// ---
@@ -528,6 +1148,61 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: When an unused cached remote image is flushed
 	"images.remote_cache_expiry": {Type: config.Int64, Default: "10"},
 
+	// gendoc:generate(entity=server, group=miscellaneous, key=instances.autostart.attempts)
+	// Number of times Incus tries to start an instance that is automatically started (see {config:option}`instance-boot:boot.autostart`) before giving up and raising a warning.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `3`
+	//  shortdesc: Number of attempts to automatically start an instance
+	"instances.autostart.attempts": {Type: config.Int64, Default: "3", Validator: validate.Optional(validate.IsInRange(1, 100))},
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=instances.autostart.retry_delay)
+	// Delay in seconds before retrying to automatically start an instance that failed to start.
+	// The delay doubles after each failed attempt, up to one hour.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `5`
+	//  shortdesc: Initial delay before retrying to automatically start an instance
+	"instances.autostart.retry_delay": {Type: config.Int64, Default: "5", Validator: validate.Optional(validate.IsInRange(1, 3600))},
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=instances.console.log_size)
+	// Amount of console output kept for instances which don't set {config:option}`instance-miscellaneous:console.log_size`.
+	// ---
+	//  type: string
+	//  scope: global
+	//  defaultdesc: `128KiB`
+	//  shortdesc: Default size of the instance console logs
+	"instances.console.log_size": {Validator: validate.Optional(validate.IsSize)},
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=instances.hook)
+	// Absolute path of a command or `http`/`https` URL of a webhook, run when instance lifecycle events occur.
+	// See {ref}`instances-lifecycle-hook`.
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: Hook run on instance lifecycle events
+	"instances.hook": {Validator: validate.Optional(validateHook)},
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=instances.hook.actions)
+	// Comma-separated list of the instance lifecycle actions (for example `instance-started,instance-deleted`)
+	// which trigger {config:option}`server-miscellaneous:instances.hook`. All instance lifecycle actions trigger it if not set.
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: Instance lifecycle actions triggering the hook
+	"instances.hook.actions": {Validator: validate.Optional(validate.IsListOf(validateInstanceLifecycleAction))},
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=instances.hook.timeout)
+	// Number of seconds given to {config:option}`server-miscellaneous:instances.hook` to complete.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `30`
+	//  shortdesc: Timeout of the instance lifecycle hook
+	"instances.hook.timeout": {Type: config.Int64, Default: "30", Validator: validate.Optional(validate.IsInRange(1, 3600))},
+
 	// gendoc:generate(entity=server, group=miscellaneous, key=instances.nic.host_name)
 	// Possible values are `random` and `mac`.
 	//
@@ -540,6 +1215,15 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: How to set the host name for a NIC
 	"instances.nic.host_name": {Validator: validate.Optional(validate.IsOneOf("random", "mac"))},
 
+	// gendoc:generate(entity=server, group=miscellaneous, key=instances.name.pattern)
+	// Regular expression that the names of new or renamed instances must fully match, for example `[a-z][a-z0-9-]{0,15}`.
+	// It applies on top of the built-in rules (valid DNS label, no `_` or `/` character) and of the pattern set in the project.
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: Pattern that instance names must match
+	"instances.name.pattern": {Validator: validate.Optional(internalInstance.ValidNamePattern)},
+
 	// gendoc:generate(entity=server, group=miscellaneous, key=instances.placement.scriptlet)
 	// When using custom automatic instance placement logic, this option stores the scriptlet.
 	// See {ref}`clustering-instance-placement-scriptlet` for more information.
@@ -600,13 +1284,13 @@ var ConfigSchema = config.Schema{
 
 	// gendoc:generate(entity=server, group=loki, key=loki.types)
 	// Specify a comma-separated list of events to send to the Loki server.
-	// The events can be any combination of `lifecycle`, `logging`, and `network-acl`.
+	// The events can be any combination of `lifecycle`, `logging`, `network-acl` and `syscall`.
 	// ---
 	//  type: string
 	//  scope: global
 	//  defaultdesc: `lifecycle,logging`
 	//  shortdesc: Events to send to the Loki server
-	"loki.types": {Validator: validate.Optional(validate.IsListOf(validate.IsOneOf("lifecycle", "logging", "network-acl"))), Default: "lifecycle,logging"},
+	"loki.types": {Validator: validate.Optional(validate.IsListOf(validate.IsOneOf("lifecycle", "logging", "network-acl", "syscall"))), Default: "lifecycle,logging"},
 
 	// gendoc:generate(entity=server, group=oidc, key=oidc.client.id)
 	//
@@ -632,6 +1316,69 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Expected audience value for the application
 	"oidc.audience": {},
 
+	// gendoc:generate(entity=server, group=openfga, key=openfga.api.url)
+	// When set, authorization of remote users is delegated to the OpenFGA server.
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: URL of the OpenFGA server
+	"openfga.api.url": {},
+
+	// gendoc:generate(entity=server, group=openfga, key=openfga.api.token)
+	//
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: API token of the OpenFGA server
+	"openfga.api.token": {},
+
+	// gendoc:generate(entity=server, group=openfga, key=openfga.store.id)
+	//
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: ID of the OpenFGA permission store
+	"openfga.store.id": {},
+
+	// gendoc:generate(entity=server, group=openfga, key=openfga.store.model_id)
+	// If not set, the latest authorization model of the store is used.
+	// ---
+	//  type: string
+	//  scope: global
+	//  shortdesc: ID of the OpenFGA authorization model
+	"openfga.store.model_id": {},
+
+	// gendoc:generate(entity=server, group=openfga, key=openfga.cache.ttl)
+	// Specify the number of seconds for which an authorization decision is cached.
+	// To disable caching, set this option to `0`.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `60`
+	//  shortdesc: How long to cache authorization decisions
+	"openfga.cache.ttl": {Type: config.Int64, Default: "60", Validator: validate.Optional(validate.IsInRange(0, 3600))},
+
+	// gendoc:generate(entity=server, group=openfga, key=openfga.fail_closed)
+	// If disabled, requests fall back to the TLS based authorization when the OpenFGA server can't be reached.
+	// ---
+	//  type: bool
+	//  scope: global
+	//  defaultdesc: `true`
+	//  shortdesc: Whether to deny access when the OpenFGA server is unavailable
+	"openfga.fail_closed": {Type: config.Bool, Default: "true"},
+
+	// gendoc:generate(entity=server, group=openfga, key=openfga.tls_restrictions)
+	// If enabled, remote users need to be allowed both by their TLS client certificate (unrestricted or
+	// restricted to the project) and by OpenFGA, so OpenFGA can only further restrict access.
+	// If disabled, OpenFGA alone decides for remote users and can grant access beyond the restrictions of
+	// their certificate.
+	// ---
+	//  type: bool
+	//  scope: global
+	//  defaultdesc: `true`
+	//  shortdesc: Whether the restrictions of TLS clients also apply
+	"openfga.tls_restrictions": {Type: config.Bool, Default: "true"},
+
 	// OVN networking global keys.
 
 	// gendoc:generate(entity=server, group=miscellaneous, key=network.ovn.integration_bridge)
@@ -675,6 +1422,54 @@ func logLevelValidator(value string) error {
 	return nil
 }
 
+func logRouteValidator(value string) error {
+	_, _, err := parseLogRoute(value)
+	return err
+}
+
+// parseLogRoute parses a `<route>=<level>[+body]` entry of core.log_routes.
+func parseLogRoute(value string) (string, LogRoute, error) {
+	name, level, ok := strings.Cut(value, "=")
+	if !ok || name == "" {
+		return "", LogRoute{}, fmt.Errorf("Invalid route logging entry %q, expected <route>=<level>", value)
+	}
+
+	route := LogRoute{}
+	level, route.Body = strings.CutSuffix(level, "+body")
+
+	switch level {
+	case "none":
+		if route.Body {
+			return "", LogRoute{}, fmt.Errorf("Request bodies can't be logged for route %q with level %q", name, level)
+		}
+	case "debug", "info", "warn":
+		route.Level = level
+	default:
+		return "", LogRoute{}, fmt.Errorf("Invalid log level %q for route %q", level, name)
+	}
+
+	return name, route, nil
+}
+
+func messageValidator(value string) error {
+	for _, r := range value {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("The message can't contain control characters")
+		}
+	}
+
+	return nil
+}
+
+func messageExpiryValidator(value string) error {
+	_, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return fmt.Errorf("Invalid timestamp: %w", err)
+	}
+
+	return nil
+}
+
 func offlineThresholdDefault() string {
 	return strconv.Itoa(db.DefaultOfflineThreshold)
 }
@@ -734,3 +1529,19 @@ func maxStandByValidator(value string) error {
 
 	return nil
 }
+
+func validateInstanceLifecycleAction(value string) error {
+	if !strings.HasPrefix(value, "instance-") {
+		return fmt.Errorf("%q isn't an instance lifecycle action", value)
+	}
+
+	return nil
+}
+
+func validateHook(value string) error {
+	if strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+		return validate.IsRequestURL(value)
+	}
+
+	return validate.IsAbsFilePath(value)
+}
@@ -65,6 +65,39 @@ func TestConfigLoad_OfflineThresholdValidator(t *testing.T) {
 	require.EqualError(t, err, "cannot set 'cluster.offline_threshold' to '2': Value must be greater than '10'")
 }
 
+// Heartbeat interval must be at most half of the offline threshold.
+func TestConfigLoad_HeartbeatIntervalValidator(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	config, err := clusterConfig.Load(context.Background(), tx)
+	require.NoError(t, err)
+
+	_, err = config.Patch(map[string]string{"cluster.heartbeat_interval": "15"})
+	require.EqualError(t, err, "cluster.heartbeat_interval must be at most half of cluster.offline_threshold")
+
+	_, err = config.Patch(map[string]string{"cluster.heartbeat_interval": "15", "cluster.offline_threshold": "30"})
+	require.NoError(t, err)
+	assert.Equal(t, float64(15), config.HeartbeatInterval().Seconds())
+}
+
+// The power_save heartbeat profile relaxes the default heartbeat interval.
+func TestConfigLoad_HeartbeatProfile(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	config, err := clusterConfig.Load(context.Background(), tx)
+	require.NoError(t, err)
+
+	_, err = config.Patch(map[string]string{"cluster.heartbeat_profile": "power_save", "cluster.offline_threshold": "30"})
+	require.NoError(t, err)
+	assert.True(t, config.HeartbeatPowerSave())
+	assert.Equal(t, float64(20), config.HeartbeatInterval().Seconds())
+
+	_, err = config.Patch(map[string]string{"cluster.heartbeat_profile": "turbo"})
+	require.Error(t, err)
+}
+
 // Max number of voters must be odd.
 func TestConfigLoad_MaxVotersValidator(t *testing.T) {
 	tx, cleanup := db.NewTestClusterTx(t)
@@ -77,6 +110,76 @@ func TestConfigLoad_MaxVotersValidator(t *testing.T) {
 	require.EqualError(t, err, "cannot set 'cluster.max_voters' to '4': Value must be an odd number equal to or higher than 3")
 }
 
+// Trusted proxies must be IP addresses.
+func TestConfigLoad_HTTPSTrustedProxyValidator(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	config, err := clusterConfig.Load(context.Background(), tx)
+	require.NoError(t, err)
+
+	_, err = config.Patch(map[string]string{"core.https_trusted_proxy": "10.0.0.1, proxy.example.net"})
+	require.EqualError(t, err, "cannot set 'core.https_trusted_proxy' to '10.0.0.1, proxy.example.net': Item \"proxy.example.net\": Not an IP address \"proxy.example.net\"")
+
+	_, err = config.Patch(map[string]string{"core.https_trusted_proxy": "10.0.0.1, 2001:db8::1"})
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1, 2001:db8::1", config.HTTPSTrustedProxy())
+}
+
+// Route logging overrides are parsed into a map indexed by route name.
+func TestConfig_LogRoutes(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	config, err := clusterConfig.Load(context.Background(), tx)
+	require.NoError(t, err)
+
+	_, err = config.Patch(map[string]string{"core.log_routes": "instances=trace"})
+	require.EqualError(t, err, "cannot set 'core.log_routes' to 'instances=trace': Item \"instances=trace\": Invalid log level \"trace\" for route \"instances\"")
+
+	_, err = config.Patch(map[string]string{"core.log_routes": "instances=none+body"})
+	require.Error(t, err)
+
+	_, err = config.Patch(map[string]string{"core.log_routes": "/1.0/events=none, instanceExec=info+body"})
+	require.NoError(t, err)
+
+	routes := map[string]clusterConfig.LogRoute{
+		"/1.0/events":  {},
+		"instanceExec": {Level: "info", Body: true},
+	}
+
+	assert.Equal(t, routes, config.LogRoutes())
+
+	// The routes are parsed again when loading the configuration.
+	config, err = clusterConfig.Load(context.Background(), tx)
+	require.NoError(t, err)
+	assert.Equal(t, routes, config.LogRoutes())
+}
+
+// The hash only depends on the configuration values, not on how they were loaded.
+func TestConfig_Hash(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	config, err := clusterConfig.Load(context.Background(), tx)
+	require.NoError(t, err)
+
+	initial := config.Hash()
+
+	_, err = config.Patch(map[string]string{"core.proxy_http": "foo.bar", "core.proxy_https": "foo.bar", "images.auto_update_interval": "12"})
+	require.NoError(t, err)
+	assert.NotEqual(t, initial, config.Hash())
+
+	// A configuration loaded from the same values has the same hash.
+	reloaded, err := clusterConfig.Load(context.Background(), tx)
+	require.NoError(t, err)
+	assert.Equal(t, config.Hash(), reloaded.Hash())
+
+	_, err = config.Patch(map[string]string{"core.proxy_http": "", "core.proxy_https": "", "images.auto_update_interval": ""})
+	require.NoError(t, err)
+	assert.Equal(t, initial, config.Hash())
+}
+
 // If some previously set values are missing from the ones passed to Replace(),
 // they are deleted from the configuration.
 func TestConfig_ReplaceDeleteValues(t *testing.T) {
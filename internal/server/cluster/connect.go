@@ -22,6 +22,35 @@ import (
 	localtls "github.com/lxc/incus/shared/tls"
 )
 
+// keyPairTransport is a plain wrapper around an *http.Transport.
+type keyPairTransport struct {
+	transport *http.Transport
+}
+
+// RoundTrip executes the request using the wrapped transport.
+func (t *keyPairTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.transport.RoundTrip(req)
+}
+
+// Transport returns the wrapped transport.
+func (t *keyPairTransport) Transport() *http.Transport {
+	return t.transport
+}
+
+// SetClientKeyPair ensures the client certificate is used for the connection even when its private key can't be
+// exported (such as when held in a PKCS#11 token), by passing the key pair directly to the TLS configuration.
+func SetClientKeyPair(args *incus.ConnectionArgs, serverCert *localtls.CertInfo) {
+	if args.TLSClientKey != "" {
+		return
+	}
+
+	args.TransportWrapper = func(t *http.Transport) incus.HTTPTransporter {
+		t.TLSClientConfig.Certificates = []tls.Certificate{serverCert.KeyPair()}
+
+		return &keyPairTransport{transport: t}
+	}
+}
+
 // Connect is a convenience around incus.ConnectIncus that configures the client
 // with the correct parameters for node-to-node communication.
 //
@@ -47,6 +76,11 @@ func Connect(address string, networkCert *localtls.CertInfo, serverCert *localtl
 		UserAgent:     version.UserAgent,
 	}
 
+	// Also trust the network certificate members are switching to, if any.
+	args.TLSCA = pendingNetworkCertPEM()
+
+	SetClientKeyPair(args, serverCert)
+
 	if notify {
 		args.UserAgent = clusterRequest.UserAgentNotifier
 	}
@@ -196,6 +230,8 @@ func SetupTrust(serverCert *localtls.CertInfo, serverName string, targetAddress
 		UserAgent:     version.UserAgent,
 	}
 
+	SetClientKeyPair(args, serverCert)
+
 	target, err := incus.ConnectIncus(fmt.Sprintf("https://%s", targetAddress), args)
 	if err != nil {
 		return fmt.Errorf("Failed to connect to target cluster node %q: %w", targetAddress, err)
@@ -185,16 +185,16 @@ func EventListenerWait(ctx context.Context, address string) error {
 	}
 }
 
-// hubAddresses returns the addresses of members with event-hub role, and the event mode of the server.
-// The event mode will only be hub-server or hub-client if at least eventHubMinHosts have an event-hub role.
-// Otherwise the mode will be full-mesh.
+// hubAddresses returns the addresses of online members with event-hub role, and the event mode of the server.
+// The event mode will only be hub-server or hub-client if at least eventHubMinHosts online members have an
+// event-hub role. Otherwise the mode will be full-mesh, so that events keep flowing when hubs go offline.
 func hubAddresses(localAddress string, members map[int64]APIHeartbeatMember) ([]string, EventMode) {
 	var hubAddresses []string
 	var localHasHubRole bool
 
-	// Do a first pass of members to count the members with event-hub role, and whether we are a hub server.
+	// Do a first pass of members to count the online members with event-hub role, and whether we are a hub server.
 	for _, member := range members {
-		if RoleInSlice(db.ClusterRoleEventHub, member.Roles) {
+		if member.Online && RoleInSlice(db.ClusterRoleEventHub, member.Roles) {
 			hubAddresses = append(hubAddresses, member.Address)
 
 			if member.Address == localAddress {
@@ -0,0 +1,35 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/internal/server/db"
+)
+
+func TestHubAddresses(t *testing.T) {
+	hub := []db.ClusterRole{db.ClusterRoleEventHub}
+
+	members := map[int64]APIHeartbeatMember{
+		1: {Address: "10.0.0.1:8443", Online: true, Roles: hub},
+		2: {Address: "10.0.0.2:8443", Online: true, Roles: hub},
+		3: {Address: "10.0.0.3:8443", Online: true},
+	}
+
+	addresses, mode := hubAddresses("10.0.0.3:8443", members)
+	assert.ElementsMatch(t, []string{"10.0.0.1:8443", "10.0.0.2:8443"}, addresses)
+	assert.Equal(t, EventModeHubClient, mode)
+
+	_, mode = hubAddresses("10.0.0.1:8443", members)
+	assert.Equal(t, EventModeHubServer, mode)
+
+	// Offline hubs are ignored, falling back to full-mesh when not enough are left.
+	member := members[2]
+	member.Online = false
+	members[2] = member
+
+	addresses, mode = hubAddresses("10.0.0.3:8443", members)
+	assert.Equal(t, []string{"10.0.0.1:8443"}, addresses)
+	assert.Equal(t, EventModeFullMesh, mode)
+}
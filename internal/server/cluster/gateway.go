@@ -26,6 +26,7 @@ import (
 	"github.com/lxc/incus/internal/server/response"
 	"github.com/lxc/incus/internal/server/state"
 	localUtil "github.com/lxc/incus/internal/server/util"
+	"github.com/lxc/incus/shared/api"
 	"github.com/lxc/incus/shared/logger"
 	"github.com/lxc/incus/shared/tcp"
 	localtls "github.com/lxc/incus/shared/tls"
@@ -61,6 +62,7 @@ func NewGateway(shutdownCtx context.Context, db *db.Node, networkCert *localtls.
 		acceptCh:    make(chan net.Conn),
 		store:       &dqliteNodeStore{},
 		state:       stateFunc,
+		memberState: NewMemberStateTracker(),
 	}
 
 	err := gateway.init(false)
@@ -121,10 +123,16 @@ type Gateway struct {
 	Cluster                   *db.Cluster
 	HeartbeatNodeHook         HeartbeatHook
 	HeartbeatOfflineThreshold time.Duration
+	HeartbeatInterval         time.Duration
+	HeartbeatPowerSave        bool
+	HeartbeatStateDelay       time.Duration
 	heartbeatCancel           context.CancelFunc
 	heartbeatCancelLock       sync.Mutex
 	HeartbeatLock             sync.Mutex
 
+	// Smoothed online state of the cluster members, used for the offline warnings.
+	memberState *MemberStateTracker
+
 	// NodeStore wrapper.
 	store *dqliteNodeStore
 
@@ -489,7 +497,7 @@ func (g *Gateway) TransferLeadership() error {
 	}
 
 	if id == 0 {
-		return fmt.Errorf("No online voter found")
+		return api.StatusErrorf(http.StatusPreconditionFailed, "No online voter found")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -498,6 +506,11 @@ func (g *Gateway) TransferLeadership() error {
 	return client.Transfer(ctx, id)
 }
 
+// SnapshotParams returns the raft snapshot threshold and trailing values the gateway was configured with.
+func (g *Gateway) SnapshotParams() (uint64, uint64) {
+	return g.options.snapshotThreshold, g.options.snapshotTrailing
+}
+
 // DemoteOfflineNode force demoting an offline node.
 func (g *Gateway) DemoteOfflineNode(raftID uint64) error {
 	cli, err := g.getClient()
@@ -783,6 +796,10 @@ func (g *Gateway) init(bootstrap bool) error {
 
 		options := []dqlite.Option{
 			dqlite.WithBindAddress(g.bindAddress),
+			dqlite.WithSnapshotParams(dqlite.SnapshotParams{
+				Threshold: g.options.snapshotThreshold,
+				Trailing:  g.options.snapshotTrailing,
+			}),
 		}
 
 		if info.Address == "1" {
@@ -902,6 +919,12 @@ func (g *Gateway) isLeader() (bool, error) {
 // ErrNotLeader signals that a node not the leader.
 var ErrNotLeader = fmt.Errorf("Not leader")
 
+// RaftNodes returns information about the cluster members that are currently part of the raft cluster, as
+// configured in the raft log. It returns ErrNotLeader if this member isn't the leader.
+func (g *Gateway) RaftNodes() ([]db.RaftNode, error) {
+	return g.currentRaftNodes()
+}
+
 // Return information about the cluster members that a currently part of the raft
 // cluster, as configured in the raft log. It returns an error if this node is
 // not the leader.
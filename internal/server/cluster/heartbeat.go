@@ -41,6 +41,9 @@ type APIHeartbeatMember struct {
 	LastHeartbeat time.Time        // Last time we received a successful response from node.
 	Online        bool             // Calculated from offline threshold and LastHeatbeat time.
 	Roles         []db.ClusterRole // Supplementary non-database roles the member has.
+	Schema        int              // Schema version of the daemon running the member.
+	APIExtensions int              // Number of API extensions of the daemon running the member.
+	Latency       time.Duration    // Round-trip time of the last successful heartbeat sent by the leader.
 	updated       bool             // Has node been updated during this heartbeat run. Not sent to nodes.
 }
 
@@ -65,10 +68,17 @@ type APIHeartbeat struct {
 	Version    APIHeartbeatVersion
 	Time       time.Time
 
+	// Hash of the cluster-wide configuration loaded by the leader, used to detect configuration drift.
+	ConfigHash string
+
 	// Indicates if heartbeat contains a fresh set of node states.
 	// This can be used to indicate to the receiving node that the state is fresh enough to
 	// trigger node refresh activies.
 	FullStateList bool
+
+	// Used to smooth the member state changes before raising or resolving offline warnings.
+	memberState      *MemberStateTracker
+	memberStateDelay time.Duration
 }
 
 // Update updates an existing APIHeartbeat struct with the raft and all node states supplied.
@@ -98,6 +108,8 @@ func (hbState *APIHeartbeat) Update(fullStateList bool, raftNodes []db.RaftNode,
 			LastHeartbeat: node.Heartbeat,
 			Online:        !node.IsOffline(offlineThreshold),
 			Roles:         node.Roles,
+			Schema:        node.Schema,
+			APIExtensions: node.APIExtensions,
 		}
 
 		raftNode, exists := raftNodeMap[member.Address]
@@ -139,6 +151,18 @@ func (hbState *APIHeartbeat) Update(fullStateList bool, raftNodes []db.RaftNode,
 	}
 }
 
+// memberOnline records the outcome of a heartbeat to a member and returns whether the member should be
+// considered online for the purpose of the offline warnings.
+func (hbState *APIHeartbeat) memberOnline(nodeID int64, online bool) bool {
+	if hbState.memberState == nil {
+		return online
+	}
+
+	online, _ = hbState.memberState.Observe(nodeID, online, hbState.memberStateDelay)
+
+	return online
+}
+
 // Send sends heartbeat requests to the nodes supplied and updates heartbeat state.
 func (hbState *APIHeartbeat) Send(ctx context.Context, networkCert *localtls.CertInfo, serverCert *localtls.CertInfo, localAddress string, nodes []db.NodeInfo, spreadDuration time.Duration) {
 	heartbeatsWg := sync.WaitGroup{}
@@ -162,6 +186,7 @@ func (hbState *APIHeartbeat) Send(ctx context.Context, networkCert *localtls.Cer
 		heartbeatData.Time = time.Now().UTC()
 
 		// Don't use ctx here, as we still want to finish off the request if the ctx has been cancelled.
+		start := time.Now()
 		err := HeartbeatNode(context.Background(), address, networkCert, serverCert, heartbeatData)
 		if err == nil {
 			heartbeatData.Lock()
@@ -172,20 +197,23 @@ func (hbState *APIHeartbeat) Send(ctx context.Context, networkCert *localtls.Cer
 			}
 
 			hbNode.LastHeartbeat = time.Now()
+			hbNode.Latency = hbNode.LastHeartbeat.Sub(start)
 			hbNode.Online = true
 			hbNode.updated = true
 			heartbeatData.Members[nodeID] = hbNode
 			heartbeatData.Unlock()
 			logger.Debug("Successful heartbeat", logger.Ctx{"remote": address})
 
-			err = warnings.ResolveWarningsByLocalNodeAndProjectAndTypeAndEntity(hbState.cluster, "", warningtype.OfflineClusterMember, cluster.TypeNode, int(nodeID))
-			if err != nil {
-				logger.Warn("Failed to resolve warning", logger.Ctx{"err": err})
+			if hbState.memberOnline(nodeID, true) {
+				err = warnings.ResolveWarningsByLocalNodeAndProjectAndTypeAndEntity(hbState.cluster, "", warningtype.OfflineClusterMember, cluster.TypeNode, int(nodeID))
+				if err != nil {
+					logger.Warn("Failed to resolve warning", logger.Ctx{"err": err})
+				}
 			}
 		} else {
 			logger.Warn("Failed heartbeat", logger.Ctx{"remote": address, "err": err})
 
-			if ctx.Err() == nil {
+			if ctx.Err() == nil && !hbState.memberOnline(nodeID, false) {
 				err = hbState.cluster.UpsertWarningLocalNode("", cluster.TypeNode, int(nodeID), warningtype.OfflineClusterMember, err.Error())
 				if err != nil {
 					logger.Warn("Failed to create warning", logger.Ctx{"err": err})
@@ -223,7 +251,20 @@ func (hbState *APIHeartbeat) Send(ctx context.Context, networkCert *localtls.Cer
 func HeartbeatTask(gateway *Gateway) (task.Func, task.Schedule) {
 	// Since the database APIs are blocking we need to wrap the core logic
 	// and run it in a goroutine, so we can abort as soon as the context expires.
+	// Track how often the heartbeat wakes up to report it.
+	wakeups := 0
+	wakeupsSince := time.Now()
+
 	heartbeatWrapper := func(ctx context.Context) {
+		wakeups++
+		if time.Since(wakeupsSince) >= heartbeatWakeupsReportInterval {
+			perMinute := float64(wakeups) / time.Since(wakeupsSince).Minutes()
+			logger.Debug("Cluster heartbeat wakeups", logger.Ctx{"wakeups": wakeups, "perMinute": fmt.Sprintf("%.2f", perMinute), "powerSave": gateway.HeartbeatPowerSave})
+
+			wakeups = 0
+			wakeupsSince = time.Now()
+		}
+
 		if gateway.HearbeatCancelFunc() == nil {
 			ch := make(chan struct{})
 			go func() {
@@ -238,14 +279,43 @@ func HeartbeatTask(gateway *Gateway) (task.Func, task.Schedule) {
 	}
 
 	schedule := func() (time.Duration, error) {
-		return task.Every(gateway.heartbeatInterval())()
+		return task.Every(gateway.heartbeatDelay())()
 	}
 
 	return heartbeatWrapper, schedule
 }
 
+// heartbeatWakeupsReportInterval is how often the heartbeat wakeup frequency is logged.
+const heartbeatWakeupsReportInterval = 10 * time.Minute
+
+// heartbeatDelay returns the delay until the next heartbeat round.
+// The heartbeat interval is jittered by up to 5% so that the leaders of several clusters don't wake up in sync.
+// In power save mode, the delay is also rounded to whole seconds so that the wakeup can be coalesced with others.
+func (g *Gateway) heartbeatDelay() time.Duration {
+	interval := g.heartbeatInterval()
+
+	jitterRange := int64(interval / 10)
+	if jitterRange > 0 {
+		interval += time.Duration(rand.Int63n(jitterRange) - jitterRange/2)
+	}
+
+	if g.HeartbeatPowerSave {
+		interval = time.Until(time.Now().Add(interval).Truncate(time.Second))
+		if interval < time.Second {
+			interval = time.Second
+		}
+	}
+
+	return interval
+}
+
 // heartbeatInterval returns heartbeat interval to use.
+// Unless configured, this is half of the offline threshold.
 func (g *Gateway) heartbeatInterval() time.Duration {
+	if g.HeartbeatInterval > 0 {
+		return g.HeartbeatInterval
+	}
+
 	threshold := g.HeartbeatOfflineThreshold
 	if threshold <= 0 {
 		threshold = time.Duration(db.DefaultOfflineThreshold) * time.Second
@@ -380,11 +450,24 @@ func (g *Gateway) heartbeat(ctx context.Context, mode heartbeatMode) {
 
 	// Cumulative set of node states (will be written back to database once done).
 	hbState := NewAPIHearbeat(g.Cluster)
+	hbState.memberState = g.memberState
+	hbState.memberStateDelay = g.HeartbeatStateDelay
+
+	memberIDs := make([]int64, 0, len(members))
+	for _, member := range members {
+		memberIDs = append(memberIDs, member.ID)
+	}
+
+	g.memberState.Retain(memberIDs...)
+
+	if s.GlobalConfig != nil {
+		hbState.ConfigHash = s.GlobalConfig.Hash()
+	}
 
 	// If we are doing a normal heartbeat round then spread the requests over the heartbeatInterval in order
-	// to reduce load on the cluster.
+	// to reduce load on the cluster. In power save mode, the requests are batched to limit wakeups.
 	spreadDuration := time.Duration(0)
-	if mode == hearbeatNormal {
+	if mode == hearbeatNormal && !g.HeartbeatPowerSave {
 		spreadDuration = heartbeatInterval
 	}
 
@@ -0,0 +1,82 @@
+package cluster
+
+import (
+	"sync"
+	"time"
+)
+
+// MemberStateTracker smooths the online state of cluster members, so that a member which briefly misses a
+// heartbeat or briefly comes back doesn't cause its state to flap.
+type MemberStateTracker struct {
+	mu      sync.Mutex
+	members map[int64]*trackedMember
+
+	// Used by tests to control the passing of time.
+	now func() time.Time
+}
+
+type trackedMember struct {
+	online  bool      // Reported state.
+	pending bool      // Whether the member is currently observed in a different state than the reported one.
+	since   time.Time // When the member was first observed in the pending state.
+}
+
+// NewMemberStateTracker returns a new MemberStateTracker.
+func NewMemberStateTracker() *MemberStateTracker {
+	return &MemberStateTracker{
+		members: map[int64]*trackedMember{},
+		now:     time.Now,
+	}
+}
+
+// Observe records the observed online state of a member and returns its reported state along with whether the
+// reported state has just changed.
+// A change is only reported once the member has been continuously observed in the new state for at least the
+// given delay. Members are considered online until observed otherwise.
+func (t *MemberStateTracker) Observe(memberID int64, online bool, delay time.Duration) (bool, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.members[memberID]
+	if !ok {
+		state = &trackedMember{online: true}
+		t.members[memberID] = state
+	}
+
+	if online == state.online {
+		state.pending = false
+		return state.online, false
+	}
+
+	now := t.now()
+	if !state.pending {
+		state.pending = true
+		state.since = now
+	}
+
+	if now.Sub(state.since) < delay {
+		return state.online, false
+	}
+
+	state.online = online
+	state.pending = false
+
+	return state.online, true
+}
+
+// Retain drops the state tracked for members other than the ones given.
+func (t *MemberStateTracker) Retain(memberIDs ...int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	keep := make(map[int64]bool, len(memberIDs))
+	for _, id := range memberIDs {
+		keep[id] = true
+	}
+
+	for id := range t.members {
+		if !keep[id] {
+			delete(t.members, id)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemberStateTracker(t *testing.T) {
+	now := time.Now()
+	tracker := NewMemberStateTracker()
+	tracker.now = func() time.Time { return now }
+
+	delay := 30 * time.Second
+
+	// Members start online.
+	online, changed := tracker.Observe(1, true, delay)
+	assert.True(t, online)
+	assert.False(t, changed)
+
+	// A missed heartbeat isn't reported until it lasted for the delay.
+	online, changed = tracker.Observe(1, false, delay)
+	assert.True(t, online)
+	assert.False(t, changed)
+
+	now = now.Add(20 * time.Second)
+	online, changed = tracker.Observe(1, false, delay)
+	assert.True(t, online)
+	assert.False(t, changed)
+
+	now = now.Add(10 * time.Second)
+	online, changed = tracker.Observe(1, false, delay)
+	assert.False(t, online)
+	assert.True(t, changed)
+
+	// Coming back briefly resets the pending change.
+	online, changed = tracker.Observe(1, true, delay)
+	assert.False(t, online)
+	assert.False(t, changed)
+
+	online, changed = tracker.Observe(1, false, delay)
+	assert.False(t, online)
+	assert.False(t, changed)
+
+	now = now.Add(20 * time.Second)
+	online, changed = tracker.Observe(1, true, delay)
+	assert.False(t, online)
+	assert.False(t, changed)
+
+	now = now.Add(30 * time.Second)
+	online, changed = tracker.Observe(1, true, delay)
+	assert.True(t, online)
+	assert.True(t, changed)
+
+	// Without a delay, changes are reported straight away.
+	online, changed = tracker.Observe(2, false, 0)
+	assert.False(t, online)
+	assert.True(t, changed)
+
+	// Forgotten members start over.
+	tracker.Retain(1)
+	online, changed = tracker.Observe(2, false, delay)
+	assert.True(t, online)
+	assert.False(t, changed)
+}
@@ -300,6 +300,22 @@ func Accept(state *state.State, gateway *Gateway, name, address string, schema,
 	return nodes, nil
 }
 
+// ValidateAccept checks whether a new member with the given name, address, schema version and API extensions
+// count would be accepted by Accept, without modifying the cluster.
+func ValidateAccept(state *state.State, name string, address string, schema int, api int) error {
+	if name == "" {
+		return fmt.Errorf("Member name must not be empty")
+	}
+
+	if address == "" {
+		return fmt.Errorf("Member address must not be empty")
+	}
+
+	return state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return membershipCheckClusterStateForAccept(ctx, tx, name, address, schema, api)
+	})
+}
+
 // Join makes a non-clustered server join an existing cluster.
 //
 // It's assumed that Accept() was previously called against the leader node,
@@ -22,15 +22,29 @@ func Latency(latency float64) Option {
 	}
 }
 
+// SnapshotParams sets how many raft log entries are written before a database
+// snapshot is taken (threshold) and how many are retained afterwards (trailing).
+// The defaults match those of dqlite.
+func SnapshotParams(threshold uint64, trailing uint64) Option {
+	return func(options *options) {
+		options.snapshotThreshold = threshold
+		options.snapshotTrailing = trailing
+	}
+}
+
 // Create a options instance with default values.
 func newOptions() *options {
 	return &options{
-		latency:  1.0,
-		logLevel: "ERROR",
+		latency:           1.0,
+		logLevel:          "ERROR",
+		snapshotThreshold: 1024,
+		snapshotTrailing:  8192,
 	}
 }
 
 type options struct {
-	latency  float64
-	logLevel string
+	latency           float64
+	logLevel          string
+	snapshotThreshold uint64
+	snapshotTrailing  uint64
 }
@@ -3,8 +3,10 @@ package cluster
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/lxc/incus/internal/server/certificate"
@@ -13,6 +15,48 @@ import (
 	localtls "github.com/lxc/incus/shared/tls"
 )
 
+// Network certificate trusted alongside the current one while the cluster certificate is being replaced.
+var networkCertPending []byte
+var networkCertPendingMu sync.Mutex
+
+// SetPendingNetworkCert sets an additional network certificate (DER encoded) to trust when connecting to other
+// members. This lets members keep talking to each other while the cluster certificate is being replaced on each
+// of them. A nil certificate clears it.
+func SetPendingNetworkCert(cert []byte) {
+	networkCertPendingMu.Lock()
+	networkCertPending = cert
+	networkCertPendingMu.Unlock()
+}
+
+// pendingNetworkCert returns the pending network certificate, if any.
+func pendingNetworkCert() *x509.Certificate {
+	networkCertPendingMu.Lock()
+	defer networkCertPendingMu.Unlock()
+
+	if networkCertPending == nil {
+		return nil
+	}
+
+	cert, err := x509.ParseCertificate(networkCertPending)
+	if err != nil {
+		return nil
+	}
+
+	return cert
+}
+
+// pendingNetworkCertPEM returns the pending network certificate PEM encoded, if any.
+func pendingNetworkCertPEM() string {
+	networkCertPendingMu.Lock()
+	defer networkCertPendingMu.Unlock()
+
+	if networkCertPending == nil {
+		return ""
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: networkCertPending}))
+}
+
 // Return a TLS configuration suitable for establishing intra-member network connections using the server cert.
 func tlsClientConfig(networkCert *localtls.CertInfo, serverCert *localtls.CertInfo) (*tls.Config, error) {
 	if networkCert == nil {
@@ -44,6 +88,14 @@ func tlsClientConfig(networkCert *localtls.CertInfo, serverCert *localtls.CertIn
 	netCert.KeyUsage = x509.KeyUsageCertSign
 	config.RootCAs.AddCert(netCert)
 
+	// Also trust the network certificate members are switching to, if any.
+	pendingCert := pendingNetworkCert()
+	if pendingCert != nil {
+		pendingCert.IsCA = true
+		pendingCert.KeyUsage = x509.KeyUsageCertSign
+		config.RootCAs.AddCert(pendingCert)
+	}
+
 	// Always use network certificate's DNS name rather than server cert, so that it matches.
 	if len(netCert.DNSNames) > 0 {
 		config.ServerName = netCert.DNSNames[0]
@@ -71,13 +123,13 @@ func tlsCheckCert(r *http.Request, networkCert *localtls.CertInfo, serverCert *l
 		// member before the database is available. It also allows us to switch the server certificate to
 		// the network certificate during cluster upgrade to per-server certificates, and it be trusted.
 		trustedServerCert, _ := x509.ParseCertificate(serverCert.KeyPair().Certificate[0])
-		trusted, _ := localUtil.CheckTrustState(*i, map[string]x509.Certificate{serverCert.Fingerprint(): *trustedServerCert}, networkCert, false)
+		trusted, _ := localUtil.CheckTrustState(*i, map[string]x509.Certificate{serverCert.Fingerprint(): *trustedServerCert}, networkCert, false, nil)
 		if trusted {
 			return true
 		}
 
 		// Check the trusted server certficates list provided.
-		trusted, _ = localUtil.CheckTrustState(*i, trustedCerts[certificate.TypeServer], networkCert, false)
+		trusted, _ = localUtil.CheckTrustState(*i, trustedCerts[certificate.TypeServer], networkCert, false, nil)
 		if trusted {
 			return true
 		}
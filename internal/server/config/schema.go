@@ -62,6 +62,7 @@ type Key struct {
 	Type       Type   // Type of the value. It defaults to String.
 	Default    string // If the key is not set in a Map, use this value instead.
 	Deprecated string // Optional message to set if this config value is deprecated.
+	Restart    bool   // Whether changes only take effect after restarting the daemon.
 
 	// Optional function used to validate the values. It's called by Map
 	// all the times the value associated with this Key is going to be
@@ -85,6 +86,18 @@ const (
 	Int64
 )
 
+// String returns the name of the value type.
+func (t Type) String() string {
+	switch t {
+	case Bool:
+		return "bool"
+	case Int64:
+		return "integer"
+	default:
+		return "string"
+	}
+}
+
 // Tells if the given value can be assigned to this particular Value instance.
 func (v *Key) validate(value string) error {
 	validator := v.Validator
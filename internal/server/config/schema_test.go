@@ -27,3 +27,9 @@ func TestSchema_Keys(t *testing.T) {
 	keys := []string{"bar", "foo"}
 	assert.Equal(t, keys, schema.Keys())
 }
+
+func TestType_String(t *testing.T) {
+	assert.Equal(t, "string", config.String.String())
+	assert.Equal(t, "bool", config.Bool.String())
+	assert.Equal(t, "integer", config.Int64.String())
+}
@@ -28,6 +28,20 @@ CREATE TABLE "cluster_groups" (
     description TEXT NOT NULL,
     UNIQUE (name)
 );
+CREATE TABLE "cluster_healing_evacuations" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    member_name TEXT NOT NULL,
+    evacuated_at DATETIME NOT NULL
+);
+CREATE INDEX cluster_healing_evacuations_evacuated_at_idx ON cluster_healing_evacuations (evacuated_at);
+CREATE TABLE "cluster_join_token_uses" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    secret_hash TEXT NOT NULL,
+    server_name TEXT NOT NULL,
+    used_at DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL
+);
+CREATE INDEX cluster_join_token_uses_secret_hash_idx ON cluster_join_token_uses (secret_hash);
 CREATE TABLE config (
     id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
     key TEXT NOT NULL,
@@ -393,6 +407,21 @@ CREATE TABLE "operations" (
     FOREIGN KEY (node_id) REFERENCES "nodes" (id) ON DELETE CASCADE,
     FOREIGN KEY (project_id) REFERENCES "projects" (id) ON DELETE CASCADE
 );
+CREATE TABLE "operations_history" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    uuid TEXT NOT NULL,
+    project TEXT NOT NULL,
+    location TEXT NOT NULL,
+    type INTEGER NOT NULL DEFAULT 0,
+    description TEXT NOT NULL,
+    status_code INTEGER NOT NULL,
+    error TEXT NOT NULL,
+    requestor TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    finished_at DATETIME NOT NULL,
+    UNIQUE (uuid)
+);
+CREATE INDEX operations_history_finished_at_idx ON operations_history (finished_at);
 CREATE TABLE "profiles" (
     id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
     name TEXT NOT NULL,
@@ -621,5 +650,5 @@ CREATE TABLE "warnings" (
 );
 CREATE UNIQUE INDEX warnings_unique_node_id_project_id_entity_type_code_entity_id_type_code ON warnings(IFNULL(node_id, -1), IFNULL(project_id, -1), entity_type_code, entity_id, type_code);
 
-INSERT INTO schema (version, updated_at) VALUES (69, strftime("%s"))
+INSERT INTO schema (version, updated_at) VALUES (72, strftime("%s"))
 `
@@ -106,6 +106,74 @@ var updates = map[int]schema.Update{
 	67: updateFromV66,
 	68: updateFromV67,
 	69: updateFromV68,
+	70: updateFromV69,
+	71: updateFromV70,
+	72: updateFromV71,
+}
+
+// updateFromV71 adds a table recording the automatic evacuations of offline members, so that the limit on the
+// number of evacuations within the healing window holds across leader changes and restarts.
+func updateFromV71(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+CREATE TABLE "cluster_healing_evacuations" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    member_name TEXT NOT NULL,
+    evacuated_at DATETIME NOT NULL
+);
+CREATE INDEX cluster_healing_evacuations_evacuated_at_idx ON cluster_healing_evacuations (evacuated_at);
+`)
+	if err != nil {
+		return fmt.Errorf("Failed adding cluster_healing_evacuations table: %w", err)
+	}
+
+	return nil
+}
+
+// updateFromV70 adds a table recording the uses of the cluster join tokens, so that single-use tokens can't be
+// used twice even when presented to different members.
+func updateFromV70(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+CREATE TABLE "cluster_join_token_uses" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    secret_hash TEXT NOT NULL,
+    server_name TEXT NOT NULL,
+    used_at DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL
+);
+CREATE INDEX cluster_join_token_uses_secret_hash_idx ON cluster_join_token_uses (secret_hash);
+`)
+	if err != nil {
+		return fmt.Errorf("Failed adding cluster_join_token_uses table: %w", err)
+	}
+
+	return nil
+}
+
+// updateFromV69 adds a table keeping the history of completed operations.
+// The project and location are recorded by name so that the history outlives them.
+func updateFromV69(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+CREATE TABLE "operations_history" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    uuid TEXT NOT NULL,
+    project TEXT NOT NULL,
+    location TEXT NOT NULL,
+    type INTEGER NOT NULL DEFAULT 0,
+    description TEXT NOT NULL,
+    status_code INTEGER NOT NULL,
+    error TEXT NOT NULL,
+    requestor TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    finished_at DATETIME NOT NULL,
+    UNIQUE (uuid)
+);
+CREATE INDEX operations_history_finished_at_idx ON operations_history (finished_at);
+`)
+	if err != nil {
+		return fmt.Errorf("Failed adding operations_history table: %w", err)
+	}
+
+	return nil
 }
 
 // updateFromV68 fixes unique index for record name to make it zone specific.
@@ -0,0 +1,43 @@
+//go:build linux && cgo && !agent
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lxc/incus/internal/server/db/query"
+)
+
+// GetClusterHealingEvacuationsCount returns the number of automatic evacuations of offline members recorded since
+// the given time.
+func (c *ClusterTx) GetClusterHealingEvacuationsCount(ctx context.Context, since time.Time) (int, error) {
+	count, err := query.Count(ctx, c.tx, "cluster_healing_evacuations", "evacuated_at >= ?", since.UTC())
+	if err != nil {
+		return -1, fmt.Errorf("Failed counting automatic evacuations: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateClusterHealingEvacuation records the automatic evacuation of the named offline member.
+func (c *ClusterTx) CreateClusterHealingEvacuation(ctx context.Context, memberName string) error {
+	stmt := `INSERT INTO cluster_healing_evacuations (member_name, evacuated_at) VALUES (?, ?)`
+	_, err := c.tx.ExecContext(ctx, stmt, memberName, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("Failed recording automatic evacuation: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteClusterHealingEvacuations removes the automatic evacuations recorded before the given time.
+func (c *ClusterTx) DeleteClusterHealingEvacuations(ctx context.Context, before time.Time) error {
+	_, err := c.tx.ExecContext(ctx, "DELETE FROM cluster_healing_evacuations WHERE evacuated_at < ?", before.UTC())
+	if err != nil {
+		return fmt.Errorf("Failed removing old automatic evacuations: %w", err)
+	}
+
+	return nil
+}
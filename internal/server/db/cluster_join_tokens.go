@@ -0,0 +1,68 @@
+//go:build linux && cgo && !agent
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lxc/incus/internal/server/db/query"
+)
+
+// ClusterJoinTokenUse is a recorded use of a cluster join token.
+type ClusterJoinTokenUse struct {
+	ServerName string
+	UsedAt     time.Time
+}
+
+// GetClusterJoinTokenUses returns the recorded uses of the cluster join token with the given secret hash.
+func (c *ClusterTx) GetClusterJoinTokenUses(ctx context.Context, secretHash string) ([]ClusterJoinTokenUse, error) {
+	q := `SELECT server_name, used_at FROM cluster_join_token_uses WHERE secret_hash = ? ORDER BY used_at`
+
+	var uses []ClusterJoinTokenUse
+	err := query.Scan(ctx, c.tx, q, func(scan func(dest ...any) error) error {
+		use := ClusterJoinTokenUse{}
+
+		err := scan(&use.ServerName, &use.UsedAt)
+		if err != nil {
+			return err
+		}
+
+		uses = append(uses, use)
+
+		return nil
+	}, secretHash)
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading cluster join token uses: %w", err)
+	}
+
+	return uses, nil
+}
+
+// CreateClusterJoinTokenUse records a use of the cluster join token with the given secret hash by the named
+// member. The record is kept until the token expiry, or for a day if the token doesn't expire.
+func (c *ClusterTx) CreateClusterJoinTokenUse(ctx context.Context, secretHash string, serverName string, expiresAt time.Time) error {
+	if expiresAt.IsZero() {
+		expiresAt = time.Now().Add(24 * time.Hour)
+	}
+
+	stmt := `INSERT INTO cluster_join_token_uses (secret_hash, server_name, used_at, expires_at) VALUES (?, ?, ?, ?)`
+	_, err := c.tx.ExecContext(ctx, stmt, secretHash, serverName, time.Now().UTC(), expiresAt.UTC())
+	if err != nil {
+		return fmt.Errorf("Failed recording cluster join token use: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredClusterJoinTokenUses removes the recorded uses of the cluster join tokens which expired before the
+// given time. It returns the number of removed records.
+func (c *ClusterTx) DeleteExpiredClusterJoinTokenUses(ctx context.Context, before time.Time) (int64, error) {
+	result, err := c.tx.ExecContext(ctx, "DELETE FROM cluster_join_token_uses WHERE expires_at < ?", before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("Failed removing expired cluster join token uses: %w", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -299,7 +299,13 @@ func (c *Cluster) GetNodeID() int64 {
 //
 // If EnterExclusive has been called before, calling Transaction will block
 // until ExitExclusive has been called as well to release the lock.
+//
+// Transactions taking longer than the threshold set with SetSlowTransactionThreshold are logged along with
+// their caller.
 func (c *Cluster) Transaction(ctx context.Context, f func(context.Context, *ClusterTx) error) error {
+	start := time.Now()
+	defer checkSlowTransaction(start, 1)
+
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	return c.transaction(ctx, f)
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/lxc/incus/internal/server/db/query"
+	"github.com/lxc/incus/internal/version"
 	"github.com/lxc/incus/shared/api"
 )
 
@@ -74,6 +75,23 @@ func (c *Cluster) GetNetworkZonesByProject(project string) ([]string, error) {
 	return zoneNames, nil
 }
 
+// GetNetworkZoneURIs returns the URIs for the network zones with the given project.
+func (c *ClusterTx) GetNetworkZoneURIs(ctx context.Context, projectID int, project string) ([]string, error) {
+	sql := `SELECT networks_zones.name from networks_zones WHERE networks_zones.project_id = ?`
+
+	names, err := query.SelectStrings(ctx, c.tx, sql, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get URIs for network zone: %w", err)
+	}
+
+	uris := make([]string, len(names))
+	for i := range names {
+		uris[i] = api.NewURL().Path(version.APIVersion, "network-zones", names[i]).Project(project).String()
+	}
+
+	return uris, nil
+}
+
 // GetNetworkZoneKeys returns a map of key names to keys.
 func (c *Cluster) GetNetworkZoneKeys() (map[string]string, error) {
 	q := `SELECT networks_zones.name, networks_zones_config.key, networks_zones_config.value
@@ -198,6 +198,26 @@ func (c *ClusterTx) GetNetworkID(ctx context.Context, projectName string, name s
 	}
 }
 
+// MoveNetwork moves the network to another project.
+func (c *ClusterTx) MoveNetwork(ctx context.Context, projectName string, name string, targetProjectName string) error {
+	stmt := "UPDATE networks SET project_id = (SELECT id FROM projects WHERE name = ?) WHERE project_id = (SELECT id FROM projects WHERE name = ?) AND name = ?"
+	result, err := c.tx.ExecContext(ctx, stmt, targetProjectName, projectName, name)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n != 1 {
+		return api.StatusErrorf(http.StatusNotFound, "Network not found")
+	}
+
+	return nil
+}
+
 // GetNetworkNameAndProjectWithID returns the network name and project name for the given ID.
 func (c *Cluster) GetNetworkNameAndProjectWithID(networkID int) (string, string, error) {
 	var networkName string
@@ -773,32 +793,10 @@ func (c *Cluster) getNetworkConfig(ctx context.Context, tx *ClusterTx, networkID
 func (c *Cluster) CreateNetwork(projectName string, name string, description string, netType NetworkType, config map[string]string) (int64, error) {
 	var id int64
 	err := c.Transaction(context.TODO(), func(ctx context.Context, tx *ClusterTx) error {
-		// Insert a new network record with state networkCreated.
-		result, err := tx.tx.Exec("INSERT INTO networks (project_id, name, description, state, type) VALUES ((SELECT id FROM projects WHERE name = ?), ?, ?, ?, ?)",
-			projectName, name, description, networkCreated, netType)
-		if err != nil {
-			return err
-		}
-
-		id, err := result.LastInsertId()
-		if err != nil {
-			return err
-		}
+		var err error
+		id, err = tx.CreateNetwork(ctx, projectName, name, description, netType, config)
 
-		// Insert a node-specific entry pointing to ourselves with state networkPending.
-		columns := []string{"network_id", "node_id", "state"}
-		values := []any{id, c.nodeID, networkPending}
-		_, err = query.UpsertObject(tx.tx, "networks_nodes", columns, values)
-		if err != nil {
-			return err
-		}
-
-		err = networkConfigAdd(tx.tx, id, c.nodeID, config)
-		if err != nil {
-			return err
-		}
-
-		return nil
+		return err
 	})
 	if err != nil {
 		id = -1
@@ -807,6 +805,36 @@ func (c *Cluster) CreateNetwork(projectName string, name string, description str
 	return id, err
 }
 
+// CreateNetwork creates a new network.
+func (c *ClusterTx) CreateNetwork(ctx context.Context, projectName string, name string, description string, netType NetworkType, config map[string]string) (int64, error) {
+	// Insert a new network record with state networkCreated.
+	result, err := c.tx.ExecContext(ctx, "INSERT INTO networks (project_id, name, description, state, type) VALUES ((SELECT id FROM projects WHERE name = ?), ?, ?, ?, ?)",
+		projectName, name, description, networkCreated, netType)
+	if err != nil {
+		return -1, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return -1, err
+	}
+
+	// Insert a node-specific entry pointing to ourselves with state networkPending.
+	columns := []string{"network_id", "node_id", "state"}
+	values := []any{id, c.nodeID, networkPending}
+	_, err = query.UpsertObject(c.tx, "networks_nodes", columns, values)
+	if err != nil {
+		return -1, err
+	}
+
+	err = networkConfigAdd(c.tx, id, c.nodeID, config)
+	if err != nil {
+		return -1, err
+	}
+
+	return id, nil
+}
+
 // UpdateNetwork updates the network with the given name.
 func (c *Cluster) UpdateNetwork(project string, name, description string, config map[string]string) error {
 	id, _, _, err := c.GetNetworkInAnyState(project, name)
@@ -0,0 +1,151 @@
+//go:build linux && cgo && !agent
+
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/internal/server/db/operationtype"
+	"github.com/lxc/incus/internal/server/db/query"
+	"github.com/lxc/incus/shared/api"
+)
+
+// OperationHistory is a completed operation kept in the operations history.
+type OperationHistory struct {
+	UUID        string
+	Project     string
+	Location    string
+	Type        operationtype.Type
+	Description string
+	StatusCode  api.StatusCode
+	Err         string
+	Requestor   string
+	CreatedAt   time.Time
+	FinishedAt  time.Time
+}
+
+// OperationHistoryFilter specifies the completed operations to return from the operations history.
+type OperationHistoryFilter struct {
+	Project    *string
+	Type       *operationtype.Type
+	StatusCode *api.StatusCode
+	Since      *time.Time
+	Until      *time.Time
+}
+
+// CreateOperationHistory records a completed operation in the operations history.
+func (c *ClusterTx) CreateOperationHistory(ctx context.Context, op OperationHistory) error {
+	stmt := `
+INSERT OR REPLACE INTO operations_history (uuid, project, location, type, description, status_code, error, requestor, created_at, finished_at)
+  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+	_, err := c.tx.ExecContext(ctx, stmt, op.UUID, op.Project, op.Location, op.Type, op.Description, op.StatusCode, op.Err, op.Requestor, op.CreatedAt.UTC(), op.FinishedAt.UTC())
+	if err != nil {
+		return fmt.Errorf("Failed recording operation %q in the history: %w", op.UUID, err)
+	}
+
+	return nil
+}
+
+// GetOperationsHistory returns the completed operations matching the filter, most recent first.
+func (c *ClusterTx) GetOperationsHistory(ctx context.Context, filter OperationHistoryFilter) ([]OperationHistory, error) {
+	var where []string
+	var args []any
+
+	if filter.Project != nil {
+		where = append(where, "project = ?")
+		args = append(args, *filter.Project)
+	}
+
+	if filter.Type != nil {
+		where = append(where, "type = ?")
+		args = append(args, *filter.Type)
+	}
+
+	if filter.StatusCode != nil {
+		where = append(where, "status_code = ?")
+		args = append(args, *filter.StatusCode)
+	}
+
+	if filter.Since != nil {
+		where = append(where, "finished_at >= ?")
+		args = append(args, filter.Since.UTC())
+	}
+
+	if filter.Until != nil {
+		where = append(where, "finished_at <= ?")
+		args = append(args, filter.Until.UTC())
+	}
+
+	q := `
+SELECT uuid, project, location, type, description, status_code, error, requestor, created_at, finished_at
+  FROM operations_history`
+
+	if len(where) > 0 {
+		q += "\n WHERE " + strings.Join(where, " AND ")
+	}
+
+	q += "\n ORDER BY finished_at DESC, id DESC"
+
+	var ops []OperationHistory
+	err := query.Scan(ctx, c.tx, q, func(scan func(dest ...any) error) error {
+		op := OperationHistory{}
+
+		err := scan(&op.UUID, &op.Project, &op.Location, &op.Type, &op.Description, &op.StatusCode, &op.Err, &op.Requestor, &op.CreatedAt, &op.FinishedAt)
+		if err != nil {
+			return err
+		}
+
+		ops = append(ops, op)
+
+		return nil
+	}, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading operations history: %w", err)
+	}
+
+	return ops, nil
+}
+
+// PruneOperationsHistory removes the completed operations which finished before the given time, if not zero, and
+// those beyond the given number of most recent operations, if not zero. It returns the number of removed entries.
+func (c *ClusterTx) PruneOperationsHistory(ctx context.Context, before time.Time, keep int64) (int64, error) {
+	var removed int64
+
+	if !before.IsZero() {
+		result, err := c.tx.ExecContext(ctx, "DELETE FROM operations_history WHERE finished_at < ?", before.UTC())
+		if err != nil {
+			return 0, fmt.Errorf("Failed pruning expired operations history: %w", err)
+		}
+
+		n, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+
+		removed += n
+	}
+
+	if keep > 0 {
+		stmt := `
+DELETE FROM operations_history WHERE id NOT IN (
+  SELECT id FROM operations_history ORDER BY finished_at DESC, id DESC LIMIT ?
+)`
+		result, err := c.tx.ExecContext(ctx, stmt, keep)
+		if err != nil {
+			return 0, fmt.Errorf("Failed pruning operations history: %w", err)
+		}
+
+		n, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+
+		removed += n
+	}
+
+	return removed, nil
+}
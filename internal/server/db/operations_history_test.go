@@ -0,0 +1,152 @@
+//go:build linux && cgo && !agent
+
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/internal/server/db"
+	"github.com/lxc/incus/internal/server/db/operationtype"
+	"github.com/lxc/incus/shared/api"
+)
+
+// Record completed operations and retrieve them with various filters.
+func TestGetOperationsHistory(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	entries := []db.OperationHistory{
+		{UUID: "op1", Project: "default", Type: operationtype.InstanceCreate, StatusCode: api.Success, FinishedAt: now.Add(-3 * time.Hour)},
+		{UUID: "op2", Project: "default", Type: operationtype.InstanceDelete, StatusCode: api.Failure, Err: "Boom", FinishedAt: now.Add(-2 * time.Hour)},
+		{UUID: "op3", Project: "p1", Type: operationtype.InstanceCreate, StatusCode: api.Cancelled, FinishedAt: now.Add(-1 * time.Hour)},
+	}
+
+	for _, entry := range entries {
+		entry.Location = "none"
+		entry.Description = entry.Type.Description()
+		entry.CreatedAt = entry.FinishedAt.Add(-time.Minute)
+
+		err := tx.CreateOperationHistory(context.Background(), entry)
+		require.NoError(t, err)
+	}
+
+	project := "default"
+	opType := operationtype.InstanceCreate
+	statusCode := api.Failure
+	since := now.Add(-150 * time.Minute)
+	until := now.Add(-90 * time.Minute)
+
+	cases := []struct {
+		name   string
+		filter db.OperationHistoryFilter
+		uuids  []string
+	}{
+		{"all", db.OperationHistoryFilter{}, []string{"op3", "op2", "op1"}},
+		{"project", db.OperationHistoryFilter{Project: &project}, []string{"op2", "op1"}},
+		{"type", db.OperationHistoryFilter{Type: &opType}, []string{"op3", "op1"}},
+		{"project and type", db.OperationHistoryFilter{Project: &project, Type: &opType}, []string{"op1"}},
+		{"status", db.OperationHistoryFilter{StatusCode: &statusCode}, []string{"op2"}},
+		{"since", db.OperationHistoryFilter{Since: &since}, []string{"op3", "op2"}},
+		{"until", db.OperationHistoryFilter{Until: &until}, []string{"op2", "op1"}},
+		{"since and until", db.OperationHistoryFilter{Since: &since, Until: &until}, []string{"op2"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ops, err := tx.GetOperationsHistory(context.Background(), c.filter)
+			require.NoError(t, err)
+
+			uuids := []string{}
+			for _, op := range ops {
+				uuids = append(uuids, op.UUID)
+			}
+
+			assert.Equal(t, c.uuids, uuids)
+		})
+	}
+
+	ops, err := tx.GetOperationsHistory(context.Background(), db.OperationHistoryFilter{StatusCode: &statusCode})
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+	assert.Equal(t, "default", ops[0].Project)
+	assert.Equal(t, operationtype.InstanceDelete, ops[0].Type)
+	assert.Equal(t, "Deleting instance", ops[0].Description)
+	assert.Equal(t, "Boom", ops[0].Err)
+	assert.True(t, ops[0].FinishedAt.Equal(now.Add(-2*time.Hour)))
+}
+
+// Recording an operation twice replaces the previous entry.
+func TestCreateOperationHistory_Replace(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	entry := db.OperationHistory{UUID: "op1", Project: "default", Type: operationtype.InstanceStart, StatusCode: api.Failure, CreatedAt: now, FinishedAt: now}
+
+	err := tx.CreateOperationHistory(context.Background(), entry)
+	require.NoError(t, err)
+
+	entry.StatusCode = api.Success
+	err = tx.CreateOperationHistory(context.Background(), entry)
+	require.NoError(t, err)
+
+	ops, err := tx.GetOperationsHistory(context.Background(), db.OperationHistoryFilter{})
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+	assert.Equal(t, api.Success, ops[0].StatusCode)
+}
+
+// Prune the operations history by age and count.
+func TestPruneOperationsHistory(t *testing.T) {
+	now := time.Now().UTC()
+
+	cases := []struct {
+		name    string
+		before  time.Time
+		keep    int64
+		removed int64
+		uuids   []string
+	}{
+		{"nothing", time.Time{}, 0, 0, []string{"op4", "op3", "op2", "op1"}},
+		{"age", now.Add(-150 * time.Minute), 0, 2, []string{"op4", "op3"}},
+		{"count", time.Time{}, 3, 1, []string{"op4", "op3", "op2"}},
+		{"age and count", now.Add(-150 * time.Minute), 1, 3, []string{"op4"}},
+		{"all", now, 0, 4, []string{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tx, cleanup := db.NewTestClusterTx(t)
+			defer cleanup()
+
+			for i, uuid := range []string{"op1", "op2", "op3", "op4"} {
+				finishedAt := now.Add(time.Duration(i-4) * time.Hour)
+				entry := db.OperationHistory{UUID: uuid, Project: "default", StatusCode: api.Success, CreatedAt: finishedAt, FinishedAt: finishedAt}
+
+				err := tx.CreateOperationHistory(context.Background(), entry)
+				require.NoError(t, err)
+			}
+
+			removed, err := tx.PruneOperationsHistory(context.Background(), c.before, c.keep)
+			require.NoError(t, err)
+			assert.Equal(t, c.removed, removed)
+
+			ops, err := tx.GetOperationsHistory(context.Background(), db.OperationHistoryFilter{})
+			require.NoError(t, err)
+
+			uuids := []string{}
+			for _, op := range ops {
+				uuids = append(uuids, op.UUID)
+			}
+
+			assert.Equal(t, c.uuids, uuids)
+		})
+	}
+}
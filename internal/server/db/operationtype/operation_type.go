@@ -1,5 +1,9 @@
 package operationtype
 
+import (
+	"fmt"
+)
+
 // Type is a numeric code indentifying the type of an Operation.
 type Type int64
 
@@ -71,8 +75,98 @@ const (
 	RenewServerCertificate
 	RemoveExpiredTokens
 	ClusterHeal
+	ServerConfigReload
+	ImageUpload
 )
 
+// names are the stable names of the operation types, as exposed through the API.
+var names = map[Type]string{
+	ClusterBootstrap:            "cluster-bootstrap",
+	ClusterJoin:                 "cluster-join",
+	BackupCreate:                "backup-create",
+	BackupRename:                "backup-rename",
+	BackupRestore:               "backup-restore",
+	BackupRemove:                "backup-remove",
+	ConsoleShow:                 "console-show",
+	InstanceCreate:              "instance-create",
+	InstanceUpdate:              "instance-update",
+	InstanceRename:              "instance-rename",
+	InstanceMigrate:             "instance-migrate",
+	InstanceLiveMigrate:         "instance-live-migrate",
+	InstanceFreeze:              "instance-freeze",
+	InstanceUnfreeze:            "instance-unfreeze",
+	InstanceDelete:              "instance-delete",
+	InstanceStart:               "instance-start",
+	InstanceStop:                "instance-stop",
+	InstanceRestart:             "instance-restart",
+	InstanceRebuild:             "instance-rebuild",
+	CommandExec:                 "command-exec",
+	SnapshotCreate:              "snapshot-create",
+	SnapshotRename:              "snapshot-rename",
+	SnapshotRestore:             "snapshot-restore",
+	SnapshotTransfer:            "snapshot-transfer",
+	SnapshotUpdate:              "snapshot-update",
+	SnapshotDelete:              "snapshot-delete",
+	ImageDownload:               "image-download",
+	ImageDelete:                 "image-delete",
+	ImageToken:                  "image-token",
+	ImageRefresh:                "image-refresh",
+	VolumeCopy:                  "volume-copy",
+	VolumeCreate:                "volume-create",
+	VolumeMigrate:               "volume-migrate",
+	VolumeMove:                  "volume-move",
+	VolumeSnapshotCreate:        "volume-snapshot-create",
+	VolumeSnapshotDelete:        "volume-snapshot-delete",
+	VolumeSnapshotUpdate:        "volume-snapshot-update",
+	ProjectRename:               "project-rename",
+	ImagesExpire:                "images-expire",
+	ImagesPruneLeftover:         "images-prune-leftover",
+	ImagesUpdate:                "images-update",
+	ImagesSynchronize:           "images-synchronize",
+	LogsExpire:                  "logs-expire",
+	InstanceTypesUpdate:         "instance-types-update",
+	BackupsExpire:               "backups-expire",
+	SnapshotsExpire:             "snapshots-expire",
+	CustomVolumeSnapshotsExpire: "custom-volume-snapshots-expire",
+	CustomVolumeBackupCreate:    "custom-volume-backup-create",
+	CustomVolumeBackupRemove:    "custom-volume-backup-remove",
+	CustomVolumeBackupRename:    "custom-volume-backup-rename",
+	CustomVolumeBackupRestore:   "custom-volume-backup-restore",
+	WarningsPruneResolved:       "warnings-prune-resolved",
+	ClusterJoinToken:            "cluster-join-token",
+	VolumeSnapshotRename:        "volume-snapshot-rename",
+	ClusterMemberEvacuate:       "cluster-member-evacuate",
+	ClusterMemberRestore:        "cluster-member-restore",
+	CertificateAddToken:         "certificate-add-token",
+	RemoveOrphanedOperations:    "remove-orphaned-operations",
+	RenewServerCertificate:      "renew-server-certificate",
+	RemoveExpiredTokens:         "remove-expired-tokens",
+	ClusterHeal:                 "cluster-heal",
+	ServerConfigReload:          "server-config-reload",
+	ImageUpload:                 "image-upload",
+}
+
+// Name returns the stable name of the operation type, such as "instance-create".
+func (t Type) Name() string {
+	name, ok := names[t]
+	if !ok {
+		return "unknown"
+	}
+
+	return name
+}
+
+// FromName returns the operation type with the given stable name.
+func FromName(name string) (Type, error) {
+	for t, typeName := range names {
+		if typeName == name {
+			return t, nil
+		}
+	}
+
+	return Unknown, fmt.Errorf("Unknown operation type %q", name)
+}
+
 // Description return a human-readable description of the operation type.
 func (t Type) Description() string {
 	switch t {
@@ -194,6 +288,10 @@ func (t Type) Description() string {
 		return "Remove expired tokens"
 	case ClusterHeal:
 		return "Healing cluster"
+	case ServerConfigReload:
+		return "Reloading server configuration"
+	case ImageUpload:
+		return "Uploading image"
 	default:
 		return "Executing operation"
 	}
@@ -0,0 +1,27 @@
+package operationtype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Every operation type has a unique name which maps back to it.
+func TestName(t *testing.T) {
+	seen := map[string]bool{}
+
+	for opType := ClusterBootstrap; opType <= ImageUpload; opType++ {
+		name := opType.Name()
+		assert.NotEqual(t, "unknown", name, "Operation type %d has no name", opType)
+		assert.False(t, seen[name], "Duplicate operation type name %q", name)
+		seen[name] = true
+
+		found, err := FromName(name)
+		require.NoError(t, err)
+		assert.Equal(t, opType, found)
+	}
+
+	_, err := FromName("instance-teleport")
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ package db
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/lxc/incus/internal/server/db/cluster"
 	deviceConfig "github.com/lxc/incus/internal/server/device/config"
@@ -108,6 +109,26 @@ func (c *Cluster) GetProfiles(projectName string, profileNames []string) ([]api.
 	return profiles, nil
 }
 
+// MoveProfile moves the profile to another project.
+func (c *ClusterTx) MoveProfile(ctx context.Context, projectName string, name string, targetProjectName string) error {
+	stmt := "UPDATE profiles SET project_id = (SELECT id FROM projects WHERE name = ?) WHERE project_id = (SELECT id FROM projects WHERE name = ?) AND name = ?"
+	result, err := c.tx.ExecContext(ctx, stmt, targetProjectName, projectName, name)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n != 1 {
+		return api.StatusErrorf(http.StatusNotFound, "Profile not found")
+	}
+
+	return nil
+}
+
 // GetInstancesWithProfile gets the names of the instance associated with the
 // profile with the given name in the given project.
 func (c *Cluster) GetInstancesWithProfile(project, profile string) (map[string][]string, error) {
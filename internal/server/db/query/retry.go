@@ -5,6 +5,8 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Rican7/retry/jitter"
@@ -15,16 +17,59 @@ import (
 	"github.com/lxc/incus/shared/logger"
 )
 
-const maxRetries = 250
+// Default retry policy.
+const (
+	defaultRetryAttempts = 250
+	defaultRetryBackoff  = 100 * time.Millisecond
+)
+
+var retryPolicy = struct {
+	mu       sync.RWMutex
+	attempts int
+	backoff  time.Duration
+}{
+	attempts: defaultRetryAttempts,
+	backoff:  defaultRetryBackoff,
+}
+
+// retries counts the retry attempts made after a transient error, an interaction retried several times is
+// counted once per attempt.
+var retries atomic.Uint64
+
+// SetRetryPolicy sets the maximum number of attempts made by Retry and the delay between them.
+// A value which isn't positive resets the matching setting to its default.
+func SetRetryPolicy(attempts int, backoff time.Duration) {
+	if attempts <= 0 {
+		attempts = defaultRetryAttempts
+	}
+
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+
+	retryPolicy.mu.Lock()
+	retryPolicy.attempts = attempts
+	retryPolicy.backoff = backoff
+	retryPolicy.mu.Unlock()
+}
+
+// RetryCount returns the number of retry attempts made by Retry after a transient error.
+func RetryCount() uint64 {
+	return retries.Load()
+}
 
 // Retry wraps a function that interacts with the database, and retries it in
 // case a transient error is hit.
 //
 // This should by typically used to wrap transactions.
 func Retry(f func() error) error {
-	// TODO: the retry loop should be configurable.
+	retryPolicy.mu.RLock()
+	attempts := retryPolicy.attempts
+	backoff := retryPolicy.backoff
+	retryPolicy.mu.RUnlock()
+
 	var err error
-	for i := 0; i < maxRetries; i++ {
+	for i := 0; i < attempts; i++ {
 		err = f()
 		if err != nil {
 			// No point in re-trying or logging a no-row or not found error.
@@ -34,13 +79,14 @@ func Retry(f func() error) error {
 
 			// Process actual errors.
 			if IsRetriableError(err) {
-				if i == maxRetries {
+				if i == attempts-1 {
 					logger.Warn("Database error, giving up", logger.Ctx{"attempt": i, "err": err})
 					break
 				}
 
+				retries.Add(1)
 				logger.Debug("Database error, retrying", logger.Ctx{"attempt": i, "err": err})
-				time.Sleep(jitter.Deviation(nil, 0.8)(100 * time.Millisecond))
+				time.Sleep(jitter.Deviation(nil, 0.8)(backoff))
 				continue
 			} else {
 				logger.Debug("Database error", logger.Ctx{"err": err})
@@ -0,0 +1,41 @@
+package query_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lxc/incus/internal/server/db/query"
+)
+
+// Transient errors are retried up to the configured number of attempts.
+func TestRetry_Policy(t *testing.T) {
+	query.SetRetryPolicy(3, time.Millisecond)
+	defer query.SetRetryPolicy(0, 0)
+
+	before := query.RetryCount()
+
+	calls := 0
+	err := query.Retry(func() error {
+		calls++
+		return fmt.Errorf("database is locked")
+	})
+
+	assert.EqualError(t, err, "database is locked")
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, before+2, query.RetryCount())
+}
+
+// Other errors aren't retried.
+func TestRetry_NotRetriable(t *testing.T) {
+	calls := 0
+	err := query.Retry(func() error {
+		calls++
+		return fmt.Errorf("boom")
+	})
+
+	assert.EqualError(t, err, "boom")
+	assert.Equal(t, 1, calls)
+}
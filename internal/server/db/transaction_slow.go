@@ -0,0 +1,57 @@
+//go:build linux && cgo && !agent
+
+package db
+
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/lxc/incus/shared/logger"
+)
+
+// slowTransactionThreshold is the duration above which cluster database transactions get logged (0 to disable).
+var slowTransactionThreshold atomic.Int64
+
+// slowTransactions counts the cluster database transactions which exceeded the threshold.
+var slowTransactions atomic.Uint64
+
+// SetSlowTransactionThreshold sets the duration above which cluster database transactions are logged as slow.
+// A zero duration disables the logging.
+func SetSlowTransactionThreshold(threshold time.Duration) {
+	slowTransactionThreshold.Store(int64(threshold))
+}
+
+// SlowTransactionCount returns the number of cluster database transactions which exceeded the slow threshold.
+func SlowTransactionCount() uint64 {
+	return slowTransactions.Load()
+}
+
+// checkSlowTransaction logs the transaction started at the given time if it took longer than the slow threshold.
+// The skip argument is the number of stack frames to skip to reach the caller of the transaction, as for
+// runtime.Caller.
+func checkSlowTransaction(start time.Time, skip int) {
+	threshold := time.Duration(slowTransactionThreshold.Load())
+	if threshold <= 0 {
+		return
+	}
+
+	duration := time.Since(start)
+	if duration < threshold {
+		return
+	}
+
+	slowTransactions.Add(1)
+
+	caller := "unknown"
+	pc, _, line, ok := runtime.Caller(skip + 1)
+	if ok {
+		fn := runtime.FuncForPC(pc)
+		if fn != nil {
+			caller = fmt.Sprintf("%s:%d", fn.Name(), line)
+		}
+	}
+
+	logger.Warn("Slow cluster database transaction", logger.Ctx{"caller": caller, "duration": duration, "threshold": threshold})
+}
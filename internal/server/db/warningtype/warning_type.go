@@ -56,6 +56,16 @@ const (
 	StoragePoolUnvailable
 	// UnableToUpdateClusterCertificate represents the unable to update cluster certificate warning.
 	UnableToUpdateClusterCertificate
+	// ClusterConfigDrift represents the cluster configuration drift warning.
+	ClusterConfigDrift
+	// NetworkMTUMismatch represents a network whose MTU doesn't fit its parent interfaces or uplink.
+	NetworkMTUMismatch
+	// NodevMount represents the inaccessible device nodes warning (nodev mount).
+	NodevMount
+	// DaemonStorageUnmountTimeout represents a daemon storage volume which couldn't be unmounted in time on shutdown.
+	DaemonStorageUnmountTimeout
+	// ClusterQuorumLost represents the cluster database having lost quorum.
+	ClusterQuorumLost
 )
 
 // TypeNames associates a warning code to its name.
@@ -85,6 +95,11 @@ var TypeNames = map[Type]string{
 	InstanceTypeNotOperational:             "Instance type not operational",
 	StoragePoolUnvailable:                  "Storage pool unavailable",
 	UnableToUpdateClusterCertificate:       "Unable to update cluster certificate",
+	ClusterConfigDrift:                     "Cluster configuration differs between leader and local",
+	NetworkMTUMismatch:                     "Network MTU mismatch",
+	NodevMount:                             "Unable to access device nodes",
+	DaemonStorageUnmountTimeout:            "Timed out unmounting daemon storage volumes",
+	ClusterQuorumLost:                      "Cluster database quorum lost",
 }
 
 // Severity returns the severity of the warning type.
@@ -140,7 +155,28 @@ func (t Type) Severity() Severity {
 		return SeverityHigh
 	case UnableToUpdateClusterCertificate:
 		return SeverityLow
+	case ClusterConfigDrift:
+		return SeverityModerate
+	case NetworkMTUMismatch:
+		return SeverityModerate
+	case NodevMount:
+		return SeverityModerate
+	case DaemonStorageUnmountTimeout:
+		return SeverityModerate
+	case ClusterQuorumLost:
+		return SeverityHigh
 	}
 
 	return SeverityLow
 }
+
+// ResolvedOnStartup returns whether the warnings of this type are resolved when the daemon starts.
+// Warnings about the previous shutdown of the daemon are kept until the condition is cleared.
+func (t Type) ResolvedOnStartup() bool {
+	switch t {
+	case DaemonStorageUnmountTimeout:
+		return false
+	}
+
+	return true
+}
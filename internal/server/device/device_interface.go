@@ -86,3 +86,8 @@ type device interface {
 type NICState interface {
 	State() (*api.InstanceStateNetwork, error)
 }
+
+// FirewallReapplier provides the ability to re-apply the host firewall rules of a started device.
+type FirewallReapplier interface {
+	FirewallReapply() error
+}
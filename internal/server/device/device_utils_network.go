@@ -591,6 +591,37 @@ func networkSetupHostVethLimits(d *deviceCommon, oldConfig deviceConfig.Device,
 	return nil
 }
 
+// networkReapplyHostVethNetPrio re-applies the network priority firewall rules of the veth device specified in the config.
+func networkReapplyHostVethNetPrio(d *deviceCommon, bridged bool) error {
+	if d.config["limits.priority"] == "" {
+		return nil
+	}
+
+	networkPriority, err := strconv.ParseUint(d.config["limits.priority"], 10, 32)
+	if err != nil {
+		return fmt.Errorf("Failed to parse limits.priority %q: %w", d.config["limits.priority"], err)
+	}
+
+	// The xtables driver can't set the priority on bridged devices so there is nothing to re-apply.
+	if networkPriority == 0 || (bridged && d.state.Firewall.String() == "xtables") {
+		return nil
+	}
+
+	veth := d.config["host_name"]
+
+	err = d.state.Firewall.InstanceClearNetPrio(d.inst.Project().Name, d.inst.Name(), veth)
+	if err != nil {
+		return err
+	}
+
+	err = d.state.Firewall.InstanceSetupNetPrio(d.inst.Project().Name, d.inst.Name(), veth, uint32(networkPriority))
+	if err != nil {
+		return fmt.Errorf("Failed to setup instance device network priority: %w", err)
+	}
+
+	return nil
+}
+
 // networkClearHostVethLimits clears any network rate limits to the veth device specified in the config.
 func networkClearHostVethLimits(d *deviceCommon) error {
 	err := d.state.Firewall.InstanceClearNetPrio(d.inst.Project().Name, d.inst.Name(), d.config["host_name"])
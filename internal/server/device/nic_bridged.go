@@ -1741,6 +1741,26 @@ func (d *nicBridged) getHostMTU() (int, error) {
 	return mtu, nil
 }
 
+// FirewallReapply re-applies the host firewall rules of the started device.
+func (d *nicBridged) FirewallReapply() error {
+	networkVethFillFromVolatile(d.config, d.volatileGet())
+
+	if d.config["host_name"] == "" || !network.InterfaceExists(d.config["host_name"]) {
+		return nil // Device isn't started.
+	}
+
+	if util.IsTrue(d.config["security.mac_filtering"]) || util.IsTrue(d.config["security.ipv4_filtering"]) || util.IsTrue(d.config["security.ipv6_filtering"]) {
+		d.removeFilters(d.config)
+
+		err := d.setFilters()
+		if err != nil {
+			return err
+		}
+	}
+
+	return networkReapplyHostVethNetPrio(&d.deviceCommon, true)
+}
+
 // Register sets up anything needed on startup.
 func (d *nicBridged) Register() error {
 	err := bgpAddPrefix(&d.deviceCommon, d.network, d.config)
@@ -162,6 +162,17 @@ func (d *nicP2P) Start() (*deviceConfig.RunConfig, error) {
 	return &runConf, nil
 }
 
+// FirewallReapply re-applies the host firewall rules of the started device.
+func (d *nicP2P) FirewallReapply() error {
+	networkVethFillFromVolatile(d.config, d.volatileGet())
+
+	if d.config["host_name"] == "" || !network.InterfaceExists(d.config["host_name"]) {
+		return nil // Device isn't started.
+	}
+
+	return networkReapplyHostVethNetPrio(&d.deviceCommon, false)
+}
+
 // Update applies configuration changes to a started device.
 func (d *nicP2P) Update(oldDevices deviceConfig.Devices, isRunning bool) error {
 	if !isRunning {
@@ -563,6 +563,27 @@ func (d *nicRouted) setupParentSysctls(parentName string) error {
 	return nil
 }
 
+// FirewallReapply re-applies the host firewall rules of the started device.
+func (d *nicRouted) FirewallReapply() error {
+	networkVethFillFromVolatile(d.config, d.volatileGet())
+
+	if d.config["host_name"] == "" || !network.InterfaceExists(d.config["host_name"]) {
+		return nil // Device isn't started.
+	}
+
+	err := d.state.Firewall.InstanceClearRPFilter(d.inst.Project().Name, d.inst.Name(), d.name)
+	if err != nil {
+		return err
+	}
+
+	err = d.state.Firewall.InstanceSetupRPFilter(d.inst.Project().Name, d.inst.Name(), d.name, d.config["host_name"])
+	if err != nil {
+		return fmt.Errorf("Error setting up reverse path filter: %w", err)
+	}
+
+	return networkReapplyHostVethNetPrio(&d.deviceCommon, false)
+}
+
 // Update returns an error as most devices do not support live updates without being restarted.
 func (d *nicRouted) Update(oldDevices deviceConfig.Devices, isRunning bool) error {
 	v := d.volatileGet()
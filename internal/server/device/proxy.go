@@ -347,6 +347,20 @@ func (d *proxy) checkProcStarted(logPath string) (bool, error) {
 	return false, nil
 }
 
+// FirewallReapply re-applies the NAT rules of the started device.
+func (d *proxy) FirewallReapply() error {
+	if util.IsFalseOrEmpty(d.config["nat"]) || !d.inst.IsRunning() {
+		return nil
+	}
+
+	err := d.state.Firewall.InstanceClearProxyNAT(d.inst.Project().Name, d.inst.Name(), d.name)
+	if err != nil {
+		return err
+	}
+
+	return d.setupNAT()
+}
+
 // Stop is run when the device is removed from the instance.
 func (d *proxy) Stop() (*deviceConfig.RunConfig, error) {
 	// Remove possible iptables entries
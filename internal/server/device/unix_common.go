@@ -8,7 +8,6 @@ import (
 
 	"github.com/lxc/incus/internal/linux"
 	deviceConfig "github.com/lxc/incus/internal/server/device/config"
-	"github.com/lxc/incus/internal/server/fsmonitor/drivers"
 	"github.com/lxc/incus/internal/server/instance"
 	"github.com/lxc/incus/internal/server/instance/instancetype"
 	"github.com/lxc/incus/shared/util"
@@ -50,11 +49,8 @@ func (d *unixCommon) validateConfig(instConf instance.ConfigReader) error {
 				return nil
 			}
 
-			if strings.HasPrefix(value, d.state.DevMonitor.PrefixPath()) {
-				return nil
-			}
-
-			return &drivers.ErrInvalidPath{PrefixPath: d.state.DevMonitor.PrefixPath()}
+			_, err := d.state.DevMonitor.PrefixPath(value)
+			return err
 		},
 		"path":     validate.IsAny,
 		"major":    unixValidDeviceNum,
@@ -0,0 +1,125 @@
+package endpoints
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pborman/uuid"
+
+	"github.com/lxc/incus/internal/server/endpoints/listeners"
+	"github.com/lxc/incus/shared/api"
+)
+
+// Connection represents a live client connection to one of the network endpoints.
+type Connection struct {
+	ID            string
+	Listener      string
+	RemoteAddress string
+	Protocol      string
+	Identity      string
+	ConnectedAt   time.Time
+
+	conn net.Conn
+}
+
+// connections keeps track of the live connections to the network endpoints.
+type connections struct {
+	mu     sync.Mutex
+	byConn map[net.Conn]*Connection
+}
+
+// Short names of the endpoints whose connections are tracked.
+var connectionListeners = map[kind]string{
+	network:        "network",
+	cluster:        "cluster",
+	metrics:        "metrics",
+	storageBuckets: "storage-buckets",
+}
+
+// track returns the function registering the connections accepted by the listener of the given kind.
+func (c *connections) track(kind kind) listeners.TrackFunc {
+	return func(conn net.Conn) func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if c.byConn == nil {
+			c.byConn = map[net.Conn]*Connection{}
+		}
+
+		c.byConn[conn] = &Connection{
+			ID:            uuid.New(),
+			Listener:      connectionListeners[kind],
+			RemoteAddress: conn.RemoteAddr().String(),
+			ConnectedAt:   time.Now(),
+			conn:          conn,
+		}
+
+		return func() {
+			c.mu.Lock()
+			delete(c.byConn, conn)
+			c.mu.Unlock()
+		}
+	}
+}
+
+// Connections returns the live connections to the network endpoints, oldest first.
+// Connections to the local unix socket aren't tracked.
+func (e *Endpoints) Connections() []Connection {
+	e.connections.mu.Lock()
+	defer e.connections.mu.Unlock()
+
+	conns := make([]Connection, 0, len(e.connections.byConn))
+	for _, conn := range e.connections.byConn {
+		conns = append(conns, *conn)
+	}
+
+	sort.Slice(conns, func(i, j int) bool { return conns[i].ConnectedAt.Before(conns[j].ConnectedAt) })
+
+	return conns
+}
+
+// ConnectionSetIdentity records the identity and protocol that a connection was authenticated with.
+// Connections which aren't tracked are ignored.
+func (e *Endpoints) ConnectionSetIdentity(conn net.Conn, identity string, protocol string) {
+	e.connections.mu.Lock()
+	defer e.connections.mu.Unlock()
+
+	tracked, ok := e.connections.byConn[conn]
+	if !ok {
+		return
+	}
+
+	tracked.Identity = identity
+	tracked.Protocol = protocol
+}
+
+// ConnectionClose forcibly closes the connection with the given ID.
+func (e *Endpoints) ConnectionClose(id string) error {
+	var conn net.Conn
+
+	e.connections.mu.Lock()
+	for _, tracked := range e.connections.byConn {
+		if tracked.ID == id {
+			conn = tracked.conn
+			break
+		}
+	}
+
+	e.connections.mu.Unlock()
+
+	if conn == nil {
+		return api.StatusErrorf(http.StatusNotFound, "Connection %q not found", id)
+	}
+
+	// Don't wait on the peer to acknowledge the TLS close notification.
+	tlsConn, ok := conn.(*tls.Conn)
+	if ok {
+		return tlsConn.NetConn().Close()
+	}
+
+	return conn.Close()
+}
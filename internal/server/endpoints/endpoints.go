@@ -42,6 +42,10 @@ type Config struct {
 	// string means "use the default".
 	LocalUnixSocketGroup string
 
+	// Additional system group names which should be granted access to the
+	// unix socket for the local endpoint through a POSIX ACL.
+	LocalUnixSocketExtraGroups []string
+
 	// NetworkSetAddress sets the address for the network endpoint. If not
 	// set, the network endpoint won't be started (unless it's passed via
 	// socket-based activation).
@@ -63,6 +67,12 @@ type Config struct {
 	// HTTP server handling requests for the metrics API.
 	MetricsServer *http.Server
 
+	// Optional dedicated TLS keypair for the metrics endpoint. If not set,
+	// Cert will be used.
+	//
+	// It can be updated after the endpoints are up using MetricsUpdateCert().
+	MetricsCert *localtls.CertInfo
+
 	// HTTP server handling requests for the storage buckets API.
 	StorageBucketsServer *http.Server
 
@@ -154,12 +164,14 @@ func Up(config *Config) (*Endpoints, error) {
 // Endpoints are in charge of bringing up and down the HTTP endpoints for
 // serving the REST API.
 type Endpoints struct {
-	tomb      *tomb.Tomb            // Controls the HTTP servers shutdown.
-	mu        sync.RWMutex          // Serialize access to internal state.
-	listeners map[kind]net.Listener // Activer listeners by endpoint type.
-	servers   map[kind]*http.Server // HTTP servers by endpoint type.
-	cert      *localtls.CertInfo    // Keypair and CA to use for TLS.
-	inherited map[kind]bool         // Store whether the listener came through socket activation
+	tomb        *tomb.Tomb            // Controls the HTTP servers shutdown.
+	mu          sync.RWMutex          // Serialize access to internal state.
+	listeners   map[kind]net.Listener // Activer listeners by endpoint type.
+	servers     map[kind]*http.Server // HTTP servers by endpoint type.
+	cert        *localtls.CertInfo    // Keypair and CA to use for TLS.
+	metricsCert *localtls.CertInfo    // Dedicated keypair for the metrics endpoint (optional).
+	inherited   map[kind]bool         // Store whether the listener came through socket activation
+	connections connections           // Live connections to the network endpoints.
 
 	systemdListenFDsStart int // First socket activation FD, for tests.
 }
@@ -181,6 +193,7 @@ func (e *Endpoints) up(config *Config) error {
 	}
 
 	e.cert = config.Cert
+	e.metricsCert = config.MetricsCert
 	e.inherited = map[kind]bool{}
 
 	var err error
@@ -195,7 +208,7 @@ func (e *Endpoints) up(config *Config) error {
 	} else {
 		e.listeners = map[kind]net.Listener{}
 
-		e.listeners[local], err = localCreateListener(config.UnixSocket, config.LocalUnixSocketGroup)
+		e.listeners[local], err = localCreateListener(config.UnixSocket, config.LocalUnixSocketGroup, config.LocalUnixSocketExtraGroups)
 		if err != nil {
 			return fmt.Errorf("local endpoint: %w", err)
 		}
@@ -312,7 +325,7 @@ func (e *Endpoints) up(config *Config) error {
 // UpMetrics brings up metrics listener on specified address.
 func (e *Endpoints) UpMetrics(listenAddress string) error {
 	var err error
-	e.listeners[metrics], err = metricsCreateListener(listenAddress, e.cert)
+	e.listeners[metrics], err = metricsCreateListener(listenAddress, e.MetricsCert())
 	if err != nil {
 		return fmt.Errorf("Failed starting metrics listener: %w", err)
 	}
@@ -419,6 +432,13 @@ func (e *Endpoints) serve(kind kind) {
 
 	server := e.servers[kind]
 
+	// Keep track of the connections to the network endpoints.
+	_, trackConnections := connectionListeners[kind]
+	tlsListener, ok := listener.(*listeners.FancyTLSListener)
+	if ok && trackConnections {
+		tlsListener.Track(e.connections.track(kind))
+	}
+
 	// Defer the creation of the tomb, so Down() doesn't wait on it unless
 	// we actually have spawned at least a server.
 	if e.tomb == nil {
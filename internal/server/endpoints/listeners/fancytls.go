@@ -19,8 +19,13 @@ type FancyTLSListener struct {
 	mu           sync.RWMutex
 	config       *tls.Config
 	trustedProxy []net.IP
+	track        TrackFunc
 }
 
+// TrackFunc is called with each connection accepted by a listener.
+// It returns a function called once the connection gets closed.
+type TrackFunc func(conn net.Conn) func()
+
 // NewFancyTLSListener creates a new FancyTLSListener.
 func NewFancyTLSListener(inner net.Listener, cert *localtls.CertInfo) *FancyTLSListener {
 	listener := &FancyTLSListener{
@@ -42,11 +47,46 @@ func (l *FancyTLSListener) Accept() (net.Conn, error) {
 	l.mu.RLock()
 	defer l.mu.RUnlock()
 	config := l.config
+
+	// Get notified when the connection is closed, including after being hijacked.
+	var tracked *trackedConn
+	if l.track != nil {
+		tracked = &trackedConn{Conn: c}
+		c = tracked
+	}
+
 	if isProxy(c.RemoteAddr().String(), l.trustedProxy) {
 		c = proxyproto.NewConn(c, 0)
 	}
 
-	return tls.Server(c, config), nil
+	conn := tls.Server(c, config)
+	if tracked != nil {
+		tracked.untrack = l.track(conn)
+	}
+
+	return conn, nil
+}
+
+// Track sets the function called with each accepted connection.
+func (l *FancyTLSListener) Track(track TrackFunc) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.track = track
+}
+
+// trackedConn is a connection notifying its tracker once closed.
+type trackedConn struct {
+	net.Conn
+	untrack func()
+	once    sync.Once
+}
+
+// Close closes the connection and notifies the tracker.
+func (c *trackedConn) Close() error {
+	c.once.Do(c.untrack)
+
+	return c.Conn.Close()
 }
 
 // Config safely swaps the underlying TLS configuration.
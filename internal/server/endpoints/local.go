@@ -7,7 +7,7 @@ import (
 )
 
 // Create a new net.Listener bound to the unix socket of the local endpoint.
-func localCreateListener(path string, group string) (net.Listener, error) {
+func localCreateListener(path string, group string, extraGroups []string) (net.Listener, error) {
 	err := CheckAlreadyRunning(path)
 	if err != nil {
 		return nil, err
@@ -23,7 +23,7 @@ func localCreateListener(path string, group string) (net.Listener, error) {
 		return nil, err
 	}
 
-	err = localSetAccess(path, group)
+	err = localSetAccess(path, group, extraGroups)
 	if err != nil {
 		_ = listener.Close()
 		return nil, err
@@ -33,9 +33,9 @@ func localCreateListener(path string, group string) (net.Listener, error) {
 }
 
 // Change the file mode and ownership of the local endpoint unix socket file,
-// so access is granted only to the process user and to the given group (or the
-// process group if group is empty).
-func localSetAccess(path string, group string) error {
+// so access is granted only to the process user, to the given group (or the
+// process group if group is empty) and to any extra group.
+func localSetAccess(path string, group string, extraGroups []string) error {
 	err := socketUnixSetPermissions(path, 0660)
 	if err != nil {
 		return err
@@ -46,5 +46,12 @@ func localSetAccess(path string, group string) error {
 		return err
 	}
 
+	if len(extraGroups) > 0 {
+		err = socketUnixSetACL(path, extraGroups)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -73,6 +73,19 @@ func TestEndpoints_LocalUnknownUnixGroup(t *testing.T) {
 		t, err, "local endpoint: cannot get group ID of 'xquibaz': group: unknown group xquibaz")
 }
 
+// If an extra group for the unix socket is specified, but no such one exists,
+// an error is returned.
+func TestEndpoints_LocalUnknownUnixExtraGroup(t *testing.T) {
+	endpoints, config, cleanup := newEndpoints(t)
+	defer cleanup()
+
+	config.LocalUnixSocketExtraGroups = []string{"xquibaz"}
+	err := endpoints.Up(config)
+
+	assert.EqualError(
+		t, err, "local endpoint: cannot get group ID of 'xquibaz': group: unknown group xquibaz")
+}
+
 // If another endpoint is already listening on the unix socket, an error is returned.
 func TestEndpoints_LocalAlreadyRunning(t *testing.T) {
 	endpoints1, config1, cleanup1 := newEndpoints(t)
@@ -46,6 +46,35 @@ func (e *Endpoints) MetricsAddress() string {
 	return listener.Addr().String()
 }
 
+// MetricsCert returns the TLS keypair used by the metrics endpoint.
+func (e *Endpoints) MetricsCert() *localtls.CertInfo {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.metricsCert != nil {
+		return e.metricsCert
+	}
+
+	return e.cert
+}
+
+// MetricsUpdateCert updates the TLS keypair used by the metrics endpoint.
+// Passing nil makes the metrics endpoint use the network certificate again.
+func (e *Endpoints) MetricsUpdateCert(cert *localtls.CertInfo) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.metricsCert = cert
+	if cert == nil {
+		cert = e.cert
+	}
+
+	listener, found := e.listeners[metrics]
+	if found {
+		listener.(*listeners.FancyTLSListener).Config(cert)
+	}
+}
+
 // MetricsUpdateAddress updates the address for the metrics endpoint, shutting it down and restarting it.
 func (e *Endpoints) MetricsUpdateAddress(address string, cert *localtls.CertInfo) error {
 	if address != "" {
@@ -141,6 +141,11 @@ func (e *Endpoints) NetworkUpdateCert(cert *localtls.CertInfo) {
 	e.cert = cert
 
 	for _, listenerKey := range []kind{network, cluster, vmvsock, storageBuckets, metrics} {
+		// The metrics endpoint may be using its own certificate.
+		if listenerKey == metrics && e.metricsCert != nil {
+			continue
+		}
+
 		listener, found := e.listeners[listenerKey]
 		if found {
 			listener.(*listeners.FancyTLSListener).Config(cert)
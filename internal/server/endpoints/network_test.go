@@ -1,6 +1,7 @@
 package endpoints_test
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"strings"
@@ -24,6 +25,40 @@ func TestEndpoints_NetworkCreateTCPSocket(t *testing.T) {
 	assert.NoError(t, httpGetOverTLSSocket(endpoints.NetworkAddressAndCert()))
 }
 
+// Connections to the network endpoint are tracked until closed and can be forcibly closed.
+func TestEndpoints_NetworkConnections(t *testing.T) {
+	endpoints, config, cleanup := newEndpoints(t)
+	defer cleanup()
+
+	config.NetworkAddress = "127.0.0.1:0"
+	require.NoError(t, endpoints.Up(config))
+
+	address, cert := endpoints.NetworkAddressAndCert()
+	tlsConfig, err := localtls.GetTLSConfigMem("", "", "", string(cert.PublicKey()), false)
+	require.NoError(t, err)
+
+	conn, err := tls.Dial("tcp", address, tlsConfig)
+	require.NoError(t, err)
+
+	defer func() { _ = conn.Close() }()
+
+	require.NoError(t, conn.Handshake())
+
+	conns := endpoints.Connections()
+	require.Len(t, conns, 1)
+	assert.Equal(t, "network", conns[0].Listener)
+	assert.Equal(t, conn.LocalAddr().String(), conns[0].RemoteAddress)
+
+	require.NoError(t, endpoints.ConnectionClose(conns[0].ID))
+	assert.Empty(t, endpoints.Connections())
+
+	// The peer sees the connection being closed.
+	_, err = conn.Read(make([]byte, 1))
+	assert.Error(t, err)
+
+	assert.Error(t, endpoints.ConnectionClose(conns[0].ID))
+}
+
 // It's possible to replace the TLS certificate used by the network endpoint.
 func TestEndpoints_NetworkUpdateCert(t *testing.T) {
 	endpoints, config, cleanup := newEndpoints(t)
@@ -7,7 +7,7 @@ import (
 	"net"
 )
 
-func localCreateListener(path string, group string) (net.Listener, error) {
+func localCreateListener(path string, group string, extraGroups []string) (net.Listener, error) {
 	return nil, fmt.Errorf("Platform isn't supported")
 }
 
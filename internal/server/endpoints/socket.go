@@ -3,12 +3,16 @@
 package endpoints
 
 import (
+	"encoding/binary"
 	"fmt"
 	"net"
 	"os"
 	"os/user"
+	"sort"
 	"strconv"
 
+	"golang.org/x/sys/unix"
+
 	"github.com/lxc/incus/client"
 	"github.com/lxc/incus/shared/logger"
 	"github.com/lxc/incus/shared/util"
@@ -86,6 +90,71 @@ func socketUnixSetPermissions(path string, mode os.FileMode) error {
 	return nil
 }
 
+// POSIX ACL extended attribute format, see acl(5) and <linux/posix_acl_xattr.h>.
+const (
+	posixACLXattrVersion = 0x0002
+
+	posixACLUserObj  = 0x01
+	posixACLGroupObj = 0x04
+	posixACLGroup    = 0x08
+	posixACLMask     = 0x10
+	posixACLOther    = 0x20
+
+	posixACLRead  = 0x04
+	posixACLWrite = 0x02
+)
+
+// Grant read and write access to the given unix socket file to additional groups through a POSIX ACL.
+// The owner and owning group keep read and write access, other users get none.
+func socketUnixSetACL(path string, groupNames []string) error {
+	gids := make([]int, 0, len(groupNames))
+	for _, groupName := range groupNames {
+		g, err := user.LookupGroup(groupName)
+		if err != nil {
+			return fmt.Errorf("cannot get group ID of '%s': %w", groupName, err)
+		}
+
+		gid, err := strconv.Atoi(g.Gid)
+		if err != nil {
+			return err
+		}
+
+		gids = append(gids, gid)
+	}
+
+	// The kernel requires the named group entries to be sorted and unique.
+	sort.Ints(gids)
+
+	addEntry := func(buf []byte, tag uint16, perm uint16, id uint32) []byte {
+		buf = binary.LittleEndian.AppendUint16(buf, tag)
+		buf = binary.LittleEndian.AppendUint16(buf, perm)
+		return binary.LittleEndian.AppendUint32(buf, id)
+	}
+
+	undefinedID := ^uint32(0)
+
+	buf := binary.LittleEndian.AppendUint32(nil, posixACLXattrVersion)
+	buf = addEntry(buf, posixACLUserObj, posixACLRead|posixACLWrite, undefinedID)
+	buf = addEntry(buf, posixACLGroupObj, posixACLRead|posixACLWrite, undefinedID)
+	for i, gid := range gids {
+		if i > 0 && gids[i-1] == gid {
+			continue
+		}
+
+		buf = addEntry(buf, posixACLGroup, posixACLRead|posixACLWrite, uint32(gid))
+	}
+
+	buf = addEntry(buf, posixACLMask, posixACLRead|posixACLWrite, undefinedID)
+	buf = addEntry(buf, posixACLOther, 0, undefinedID)
+
+	err := unix.Setxattr(path, "system.posix_acl_access", buf, 0)
+	if err != nil {
+		return fmt.Errorf("cannot set ACL on local socket: %w", err)
+	}
+
+	return nil
+}
+
 // Change the ownership of the given unix socket file,.
 func socketUnixSetOwnership(path string, groupName string) error {
 	var gid int
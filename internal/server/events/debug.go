@@ -0,0 +1,57 @@
+package events
+
+// DebugInfo represents the delivery state of the event listeners.
+type DebugInfo struct {
+	Sent      uint64              `json:"sent" yaml:"sent"`
+	Dropped   uint64              `json:"dropped" yaml:"dropped"`
+	Listeners []DebugInfoListener `json:"listeners" yaml:"listeners"`
+}
+
+// DebugInfoListener exposes the delivery state of a single event listener.
+type DebugInfoListener struct {
+	ID            string   `json:"id" yaml:"id"`
+	Remote        string   `json:"remote" yaml:"remote"`
+	Project       string   `json:"project" yaml:"project"`
+	AllProjects   bool     `json:"all_projects" yaml:"all_projects"`
+	Types         []string `json:"types" yaml:"types"`
+	ClusterMember bool     `json:"cluster_member" yaml:"cluster_member"`
+	Pending       int64    `json:"pending" yaml:"pending"`
+	Sent          uint64   `json:"sent" yaml:"sent"`
+	Dropped       uint64   `json:"dropped" yaml:"dropped"`
+}
+
+// Debug returns the delivery counters of the server and of each of its current listeners.
+// Pending is the number of events waiting to be written to a listener, which grows when the consumer is slow,
+// while dropped events are those which couldn't be written, either because of an error or because the listener
+// was closed in the meantime.
+func (s *Server) Debug() DebugInfo {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	info := DebugInfo{
+		Sent:      s.sent.Load(),
+		Dropped:   s.dropped.Load(),
+		Listeners: make([]DebugInfoListener, 0, len(s.listeners)),
+	}
+
+	for _, listener := range s.listeners {
+		remote := ""
+		if listener.RemoteAddr() != nil {
+			remote = listener.RemoteAddr().String()
+		}
+
+		info.Listeners = append(info.Listeners, DebugInfoListener{
+			ID:            listener.id,
+			Remote:        remote,
+			Project:       listener.projectName,
+			AllProjects:   listener.allProjects,
+			Types:         listener.messageTypes,
+			ClusterMember: listener.clusterMember,
+			Pending:       listener.pending.Load(),
+			Sent:          listener.sent.Load(),
+			Dropped:       listener.dropped.Load(),
+		})
+	}
+
+	return info
+}
@@ -0,0 +1,58 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/shared/api"
+)
+
+// blockingConnection is a listener connection whose writes wait for the test to release them.
+type blockingConnection struct {
+	writes chan error
+}
+
+func (c *blockingConnection) Reader(ctx context.Context, recvFunc EventHandler) {
+	<-ctx.Done()
+}
+
+func (c *blockingConnection) WriteJSON(event any) error {
+	return <-c.writes
+}
+
+func (c *blockingConnection) Close() error         { return nil }
+func (c *blockingConnection) LocalAddr() net.Addr  { return nil }
+func (c *blockingConnection) RemoteAddr() net.Addr { return nil }
+
+func TestServer_Debug(t *testing.T) {
+	s := NewServer(false, false, nil)
+	conn := &blockingConnection{writes: make(chan error)}
+
+	listener, err := s.AddListener("", true, conn, []string{api.EventTypeLifecycle}, nil, nil, nil, false)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		s.SendLifecycle("default", api.EventLifecycle{Action: "instance-started"})
+	}
+
+	// The events wait to be written to the slow listener.
+	assert.Eventually(t, func() bool { return s.Debug().Listeners[0].Pending == 3 }, time.Second, 10*time.Millisecond)
+
+	conn.writes <- nil
+	conn.writes <- nil
+	conn.writes <- fmt.Errorf("Write failed")
+
+	// The failed write closes and removes the listener.
+	assert.Eventually(t, listener.IsClosed, time.Second, 10*time.Millisecond)
+
+	assert.Eventually(t, func() bool {
+		info := s.Debug()
+		return info.Sent == 2 && info.Dropped == 1 && len(info.Listeners) == 0
+	}, time.Second, 10*time.Millisecond)
+}
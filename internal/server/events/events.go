@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/pborman/uuid"
@@ -39,6 +40,13 @@ type Server struct {
 	listeners map[string]*Listener
 	notify    NotifyFunc
 	location  string
+
+	// Event types which aren't forwarded to other cluster members.
+	localOnlyTypes []string
+
+	// Delivery counters across all listeners, including those which are gone.
+	sent    atomic.Uint64
+	dropped atomic.Uint64
 }
 
 // NewServer returns a new event server.
@@ -64,8 +72,18 @@ func (s *Server) SetLocalLocation(location string) {
 	s.location = location
 }
 
+// SetLocalOnlyTypes sets the event types which aren't forwarded to other cluster members, either through the
+// notification hook or to the listeners of cluster members.
+func (s *Server) SetLocalOnlyTypes(types []string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.localOnlyTypes = types
+}
+
 // AddListener creates and returns a new event listener.
-func (s *Server) AddListener(projectName string, allProjects bool, connection EventListenerConnection, messageTypes []string, excludeSources []EventSource, recvFunc EventHandler, excludeLocations []string) (*Listener, error) {
+// Listeners for other cluster members don't receive the event types kept local.
+func (s *Server) AddListener(projectName string, allProjects bool, connection EventListenerConnection, messageTypes []string, excludeSources []EventSource, recvFunc EventHandler, excludeLocations []string, clusterMember bool) (*Listener, error) {
 	if allProjects && projectName != "" {
 		return nil, fmt.Errorf("Cannot specify project name when listening for events on all projects")
 	}
@@ -83,6 +101,7 @@ func (s *Server) AddListener(projectName string, allProjects bool, connection Ev
 		projectName:      projectName,
 		excludeSources:   excludeSources,
 		excludeLocations: excludeLocations,
+		clusterMember:    clusterMember,
 	}
 
 	s.lock.Lock()
@@ -166,9 +185,11 @@ func (s *Server) broadcast(event api.Event, eventSource EventSource) error {
 		event.Location = s.location
 	}
 
+	localOnly := util.ValueInSlice(event.Type, s.localOnlyTypes)
+
 	// If a notifcation hook is present, then call it for locally produced events.
 	// This can be used to send local events to another target (such as an event-hub member).
-	if s.notify != nil && eventSource == EventSourceLocal {
+	if s.notify != nil && eventSource == EventSourceLocal && !localOnly {
 		s.notify(event)
 	}
 
@@ -187,19 +208,25 @@ func (s *Server) broadcast(event api.Event, eventSource EventSource) error {
 			continue
 		}
 
+		// Don't forward the event types kept local to other cluster members.
+		if localOnly && listener.clusterMember {
+			continue
+		}
+
 		// If the event doesn't come from this member and has been excluded by listener, don't deliver it.
 		if eventSource != EventSourceLocal && util.ValueInSlice(event.Location, listener.excludeLocations) {
 			continue
 		}
 
+		listener.pending.Add(1)
+
 		go func(listener *Listener, event api.Event) {
-			// Check that the listener still exists
-			if listener == nil {
-				return
-			}
+			defer listener.pending.Add(-1)
 
 			// Make sure we're not done already
 			if listener.IsClosed() {
+				s.dropEvent(listener)
+
 				// Remove the listener from the list
 				s.lock.Lock()
 				delete(s.listeners, listener.id)
@@ -209,13 +236,19 @@ func (s *Server) broadcast(event api.Event, eventSource EventSource) error {
 
 			err := listener.WriteJSON(event)
 			if err != nil {
+				s.dropEvent(listener)
+
 				// Remove the listener from the list
 				s.lock.Lock()
 				delete(s.listeners, listener.id)
 				s.lock.Unlock()
 
 				listener.Close()
+				return
 			}
+
+			listener.sent.Add(1)
+			s.sent.Add(1)
 		}(listener, event)
 	}
 
@@ -224,6 +257,12 @@ func (s *Server) broadcast(event api.Event, eventSource EventSource) error {
 	return nil
 }
 
+// dropEvent records an event which couldn't be delivered to the listener.
+func (s *Server) dropEvent(listener *Listener) {
+	listener.dropped.Add(1)
+	s.dropped.Add(1)
+}
+
 // Listener describes an event listener.
 type Listener struct {
 	listenerCommon
@@ -232,4 +271,12 @@ type Listener struct {
 	projectName      string
 	excludeSources   []EventSource
 	excludeLocations []string
+
+	// Whether the listener is another cluster member.
+	clusterMember bool
+
+	// Delivery counters, pending being the number of events waiting to be written.
+	pending atomic.Int64
+	sent    atomic.Uint64
+	dropped atomic.Uint64
 }
@@ -0,0 +1,79 @@
+package events
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/shared/api"
+)
+
+// testConnection is a listener connection recording the events written to it.
+type testConnection struct {
+	events chan api.Event
+}
+
+func (c *testConnection) Reader(ctx context.Context, recvFunc EventHandler) {
+	<-ctx.Done()
+}
+
+func (c *testConnection) WriteJSON(event any) error {
+	c.events <- event.(api.Event)
+	return nil
+}
+
+func (c *testConnection) Close() error         { return nil }
+func (c *testConnection) LocalAddr() net.Addr  { return nil }
+func (c *testConnection) RemoteAddr() net.Addr { return nil }
+
+// receivedTypes returns the types of the events received by the connection until none arrive for a while.
+func (c *testConnection) receivedTypes() []string {
+	types := []string{}
+
+	for {
+		select {
+		case event := <-c.events:
+			types = append(types, event.Type)
+		case <-time.After(100 * time.Millisecond):
+			return types
+		}
+	}
+}
+
+func TestServerLocalOnlyTypes(t *testing.T) {
+	notified := make(chan string, 10)
+	s := NewServer(false, false, func(event api.Event) { notified <- event.Type })
+	s.SetLocalOnlyTypes([]string{api.EventTypeLogging})
+
+	messageTypes := []string{api.EventTypeLifecycle, api.EventTypeLogging}
+
+	client := &testConnection{events: make(chan api.Event, 10)}
+	clientListener, err := s.AddListener("", true, client, messageTypes, nil, nil, nil, false)
+	require.NoError(t, err)
+	defer clientListener.Close()
+
+	member := &testConnection{events: make(chan api.Event, 10)}
+	memberListener, err := s.AddListener("", true, member, messageTypes, nil, nil, nil, true)
+	require.NoError(t, err)
+	defer memberListener.Close()
+
+	require.NoError(t, s.Send("", api.EventTypeLogging, api.EventLogging{Message: "foo"}))
+	require.NoError(t, s.Send("", api.EventTypeLifecycle, api.EventLifecycle{Action: "instance-started"}))
+
+	// Clients receive all the events while other cluster members don't receive those kept local.
+	assert.ElementsMatch(t, []string{api.EventTypeLogging, api.EventTypeLifecycle}, client.receivedTypes())
+	assert.Equal(t, []string{api.EventTypeLifecycle}, member.receivedTypes())
+
+	// Events kept local aren't passed to the notification hook either.
+	close(notified)
+	types := []string{}
+	for eventType := range notified {
+		types = append(types, eventType)
+	}
+
+	assert.Equal(t, []string{api.EventTypeLifecycle}, types)
+}
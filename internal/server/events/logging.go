@@ -11,6 +11,9 @@ import (
 // LoggingServer controls what server to use for messages coming from the logger.
 var LoggingServer *Server
 
+// LoggingSampler controls which repeated messages coming from the logger get dropped.
+var LoggingSampler = NewLogSampler(0, 0)
+
 // Handler describes an event handler.
 type Handler struct {
 }
@@ -26,6 +29,10 @@ func (h Handler) Fire(entry *logrus.Entry) error {
 		return nil
 	}
 
+	if !LoggingSampler.Keep(entry.Level, entry.Message) {
+		return nil
+	}
+
 	return LoggingServer.Send("", api.EventTypeLogging, api.EventLogging{
 		Message: entry.Message,
 		Level:   entry.Level.String(),
@@ -0,0 +1,126 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/lxc/incus/shared/api"
+)
+
+// LogStreamQueueSize is the number of log entries which can be queued for a log stream client before new entries
+// get dropped.
+const LogStreamQueueSize = 1024
+
+// logStreamConnection is a listener connection which only delivers logging events of a minimum level, queuing
+// them so that a slow client can't hold up the event server. Entries which don't fit in the queue are dropped and
+// the client is told about it once it catches up.
+type logStreamConnection struct {
+	EventListenerConnection
+
+	level   logrus.Level
+	queue   chan api.Event
+	dropped atomic.Int64
+	done    chan struct{}
+	once    sync.Once
+}
+
+// NewLogStreamConnection returns a listener connection for streaming log entries of the given level (or more
+// severe) to the provided connection.
+func NewLogStreamConnection(connection EventListenerConnection, level string) (EventListenerConnection, error) {
+	logLevel, err := logrus.ParseLevel(level)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid log level %q", level)
+	}
+
+	c := &logStreamConnection{
+		EventListenerConnection: connection,
+		level:                   logLevel,
+		queue:                   make(chan api.Event, LogStreamQueueSize),
+		done:                    make(chan struct{}),
+	}
+
+	go c.writer()
+
+	return c, nil
+}
+
+// WriteJSON queues the event for delivery if it's a log entry of a suitable level.
+func (c *logStreamConnection) WriteJSON(event any) error {
+	e, ok := event.(api.Event)
+	if !ok || e.Type != api.EventTypeLogging {
+		return nil
+	}
+
+	entry := api.EventLogging{}
+	err := json.Unmarshal(e.Metadata, &entry)
+	if err != nil {
+		return nil
+	}
+
+	entryLevel, err := logrus.ParseLevel(entry.Level)
+	if err == nil && entryLevel > c.level {
+		return nil
+	}
+
+	select {
+	case <-c.done:
+		return fmt.Errorf("Log stream closed")
+	default:
+	}
+
+	select {
+	case c.queue <- e:
+	default:
+		c.dropped.Add(1)
+	}
+
+	return nil
+}
+
+// Close stops the delivery of log entries and closes the underlying connection.
+func (c *logStreamConnection) Close() error {
+	c.once.Do(func() { close(c.done) })
+
+	return c.EventListenerConnection.Close()
+}
+
+// writer delivers the queued events to the client.
+func (c *logStreamConnection) writer() {
+	for {
+		select {
+		case <-c.done:
+			return
+		case event := <-c.queue:
+			dropped := c.dropped.Swap(0)
+			if dropped > 0 {
+				metadata, _ := json.Marshal(api.EventLogging{
+					Message: fmt.Sprintf("Dropped %d log entries as the client isn't keeping up", dropped),
+					Level:   logrus.WarnLevel.String(),
+					Context: map[string]string{},
+				})
+
+				err := c.EventListenerConnection.WriteJSON(api.Event{
+					Type:      api.EventTypeLogging,
+					Timestamp: time.Now(),
+					Metadata:  metadata,
+					Location:  event.Location,
+				})
+				if err != nil {
+					_ = c.Close()
+					return
+				}
+			}
+
+			err := c.EventListenerConnection.WriteJSON(event)
+			if err != nil {
+				_ = c.Close()
+				return
+			}
+		}
+	}
+}
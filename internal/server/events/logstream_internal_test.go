@@ -0,0 +1,114 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/shared/api"
+)
+
+// heldConnection is a listener connection holding up the first write until released.
+type heldConnection struct {
+	testConnection
+
+	blocked chan struct{}
+	release chan struct{}
+}
+
+func (c *heldConnection) WriteJSON(event any) error {
+	select {
+	case c.blocked <- struct{}{}:
+		<-c.release
+	default:
+	}
+
+	return c.testConnection.WriteJSON(event)
+}
+
+// logEvent returns a logging event with the given message and level.
+func logEvent(t *testing.T, message string, level string) api.Event {
+	metadata, err := json.Marshal(api.EventLogging{Message: message, Level: level})
+	require.NoError(t, err)
+
+	return api.Event{Type: api.EventTypeLogging, Metadata: metadata}
+}
+
+// receivedMessages returns the messages of the log entries received by the connection until none arrive for a while.
+func (c *testConnection) receivedMessages(t *testing.T) []string {
+	messages := []string{}
+
+	for {
+		select {
+		case event := <-c.events:
+			entry := api.EventLogging{}
+			require.NoError(t, json.Unmarshal(event.Metadata, &entry))
+			messages = append(messages, entry.Message)
+		case <-time.After(100 * time.Millisecond):
+			return messages
+		}
+	}
+}
+
+func TestNewLogStreamConnection_InvalidLevel(t *testing.T) {
+	_, err := NewLogStreamConnection(&testConnection{}, "chatty")
+	assert.Error(t, err)
+}
+
+func TestLogStreamConnection_Level(t *testing.T) {
+	client := &testConnection{events: make(chan api.Event, 10)}
+	c, err := NewLogStreamConnection(client, "warning")
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, c.WriteJSON(logEvent(t, "debug", "debug")))
+	require.NoError(t, c.WriteJSON(logEvent(t, "error", "error")))
+	require.NoError(t, c.WriteJSON(logEvent(t, "info", "info")))
+	require.NoError(t, c.WriteJSON(logEvent(t, "warning", "warning")))
+	require.NoError(t, c.WriteJSON(api.Event{Type: api.EventTypeLifecycle}))
+
+	// Only the log entries at least as severe as the requested level are delivered, in order.
+	assert.Equal(t, []string{"error", "warning"}, client.receivedMessages(t))
+}
+
+func TestLogStreamConnection_Dropped(t *testing.T) {
+	client := &heldConnection{
+		testConnection: testConnection{events: make(chan api.Event, LogStreamQueueSize+10)},
+		blocked:        make(chan struct{}),
+		release:        make(chan struct{}),
+	}
+
+	c, err := NewLogStreamConnection(client, "info")
+	require.NoError(t, err)
+	defer c.Close()
+
+	// Hold up the delivery of the first entry, then overflow the queue.
+	require.NoError(t, c.WriteJSON(logEvent(t, "first", "info")))
+	<-client.blocked
+
+	for i := 0; i < LogStreamQueueSize+5; i++ {
+		require.NoError(t, c.WriteJSON(logEvent(t, fmt.Sprintf("entry %d", i), "info")))
+	}
+
+	close(client.release)
+
+	// The client is told about the dropped entries once it catches up.
+	messages := client.receivedMessages(t)
+	require.Len(t, messages, LogStreamQueueSize+2)
+	assert.Equal(t, "first", messages[0])
+	assert.Equal(t, "Dropped 5 log entries as the client isn't keeping up", messages[1])
+	assert.Equal(t, "entry 0", messages[2])
+	assert.Equal(t, fmt.Sprintf("entry %d", LogStreamQueueSize-1), messages[len(messages)-1])
+}
+
+func TestLogStreamConnection_Close(t *testing.T) {
+	c, err := NewLogStreamConnection(&testConnection{events: make(chan api.Event, 10)}, "info")
+	require.NoError(t, err)
+
+	require.NoError(t, c.Close())
+	assert.Error(t, c.WriteJSON(logEvent(t, "late", "info")))
+}
@@ -0,0 +1,78 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogSamplingWindow is the period over which repeated log messages are counted.
+const LogSamplingWindow = time.Minute
+
+// LogSampler decides which repeated log messages get dropped to limit the volume of logging events.
+// Only debug and info messages are ever sampled, warnings and errors are always kept.
+type LogSampler struct {
+	mu       sync.Mutex
+	every    int64
+	limit    int64
+	start    time.Time
+	messages map[string]int64
+	now      func() time.Time
+}
+
+// NewLogSampler returns a new LogSampler keeping 1 in every N identical messages and at most limit identical
+// messages per window. A value of 0 disables the respective policy.
+func NewLogSampler(every int64, limit int64) *LogSampler {
+	return &LogSampler{
+		every:    every,
+		limit:    limit,
+		messages: map[string]int64{},
+		now:      time.Now,
+	}
+}
+
+// Configure updates the sampling policy.
+func (s *LogSampler) Configure(every int64, limit int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.every = every
+	s.limit = limit
+	s.messages = map[string]int64{}
+}
+
+// Keep records the message and returns whether it should be logged.
+func (s *LogSampler) Keep(level logrus.Level, message string) bool {
+	if level < logrus.InfoLevel {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.every <= 1 && s.limit <= 0 {
+		return true
+	}
+
+	// Start over on each window, this also keeps the number of tracked messages in check.
+	now := s.now()
+	if now.Sub(s.start) >= LogSamplingWindow {
+		s.start = now
+		s.messages = map[string]int64{}
+	}
+
+	key := level.String() + "\x00" + message
+	count := s.messages[key]
+	s.messages[key] = count + 1
+
+	if s.limit > 0 && count >= s.limit {
+		return false
+	}
+
+	if s.every > 1 && count%s.every != 0 {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,50 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogSampler(t *testing.T) {
+	now := time.Now()
+
+	s := NewLogSampler(0, 0)
+	s.now = func() time.Time { return now }
+
+	// Nothing is sampled by default.
+	for i := 0; i < 5; i++ {
+		assert.True(t, s.Keep(logrus.DebugLevel, "foo"))
+	}
+
+	// Keep 1 in 3.
+	s.Configure(3, 0)
+	kept := 0
+	for i := 0; i < 9; i++ {
+		if s.Keep(logrus.InfoLevel, "foo") {
+			kept++
+		}
+	}
+
+	assert.Equal(t, 3, kept)
+
+	// Other messages are counted separately.
+	assert.True(t, s.Keep(logrus.InfoLevel, "bar"))
+
+	// Limit to 2 per window.
+	s.Configure(0, 2)
+	assert.True(t, s.Keep(logrus.DebugLevel, "foo"))
+	assert.True(t, s.Keep(logrus.DebugLevel, "foo"))
+	assert.False(t, s.Keep(logrus.DebugLevel, "foo"))
+
+	// Warnings and errors are never sampled.
+	assert.True(t, s.Keep(logrus.WarnLevel, "foo"))
+	assert.True(t, s.Keep(logrus.WarnLevel, "foo"))
+	assert.True(t, s.Keep(logrus.ErrorLevel, "foo"))
+
+	// A new window resets the counts.
+	now = now.Add(LogSamplingWindow)
+	assert.True(t, s.Keep(logrus.DebugLevel, "foo"))
+}
@@ -49,3 +49,15 @@ type AddressForward struct {
 	ListenPorts   []uint64
 	TargetPorts   []uint64
 }
+
+// Rule represents a firewall rule managed by Incus, along with the object it belongs to.
+type Rule struct {
+	Family   string `json:"family"`             // Family of the rule (e.g. inet or bridge for nftables, ipv4 or ipv6 for xtables).
+	Table    string `json:"table"`              // Table the rule is in.
+	Chain    string `json:"chain"`              // Chain the rule is in.
+	Rule     string `json:"rule"`               // Rule in the syntax of the firewall tool.
+	Network  string `json:"network,omitempty"`  // Network the rule belongs to.
+	Project  string `json:"project,omitempty"`  // Project of the instance the rule belongs to.
+	Instance string `json:"instance,omitempty"` // Instance the rule belongs to.
+	Device   string `json:"device,omitempty"`   // Instance device the rule belongs to.
+}
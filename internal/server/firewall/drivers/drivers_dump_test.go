@@ -0,0 +1,56 @@
+package drivers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNftablesParseRules(t *testing.T) {
+	output := `table inet incus {
+	chain pstrt.incusbr0 {
+		type nat hook postrouting priority srcnat; policy accept;
+		ip saddr 10.0.0.0/24 ip daddr != 10.0.0.0/24 masquerade
+	}
+
+	chain prert.p1_c1.eth0 {
+		type filter hook prerouting priority raw; policy accept;
+		iifname "veth1234" fib saddr . iif oif missing drop
+	}
+}
+table bridge incus {
+	chain egress.netprio.c2.eth0 {
+		type filter hook postrouting priority filter; policy accept;
+		meta priority set 0:5
+	}
+}
+table inet other {
+	chain input {
+		type filter hook input priority filter; policy accept;
+		tcp dport 22 accept
+	}
+}`
+
+	rules := Nftables{}.parseRules(output)
+	assert.Equal(t, []Rule{
+		{Family: "inet", Table: "incus", Chain: "pstrt.incusbr0", Rule: "ip saddr 10.0.0.0/24 ip daddr != 10.0.0.0/24 masquerade", Network: "incusbr0"},
+		{Family: "inet", Table: "incus", Chain: "prert.p1_c1.eth0", Rule: `iifname "veth1234" fib saddr . iif oif missing drop`, Project: "p1", Instance: "c1", Device: "eth0"},
+		{Family: "bridge", Table: "incus", Chain: "egress.netprio.c2.eth0", Rule: "meta priority set 0:5", Project: "default", Instance: "c2", Device: "eth0"},
+	}, rules)
+}
+
+func TestXtablesParseRules(t *testing.T) {
+	output := `-P INPUT ACCEPT
+-A INPUT -i incusbr0 -p tcp -m tcp --dport 53 -m comment --comment "generated for Incus network incusbr0" -j ACCEPT
+-A FORWARD -o eth0 -m comment --comment "generated for Incus container p1_c1 (eth0)" -j DROP
+-A INPUT -p tcp -m tcp --dport 22 -j ACCEPT`
+
+	rules := Xtables{}.parseRules("ipv4", "filter", output)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, "INPUT", rules[0].Chain)
+	assert.Equal(t, "incusbr0", rules[0].Network)
+	assert.Equal(t, "FORWARD", rules[1].Chain)
+	assert.Equal(t, "p1", rules[1].Project)
+	assert.Equal(t, "c1", rules[1].Instance)
+	assert.Equal(t, "eth0", rules[1].Device)
+}
@@ -1095,3 +1095,97 @@ func (d Nftables) NetworkApplyForwards(networkName string, rules []AddressForwar
 
 	return nil
 }
+
+// Dump returns the rules currently in the Incus tables.
+func (d Nftables) Dump() ([]Rule, error) {
+	output, err := subprocess.RunCommand("nft", "-nn", "list", "ruleset")
+	if err != nil {
+		return nil, fmt.Errorf("Failed listing nftables ruleset: %w", err)
+	}
+
+	return d.parseRules(output), nil
+}
+
+// parseRules extracts the rules of the Incus tables from the output of "nft list ruleset".
+func (d Nftables) parseRules(output string) []Rule {
+	rules := []Rule{}
+
+	var family, table, chain string
+	depth := 0
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if line == "}" {
+			depth--
+			if depth < 2 {
+				chain = ""
+			}
+
+			if depth < 1 {
+				family = ""
+				table = ""
+			}
+
+			continue
+		}
+
+		if strings.HasSuffix(line, "{") {
+			fields := strings.Fields(line)
+			if depth == 0 && len(fields) == 4 && fields[0] == "table" {
+				family = fields[1]
+				table = fields[2]
+			} else if depth == 1 && len(fields) == 3 && fields[0] == "chain" {
+				chain = fields[1]
+			}
+
+			depth++
+			continue
+		}
+
+		// Only consider rules from chains of the Incus tables.
+		if depth != 2 || table != nftablesNamespace || chain == "" {
+			continue
+		}
+
+		// Skip the chain definition.
+		if strings.HasPrefix(line, "type ") || strings.HasPrefix(line, "policy ") {
+			continue
+		}
+
+		rule := Rule{
+			Family: family,
+			Table:  table,
+			Chain:  chain,
+			Rule:   line,
+		}
+
+		d.ruleOwner(&rule)
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+// ruleOwner fills in the object the rule belongs to based on the name of its chain.
+// Network chains are named "<chain>.<network>" and instance device chains "<chain>.<instance>.<device>".
+func (d Nftables) ruleOwner(rule *Rule) {
+	chain := rule.Chain
+
+	// The network priority chains have an additional component.
+	netprioPrefix := "egress" + nftablesChainSeparator + "netprio" + nftablesChainSeparator
+	if strings.HasPrefix(chain, netprioPrefix) {
+		chain = "egress" + nftablesChainSeparator + strings.TrimPrefix(chain, netprioPrefix)
+	}
+
+	parts := strings.SplitN(chain, nftablesChainSeparator, 3)
+	if len(parts) == 2 {
+		rule.Network = parts[1]
+	} else if len(parts) == 3 {
+		rule.Project, rule.Instance = project.InstanceParts(parts[1])
+		rule.Device = parts[2]
+	}
+}
@@ -1647,3 +1647,98 @@ func (d Xtables) NetworkApplyForwards(networkName string, rules []AddressForward
 	reverter.Success()
 	return nil
 }
+
+// Dump returns the iptables rules added by Incus.
+// The ebtables rules aren't included as they can't be tied back to the object they belong to.
+func (d Xtables) Dump() ([]Rule, error) {
+	rules := []Rule{}
+
+	for _, ipVersion := range []uint{4, 6} {
+		cmd := "iptables"
+		family := "ipv4"
+		if ipVersion == 6 {
+			cmd = "ip6tables"
+			family = "ipv6"
+
+			// Detect kernels that lack IPv6 support.
+			if !util.PathExists("/proc/sys/net/ipv6") {
+				continue
+			}
+		}
+
+		// Check command exists.
+		_, err := exec.LookPath(cmd)
+		if err != nil {
+			continue
+		}
+
+		for _, table := range []string{"filter", "mangle", "nat"} {
+			output, err := subprocess.TryRunCommand(cmd, "-w", "-t", table, "--list-rules")
+			if err != nil {
+				return nil, fmt.Errorf("Failed to list IPv%d rules (table %s): %w", ipVersion, table, err)
+			}
+
+			rules = append(rules, d.parseRules(family, table, output)...)
+		}
+	}
+
+	return rules, nil
+}
+
+// parseRules extracts the rules added by Incus from the output of "iptables --list-rules".
+func (d Xtables) parseRules(family string, table string, output string) []Rule {
+	rules := []Rule{}
+	commentMarker := fmt.Sprintf("--comment \"%s ", iptablesCommentPrefix)
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "-A" {
+			continue
+		}
+
+		start := strings.Index(line, commentMarker)
+		if start < 0 {
+			continue
+		}
+
+		comment := line[start+len(commentMarker):]
+		end := strings.Index(comment, "\"")
+		if end < 0 {
+			continue
+		}
+
+		rule := Rule{
+			Family: family,
+			Table:  table,
+			Chain:  fields[1],
+			Rule:   line,
+		}
+
+		d.ruleOwner(&rule, comment[:end])
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+// ruleOwner fills in the object the rule belongs to based on its comment.
+func (d Xtables) ruleOwner(rule *Rule, comment string) {
+	if strings.HasPrefix(comment, "Incus network-forward ") {
+		rule.Network = strings.TrimPrefix(comment, "Incus network-forward ")
+	} else if strings.HasPrefix(comment, "Incus network ") {
+		rule.Network = strings.TrimPrefix(comment, "Incus network ")
+	} else if strings.HasPrefix(comment, "Incus container ") {
+		label := strings.TrimPrefix(comment, "Incus container ")
+
+		// The device name is between parentheses after the instance name.
+		i := strings.LastIndex(label, " (")
+		if i < 0 || !strings.HasSuffix(label, ")") {
+			return
+		}
+
+		rule.Project, rule.Instance = project.InstanceParts(label[:i])
+		rule.Device = label[i+2 : len(label)-1]
+	}
+}
@@ -27,4 +27,6 @@ type Firewall interface {
 
 	InstanceSetupNetPrio(projectName string, instanceName string, deviceName string, netPrio uint32) error
 	InstanceClearNetPrio(projectName string, instanceName string, deviceName string) error
+
+	Dump() ([]drivers.Rule, error)
 }
@@ -1,6 +1,8 @@
 package firewall
 
 import (
+	"fmt"
+
 	"github.com/lxc/incus/internal/server/firewall/drivers"
 	"github.com/lxc/incus/shared/logger"
 )
@@ -48,3 +50,27 @@ func New() Firewall {
 	// If xtables is compatible, but not in use, and nftables is not compatible, use xtables.
 	return xtables
 }
+
+// Load returns the requested firewall implementation, or an automatically detected one if no driver is specified.
+// An error is returned if the requested driver isn't usable on this system.
+func Load(driverName string) (Firewall, error) {
+	var driver Firewall
+
+	switch driverName {
+	case "":
+		return New(), nil
+	case "nftables":
+		driver = drivers.Nftables{}
+	case "xtables":
+		driver = drivers.Xtables{}
+	default:
+		return nil, fmt.Errorf("Unknown firewall driver %q", driverName)
+	}
+
+	_, err := driver.Compat()
+	if err != nil {
+		return nil, fmt.Errorf("Firewall driver %q isn't usable: %w", driverName, err)
+	}
+
+	return driver, nil
+}
@@ -1,32 +1,84 @@
 package fsmonitor
 
 import (
+	"path/filepath"
+	"strings"
+
 	"github.com/lxc/incus/internal/server/fsmonitor/drivers"
 	"github.com/lxc/incus/shared/logger"
 )
 
 type fsMonitor struct {
-	driver drivers.Driver
-	logger logger.Logger
+	drivers []drivers.Driver
+	logger  logger.Logger
 }
 
-// PrefixPath returns the prefix path.
-func (fs *fsMonitor) PrefixPath() string {
-	return fs.driver.PrefixPath()
+// PrefixPath returns the prefix path of the driver responsible for the given path.
+func (fs *fsMonitor) PrefixPath(path string) (string, error) {
+	driver, err := fs.driver(path)
+	if err != nil {
+		return "", err
+	}
+
+	return driver.PrefixPath(), nil
+}
+
+// PrefixPaths returns all the watched prefix paths.
+func (fs *fsMonitor) PrefixPaths() []string {
+	paths := make([]string, 0, len(fs.drivers))
+	for _, driver := range fs.drivers {
+		paths = append(paths, driver.PrefixPath())
+	}
+
+	return paths
+}
+
+// driver returns the driver responsible for the given path.
+// When prefix paths are nested, the most specific one is used.
+func (fs *fsMonitor) driver(path string) (drivers.Driver, error) {
+	path = filepath.Clean(path)
+
+	var match drivers.Driver
+	for _, driver := range fs.drivers {
+		prefixPath := driver.PrefixPath()
+		if path != prefixPath && !strings.HasPrefix(path, prefixPath+"/") {
+			continue
+		}
+
+		if match == nil || len(prefixPath) > len(match.PrefixPath()) {
+			match = driver
+		}
+	}
+
+	if match == nil {
+		return nil, &drivers.ErrInvalidPath{PrefixPath: strings.Join(fs.PrefixPaths(), ", ")}
+	}
+
+	return match, nil
 }
 
 // Watch creates a watch for a path which may or may not yet exist. If the provided path gets an
 // inotify event, f() is called.
 // Note: If f() returns false, the watch is removed.
 func (fs *fsMonitor) Watch(path string, identifier string, f func(path string, event string) bool) error {
+	driver, err := fs.driver(path)
+	if err != nil {
+		return err
+	}
+
 	fs.logger.Info("Watching path", logger.Ctx{"path": path})
 
-	return fs.driver.Watch(path, identifier, f)
+	return driver.Watch(path, identifier, f)
 }
 
 // Unwatch removes the given path from the watchlist.
 func (fs *fsMonitor) Unwatch(path string, identifier string) error {
+	driver, err := fs.driver(path)
+	if err != nil {
+		return err
+	}
+
 	fs.logger.Info("Unwatching path", logger.Ctx{"path": path})
 
-	return fs.driver.Unwatch(path, identifier)
+	return driver.Unwatch(path, identifier)
 }
@@ -1,8 +1,9 @@
 package fsmonitor
 
-// FSMonitor represents a filesystem monitor.
+// FSMonitor represents a filesystem monitor.
 type FSMonitor interface {
-	PrefixPath() string
+	PrefixPath(path string) (string, error)
+	PrefixPaths() []string
 	Watch(path string, identifier string, f func(path string, event string) bool) error
 	Unwatch(path string, identifier string) error
 }
@@ -3,15 +3,18 @@ package fsmonitor
 import (
 	"context"
 	"errors"
+	"fmt"
+	"path/filepath"
 
 	"github.com/lxc/incus/internal/linux"
 	"github.com/lxc/incus/internal/server/fsmonitor/drivers"
 	"github.com/lxc/incus/shared/logger"
 )
 
-// New creates a new FSMonitor instance.
-func New(ctx context.Context, path string) (FSMonitor, error) {
-	startMonitor := func(driverName string) (drivers.Driver, logger.Logger, error) {
+// New creates a new FSMonitor instance watching the given paths.
+// Paths which can't be monitored are skipped with a warning, an error is only returned if none of them can be.
+func New(ctx context.Context, paths []string) (FSMonitor, error) {
+	startMonitor := func(driverName string, path string) (drivers.Driver, logger.Logger, error) {
 		logger := logger.AddContext(logger.Ctx{"driver": driverName})
 
 		driver, err := drivers.Load(ctx, logger, driverName, path)
@@ -22,24 +25,50 @@ func New(ctx context.Context, path string) (FSMonitor, error) {
 		return driver, logger, nil
 	}
 
-	if !linux.IsMountPoint(path) {
-		return nil, errors.New("Path needs to be a mountpoint")
-	}
+	startPath := func(path string) (drivers.Driver, logger.Logger, error) {
+		if !linux.IsMountPoint(path) {
+			return nil, nil, errors.New("Path needs to be a mountpoint")
+		}
 
-	driver, monLogger, err := startMonitor("fanotify")
-	if err != nil {
-		logger.Warn("Failed to initialize fanotify, falling back on inotify", logger.Ctx{"err": err})
-		driver, monLogger, err = startMonitor("inotify")
+		driver, monLogger, err := startMonitor("fanotify", path)
 		if err != nil {
-			return nil, err
+			logger.Warn("Failed to initialize fanotify, falling back on inotify", logger.Ctx{"path": path, "err": err})
+			driver, monLogger, err = startMonitor("inotify", path)
+			if err != nil {
+				return nil, nil, err
+			}
 		}
+
+		return driver, monLogger, nil
 	}
 
-	logger.Info("Initialized filesystem monitor", logger.Ctx{"path": path, "driver": driver.Name()})
+	monitor := fsMonitor{}
+	seen := map[string]bool{}
+
+	for _, path := range paths {
+		path = filepath.Clean(path)
+		if seen[path] {
+			continue
+		}
+
+		seen[path] = true
+
+		driver, monLogger, err := startPath(path)
+		if err != nil {
+			logger.Warn("Failed to initialize filesystem monitor", logger.Ctx{"path": path, "err": err})
+			continue
+		}
+
+		logger.Info("Initialized filesystem monitor", logger.Ctx{"path": path, "driver": driver.Name()})
+
+		monitor.drivers = append(monitor.drivers, driver)
+		if monitor.logger == nil {
+			monitor.logger = monLogger
+		}
+	}
 
-	monitor := fsMonitor{
-		driver: driver,
-		logger: monLogger,
+	if len(monitor.drivers) == 0 {
+		return nil, fmt.Errorf("Failed to monitor any of the paths %v", paths)
 	}
 
 	return &monitor, nil
@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io/fs"
 	"net/http"
+	"os"
 	"path/filepath"
 	"sort"
 	"strconv"
@@ -36,6 +38,7 @@ import (
 	"github.com/lxc/incus/shared/api"
 	"github.com/lxc/incus/shared/logger"
 	"github.com/lxc/incus/shared/subprocess"
+	"github.com/lxc/incus/shared/units"
 	"github.com/lxc/incus/shared/util"
 )
 
@@ -701,7 +704,7 @@ func (d *common) snapshotCommon(inst instance.Instance, name string, expiry time
 	}
 
 	// Create the snapshot.
-	snap, snapInstOp, cleanup, err := instance.CreateInternal(d.state, args, true)
+	snap, snapInstOp, cleanup, err := instance.CreateInternal(d.state, args, true, false)
 	if err != nil {
 		return fmt.Errorf("Failed creating instance snapshot record %q: %w", name, err)
 	}
@@ -1228,6 +1231,32 @@ func (d *common) devicesRegister(inst instance.Instance) {
 	}
 }
 
+// devicesFirewallReapply re-applies the host firewall rules of all of the instance's devices.
+func (d *common) devicesFirewallReapply(inst instance.Instance) error {
+	for _, entry := range d.ExpandedDevices().Sorted() {
+		dev, err := d.deviceLoad(inst, entry.Name, entry.Config)
+		if err != nil {
+			if errors.Is(err, device.ErrUnsupportedDevType) {
+				continue // Skip unsupported device (allows for mixed instance type profiles).
+			}
+
+			return fmt.Errorf("Failed loading device %q: %w", entry.Name, err)
+		}
+
+		fwDev, ok := dev.(device.FirewallReapplier)
+		if !ok {
+			continue
+		}
+
+		err = fwDev.FirewallReapply()
+		if err != nil {
+			return fmt.Errorf("Failed re-applying firewall rules for device %q: %w", entry.Name, err)
+		}
+	}
+
+	return nil
+}
+
 // devicesUpdate applies device changes to an instance.
 func (d *common) devicesUpdate(inst instance.Instance, removeDevices deviceConfig.Devices, addDevices deviceConfig.Devices, updateDevices deviceConfig.Devices, oldExpandedDevices deviceConfig.Devices, instanceRunning bool, userRequested bool) error {
 	revert := revert.New()
@@ -1425,3 +1454,78 @@ func (d *common) deleteSnapshots(deleteFunc func(snapInst instance.Instance) err
 
 	return nil
 }
+
+// consoleLogDefaultSize is the amount of console output kept when no size is configured.
+const consoleLogDefaultSize = 128 * 1024
+
+// consoleLogSize returns the amount of console output to keep for the instance.
+func (d *common) consoleLogSize() (int64, error) {
+	value := d.expandedConfig["console.log_size"]
+	if value == "" && d.state.GlobalConfig != nil {
+		value = d.state.GlobalConfig.InstancesConsoleLogSize()
+	}
+
+	if value == "" {
+		return consoleLogDefaultSize, nil
+	}
+
+	size, err := units.ParseByteSizeString(value)
+	if err != nil {
+		return -1, fmt.Errorf("Invalid console log size %q: %w", value, err)
+	}
+
+	if size <= 0 {
+		return consoleLogDefaultSize, nil
+	}
+
+	return size, nil
+}
+
+// consoleBufferSize returns the size of the console ring buffer holding size bytes of output.
+// It's rounded up to a power of two as required by both liblxc and QEMU.
+func consoleBufferSize(size int64) int64 {
+	bufferSize := int64(4096)
+	for bufferSize < size {
+		bufferSize <<= 1
+	}
+
+	return bufferSize
+}
+
+// appendConsoleLog appends data to the console log file at path, discarding the oldest output so that it
+// doesn't exceed size. The file is replaced rather than rewritten in place.
+func appendConsoleLog(path string, data []byte, size int64) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	content = append(content, data...)
+	if int64(len(content)) > size {
+		content = content[int64(len(content))-size:]
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".")
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = os.Remove(f.Name()) }()
+	defer func() { _ = f.Close() }()
+
+	_, err = f.Write(content)
+	if err != nil {
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,68 @@
+package drivers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConsoleBufferSize(t *testing.T) {
+	testCases := []struct {
+		size     int64
+		expected int64
+	}{
+		{0, 4096},
+		{4096, 4096},
+		{4097, 8192},
+		{128 * 1024, 128 * 1024},
+		{1000 * 1000, 1024 * 1024},
+	}
+
+	for _, tc := range testCases {
+		got := consoleBufferSize(tc.size)
+		if got != tc.expected {
+			t.Errorf("consoleBufferSize(%d) = %d, expected %d", tc.size, got, tc.expected)
+		}
+	}
+}
+
+func TestAppendConsoleLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "console.log")
+
+	steps := []struct {
+		data     string
+		expected string
+	}{
+		{"", ""},
+		{"boot", "boot"},
+		{"ing\n", "booting\n"},
+		{"login: ", "ng\nlogin: "},
+		{"0123456789ab", "23456789ab"},
+	}
+
+	for _, step := range steps {
+		err := appendConsoleLog(path, []byte(step.data), 10)
+		if err != nil {
+			t.Fatalf("appendConsoleLog(%q) failed: %v", step.data, err)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			t.Fatalf("Failed reading console log: %v", err)
+		}
+
+		if string(content) != step.expected {
+			t.Errorf("After appending %q, got %q, expected %q", step.data, content, step.expected)
+		}
+	}
+
+	// No temporary file is left behind.
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 {
+		t.Errorf("Expected only the console log in the directory, got %d entries", len(entries))
+	}
+}
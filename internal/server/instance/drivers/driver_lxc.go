@@ -697,13 +697,20 @@ func (d *lxc) initLXC(config bool) (*liblxc.Container, error) {
 	}
 
 	if liblxc.RuntimeLiblxcVersionAtLeast(liblxc.Version(), 3, 0, 0) {
-		// Default size log buffer
-		err = lxcSetConfigItem(cc, "lxc.console.buffer.size", "auto")
+		// Console log buffer, liblxc requires its size to be a power of two.
+		consoleLogSize, err := d.consoleLogSize()
 		if err != nil {
 			return nil, err
 		}
 
-		err = lxcSetConfigItem(cc, "lxc.console.size", "auto")
+		bufferSize := consoleBufferSize(consoleLogSize)
+
+		err = lxcSetConfigItem(cc, "lxc.console.buffer.size", strconv.FormatInt(bufferSize, 10))
+		if err != nil {
+			return nil, err
+		}
+
+		err = lxcSetConfigItem(cc, "lxc.console.size", strconv.FormatInt(bufferSize, 10))
 		if err != nil {
 			return nil, err
 		}
@@ -1405,6 +1412,11 @@ func (d *lxc) RegisterDevices() {
 	d.devicesRegister(d)
 }
 
+// FirewallReapply re-applies the host firewall rules of the instance's devices.
+func (d *lxc) FirewallReapply() error {
+	return d.devicesFirewallReapply(d)
+}
+
 // deviceStart loads a new device and calls its Start() function.
 func (d *lxc) deviceStart(dev device.Device, instanceRunning bool) (*deviceConfig.RunConfig, error) {
 	configCopy := dev.Config()
@@ -1829,6 +1841,18 @@ func (d *lxc) handleIdmappedStorage() (idmap.IdmapStorageType, *idmap.IdmapSet,
 		return idmap.IdmapStorageNone, nil, fmt.Errorf("Set ID map: %w", err)
 	}
 
+	idmapType := d.IdmappedStorage(d.RootfsPath(), "none")
+
+	// Refuse to run unprivileged containers without idmapped mounts if they are required, whether
+	// the filesystem needs shifting now or was shifted on disk before.
+	if nextIdmap != nil && idmapType == idmap.IdmapStorageNone && d.state.LocalConfig != nil && d.state.LocalConfig.IdmappedMounts() == "required" {
+		if !d.state.OS.IdmappedMounts || !d.state.OS.LXCFeatures["idmapped_mounts_v2"] {
+			return idmap.IdmapStorageNone, nil, fmt.Errorf("Idmapped mounts are required by the server configuration but aren't supported on this system")
+		}
+
+		return idmap.IdmapStorageNone, nil, fmt.Errorf("Idmapped mounts are required by the server configuration but aren't supported by the container's storage")
+	}
+
 	// Identical on-disk idmaps so no changes required.
 	if nextIdmap.Equals(diskIdmap) {
 		return idmap.IdmapStorageNone, nextIdmap, nil
@@ -1836,7 +1860,6 @@ func (d *lxc) handleIdmappedStorage() (idmap.IdmapStorageType, *idmap.IdmapSet,
 
 	// There's no on-disk idmap applied and the container can use idmapped
 	// storage.
-	idmapType := d.IdmappedStorage(d.RootfsPath(), "none")
 	if diskIdmap == nil && idmapType != idmap.IdmapStorageNone {
 		return idmapType, nextIdmap, nil
 	}
@@ -4719,9 +4742,9 @@ func (d *lxc) Update(args db.InstanceArgs, userRequested bool) error {
 
 	// Send devIncus notifications
 	if isRunning {
-		// Config changes (only for user.* keys
+		// Config changes (only for keys exposed through the guest API)
 		for _, key := range changedConfig {
-			if !strings.HasPrefix(key, "user.") {
+			if !internalInstance.IsGuestAPIConfig(key) {
 				continue
 			}
 
@@ -6054,7 +6077,7 @@ func (d *lxc) MigrateReceive(args instance.MigrateReceiveArgs) error {
 					}
 
 					// Create the snapshot instance.
-					_, snapInstOp, cleanup, err := instance.CreateInternal(d.state, *snapArgs, true)
+					_, snapInstOp, cleanup, err := instance.CreateInternal(d.state, *snapArgs, true, false)
 					if err != nil {
 						return fmt.Errorf("Failed creating instance snapshot record %q: %w", snapArgs.Name, err)
 					}
@@ -24,6 +24,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unsafe"
 
@@ -1908,6 +1909,82 @@ func (d *qemu) advertiseVsockAddress() error {
 	return nil
 }
 
+// qemuConsoleLogMu serializes the updates to the console log files.
+var qemuConsoleLogMu sync.Mutex
+
+// saveConsoleLog moves the output held in the console ring buffer to the console log file.
+func (d *qemu) saveConsoleLog(monitor *qmp.Monitor) error {
+	size, err := d.consoleLogSize()
+	if err != nil {
+		return err
+	}
+
+	qemuConsoleLogMu.Lock()
+	defer qemuConsoleLogMu.Unlock()
+
+	output, err := monitor.RingbufRead("console", int(consoleBufferSize(size)))
+	if err != nil {
+		return err
+	}
+
+	err = appendConsoleLog(d.ConsoleBufferLogPath(), []byte(output), size)
+	if err != nil {
+		return fmt.Errorf("Failed writing console log: %w", err)
+	}
+
+	return nil
+}
+
+// ConsoleLog returns the recorded console output, at most the configured console log size.
+func (d *qemu) ConsoleLog() (string, error) {
+	if d.IsRunning() {
+		monitor, err := qmp.Connect(d.monitorPath(), qemuSerialChardevName, d.getMonitorEventHandler())
+		if err != nil {
+			return "", err
+		}
+
+		err = d.saveConsoleLog(monitor)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	qemuConsoleLogMu.Lock()
+	defer qemuConsoleLogMu.Unlock()
+
+	content, err := os.ReadFile(d.ConsoleBufferLogPath())
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+// ClearConsoleLog discards the recorded console output.
+func (d *qemu) ClearConsoleLog() error {
+	if d.IsRunning() {
+		monitor, err := qmp.Connect(d.monitorPath(), qemuSerialChardevName, d.getMonitorEventHandler())
+		if err != nil {
+			return err
+		}
+
+		err = d.saveConsoleLog(monitor)
+		if err != nil {
+			return err
+		}
+	}
+
+	qemuConsoleLogMu.Lock()
+	defer qemuConsoleLogMu.Unlock()
+
+	err := os.Remove(d.ConsoleBufferLogPath())
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
 // AgentCertificate returns the server certificate of the agent.
 func (d *qemu) AgentCertificate() *x509.Certificate {
 	agentCert := filepath.Join(d.Path(), "config", "agent.crt")
@@ -2035,6 +2112,11 @@ func (d *qemu) RegisterDevices() {
 	d.devicesRegister(d)
 }
 
+// FirewallReapply re-applies the host firewall rules of the instance's devices.
+func (d *qemu) FirewallReapply() error {
+	return d.devicesFirewallReapply(d)
+}
+
 func (d *qemu) saveConnectionInfo(connInfo *agentAPI.API10Put) error {
 	configDrivePath := filepath.Join(d.Path(), "config")
 
@@ -2381,10 +2463,6 @@ func (d *qemu) nvramPath() string {
 	return filepath.Join(d.Path(), "qemu.nvram")
 }
 
-func (d *qemu) consolePath() string {
-	return filepath.Join(d.LogPath(), "qemu.console")
-}
-
 func (d *qemu) spicePath() string {
 	return filepath.Join(d.LogPath(), "qemu.spice")
 }
@@ -2908,8 +2986,13 @@ func (d *qemu) generateQemuConfigFile(cpuInfo *cpuTopology, mountInfo *storagePo
 	// QMP socket.
 	cfg = append(cfg, qemuControlSocket(&qemuControlSocketOpts{d.monitorPath()})...)
 
-	// Console output.
-	cfg = append(cfg, qemuConsole(&qemuConsoleOpts{d.consolePath()})...)
+	// Console output, kept in a ring buffer until moved to the console log.
+	consoleLogSize, err := d.consoleLogSize()
+	if err != nil {
+		return "", nil, err
+	}
+
+	cfg = append(cfg, qemuConsole(&qemuConsoleOpts{ringbufSizeBytes: int(consoleBufferSize(consoleLogSize))})...)
 
 	// Setup the bus allocator.
 	bus := qemuNewBus(busName, &cfg)
@@ -4448,6 +4531,12 @@ func (d *qemu) Stop(stateful bool) error {
 		return nil
 	}
 
+	// Keep the console output which wasn't retrieved yet.
+	err = d.saveConsoleLog(monitor)
+	if err != nil {
+		d.logger.Warn("Failed saving console log", logger.Ctx{"err": err})
+	}
+
 	// Handle stateful stop.
 	if stateful {
 		// Dump the state.
@@ -5340,9 +5429,9 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 	revert.Success()
 
 	if isRunning {
-		// Send devIncus notifications only for user.* key changes
+		// Send devIncus notifications only for changes to keys exposed through the guest API
 		for _, key := range changedConfig {
-			if !strings.HasPrefix(key, "user.") {
+			if !internalInstance.IsGuestAPIConfig(key) {
 				continue
 			}
 
@@ -6820,7 +6909,7 @@ func (d *qemu) MigrateReceive(args instance.MigrateReceiveArgs) error {
 					}
 
 					// Create the snapshot instance.
-					_, snapInstOp, cleanup, err := instance.CreateInternal(d.state, *snapArgs, true)
+					_, snapInstOp, cleanup, err := instance.CreateInternal(d.state, *snapArgs, true, false)
 					if err != nil {
 						return fmt.Errorf("Failed creating instance snapshot record %q: %w", snapArgs.Name, err)
 					}
@@ -7033,15 +7122,82 @@ func (d *qemu) FileSFTP() (*sftp.Client, error) {
 
 // Console gets access to the instance's console.
 func (d *qemu) Console(protocol string) (*os.File, chan error, error) {
-	var path string
 	switch protocol {
 	case instance.ConsoleTypeConsole:
-		path = d.consolePath()
+		return d.console()
 	case instance.ConsoleTypeVGA:
-		path = d.spicePath()
+		return d.vga()
 	default:
 		return nil, nil, fmt.Errorf("Unknown protocol %q", protocol)
 	}
+}
+
+// console attaches to the serial console by switching its ring buffer for a socket until disconnection.
+func (d *qemu) console() (*os.File, chan error, error) {
+	size, err := d.consoleLogSize()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	monitor, err := qmp.Connect(d.monitorPath(), qemuSerialChardevName, d.getMonitorEventHandler())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Keep the output received so far, the ring buffer is reset when switching back to it.
+	err = d.saveConsoleLog(monitor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fds, err := unix.Socketpair(unix.AF_UNIX, unix.SOCK_STREAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed creating console socket pair: %w", err)
+	}
+
+	file := os.NewFile(uintptr(fds[0]), "console")
+	vmFile := os.NewFile(uintptr(fds[1]), "console-vm")
+	defer func() { _ = vmFile.Close() }()
+
+	err = monitor.SendFile("console", vmFile)
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, err
+	}
+
+	err = monitor.ChardevChange("console", qmp.ChardevChangeInfo{Type: "socket", FDName: "console"})
+	if err != nil {
+		_ = monitor.CloseFile("console")
+		_ = file.Close()
+		return nil, nil, err
+	}
+
+	// Disconnection notification.
+	chDisconnect := make(chan error, 1)
+
+	// Go back to the ring buffer once the console is detached.
+	go func() {
+		<-chDisconnect
+
+		monitor, err := qmp.Connect(d.monitorPath(), qemuSerialChardevName, d.getMonitorEventHandler())
+		if err != nil {
+			return
+		}
+
+		err = monitor.ChardevChange("console", qmp.ChardevChangeInfo{Type: "ringbuf", Size: int(consoleBufferSize(size))})
+		if err != nil {
+			d.logger.Warn("Failed restoring the console ring buffer", logger.Ctx{"err": err})
+		}
+	}()
+
+	d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceConsole.Event(d, logger.Ctx{"type": instance.ConsoleTypeConsole}))
+
+	return file, chDisconnect, nil
+}
+
+// vga attaches to the SPICE socket.
+func (d *qemu) vga() (*os.File, chan error, error) {
+	path := d.spicePath()
 
 	// Disconnection notification.
 	chDisconnect := make(chan error, 1)
@@ -7059,7 +7215,7 @@ func (d *qemu) Console(protocol string) (*os.File, chan error, error) {
 
 	_ = conn.Close()
 
-	d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceConsole.Event(d, logger.Ctx{"type": protocol}))
+	d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceConsole.Event(d, logger.Ctx{"type": instance.ConsoleTypeVGA}))
 
 	return file, chDisconnect, nil
 }
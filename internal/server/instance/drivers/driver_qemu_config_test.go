@@ -704,13 +704,11 @@ func TestQemuConfigTemplates(t *testing.T) {
 			opts     qemuConsoleOpts
 			expected string
 		}{{
-			qemuConsoleOpts{"/dev/shm/console-socket"},
+			qemuConsoleOpts{131072},
 			`# Console
 			[chardev "console"]
-			backend = "socket"
-			path = "/dev/shm/console-socket"
-			server = "on"
-			wait = "off"`,
+			backend = "ringbuf"
+			size = "131072B"`,
 		}}
 		for _, tc := range testCases {
 			runTest(tc.expected, qemuConsole(&tc.opts))
@@ -566,7 +566,7 @@ func qemuControlSocket(opts *qemuControlSocketOpts) []cfgSection {
 }
 
 type qemuConsoleOpts struct {
-	path string
+	ringbufSizeBytes int
 }
 
 func qemuConsole(opts *qemuConsoleOpts) []cfgSection {
@@ -574,10 +574,8 @@ func qemuConsole(opts *qemuConsoleOpts) []cfgSection {
 		name:    `chardev "console"`,
 		comment: "Console",
 		entries: []cfgEntry{
-			{key: "backend", value: "socket"},
-			{key: "path", value: opts.path},
-			{key: "server", value: "on"},
-			{key: "wait", value: "off"},
+			{key: "backend", value: "ringbuf"},
+			{key: "size", value: fmt.Sprintf("%dB", opts.ringbufSizeBytes)},
 		},
 	}}
 }
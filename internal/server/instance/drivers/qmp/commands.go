@@ -621,6 +621,76 @@ func (m *Monitor) Reset() error {
 	return nil
 }
 
+// ChardevChangeInfo contains the new backend of a character device.
+type ChardevChangeInfo struct {
+	// Type is the backend type, either "socket" or "ringbuf".
+	Type string
+
+	// FDName is the name of the file descriptor of a connected socket, as passed with SendFile.
+	FDName string
+
+	// Size is the size of a ringbuf backend in bytes, it must be a power of two.
+	Size int
+}
+
+// ChardevChange changes the backend of a character device.
+func (m *Monitor) ChardevChange(device string, info ChardevChangeInfo) error {
+	var data map[string]any
+
+	switch info.Type {
+	case "socket":
+		data = map[string]any{
+			"addr": map[string]any{
+				"type": "fd",
+				"data": map[string]any{"str": info.FDName},
+			},
+			"server": false,
+		}
+
+	case "ringbuf":
+		data = map[string]any{"size": info.Size}
+
+	default:
+		return fmt.Errorf("Unsupported character device backend %q", info.Type)
+	}
+
+	args := map[string]any{
+		"id": device,
+		"backend": map[string]any{
+			"type": info.Type,
+			"data": data,
+		},
+	}
+
+	err := m.run("chardev-change", args, nil)
+	if err != nil {
+		return fmt.Errorf("Failed changing the %q character device: %w", device, err)
+	}
+
+	return nil
+}
+
+// RingbufRead returns and consumes up to size bytes from a ringbuf character device.
+func (m *Monitor) RingbufRead(device string, size int) (string, error) {
+	// Prepare the response.
+	var resp struct {
+		Return string `json:"return"`
+	}
+
+	args := map[string]any{
+		"device": device,
+		"size":   size,
+		"format": "utf8",
+	}
+
+	err := m.run("ringbuf-read", args, &resp)
+	if err != nil {
+		return "", fmt.Errorf("Failed reading the %q ring buffer: %w", device, err)
+	}
+
+	return resp.Return, nil
+}
+
 // PCIClassInfo info about a device's class.
 type PCIClassInfo struct {
 	Class       int    `json:"class"`
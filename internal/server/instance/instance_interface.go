@@ -81,6 +81,7 @@ type Instance interface {
 	Rebuild(img *api.Image, op *operations.Operation) error
 	Unfreeze() error
 	RegisterDevices()
+	FirewallReapply() error
 
 	Info() Info
 	IsPrivileged() bool
@@ -187,6 +188,8 @@ type VM interface {
 	Instance
 
 	AgentCertificate() *x509.Certificate
+	ConsoleLog() (string, error)
+	ClearConsoleLog() error
 }
 
 // CriuMigrationArgs arguments for CRIU migration.
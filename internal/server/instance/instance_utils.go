@@ -130,6 +130,11 @@ func ValidConfig(sysOS *sys.OS, config map[string]string, expanded bool, instanc
 		return err
 	}
 
+	err = seccomp.ValidateProfiles(config["security.syscalls.intercept.profiles"])
+	if err != nil {
+		return err
+	}
+
 	if expanded && (util.IsFalseOrEmpty(config["security.privileged"])) && sysOS.IdmapSet == nil {
 		return fmt.Errorf("No uid/gid allocation configured. In this mode, only privileged containers are supported")
 	}
@@ -676,13 +681,9 @@ func ValidName(instanceName string, isSnapshot bool) error {
 			return fmt.Errorf("Invalid instance snapshot name: Cannot contain space or / characters")
 		}
 	} else {
-		if strings.Contains(instanceName, internalInstance.SnapshotDelimiter) {
-			return fmt.Errorf("The character %q is reserved for snapshots", internalInstance.SnapshotDelimiter)
-		}
-
-		err := validate.IsHostname(instanceName)
+		err := internalInstance.ValidName(instanceName)
 		if err != nil {
-			return fmt.Errorf("Invalid instance name: %w", err)
+			return err
 		}
 	}
 
@@ -692,10 +693,11 @@ func ValidName(instanceName string, isSnapshot bool) error {
 // CreateInternal creates an instance record and storage volume record in the database and sets up devices.
 // Accepts a reverter that revert steps this function does will be added to. It is up to the caller to
 // call the revert's Fail() or Success() function as needed.
+// If checkProjectLimits is true, the project limits are checked again in the transaction adding the instance record.
 // Returns the created instance, along with a "create" operation lock that needs to be marked as Done once the
 // instance is fully completed, and a revert fail function that can be used to undo this function if a subsequent
 // step fails.
-func CreateInternal(s *state.State, args db.InstanceArgs, clearLogDir bool) (Instance, *operationlock.InstanceOperation, revert.Hook, error) {
+func CreateInternal(s *state.State, args db.InstanceArgs, clearLogDir bool, checkProjectLimits bool) (Instance, *operationlock.InstanceOperation, revert.Hook, error) {
 	revert := revert.New()
 	defer revert.Fail()
 
@@ -927,6 +929,15 @@ func CreateInternal(s *state.State, args db.InstanceArgs, clearLogDir bool) (Ins
 			return err
 		}
 
+		// Check the project limits again now that the instance is recorded, in case other instances
+		// were created in the project since the limits were first checked.
+		if checkProjectLimits {
+			err = project.CheckInstanceLimits(tx, args.Project, args.Type)
+			if err != nil {
+				return api.StatusErrorf(http.StatusForbidden, "%v", err)
+			}
+		}
+
 		// Read back the instance, to get ID and creation time.
 		dbRow, err := cluster.GetInstance(ctx, tx.Tx(), args.Project, args.Name)
 		if err != nil {
@@ -14,6 +14,8 @@ const (
 	ClusterDisabled           = ClusterAction(api.EventLifecycleClusterDisabled)
 	ClusterCertificateUpdated = ClusterAction(api.EventLifecycleClusterCertificateUpdated)
 	ClusterTokenCreated       = ClusterAction(api.EventLifecycleClusterTokenCreated)
+	ClusterQuorumLost         = ClusterAction(api.EventLifecycleClusterQuorumLost)
+	ClusterQuorumRestored     = ClusterAction(api.EventLifecycleClusterQuorumRestored)
 )
 
 // Event creates the lifecycle event for an action on a cluster.
@@ -0,0 +1,127 @@
+package lifecyclehook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/shared/api"
+	"github.com/lxc/incus/shared/logger"
+	"github.com/lxc/incus/shared/subprocess"
+	"github.com/lxc/incus/shared/util"
+)
+
+// Request is the information passed to the hook about an instance lifecycle event.
+type Request struct {
+	Action    string                       `json:"action"`
+	Project   string                       `json:"project"`
+	Instance  string                       `json:"instance"`
+	Source    string                       `json:"source"`
+	Location  string                       `json:"location,omitempty"`
+	Timestamp time.Time                    `json:"timestamp"`
+	Requestor *api.EventLifecycleRequestor `json:"requestor,omitempty"`
+	Context   map[string]any               `json:"context,omitempty"`
+}
+
+// Hook runs a command or calls a webhook when instance lifecycle events occur.
+type Hook struct {
+	ctx     context.Context
+	target  string
+	actions []string
+	timeout time.Duration
+}
+
+// IsWebhook returns whether the hook is a webhook URL rather than a command.
+func IsWebhook(target string) bool {
+	return strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://")
+}
+
+// NewHook returns a Hook running target, either the absolute path of a command or a webhook URL, on the given
+// instance lifecycle actions (all of them if empty). Each run is given at most timeout to complete.
+func NewHook(ctx context.Context, target string, actions []string, timeout time.Duration) *Hook {
+	return &Hook{
+		ctx:     ctx,
+		target:  target,
+		actions: actions,
+		timeout: timeout,
+	}
+}
+
+// HandleEvent handles the event received from the internal event listener.
+// Failures are logged as the hook runs independently from the action which triggered the event.
+func (h *Hook) HandleEvent(event api.Event) {
+	if event.Type != api.EventTypeLifecycle {
+		return
+	}
+
+	lifecycleEvent := api.EventLifecycle{}
+	err := json.Unmarshal(event.Metadata, &lifecycleEvent)
+	if err != nil {
+		return
+	}
+
+	if !strings.HasPrefix(lifecycleEvent.Action, "instance-") {
+		return
+	}
+
+	if len(h.actions) > 0 && !util.ValueInSlice(lifecycleEvent.Action, h.actions) {
+		return
+	}
+
+	req := Request{
+		Action:    lifecycleEvent.Action,
+		Project:   lifecycleEvent.Project,
+		Instance:  lifecycleEvent.Name,
+		Source:    lifecycleEvent.Source,
+		Location:  event.Location,
+		Timestamp: event.Timestamp,
+		Requestor: lifecycleEvent.Requestor,
+		Context:   lifecycleEvent.Context,
+	}
+
+	err = h.Run(h.ctx, req)
+	if err != nil {
+		logger.Warn("Failed running instance lifecycle hook", logger.Ctx{"hook": h.target, "action": req.Action, "project": req.Project, "instance": req.Instance, "err": err})
+	}
+}
+
+// Run runs the hook for the given event.
+// Commands are passed the action, project and instance name as arguments and the JSON encoded request on stdin.
+// Webhooks receive the JSON encoded request as a POST request and must reply with a 2xx status code.
+func (h *Hook) Run(ctx context.Context, req Request) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	if !IsWebhook(h.target) {
+		return subprocess.RunCommandWithFds(ctx, bytes.NewReader(body), nil, h.target, req.Action, req.Project, req.Instance)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, h.target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("Webhook returned %q", resp.Status)
+	}
+
+	return nil
+}
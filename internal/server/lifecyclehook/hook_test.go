@@ -0,0 +1,72 @@
+package lifecyclehook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/shared/api"
+)
+
+func TestHook_Webhook(t *testing.T) {
+	requests := make(chan Request, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := Request{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		requests <- req
+	}))
+	defer server.Close()
+
+	hook := NewHook(context.Background(), server.URL, []string{api.EventLifecycleInstanceStarted}, time.Second)
+
+	// Only the selected instance actions trigger the hook.
+	for _, action := range []string{api.EventLifecycleInstanceStopped, api.EventLifecycleProjectCreated, api.EventLifecycleInstanceStarted} {
+		metadata, err := json.Marshal(api.EventLifecycle{Action: action, Source: "/1.0/instances/c1", Name: "c1", Project: "default"})
+		require.NoError(t, err)
+
+		hook.HandleEvent(api.Event{Type: api.EventTypeLifecycle, Metadata: metadata, Location: "server01"})
+	}
+
+	require.Len(t, requests, 1)
+	req := <-requests
+	assert.Equal(t, api.EventLifecycleInstanceStarted, req.Action)
+	assert.Equal(t, "default", req.Project)
+	assert.Equal(t, "c1", req.Instance)
+	assert.Equal(t, "server01", req.Location)
+}
+
+func TestHook_Command(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "hook.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\necho \"$1 $2 $3\" > "+dir+"/args\ncat > "+dir+"/stdin\n"), 0700))
+
+	hook := NewHook(context.Background(), script, nil, time.Second)
+	require.NoError(t, hook.Run(context.Background(), Request{Action: api.EventLifecycleInstanceDeleted, Project: "default", Instance: "c1"}))
+
+	args, err := os.ReadFile(filepath.Join(dir, "args"))
+	require.NoError(t, err)
+	assert.Equal(t, "instance-deleted default c1\n", string(args))
+
+	stdin, err := os.ReadFile(filepath.Join(dir, "stdin"))
+	require.NoError(t, err)
+	assert.Contains(t, string(stdin), `"action":"instance-deleted"`)
+}
+
+func TestHook_Timeout(t *testing.T) {
+	script := filepath.Join(t.TempDir(), "hook.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\nexec sleep 10\n"), 0700))
+
+	hook := NewHook(context.Background(), script, nil, 100*time.Millisecond)
+
+	start := time.Now()
+	assert.Error(t, hook.Run(context.Background(), Request{Action: api.EventLifecycleInstanceStarted}))
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
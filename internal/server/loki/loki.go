@@ -304,7 +304,7 @@ func (c *Client) HandleEvent(event api.Event) {
 		}
 
 		entry.Line = fmt.Sprintf("%s%s", messagePrefix, lifecycleEvent.Action)
-	} else if event.Type == api.EventTypeLogging || event.Type == api.EventTypeNetworkACL {
+	} else if event.Type == api.EventTypeLogging || event.Type == api.EventTypeNetworkACL || event.Type == api.EventTypeSyscall {
 		logEvent := api.EventLogging{}
 
 		err := json.Unmarshal(event.Metadata, &logEvent)
@@ -0,0 +1,153 @@
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CustomMetricsLimit is the maximum number of custom metrics a single instance can publish.
+const CustomMetricsLimit = 64
+
+// CustomMetricsExpiry is how long published custom metrics are kept without being refreshed.
+const CustomMetricsExpiry = 5 * time.Minute
+
+// CustomMetricPrefix is prepended to the name of all custom metrics.
+const CustomMetricPrefix = "incus_instance_custom_"
+
+var customMetricNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]{0,63}$`)
+
+// CustomMetric represents a metric value published by an instance.
+type CustomMetric struct {
+	Type  string
+	Value float64
+}
+
+type customMetricsEntry struct {
+	metrics map[string]CustomMetric
+	updated time.Time
+}
+
+// CustomMetricsStore keeps track of the custom metrics published by instances.
+type CustomMetricsStore struct {
+	mu      sync.Mutex
+	entries map[string]customMetricsEntry
+	now     func() time.Time
+}
+
+// NewCustomMetricsStore returns a new CustomMetricsStore.
+func NewCustomMetricsStore() *CustomMetricsStore {
+	return &CustomMetricsStore{
+		entries: map[string]customMetricsEntry{},
+		now:     time.Now,
+	}
+}
+
+// ValidateCustomMetrics checks that a set of custom metrics is within limits and only uses valid names and types.
+func ValidateCustomMetrics(metrics map[string]CustomMetric) error {
+	if len(metrics) > CustomMetricsLimit {
+		return fmt.Errorf("Too many metrics (%d), at most %d can be published", len(metrics), CustomMetricsLimit)
+	}
+
+	for name, metric := range metrics {
+		if !customMetricNameRegex.MatchString(name) {
+			return fmt.Errorf("Invalid metric name %q", name)
+		}
+
+		if metric.Type != "gauge" && metric.Type != "counter" {
+			return fmt.Errorf("Invalid type %q for metric %q, must be gauge or counter", metric.Type, name)
+		}
+	}
+
+	return nil
+}
+
+func customMetricsKey(projectName string, instanceName string) string {
+	return projectName + "/" + instanceName
+}
+
+// Set replaces the custom metrics published by the instance.
+func (s *CustomMetricsStore) Set(projectName string, instanceName string, metrics map[string]CustomMetric) error {
+	err := ValidateCustomMetrics(metrics)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Drop expired entries, such as those of deleted or renamed instances.
+	now := s.now()
+	for key, entry := range s.entries {
+		if now.Sub(entry.updated) >= CustomMetricsExpiry {
+			delete(s.entries, key)
+		}
+	}
+
+	key := customMetricsKey(projectName, instanceName)
+	if len(metrics) == 0 {
+		delete(s.entries, key)
+		return nil
+	}
+
+	values := make(map[string]CustomMetric, len(metrics))
+	for name, metric := range metrics {
+		values[name] = metric
+	}
+
+	s.entries[key] = customMetricsEntry{metrics: values, updated: now}
+
+	return nil
+}
+
+// AddCustomMetrics adds the custom metrics published by an instance to the MetricSet.
+func (m *MetricSet) AddCustomMetrics(metrics map[string]CustomMetric) {
+	for name, metric := range metrics {
+		name = CustomMetricPrefix + name
+		if metric.Type == "counter" && !strings.HasSuffix(name, "_total") {
+			name += "_total"
+		}
+
+		// Add global labels to samples.
+		labels := make(map[string]string, len(m.labels))
+		for labelName, labelValue := range m.labels {
+			labels[labelName] = labelValue
+		}
+
+		samples, ok := m.custom[name]
+		if !ok {
+			samples = &customSamples{metricTypeName: metric.Type}
+			m.custom[name] = samples
+		}
+
+		samples.samples = append(samples.samples, Sample{Labels: labels, Value: metric.Value})
+	}
+}
+
+// Get returns the custom metrics currently published by the instance.
+// Metrics that haven't been refreshed within CustomMetricsExpiry are discarded.
+func (s *CustomMetricsStore) Get(projectName string, instanceName string) map[string]CustomMetric {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := customMetricsKey(projectName, instanceName)
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return map[string]CustomMetric{}
+	}
+
+	if s.now().Sub(entry.updated) >= CustomMetricsExpiry {
+		delete(s.entries, key)
+		return map[string]CustomMetric{}
+	}
+
+	values := make(map[string]CustomMetric, len(entry.metrics))
+	for name, metric := range entry.metrics {
+		values[name] = metric
+	}
+
+	return values
+}
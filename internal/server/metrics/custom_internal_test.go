@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomMetricsStore(t *testing.T) {
+	now := time.Now()
+
+	s := NewCustomMetricsStore()
+	s.now = func() time.Time { return now }
+
+	err := s.Set("default", "c1", map[string]CustomMetric{"requests": {Type: "counter", Value: 10}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]CustomMetric{"requests": {Type: "counter", Value: 10}}, s.Get("default", "c1"))
+	assert.Empty(t, s.Get("other", "c1"))
+
+	// Invalid names, types and too many metrics are rejected.
+	assert.Error(t, s.Set("default", "c1", map[string]CustomMetric{"bad-name": {Type: "gauge"}}))
+	assert.Error(t, s.Set("default", "c1", map[string]CustomMetric{"queue": {Type: "histogram"}}))
+
+	tooMany := map[string]CustomMetric{}
+	for i := 0; i <= CustomMetricsLimit; i++ {
+		tooMany[fmt.Sprintf("m%d", i)] = CustomMetric{Type: "gauge"}
+	}
+
+	assert.Error(t, s.Set("default", "c1", tooMany))
+
+	// Metrics which aren't refreshed expire.
+	now = now.Add(CustomMetricsExpiry)
+	assert.Empty(t, s.Get("default", "c1"))
+}
+
+func TestMetricSet_AddCustomMetrics(t *testing.T) {
+	set := NewMetricSet(map[string]string{"name": "c1", "project": "default"})
+	set.AddCustomMetrics(map[string]CustomMetric{
+		"requests": {Type: "counter", Value: 10},
+		"queue":    {Type: "gauge", Value: 2.5},
+	})
+
+	expected := `# HELP incus_instance_custom_queue Custom metric published by the instance.
+# TYPE incus_instance_custom_queue gauge
+incus_instance_custom_queue{name="c1",project="default"} 2.5
+# HELP incus_instance_custom_requests_total Custom metric published by the instance.
+# TYPE incus_instance_custom_requests_total counter
+incus_instance_custom_requests_total{name="c1",project="default"} 10
+# EOF
+`
+
+	assert.Equal(t, expected, set.String())
+}
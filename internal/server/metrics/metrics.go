@@ -9,7 +9,7 @@ import (
 
 // NewMetricSet returns a new MetricSet.
 func NewMetricSet(labels map[string]string) *MetricSet {
-	out := MetricSet{set: make(map[MetricType][]Sample)}
+	out := MetricSet{set: make(map[MetricType][]Sample), custom: make(map[string]*customSamples)}
 
 	if labels != nil {
 		out.labels = labels
@@ -46,6 +46,21 @@ func (m *MetricSet) Merge(metricSet *MetricSet) {
 	for k := range metricSet.set {
 		m.set[k] = append(m.set[k], metricSet.set[k]...)
 	}
+
+	for name, custom := range metricSet.custom {
+		samples, ok := m.custom[name]
+		if !ok {
+			samples = &customSamples{metricTypeName: custom.metricTypeName}
+			m.custom[name] = samples
+		}
+
+		// Metrics of the same name but a different type can't be exposed together, keep the first one.
+		if samples.metricTypeName != custom.metricTypeName {
+			continue
+		}
+
+		samples.samples = append(samples.samples, custom.samples...)
+	}
 }
 
 func (m *MetricSet) String() string {
@@ -71,7 +86,7 @@ func (m *MetricSet) String() string {
 		metricTypeName := ""
 
 		// ProcsTotal is a gauge according to the OpenMetrics spec as its value can decrease.
-		if metricType == ProcsTotal || metricType == CPUs || metricType == GoGoroutines || metricType == GoHeapObjects {
+		if metricType == ProcsTotal || metricType == CPUs || metricType == GoGoroutines || metricType == GoHeapObjects || metricType == Warnings || metricType == EventsListeners || metricType == EventsPending || metricType == EventsListenerPending || metricType == OperationsTasksRunning || metricType == OperationsTasksQueued {
 			metricTypeName = "gauge"
 		} else if strings.HasSuffix(MetricNames[metricType], "_total") || strings.HasSuffix(MetricNames[metricType], "_seconds") {
 			metricTypeName = "counter"
@@ -86,34 +101,29 @@ func (m *MetricSet) String() string {
 		}
 
 		for _, sample := range m.set[metricType] {
-			firstLabel := true
-			labels := ""
-			labelNames := []string{}
-
-			// Add and sort labels if there are any
-			for labelName := range sample.Labels {
-				labelNames = append(labelNames, labelName)
+			_, err = out.WriteString(sampleString(MetricNames[metricType], sample))
+			if err != nil {
+				return ""
 			}
+		}
+	}
 
-			sort.Strings(labelNames)
-
-			for _, labelName := range labelNames {
-				if !firstLabel {
-					labels += ","
-				}
-
-				labels += fmt.Sprintf(`%s="%s"`, labelName, sample.Labels[labelName])
-				firstLabel = false
-			}
+	// Sort custom metrics by name.
+	customNames := make([]string, 0, len(m.custom))
+	for name := range m.custom {
+		customNames = append(customNames, name)
+	}
 
-			valueStr := strconv.FormatFloat(sample.Value, 'g', -1, 64)
+	sort.Strings(customNames)
 
-			if labels != "" {
-				_, err = out.WriteString(fmt.Sprintf("%s{%s} %s\n", MetricNames[metricType], labels, valueStr))
-			} else {
-				_, err = out.WriteString(fmt.Sprintf("%s %s\n", MetricNames[metricType], valueStr))
-			}
+	for _, name := range customNames {
+		_, err := out.WriteString(fmt.Sprintf("# HELP %s Custom metric published by the instance.\n# TYPE %s %s\n", name, name, m.custom[name].metricTypeName))
+		if err != nil {
+			return ""
+		}
 
+		for _, sample := range m.custom[name].samples {
+			_, err = out.WriteString(sampleString(name, sample))
 			if err != nil {
 				return ""
 			}
@@ -128,6 +138,37 @@ func (m *MetricSet) String() string {
 	return out.String()
 }
 
+// sampleString returns the OpenMetrics representation of a sample.
+func sampleString(name string, sample Sample) string {
+	firstLabel := true
+	labels := ""
+	labelNames := []string{}
+
+	// Add and sort labels if there are any
+	for labelName := range sample.Labels {
+		labelNames = append(labelNames, labelName)
+	}
+
+	sort.Strings(labelNames)
+
+	for _, labelName := range labelNames {
+		if !firstLabel {
+			labels += ","
+		}
+
+		labels += fmt.Sprintf(`%s="%s"`, labelName, sample.Labels[labelName])
+		firstLabel = false
+	}
+
+	valueStr := strconv.FormatFloat(sample.Value, 'g', -1, 64)
+
+	if labels != "" {
+		return fmt.Sprintf("%s{%s} %s\n", name, labels, valueStr)
+	}
+
+	return fmt.Sprintf("%s %s\n", name, valueStr)
+}
+
 // MetricSetFromAPI converts api.Metrics to a MetricSet, and returns it.
 func MetricSetFromAPI(metrics *Metrics, labels map[string]string) (*MetricSet, error) {
 	set := NewMetricSet(labels)
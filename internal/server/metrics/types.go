@@ -9,9 +9,16 @@ type Sample struct {
 // MetricSet represents a set of metrics.
 type MetricSet struct {
 	set    map[MetricType][]Sample
+	custom map[string]*customSamples
 	labels map[string]string
 }
 
+// customSamples holds the samples of a custom metric along with its type (gauge or counter).
+type customSamples struct {
+	metricTypeName string
+	samples        []Sample
+}
+
 // MetricType is a numeric code identifying the metric.
 type MetricType int
 
@@ -94,8 +101,36 @@ const (
 	ProcsTotal
 	// OperationsTotal represents the number of running operations.
 	OperationsTotal
+	// OperationsTasksRunning represents the number of background operations currently running.
+	OperationsTasksRunning
+	// OperationsTasksQueued represents the number of background operations queued past the concurrency limit.
+	OperationsTasksQueued
 	// WarningsTotal represents the number of active warnings.
 	WarningsTotal
+	// Warnings represents the number of unresolved warnings, grouped by type, severity, status, project and location.
+	Warnings
+	// ImagesSyncTotal represents the number of images synchronized across the cluster by the periodic task.
+	ImagesSyncTotal
+	// ImagesSyncFailuresTotal represents the number of failed image synchronizations of the periodic task.
+	ImagesSyncFailuresTotal
+	// DatabaseRetriesTotal represents the number of retry attempts of database transactions after a transient error.
+	DatabaseRetriesTotal
+	// DatabaseSlowQueriesTotal represents the number of cluster database transactions exceeding the slow threshold.
+	DatabaseSlowQueriesTotal
+	// EventsListeners represents the number of event listeners.
+	EventsListeners
+	// EventsPending represents the number of events waiting to be written to the event listeners.
+	EventsPending
+	// EventsSentTotal represents the number of events written to the event listeners.
+	EventsSentTotal
+	// EventsDroppedTotal represents the number of events which couldn't be written to the event listeners.
+	EventsDroppedTotal
+	// EventsListenerPending represents the number of events waiting to be written to each event listener.
+	EventsListenerPending
+	// EventsListenerSentTotal represents the number of events written to each event listener.
+	EventsListenerSentTotal
+	// EventsListenerDroppedTotal represents the number of events which couldn't be written to each event listener.
+	EventsListenerDroppedTotal
 	// UptimeSeconds represents the daemon uptime in seconds.
 	UptimeSeconds
 	// GoGoroutines represents the number of goroutines that currently exist..
@@ -209,9 +244,23 @@ var MetricNames = map[MetricType]string{
 	NetworkTransmitErrsTotal:    "incus_network_transmit_errs_total",
 	NetworkTransmitPacketsTotal: "incus_network_transmit_packets_total",
 	OperationsTotal:             "incus_operations_total",
+	OperationsTasksRunning:      "incus_operations_tasks_running",
+	OperationsTasksQueued:       "incus_operations_tasks_queued",
 	ProcsTotal:                  "incus_procs_total",
 	UptimeSeconds:               "incus_uptime_seconds",
 	WarningsTotal:               "incus_warnings_total",
+	Warnings:                    "incus_warnings",
+	ImagesSyncTotal:             "incus_images_sync_total",
+	ImagesSyncFailuresTotal:     "incus_images_sync_failures_total",
+	DatabaseRetriesTotal:        "incus_database_retries_total",
+	DatabaseSlowQueriesTotal:    "incus_database_slow_queries_total",
+	EventsListeners:             "incus_events_listeners",
+	EventsPending:               "incus_events_pending",
+	EventsSentTotal:             "incus_events_sent_total",
+	EventsDroppedTotal:          "incus_events_dropped_total",
+	EventsListenerPending:       "incus_events_listener_pending",
+	EventsListenerSentTotal:     "incus_events_listener_sent_total",
+	EventsListenerDroppedTotal:  "incus_events_listener_dropped_total",
 }
 
 // MetricHeaders represents the metric headers which contain help messages as specified by OpenMetrics.
@@ -277,7 +326,21 @@ var MetricHeaders = map[MetricType]string{
 	NetworkTransmitErrsTotal:    "# HELP incus_network_transmit_errs_total The amount of transmitted errors on a given interface.",
 	NetworkTransmitPacketsTotal: "# HELP incus_network_transmit_packets_total The amount of transmitted packets on a given interface.",
 	OperationsTotal:             "# HELP incus_operations_total The number of running operations",
+	OperationsTasksRunning:      "# HELP incus_operations_tasks_running The number of background operations currently running.",
+	OperationsTasksQueued:       "# HELP incus_operations_tasks_queued The number of background operations waiting for a running one to complete.",
 	ProcsTotal:                  "# HELP incus_procs_total The number of running processes.",
 	UptimeSeconds:               "# HELP incus_uptime_seconds The daemon uptime in seconds.",
 	WarningsTotal:               "# HELP incus_warnings_total The number of active warnings.",
+	Warnings:                    "# HELP incus_warnings The number of unresolved warnings.",
+	ImagesSyncTotal:             "# HELP incus_images_sync_total The number of images synchronized across the cluster.",
+	ImagesSyncFailuresTotal:     "# HELP incus_images_sync_failures_total The number of failed image synchronizations across the cluster.",
+	DatabaseRetriesTotal:        "# HELP incus_database_retries_total The number of retry attempts of database transactions after a transient error.",
+	DatabaseSlowQueriesTotal:    "# HELP incus_database_slow_queries_total The number of cluster database transactions exceeding the slow threshold.",
+	EventsListeners:             "# HELP incus_events_listeners The number of event listeners.",
+	EventsPending:               "# HELP incus_events_pending The number of events waiting to be written to the event listeners.",
+	EventsSentTotal:             "# HELP incus_events_sent_total The number of events written to the event listeners.",
+	EventsDroppedTotal:          "# HELP incus_events_dropped_total The number of events which couldn't be written to the event listeners.",
+	EventsListenerPending:       "# HELP incus_events_listener_pending The number of events waiting to be written to the event listener.",
+	EventsListenerSentTotal:     "# HELP incus_events_listener_sent_total The number of events written to the event listener.",
+	EventsListenerDroppedTotal:  "# HELP incus_events_listener_dropped_total The number of events which couldn't be written to the event listener.",
 }
@@ -261,7 +261,40 @@ func progressWrapperRender(op *operations.Operation, key string, description str
 	}
 }
 
+// operationReader is a reader which stops reading once its operation is cancelled.
+type operationReader struct {
+	io.ReadCloser
+	op *operations.Operation
+}
+
+// Read fails if the operation was cancelled.
+func (r *operationReader) Read(p []byte) (int, error) {
+	err := r.op.Context().Err()
+	if err != nil {
+		return 0, fmt.Errorf("Operation cancelled: %w", err)
+	}
+
+	return r.ReadCloser.Read(p)
+}
+
+// operationWriter is a writer which stops writing once its operation is cancelled.
+type operationWriter struct {
+	io.WriteCloser
+	op *operations.Operation
+}
+
+// Write fails if the operation was cancelled.
+func (w *operationWriter) Write(p []byte) (int, error) {
+	err := w.op.Context().Err()
+	if err != nil {
+		return 0, fmt.Errorf("Operation cancelled: %w", err)
+	}
+
+	return w.WriteCloser.Write(p)
+}
+
 // ProgressReader reports the read progress.
+// Reading stops once the operation is cancelled.
 func ProgressReader(op *operations.Operation, key string, description string) func(io.ReadCloser) io.ReadCloser {
 	return func(reader io.ReadCloser) io.ReadCloser {
 		if op == nil {
@@ -273,7 +306,7 @@ func ProgressReader(op *operations.Operation, key string, description string) fu
 		}
 
 		readPipe := &ioprogress.ProgressReader{
-			ReadCloser: reader,
+			ReadCloser: &operationReader{ReadCloser: reader, op: op},
 			Tracker: &ioprogress.ProgressTracker{
 				Handler: progress,
 			},
@@ -284,6 +317,7 @@ func ProgressReader(op *operations.Operation, key string, description string) fu
 }
 
 // ProgressWriter reports the write progress.
+// Writing stops once the operation is cancelled.
 func ProgressWriter(op *operations.Operation, key string, description string) func(io.WriteCloser) io.WriteCloser {
 	return func(writer io.WriteCloser) io.WriteCloser {
 		if op == nil {
@@ -295,7 +329,7 @@ func ProgressWriter(op *operations.Operation, key string, description string) fu
 		}
 
 		writePipe := &ioprogress.ProgressWriter{
-			WriteCloser: writer,
+			WriteCloser: &operationWriter{WriteCloser: writer, op: op},
 			Tracker: &ioprogress.ProgressTracker{
 				Handler: progress,
 			},
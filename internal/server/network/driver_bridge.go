@@ -758,19 +758,10 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 		}
 	}
 
-	// Initialise a new firewall option set.
-	fwOpts := firewallDrivers.Opts{}
-
-	if n.hasIPv4Firewall() {
-		fwOpts.FeaturesV4 = &firewallDrivers.FeatureOpts{}
-	}
-
-	if n.hasIPv6Firewall() {
-		fwOpts.FeaturesV6 = &firewallDrivers.FeatureOpts{}
-	}
-
-	if n.config["security.acls"] != "" {
-		fwOpts.ACL = true
+	// Get the firewall options for the new configuration.
+	fwOpts, err := n.firewallOpts()
+	if err != nil {
+		return err
 	}
 
 	// Snapshot container specific IPv4 routes (added with boot proto) before removing IPv4 addresses.
@@ -803,22 +794,11 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 		return err
 	}
 
-	// Configure IPv4 firewall.
-	if !util.ValueInSlice(n.config["ipv4.address"], []string{"", "none"}) {
-		if n.hasDHCPv4() && n.hasIPv4Firewall() {
-			fwOpts.FeaturesV4.ICMPDHCPDNSAccess = true
-		}
-
-		// Allow forwarding.
-		if util.IsTrueOrEmpty(n.config["ipv4.routing"]) {
-			err = localUtil.SysctlSet("net/ipv4/ip_forward", "1")
-			if err != nil {
-				return err
-			}
-
-			if n.hasIPv4Firewall() {
-				fwOpts.FeaturesV4.ForwardingAllow = true
-			}
+	// Allow IPv4 forwarding.
+	if !util.ValueInSlice(n.config["ipv4.address"], []string{"", "none"}) && util.IsTrueOrEmpty(n.config["ipv4.routing"]) {
+		err = localUtil.SysctlSet("net/ipv4/ip_forward", "1")
+		if err != nil {
+			return err
 		}
 	}
 
@@ -911,24 +891,6 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 			return err
 		}
 
-		// Configure NAT.
-		if util.IsTrue(n.config["ipv4.nat"]) {
-			//If a SNAT source address is specified, use that, otherwise default to MASQUERADE mode.
-			var srcIP net.IP
-			if n.config["ipv4.nat.address"] != "" {
-				srcIP = net.ParseIP(n.config["ipv4.nat.address"])
-			}
-
-			fwOpts.SNATV4 = &firewallDrivers.SNATOpts{
-				SNATAddress: srcIP,
-				Subnet:      subnet,
-			}
-
-			if n.config["ipv4.nat.order"] == "after" {
-				fwOpts.SNATV4.Append = true
-			}
-		}
-
 		// Add additional routes.
 		if n.config["ipv4.routes"] != "" {
 			for _, route := range strings.Split(n.config["ipv4.routes"], ",") {
@@ -1014,10 +976,6 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 		// Update the dnsmasq config.
 		dnsmasqCmd = append(dnsmasqCmd, []string{fmt.Sprintf("--listen-address=%s", ipAddress.String()), "--enable-ra"}...)
 		if n.DHCPv6Subnet() != nil {
-			if n.hasIPv6Firewall() {
-				fwOpts.FeaturesV6.ICMPDHCPDNSAccess = true
-			}
-
 			// Build DHCP configuration.
 			if !util.ValueInSlice("--dhcp-no-override", dnsmasqCmd) {
 				dnsmasqCmd = append(dnsmasqCmd, []string{"--dhcp-no-override", "--dhcp-authoritative", fmt.Sprintf("--dhcp-leasefile=%s", internalUtil.VarPath("networks", n.name, "dnsmasq.leases")), fmt.Sprintf("--dhcp-hostsfile=%s", internalUtil.VarPath("networks", n.name, "dnsmasq.hosts"))}...)
@@ -1072,10 +1030,6 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 					return err
 				}
 			}
-
-			if n.hasIPv6Firewall() {
-				fwOpts.FeaturesV6.ForwardingAllow = true
-			}
 		}
 
 		// Add the address.
@@ -1090,24 +1044,6 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 			return err
 		}
 
-		// Configure NAT.
-		if util.IsTrue(n.config["ipv6.nat"]) {
-			//If a SNAT source address is specified, use that, otherwise default to MASQUERADE mode.
-			var srcIP net.IP
-			if n.config["ipv6.nat.address"] != "" {
-				srcIP = net.ParseIP(n.config["ipv6.nat.address"])
-			}
-
-			fwOpts.SNATV6 = &firewallDrivers.SNATOpts{
-				SNATAddress: srcIP,
-				Subnet:      subnet,
-			}
-
-			if n.config["ipv6.nat.order"] == "after" {
-				fwOpts.SNATV6.Append = true
-			}
-		}
-
 		// Add additional routes.
 		if n.config["ipv6.routes"] != "" {
 			for _, route := range strings.Split(n.config["ipv6.routes"], ",") {
@@ -1379,8 +1315,96 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 	}
 
 	// Setup firewall.
+	err = n.setupFirewall(fwOpts)
+	if err != nil {
+		return err
+	}
+
+	// Setup BGP.
+	err = n.bgpSetup(oldConfig)
+	if err != nil {
+		return err
+	}
+
+	revert.Success()
+	return nil
+}
+
+// firewallOpts returns the firewall options matching the network configuration.
+func (n *bridge) firewallOpts() (firewallDrivers.Opts, error) {
+	fwOpts := firewallDrivers.Opts{}
+
+	if n.hasIPv4Firewall() {
+		fwOpts.FeaturesV4 = &firewallDrivers.FeatureOpts{}
+	}
+
+	if n.hasIPv6Firewall() {
+		fwOpts.FeaturesV6 = &firewallDrivers.FeatureOpts{}
+	}
+
+	if n.config["security.acls"] != "" {
+		fwOpts.ACL = true
+	}
+
+	if !util.ValueInSlice(n.config["ipv4.address"], []string{"", "none"}) {
+		_, subnet, err := net.ParseCIDR(n.config["ipv4.address"])
+		if err != nil {
+			return fwOpts, fmt.Errorf("Failed parsing ipv4.address: %w", err)
+		}
+
+		if n.hasIPv4Firewall() {
+			fwOpts.FeaturesV4.ICMPDHCPDNSAccess = n.hasDHCPv4()
+			fwOpts.FeaturesV4.ForwardingAllow = util.IsTrueOrEmpty(n.config["ipv4.routing"])
+		}
+
+		if util.IsTrue(n.config["ipv4.nat"]) {
+			// If a SNAT source address is specified, use that, otherwise default to MASQUERADE mode.
+			var srcIP net.IP
+			if n.config["ipv4.nat.address"] != "" {
+				srcIP = net.ParseIP(n.config["ipv4.nat.address"])
+			}
+
+			fwOpts.SNATV4 = &firewallDrivers.SNATOpts{
+				SNATAddress: srcIP,
+				Subnet:      subnet,
+				Append:      n.config["ipv4.nat.order"] == "after",
+			}
+		}
+	}
+
+	if !util.ValueInSlice(n.config["ipv6.address"], []string{"", "none"}) {
+		_, subnet, err := net.ParseCIDR(n.config["ipv6.address"])
+		if err != nil {
+			return fwOpts, fmt.Errorf("Failed parsing ipv6.address: %w", err)
+		}
+
+		if n.hasIPv6Firewall() {
+			fwOpts.FeaturesV6.ICMPDHCPDNSAccess = n.DHCPv6Subnet() != nil
+			fwOpts.FeaturesV6.ForwardingAllow = util.IsTrueOrEmpty(n.config["ipv6.routing"])
+		}
+
+		if util.IsTrue(n.config["ipv6.nat"]) {
+			// If a SNAT source address is specified, use that, otherwise default to MASQUERADE mode.
+			var srcIP net.IP
+			if n.config["ipv6.nat.address"] != "" {
+				srcIP = net.ParseIP(n.config["ipv6.nat.address"])
+			}
+
+			fwOpts.SNATV6 = &firewallDrivers.SNATOpts{
+				SNATAddress: srcIP,
+				Subnet:      subnet,
+				Append:      n.config["ipv6.nat.order"] == "after",
+			}
+		}
+	}
+
+	return fwOpts, nil
+}
+
+// setupFirewall applies the network firewall rules, ACLs and address forwards.
+func (n *bridge) setupFirewall(fwOpts firewallDrivers.Opts) error {
 	n.logger.Debug("Setting up firewall")
-	err = n.state.Firewall.NetworkSetup(n.name, fwOpts)
+	err := n.state.Firewall.NetworkSetup(n.name, fwOpts)
 	if err != nil {
 		return fmt.Errorf("Failed to setup firewall: %w", err)
 	}
@@ -1406,14 +1430,40 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 		return err
 	}
 
-	// Setup BGP.
-	err = n.bgpSetup(oldConfig)
+	return nil
+}
+
+// FirewallReapply clears and re-applies the firewall rules of the running network, without restarting it.
+func (n *bridge) FirewallReapply() error {
+	// If we are in mock mode, just no-op.
+	if n.state.OS.MockMode {
+		return nil
+	}
+
+	fwOpts, err := n.firewallOpts()
 	if err != nil {
 		return err
 	}
 
-	revert.Success()
-	return nil
+	fwClearIPVersions := []uint{}
+
+	if usesIPv4Firewall(n.config) {
+		fwClearIPVersions = append(fwClearIPVersions, 4)
+	}
+
+	if usesIPv6Firewall(n.config) {
+		fwClearIPVersions = append(fwClearIPVersions, 6)
+	}
+
+	if len(fwClearIPVersions) > 0 {
+		n.logger.Debug("Clearing firewall")
+		err = n.state.Firewall.NetworkClear(n.name, false, fwClearIPVersions)
+		if err != nil {
+			return fmt.Errorf("Failed clearing firewall: %w", err)
+		}
+	}
+
+	return n.setupFirewall(fwOpts)
 }
 
 // Stop stops the network.
@@ -2443,9 +2493,25 @@ func (n *bridge) Leases(projectName string, clientType request.ClientType) ([]ap
 		return nil, err
 	}
 
+	now := time.Now()
+
 	for _, lease := range strings.Split(string(content), "\n") {
 		fields := strings.Fields(lease)
 		if len(fields) >= 5 {
+			// Parse the expiry time (a value of 0 means the lease never expires).
+			var expiresAt *time.Time
+			expiry, err := strconv.ParseInt(fields[0], 10, 64)
+			if err == nil && expiry > 0 {
+				expiryTime := time.Unix(expiry, 0).UTC()
+
+				// Skip stale leases which dnsmasq hasn't cleaned up yet.
+				if expiryTime.Before(now) {
+					continue
+				}
+
+				expiresAt = &expiryTime
+			}
+
 			// Parse the MAC.
 			mac := GetMACSlice(fields[1])
 			macStr := strings.Join(mac, ":")
@@ -2482,11 +2548,12 @@ func (n *bridge) Leases(projectName string, clientType request.ClientType) ([]ap
 
 			// Add the lease to the list.
 			leases = append(leases, api.NetworkLease{
-				Hostname: fields[3],
-				Address:  fields[2],
-				Hwaddr:   macStr,
-				Type:     "dynamic",
-				Location: n.state.ServerName,
+				Hostname:  fields[3],
+				Address:   fields[2],
+				Hwaddr:    macStr,
+				Type:      "dynamic",
+				Location:  n.state.ServerName,
+				ExpiresAt: expiresAt,
 			})
 		}
 	}
@@ -982,6 +982,11 @@ func (n *common) forwardValidate(listenAddress net.IP, forward *api.NetworkForwa
 	return portMaps, err
 }
 
+// FirewallReapply returns ErrNotImplemented for drivers that do not manage firewall rules.
+func (n *common) FirewallReapply() error {
+	return ErrNotImplemented
+}
+
 // ForwardCreate returns ErrNotImplemented for drivers that do not support forwards.
 func (n *common) ForwardCreate(forward api.NetworkForwardsPost, clientType request.ClientType) error {
 	return ErrNotImplemented
@@ -51,6 +51,7 @@ type Network interface {
 	Rename(name string) error
 	Update(newNetwork api.NetworkPut, targetNode string, clientType request.ClientType) error
 	HandleHeartbeat(heartbeatData *cluster.APIHeartbeat) error
+	FirewallReapply() error
 	Delete(clientType request.ClientType) error
 	handleDependencyChange(netName string, netConfig map[string]string, changedKeys []string) error
 
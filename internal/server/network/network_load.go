@@ -56,6 +56,25 @@ func LoadByName(s *state.State, projectName string, name string) (Network, error
 	return n, nil
 }
 
+// LoadByNameInProject loads an instantiated network from the database by project and name, as if it was part of
+// the target project. This is used to validate a network before moving it to another project.
+func LoadByNameInProject(s *state.State, projectName string, name string, targetProjectName string) (Network, error) {
+	id, netInfo, netNodes, err := s.DB.Cluster.GetNetworkInAnyState(projectName, name)
+	if err != nil {
+		return nil, err
+	}
+
+	driverFunc, ok := drivers[netInfo.Type]
+	if !ok {
+		return nil, ErrUnknownDriver
+	}
+
+	n := driverFunc()
+	n.init(s, id, targetProjectName, netInfo, netNodes)
+
+	return n, nil
+}
+
 // PatchPreCheck checks if there are any unavailable networks.
 func PatchPreCheck() error {
 	unavailableNetworksMu.Lock()
@@ -0,0 +1,133 @@
+package network
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lxc/incus/internal/server/project"
+	"github.com/lxc/incus/internal/server/state"
+)
+
+// configMTU returns the MTU set by the given config key, or 0 if not set or invalid.
+func configMTU(config map[string]string, key string) uint32 {
+	if config[key] == "" {
+		return 0
+	}
+
+	mtu, err := strconv.ParseUint(config[key], 10, 32)
+	if err != nil {
+		return 0
+	}
+
+	return uint32(mtu)
+}
+
+// networkMTU returns the MTU in effect for a local network.
+// A configured MTU takes precedence over the one of the host interface. Returns 0 if it can't be determined.
+func networkMTU(n Network) uint32 {
+	config := n.Config()
+
+	switch n.Type() {
+	case "bridge":
+		mtu := configMTU(config, "bridge.mtu")
+		if mtu == 0 {
+			mtu, _ = GetDevMTU(n.Name())
+		}
+
+		return mtu
+	case "ovn":
+		return configMTU(config, "bridge.mtu")
+	case "physical":
+		hostName := GetHostDevice(config["parent"], config["vlan"])
+
+		mtu := configMTU(config, "mtu")
+		if mtu == 0 {
+			mtu, _ = GetDevMTU(hostName)
+		}
+
+		return mtu
+	}
+
+	return 0
+}
+
+// MTUCheck checks that the MTU of a managed network fits within the MTU of its parent interfaces or uplink network.
+// Bridge networks are checked against their external interfaces, physical networks against their parent interface
+// and OVN networks against their uplink network. Returns an error describing the mismatch if one is found.
+func MTUCheck(s *state.State, n Network) error {
+	mtu := networkMTU(n)
+	if mtu == 0 {
+		return nil
+	}
+
+	config := n.Config()
+
+	checkParent := func(parentName string, parentMTU uint32) error {
+		if parentMTU != 0 && mtu > parentMTU {
+			return fmt.Errorf("Network MTU %d is larger than the MTU %d of %s", mtu, parentMTU, parentName)
+		}
+
+		return nil
+	}
+
+	switch n.Type() {
+	case "bridge":
+		// Fan bridges use an underlay rather than external interfaces.
+		if config["bridge.mode"] == "fan" || config["bridge.external_interfaces"] == "" {
+			return nil
+		}
+
+		for _, entry := range strings.Split(config["bridge.external_interfaces"], ",") {
+			entry = strings.TrimSpace(entry)
+			if !InterfaceExists(entry) {
+				continue
+			}
+
+			parentMTU, err := GetDevMTU(entry)
+			if err != nil {
+				return fmt.Errorf("Failed getting MTU of external interface %q: %w", entry, err)
+			}
+
+			err = checkParent(fmt.Sprintf("external interface %q", entry), parentMTU)
+			if err != nil {
+				return err
+			}
+		}
+	case "physical":
+		if !InterfaceExists(config["parent"]) {
+			return nil
+		}
+
+		parentMTU, err := GetDevMTU(config["parent"])
+		if err != nil {
+			return fmt.Errorf("Failed getting MTU of parent interface %q: %w", config["parent"], err)
+		}
+
+		// Without a VLAN the network uses the parent interface directly so its MTU should be the configured one.
+		if config["vlan"] == "" {
+			if parentMTU != mtu {
+				return fmt.Errorf("Network MTU %d doesn't match the MTU %d of parent interface %q", mtu, parentMTU, config["parent"])
+			}
+
+			return nil
+		}
+
+		return checkParent(fmt.Sprintf("parent interface %q", config["parent"]), parentMTU)
+	case "ovn":
+		if config["network"] == "" {
+			return nil
+		}
+
+		uplinkNet, err := LoadByName(s, project.Default, config["network"])
+		if err != nil {
+			return fmt.Errorf("Failed loading uplink network %q: %w", config["network"], err)
+		}
+
+		uplinkMTU := networkMTU(uplinkNet)
+
+		return checkParent(fmt.Sprintf("uplink network %q", uplinkNet.Name()), uplinkMTU)
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/lxc/incus/internal/server/project"
+	"github.com/lxc/incus/internal/server/state"
+	"github.com/lxc/incus/shared/util"
+)
+
+// SelfTestProbeTimeout is the maximum time given to each self-test probe.
+const SelfTestProbeTimeout = 3 * time.Second
+
+// SelfTestResult represents the result of a single self-test probe.
+type SelfTestResult struct {
+	// Kind of probe (interface, gateway or dns).
+	Probe string `json:"probe" yaml:"probe"`
+
+	// What the probe was run against.
+	Target string `json:"target" yaml:"target"`
+
+	// Whether the probe succeeded.
+	Success bool `json:"success" yaml:"success"`
+
+	// Why the probe failed.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// selfTestRun runs a single probe bounded by SelfTestProbeTimeout and records its result.
+func selfTestRun(ctx context.Context, results []SelfTestResult, probe string, target string, f func(ctx context.Context) error) []SelfTestResult {
+	ctx, cancel := context.WithTimeout(ctx, SelfTestProbeTimeout)
+	defer cancel()
+
+	result := SelfTestResult{Probe: probe, Target: target, Success: true}
+
+	err := f(ctx)
+	if err != nil {
+		result.Success = false
+		result.Error = err.Error()
+	}
+
+	return append(results, result)
+}
+
+// selfTestInterface checks that a host interface exists and is up.
+func selfTestInterface(name string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		iface, err := net.InterfaceByName(name)
+		if err != nil {
+			return err
+		}
+
+		if iface.Flags&net.FlagUp == 0 {
+			return fmt.Errorf("Interface %q is down", name)
+		}
+
+		return nil
+	}
+}
+
+// selfTestGateway checks that a gateway address answers to ping.
+func selfTestGateway(address string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		ip, _, err := net.ParseCIDR(address)
+		if err != nil {
+			ip = net.ParseIP(address)
+			if ip == nil {
+				return fmt.Errorf("Invalid address %q", address)
+			}
+		}
+
+		return pingIP(ctx, ip)
+	}
+}
+
+// selfTestDNS checks that a DNS server answers queries for the given name.
+// The root name is queried for its name servers. A negative answer still means the server is reachable so
+// isn't considered a failure.
+func selfTestDNS(server string, name string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		ip, _, err := net.ParseCIDR(server)
+		if err == nil {
+			server = ip.String()
+		}
+
+		resolver := &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network string, address string) (net.Conn, error) {
+				d := net.Dialer{}
+				return d.DialContext(ctx, network, net.JoinHostPort(server, "53"))
+			},
+		}
+
+		if name == "." {
+			_, err = resolver.LookupNS(ctx, name)
+		} else {
+			_, err = resolver.LookupHost(ctx, name)
+		}
+
+		if err != nil {
+			var dnsErr *net.DNSError
+			if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+				return nil
+			}
+
+			return err
+		}
+
+		return nil
+	}
+}
+
+// SelfTest runs connectivity probes against a network from the point of view of the host.
+// It checks that the host interface of the network is up, that its gateways answer and that its DNS servers
+// respond. Each probe is bounded by SelfTestProbeTimeout.
+func SelfTest(ctx context.Context, s *state.State, n Network) ([]SelfTestResult, error) {
+	results := []SelfTestResult{}
+	config := n.Config()
+
+	switch n.Type() {
+	case "bridge":
+		results = selfTestRun(ctx, results, "interface", n.Name(), selfTestInterface(n.Name()))
+
+		dnsDomain := config["dns.domain"]
+		if dnsDomain == "" {
+			dnsDomain = "incus"
+		}
+
+		for _, key := range []string{"ipv4.address", "ipv6.address"} {
+			if config[key] == "" || config[key] == "none" {
+				continue
+			}
+
+			results = selfTestRun(ctx, results, "gateway", config[key], selfTestGateway(config[key]))
+
+			if config["dns.mode"] != "none" {
+				results = selfTestRun(ctx, results, "dns", config[key], selfTestDNS(config[key], fmt.Sprintf("_gateway.%s", dnsDomain)))
+			}
+		}
+	case "physical":
+		hostName := GetHostDevice(config["parent"], config["vlan"])
+		results = selfTestRun(ctx, results, "interface", hostName, selfTestInterface(hostName))
+		results = selfTestUplink(ctx, results, config)
+	case "macvlan", "sriov":
+		hostName := GetHostDevice(config["parent"], config["vlan"])
+		results = selfTestRun(ctx, results, "interface", hostName, selfTestInterface(hostName))
+	case "ovn":
+		// The router of the OVN network is the host's view of the network on its uplink.
+		for _, key := range []string{ovnVolatileUplinkIPv4, ovnVolatileUplinkIPv6} {
+			if config[key] != "" {
+				results = selfTestRun(ctx, results, "gateway", config[key], selfTestGateway(config[key]))
+			}
+		}
+
+		if config["network"] != "" {
+			uplinkNet, err := LoadByName(s, project.Default, config["network"])
+			if err != nil {
+				return nil, fmt.Errorf("Failed loading uplink network %q: %w", config["network"], err)
+			}
+
+			results = selfTestUplink(ctx, results, uplinkNet.Config())
+		}
+	}
+
+	return results, nil
+}
+
+// selfTestUplink probes the gateways and DNS servers of a physical network.
+func selfTestUplink(ctx context.Context, results []SelfTestResult, config map[string]string) []SelfTestResult {
+	for _, key := range []string{"ipv4.gateway", "ipv6.gateway"} {
+		if config[key] != "" {
+			results = selfTestRun(ctx, results, "gateway", config[key], selfTestGateway(config[key]))
+		}
+	}
+
+	for _, nameserver := range util.SplitNTrimSpace(config["dns.nameservers"], ",", -1, true) {
+		results = selfTestRun(ctx, results, "dns", nameserver, selfTestDNS(nameserver, "."))
+	}
+
+	return results
+}
@@ -1185,6 +1185,70 @@ func SubnetParseAppend(subnets []*net.IPNet, parseSubnet ...string) ([]*net.IPNe
 	return subnets, nil
 }
 
+// SubnetsOverlap returns true if the two subnets have IP addresses in common.
+func SubnetsOverlap(subnet1 *net.IPNet, subnet2 *net.IPNet) bool {
+	return subnet1.Contains(subnet2.IP) || subnet2.Contains(subnet1.IP)
+}
+
+// SubnetAllocate returns the first subnet with the given prefix length within the pools which doesn't overlap
+// any of the used subnets. Pools smaller than the prefix length are allocated as a whole.
+func SubnetAllocate(pools []*net.IPNet, used []*net.IPNet, prefix int) (*net.IPNet, error) {
+	for _, pool := range pools {
+		poolOnes, bits := pool.Mask.Size()
+		ones := prefix
+		if poolOnes > ones {
+			ones = poolOnes
+		}
+
+		// Skip the pools of the other IP family.
+		if ones > bits {
+			continue
+		}
+
+		mask := net.CIDRMask(ones, bits)
+		size := big.NewInt(0).Lsh(big.NewInt(1), uint(bits-ones))
+
+		start := big.NewInt(0).SetBytes(pool.IP.Mask(pool.Mask))
+		for start.BitLen() <= bits {
+			ip := make(net.IP, bits/8)
+			start.FillBytes(ip)
+
+			candidate := &net.IPNet{IP: ip, Mask: mask}
+			if !pool.Contains(candidate.IP) {
+				break
+			}
+
+			var overlap *net.IPNet
+			for _, subnet := range used {
+				if SubnetsOverlap(candidate, subnet) {
+					overlap = subnet
+					break
+				}
+			}
+
+			if overlap == nil {
+				return candidate, nil
+			}
+
+			// Skip past the overlapping subnet, keeping the candidates aligned on their size.
+			overlapOnes, _ := overlap.Mask.Size()
+			next := big.NewInt(0).SetBytes(overlap.IP.Mask(overlap.Mask))
+			next.Add(next, big.NewInt(0).Lsh(big.NewInt(1), uint(bits-overlapOnes)))
+			next.Add(next, big.NewInt(0).Sub(size, big.NewInt(1)))
+			next.Div(next, size)
+			next.Mul(next, size)
+
+			if next.Cmp(start) <= 0 {
+				next.Add(start, size)
+			}
+
+			start = next
+		}
+	}
+
+	return nil, fmt.Errorf("No unused subnet left")
+}
+
 // IPRangesOverlap checks whether two ip ranges have ip addresses in common.
 func IPRangesOverlap(r1, r2 *iprange.Range) bool {
 	if r1.End == nil {
@@ -137,3 +137,28 @@ func Example_ipRangesOverlap() {
 	// Range1: 10.1.1.4, Range2: 10.1.1.8-10.1.1.9, overlapped: false
 	// Range1: 10.1.1.8-10.1.1.9, Range2: 10.1.1.4, overlapped: false
 }
+
+func ExampleSubnetAllocate() {
+	pools, _ := SubnetParseAppend(nil, "10.10.0.0/22", "fd42:1::/48")
+	used, _ := SubnetParseAppend(nil, "10.10.0.0/24", "10.10.1.128/25", "fd42:1::/64")
+
+	for _, prefix := range []int{24, 23, 64} {
+		subnet, err := SubnetAllocate(pools, used, prefix)
+		fmt.Println(subnet, err)
+	}
+
+	// Pools smaller than the prefix length are allocated as a whole.
+	pools, _ = SubnetParseAppend(nil, "10.20.0.0/26")
+	subnet, err := SubnetAllocate(pools, nil, 24)
+	fmt.Println(subnet, err)
+
+	// Until they're used.
+	subnet, err = SubnetAllocate(pools, []*net.IPNet{subnet}, 24)
+	fmt.Println(subnet, err)
+
+	// Output: 10.10.2.0/24 <nil>
+	// 10.10.2.0/23 <nil>
+	// fd42:1:0:1::/64 <nil>
+	// 10.20.0.0/26 <nil>
+	// <nil> No unused subnet left
+}
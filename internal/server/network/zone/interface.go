@@ -20,6 +20,7 @@ type NetworkZone interface {
 	Etag() []any
 	UsedBy() ([]string, error)
 	Content() (*strings.Builder, error)
+	ProjectRecords(projectName string) ([]api.NetworkZoneRecord, error)
 	SOA() (*strings.Builder, error)
 
 	// Records.
@@ -2,12 +2,18 @@ package zone
 
 import (
 	"net"
+	"strings"
 )
 
 // Zone suffixes.
 var ip4Arpa = ".in-addr.arpa"
 var ip6Arpa = ".ip6.arpa"
 
+// IsReverse returns whether the zone name is a reverse (ARPA) zone.
+func IsReverse(name string) bool {
+	return strings.HasSuffix(name, ip4Arpa) || strings.HasSuffix(name, ip6Arpa)
+}
+
 // reverse takes an IPv4 or IPv6 address and returns the matching ARPA record.
 func reverse(ip net.IP) (arpa string) {
 	if ip == nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/lxc/incus/internal/server/cluster/request"
 	"github.com/lxc/incus/internal/server/db"
 	"github.com/lxc/incus/internal/server/network"
+	"github.com/lxc/incus/internal/server/project"
 	"github.com/lxc/incus/internal/server/response"
 	"github.com/lxc/incus/internal/server/state"
 	localUtil "github.com/lxc/incus/internal/server/util"
@@ -154,6 +156,7 @@ func (d *zone) validateConfig(info *api.NetworkZonePut) error {
 
 	// Regular config keys.
 	rules["dns.nameservers"] = validate.IsListOf(validate.IsAny)
+	rules["dns.default_project_suffix"] = validate.Optional(validate.IsOneOf("none", "network", "project"))
 	rules["network.nat"] = validate.Optional(validate.IsBool)
 
 	// Validate peer config.
@@ -298,8 +301,67 @@ func (d *zone) Delete() error {
 	return nil
 }
 
-// Content returns the DNS zone content.
-func (d *zone) Content() (*strings.Builder, error) {
+// leaseHostname returns the record name to use for a network lease in a forward zone of the project.
+// The instances of the default project are suffixed as configured in the zone's dns.default_project_suffix.
+func leaseHostname(lease api.NetworkLease, projectName string, networkName string, zoneConfig map[string]string) string {
+	if projectName != project.Default || (lease.Type != "static" && lease.Type != "dynamic") {
+		return lease.Hostname
+	}
+
+	suffix := ""
+	switch zoneConfig["dns.default_project_suffix"] {
+	case "network":
+		suffix = networkName
+	case "project":
+		suffix = project.Default
+	}
+
+	return project.DNSWithDefaultSuffix(project.Default, lease.Hostname, suffix)
+}
+
+// isInstanceLease returns whether the lease can be tied to an instance.
+// Leases without a MAC address (DHCPv6) can't be filtered by project so they are never considered.
+func isInstanceLease(lease api.NetworkLease) bool {
+	return (lease.Type == "static" || lease.Type == "dynamic") && lease.Hwaddr != ""
+}
+
+// records returns the DNS records served by the zone (other than the SOA and NS records).
+func (d *zone) records() ([]map[string]string, error) {
+	records, err := d.leaseRecords("")
+	if err != nil {
+		return nil, err
+	}
+
+	// Add the extra records.
+	extraRecords, err := d.GetRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, extraRecord := range extraRecords {
+		for _, entry := range extraRecord.Entries {
+			record := map[string]string{}
+			if entry.TTL > 0 {
+				record["ttl"] = fmt.Sprintf("%d", entry.TTL)
+			} else {
+				record["ttl"] = "300"
+			}
+
+			record["type"] = entry.Type
+			record["name"] = extraRecord.Name
+			record["value"] = entry.Value
+
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+// leaseRecords returns the DNS records generated from the leases of the networks using the zone.
+// If instanceProjectName isn't empty, only the records of that project's instances are generated, named as the
+// zone would name them if it served that project.
+func (d *zone) leaseRecords(instanceProjectName string) ([]map[string]string, error) {
 	var err error
 	records := []map[string]string{}
 
@@ -404,18 +466,39 @@ func (d *zone) Content() (*strings.Builder, error) {
 						return nil, fmt.Errorf("Associated project not found for zone %q", forwardZoneName)
 					}
 
+					leasesProjectName := forwardZoneProjectName
+					if instanceProjectName != "" {
+						// Only use the forward zones from the same project as the reverse zone.
+						if forwardZoneProjectName != d.projectName {
+							continue
+						}
+
+						leasesProjectName = instanceProjectName
+					}
+
+					// Load the forward zone's configuration.
+					_, _, forwardZoneInfo, err := d.state.DB.Cluster.GetNetworkZone(forwardZoneName)
+					if err != nil {
+						return nil, fmt.Errorf("Failed loading network zone %q: %w", forwardZoneName, err)
+					}
+
 					// Load the leases for the forward zone project.
-					leases, err := n.Leases(forwardZoneProjectName, request.ClientTypeNormal)
+					leases, err := n.Leases(leasesProjectName, request.ClientTypeNormal)
 					if err != nil {
 						return nil, err
 					}
 
 					// Convert leases to usable PTR records.
 					for _, lease := range leases {
+						if instanceProjectName != "" && !isInstanceLease(lease) {
+							continue
+						}
+
 						ip := net.ParseIP(lease.Address)
+						hostname := leaseHostname(lease, leasesProjectName, n.Name(), forwardZoneInfo.Config)
 
 						// Get the record.
-						record := genRecord(fmt.Sprintf("%s.%s", lease.Hostname, forwardZoneName), ip)
+						record := genRecord(fmt.Sprintf("%s.%s", hostname, forwardZoneName), ip)
 						if record == nil {
 							continue
 						}
@@ -424,18 +507,27 @@ func (d *zone) Content() (*strings.Builder, error) {
 					}
 				}
 			} else {
+				leasesProjectName := d.projectName
+				if instanceProjectName != "" {
+					leasesProjectName = instanceProjectName
+				}
+
 				// Load the leases in the forward zone's project.
-				leases, err := n.Leases(d.projectName, request.ClientTypeNormal)
+				leases, err := n.Leases(leasesProjectName, request.ClientTypeNormal)
 				if err != nil {
 					return nil, err
 				}
 
 				// Convert leases to usable records.
 				for _, lease := range leases {
+					if instanceProjectName != "" && !isInstanceLease(lease) {
+						continue
+					}
+
 					ip := net.ParseIP(lease.Address)
 
 					// Get the record.
-					record := genRecord(lease.Hostname, ip)
+					record := genRecord(leaseHostname(lease, leasesProjectName, n.Name(), d.info.Config), ip)
 					if record == nil {
 						continue
 					}
@@ -446,27 +538,50 @@ func (d *zone) Content() (*strings.Builder, error) {
 		}
 	}
 
-	// Add the extra records.
-	extraRecords, err := d.GetRecords()
+	return records, nil
+}
+
+// ProjectRecords returns the DNS records the zone generates for the instances of the given project, grouped by name.
+// The records are returned whether or not the zone currently serves that project.
+func (d *zone) ProjectRecords(projectName string) ([]api.NetworkZoneRecord, error) {
+	records, err := d.leaseRecords(projectName)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, extraRecord := range extraRecords {
-		for _, entry := range extraRecord.Entries {
-			record := map[string]string{}
-			if entry.TTL > 0 {
-				record["ttl"] = fmt.Sprintf("%d", entry.TTL)
-			} else {
-				record["ttl"] = "300"
-			}
+	return groupRecords(records), nil
+}
 
-			record["type"] = entry.Type
-			record["name"] = extraRecord.Name
-			record["value"] = entry.Value
+// groupRecords converts the generated records into API records grouped by name.
+func groupRecords(records []map[string]string) []api.NetworkZoneRecord {
+	result := []api.NetworkZoneRecord{}
+	index := map[string]int{}
+	for _, record := range records {
+		ttl, _ := strconv.ParseUint(record["ttl"], 10, 64)
+		entry := api.NetworkZoneRecordEntry{
+			Type:  record["type"],
+			TTL:   ttl,
+			Value: record["value"],
+		}
 
-			records = append(records, record)
+		i, ok := index[record["name"]]
+		if !ok {
+			i = len(result)
+			index[record["name"]] = i
+			result = append(result, api.NetworkZoneRecord{Name: record["name"]})
 		}
+
+		result[i].Entries = append(result[i].Entries, entry)
+	}
+
+	return result
+}
+
+// Content returns the DNS zone content.
+func (d *zone) Content() (*strings.Builder, error) {
+	records, err := d.records()
+	if err != nil {
+		return nil, err
 	}
 
 	// Get the nameservers.
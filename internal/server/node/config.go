@@ -2,12 +2,19 @@ package node
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/lxc/incus/internal/ports"
 	"github.com/lxc/incus/internal/server/config"
 	"github.com/lxc/incus/internal/server/db"
+	"github.com/lxc/incus/internal/server/pkcs11"
 	internalUtil "github.com/lxc/incus/internal/util"
+	localtls "github.com/lxc/incus/shared/tls"
 	"github.com/lxc/incus/shared/validate"
 )
 
@@ -35,6 +42,22 @@ func ConfigLoad(ctx context.Context, tx *db.NodeTx) (*Config, error) {
 	return &Config{tx: tx, m: m}, nil
 }
 
+// FirewallDriver returns the firewall driver to use (empty for automatic detection).
+func (c *Config) FirewallDriver() string {
+	return c.m.GetString("core.firewall_driver")
+}
+
+// IdmappedMounts returns whether containers may fall back to shifting their filesystem on disk
+// when idmapped mounts can't be used ("fallback") or must fail to start ("required").
+func (c *Config) IdmappedMounts() string {
+	return c.m.GetString("core.idmapped_mounts")
+}
+
+// AuditLogPath returns the path of the audit log file (empty if the audit log is disabled).
+func (c *Config) AuditLogPath() string {
+	return c.m.GetString("audit.log.path")
+}
+
 // HTTPSAddress returns the address and port this server should expose its // API to, if any.
 func (c *Config) HTTPSAddress() string {
 	networkAddress := c.m.GetString("core.https_address")
@@ -65,6 +88,11 @@ func (c *Config) ClusterAddress() string {
 	return clusterAddress
 }
 
+// RaftSnapshotParams returns the raft snapshot threshold and trailing values.
+func (c *Config) RaftSnapshotParams() (uint64, uint64) {
+	return uint64(c.m.GetInt64("cluster.raft_snapshot_threshold")), uint64(c.m.GetInt64("cluster.raft_snapshot_trailing"))
+}
+
 // DebugAddress returns the address and port to setup the pprof listener on.
 func (c *Config) DebugAddress() string {
 	debugAddress := c.m.GetString("core.debug_address")
@@ -75,6 +103,24 @@ func (c *Config) DebugAddress() string {
 	return debugAddress
 }
 
+// DevMonitorPaths returns the additional paths the device monitor should watch.
+func (c *Config) DevMonitorPaths() []string {
+	paths := []string{}
+	for _, path := range strings.Split(c.m.GetString("core.devmonitor_paths"), ",") {
+		path = strings.TrimSpace(path)
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
+
+// DevMonitorQuietPeriod returns how long to wait for device events to settle before re-balancing.
+func (c *Config) DevMonitorQuietPeriod() time.Duration {
+	return time.Duration(c.m.GetInt64("core.devmonitor_quiet_period")) * time.Millisecond
+}
+
 // DNSAddress returns the address and port to setup the DNS listener on.
 func (c *Config) DNSAddress() string {
 	return c.m.GetString("core.dns_address")
@@ -90,6 +136,11 @@ func (c *Config) MetricsAddress() string {
 	return metricsAddress
 }
 
+// MetricsCertificate returns the dedicated certificate and key of the metrics endpoint, if any.
+func (c *Config) MetricsCertificate() (string, string) {
+	return c.m.GetString("core.metrics_certificate"), c.m.GetString("core.metrics_key")
+}
+
 // StorageBucketsAddress returns the address and port to setup the storage buckets listener on.
 func (c *Config) StorageBucketsAddress() string {
 	objectAddress := c.m.GetString("core.storage_buckets_address")
@@ -110,6 +161,19 @@ func (c *Config) StorageImagesVolume() string {
 	return c.m.GetString("storage.images_volume")
 }
 
+// PKCS11Keys returns the PKCS#11 URIs of the network and server private keys.
+func (c *Config) PKCS11Keys() (string, string) {
+	return c.m.GetString("core.pkcs11_network_key"), c.m.GetString("core.pkcs11_server_key")
+}
+
+// CertificateKey returns the type and size of the key of generated certificates.
+func (c *Config) CertificateKey() localtls.KeyParams {
+	// The size is validated by the schema.
+	size, _ := strconv.Atoi(c.m.GetString("core.certificate_key_size"))
+
+	return localtls.KeyParams{Type: c.m.GetString("core.certificate_key_type"), Size: size}
+}
+
 // SyslogSocket returns true if the syslog socket is enabled, otherwise false.
 func (c *Config) SyslogSocket() bool {
 	return c.m.GetBool("core.syslog_socket")
@@ -142,6 +206,11 @@ func (c *Config) update(values map[string]string) (map[string]string, error) {
 		return nil, err
 	}
 
+	err = c.CertificateKey().Validate()
+	if err != nil {
+		return nil, fmt.Errorf("Invalid certificate key: %w", err)
+	}
+
 	err = c.tx.UpdateConfig(changed)
 	if err != nil {
 		return nil, fmt.Errorf("Cannot persist local configuration changes: %w", err)
@@ -154,6 +223,28 @@ func (c *Config) update(values map[string]string) (map[string]string, error) {
 var ConfigSchema = config.Schema{
 	// Network address for this server
 
+	// gendoc:generate(entity=server, group=core, key=core.firewall_driver)
+	// Possible values are `nftables` and `xtables`.
+	// When not set, the driver is detected automatically.
+	// If the selected driver can't be used on the system, the server fails to start.
+	// ---
+	//  type: string
+	//  scope: local
+	//  defaultdesc: automatically detected
+	//  shortdesc: Firewall driver to use
+	"core.firewall_driver": {Validator: validate.Optional(validate.IsOneOf("nftables", "xtables")), Restart: true},
+
+	// gendoc:generate(entity=server, group=core, key=core.idmapped_mounts)
+	// Possible values are `fallback` and `required`.
+	// With `fallback`, containers whose storage can't use idmapped mounts have their filesystem shifted on disk instead.
+	// With `required`, such containers fail to start.
+	// ---
+	//  type: string
+	//  scope: local
+	//  defaultdesc: `fallback`
+	//  shortdesc: Whether containers may fall back to shifting their filesystem on disk
+	"core.idmapped_mounts": {Default: "fallback", Validator: validate.Optional(validate.IsOneOf("fallback", "required"))},
+
 	// gendoc:generate(entity=server, group=core, key=core.https_address)
 	// See {ref}`server-expose`.
 	// ---
@@ -172,6 +263,26 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Address to use for clustering traffic
 	"cluster.https_address": {Validator: validate.Optional(validate.IsListenAddress(true, false, false))},
 
+	// gendoc:generate(entity=server, group=cluster, key=cluster.raft_snapshot_threshold)
+	// Specify the number of database log entries after which a snapshot of the database is taken.
+	// Lower values reduce the size of the log on disk at the cost of more frequent snapshots.
+	// ---
+	//  type: integer
+	//  scope: local
+	//  defaultdesc: `1024`
+	//  shortdesc: Number of database log entries between snapshots
+	"cluster.raft_snapshot_threshold": {Type: config.Int64, Default: "1024", Validator: validate.Optional(validate.IsInRange(64, 1048576)), Restart: true},
+
+	// gendoc:generate(entity=server, group=cluster, key=cluster.raft_snapshot_trailing)
+	// Specify the number of database log entries that are kept after a snapshot is taken.
+	// Retained entries allow lagging members to catch up without transferring a full snapshot.
+	// ---
+	//  type: integer
+	//  scope: local
+	//  defaultdesc: `8192`
+	//  shortdesc: Number of database log entries kept after a snapshot
+	"cluster.raft_snapshot_trailing": {Type: config.Int64, Default: "8192", Validator: validate.Optional(validate.IsInRange(64, 1048576)), Restart: true},
+
 	// Network address for the BGP server
 
 	// gendoc:generate(entity=server, group=core, key=core.bgp_address)
@@ -204,6 +315,25 @@ var ConfigSchema = config.Schema{
 
 	// Network address for the DNS server
 
+	// gendoc:generate(entity=server, group=core, key=core.devmonitor_paths)
+	// Comma-separated list of additional absolute paths to watch for device hotplug.
+	// The device monitor always watches `/dev`; paths that don't exist or aren't mount points are skipped.
+	// ---
+	//  type: string
+	//  scope: local
+	//  shortdesc: Additional paths watched by the device monitor
+	"core.devmonitor_paths": {Validator: validate.Optional(validate.IsListOf(validate.IsAbsFilePath)), Restart: true},
+
+	// gendoc:generate(entity=server, group=core, key=core.devmonitor_quiet_period)
+	// Bursts of device events are coalesced and the CPU re-balancing only runs once no new event was received for this many milliseconds.
+	// Set to `0` to re-balance on every event.
+	// ---
+	//  type: integer
+	//  scope: local
+	//  defaultdesc: `500`
+	//  shortdesc: Quiet period (in milliseconds) before re-balancing after device events
+	"core.devmonitor_quiet_period": {Type: config.Int64, Default: "500", Validator: validate.Optional(validate.IsInRange(0, 60000))},
+
 	// gendoc:generate(entity=server, group=core, key=core.dns_address)
 	// See {ref}`network-dns-server`.
 	// ---
@@ -222,6 +352,22 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Address to bind the metrics server to (HTTPS)
 	"core.metrics_address": {Validator: validate.Optional(validate.IsListenAddress(true, true, false))},
 
+	// gendoc:generate(entity=server, group=core, key=core.metrics_certificate)
+	// When set along with {config:option}`server-core:core.metrics_key`, the metrics server presents this certificate instead of the server certificate.
+	// ---
+	//  type: string
+	//  scope: local
+	//  shortdesc: PEM encoded certificate for the metrics server
+	"core.metrics_certificate": {Validator: validate.Optional(validatePEMCertificate)},
+
+	// gendoc:generate(entity=server, group=core, key=core.metrics_key)
+	// See {config:option}`server-core:core.metrics_certificate`.
+	// ---
+	//  type: string
+	//  scope: local
+	//  shortdesc: PEM encoded private key for the metrics server
+	"core.metrics_key": {Validator: validate.Optional(validatePEMKey)},
+
 	// Network address for the storage buckets server
 
 	// gendoc:generate(entity=server, group=core, key=core.storage_buckets_address)
@@ -232,6 +378,63 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Address to bind the storage object server to (HTTPS)
 	"core.storage_buckets_address": {Validator: validate.Optional(validate.IsListenAddress(true, true, false))},
 
+	// PKCS#11 backed private keys
+
+	// gendoc:generate(entity=server, group=core, key=core.pkcs11_network_key)
+	// When set, the private key of the network certificate is taken from the referenced PKCS#11 token instead of the key file.
+	// The value must be a PKCS#11 URI, for example `pkcs11:token=incus;object=network?module-path=/usr/lib/softhsm/libsofthsm2.so&pin-source=/etc/incus/pin`.
+	// Changes take effect after restarting the daemon.
+	// ---
+	//  type: string
+	//  scope: local
+	//  shortdesc: PKCS#11 URI of the network certificate private key
+	"core.pkcs11_network_key": {Validator: validate.Optional(validatePKCS11URI), Restart: true},
+
+	// gendoc:generate(entity=server, group=core, key=core.pkcs11_server_key)
+	// When set, the private key of the server certificate is taken from the referenced PKCS#11 token instead of the key file.
+	// The value must be a PKCS#11 URI.
+	// Changes take effect after restarting the daemon.
+	// ---
+	//  type: string
+	//  scope: local
+	//  shortdesc: PKCS#11 URI of the server certificate private key
+	"core.pkcs11_server_key": {Validator: validate.Optional(validatePKCS11URI), Restart: true},
+
+	// Generated certificates
+
+	// gendoc:generate(entity=server, group=core, key=core.certificate_key_type)
+	// Possible values are `ecdsa` and `rsa`.
+	// Applies to the certificates generated when the server first starts and to the ones obtained through ACME on renewal.
+	// Existing certificates are kept until they're renewed.
+	// ---
+	//  type: string
+	//  scope: local
+	//  defaultdesc: `ecdsa` for generated certificates, `rsa` for ACME
+	//  shortdesc: Key type of generated certificates
+	"core.certificate_key_type": {Validator: validate.Optional(validate.IsOneOf(localtls.KeyTypeECDSA, localtls.KeyTypeRSA))},
+
+	// gendoc:generate(entity=server, group=core, key=core.certificate_key_size)
+	// Size of the key in bits of generated certificates.
+	// Possible values are `256` and `384` for ECDSA keys and `2048`, `3072` and `4096` for RSA keys.
+	// ---
+	//  type: integer
+	//  scope: local
+	//  defaultdesc: `384` for ECDSA, `3072` for RSA (`2048` for ACME)
+	//  shortdesc: Key size of generated certificates
+	"core.certificate_key_size": {Validator: validate.Optional(validate.IsOneOf("256", "384", "2048", "3072", "4096"))},
+
+	// Audit log
+
+	// gendoc:generate(entity=server, group=audit, key=audit.log.path)
+	// Absolute path of the local file lifecycle events are appended to. Each event is synced to disk as it's written.
+	// An existing file must be a regular file owned by the user running Incus, and isn't rotated if replaced by anything else.
+	// The audit log is disabled if not set.
+	// ---
+	//  type: string
+	//  scope: local
+	//  shortdesc: Path of the audit log file
+	"audit.log.path": {Validator: validate.Optional(validate.IsAbsFilePath)},
+
 	// Syslog socket
 
 	// gendoc:generate(entity=server, group=core, key=core.syslog_socket)
@@ -259,3 +462,31 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Volume to use to store the image tarballs
 	"storage.images_volume": {},
 }
+
+func validatePKCS11URI(value string) error {
+	_, err := pkcs11.ParseURI(value)
+	return err
+}
+
+func validatePEMCertificate(value string) error {
+	block, _ := pem.Decode([]byte(value))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return fmt.Errorf("Invalid PEM encoded certificate")
+	}
+
+	_, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("Invalid certificate: %w", err)
+	}
+
+	return nil
+}
+
+func validatePEMKey(value string) error {
+	block, _ := pem.Decode([]byte(value))
+	if block == nil || !strings.HasSuffix(block.Type, "PRIVATE KEY") {
+		return fmt.Errorf("Invalid PEM encoded private key")
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ package operations
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/lxc/incus/internal/server/db"
 	"github.com/lxc/incus/internal/server/db/cluster"
@@ -48,11 +49,86 @@ func removeDBOperation(op *Operation) error {
 		return nil
 	}
 
+	// Keep a record of the completed operation if the history is enabled.
+	history := op.historyEntry()
+
+	// The operation may already have been removed from the database, for example when its project was
+	// deleted first. Still record it in the history in that case, and report it as not found afterwards.
+	var deleteErr error
 	err := op.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-		return cluster.DeleteOperation(ctx, tx.Tx(), op.id)
+		deleteErr = cluster.DeleteOperation(ctx, tx.Tx(), op.id)
+		if deleteErr != nil && !api.StatusErrorCheck(deleteErr, http.StatusNotFound) {
+			return deleteErr
+		}
+
+		if history != nil {
+			err := tx.CreateOperationHistory(ctx, *history)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return deleteErr
+}
+
+// recordDBOperationHistory records the completed operation in the history, if enabled, without removing it from
+// the database.
+func recordDBOperationHistory(op *Operation) error {
+	if op.state == nil {
+		return nil
+	}
+
+	history := op.historyEntry()
+	if history == nil {
+		return nil
+	}
+
+	return op.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.CreateOperationHistory(ctx, *history)
 	})
+}
+
+// historyEntry returns the operations history entry for the completed operation, or nil if the history is
+// disabled.
+func (op *Operation) historyEntry() *db.OperationHistory {
+	if op.state.GlobalConfig == nil {
+		return nil
+	}
+
+	maxAge, maxCount := op.state.GlobalConfig.OperationsHistory()
+	if maxAge <= 0 && maxCount <= 0 {
+		return nil
+	}
+
+	op.lock.Lock()
+	defer op.lock.Unlock()
+
+	entry := &db.OperationHistory{
+		UUID:        op.id,
+		Project:     op.projectName,
+		Location:    op.state.ServerName,
+		Type:        op.dbOpType,
+		Description: op.description,
+		StatusCode:  op.status,
+		CreatedAt:   op.createdAt,
+		FinishedAt:  op.updatedAt,
+	}
+
+	if op.err != nil {
+		entry.Err = op.err.Error()
+	}
+
+	if op.requestor != nil {
+		entry.Requestor = op.requestor.Username
+	}
 
-	return err
+	return entry
 }
 
 func (op *Operation) sendEvent(eventMessage any) {
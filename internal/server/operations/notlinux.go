@@ -25,6 +25,14 @@ func removeDBOperation(op *Operation) error {
 	return nil
 }
 
+func recordDBOperationHistory(op *Operation) error {
+	if op.state != nil {
+		return fmt.Errorf("recordDBOperationHistory not supported on this platform")
+	}
+
+	return nil
+}
+
 func (op *Operation) sendEvent(eventMessage any) {
 	if op.events == nil {
 		return
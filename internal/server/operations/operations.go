@@ -107,6 +107,9 @@ type Operation struct {
 	requestor   *api.EventLifecycleRequestor
 	logger      logger.Logger
 
+	// Indicates that the operation is waiting for other task operations to complete before it can run.
+	queued bool
+
 	// Those functions are called at various points in the Operation lifecycle
 	onRun     func(*Operation) error
 	onCancel  func(*Operation) error
@@ -115,6 +118,9 @@ type Operation struct {
 	// Indicates if operation has finished.
 	finished *cancel.Canceller
 
+	// Cancelled when the operation is cancelled or has finished, for the work it runs to stop.
+	running *cancel.Canceller
+
 	// Locking for concurent access to the Operation
 	lock sync.Mutex
 
@@ -143,6 +149,7 @@ func OperationCreate(s *state.State, projectName string, opClass OperationClass,
 	op.url = fmt.Sprintf("/%s/operations/%s", version.APIVersion, op.id)
 	op.resources = opResources
 	op.finished = cancel.New(context.Background())
+	op.running = cancel.New(context.Background())
 	op.state = s
 	op.logger = logger.AddContext(logger.Ctx{"operation": op.id, "project": op.projectName, "class": op.class.String(), "description": op.description})
 
@@ -228,6 +235,7 @@ func (op *Operation) done() {
 	op.onRun = nil
 	op.onCancel = nil
 	op.onConnect = nil
+	op.running.Cancel()
 	op.finished.Cancel()
 	op.lock.Unlock()
 
@@ -239,7 +247,14 @@ func (op *Operation) done() {
 
 		select {
 		case <-shutdownCtx.Done():
-			return // Expect all operation records to be removed by waitForOperations in one query.
+			// Expect all operation records to be removed by waitForOperations in one query, only keep
+			// a record of the operation in the history.
+			err := recordDBOperationHistory(op)
+			if err != nil {
+				op.logger.Warn("Failed to record operation in the history", logger.Ctx{"status": op.status, "err": err})
+			}
+
+			return
 		case <-time.After(time.Second * 5): // Wait 5s before removing from internal map and database.
 		}
 
@@ -268,17 +283,66 @@ func (op *Operation) done() {
 }
 
 // Start a pending operation. It returns an error if the operation cannot be started.
+// Task operations started past the limit set with SetTasksLimit are queued and remain pending until they can run.
 func (op *Operation) Start() error {
 	op.lock.Lock()
-	if op.status != api.Pending {
+	if op.status != api.Pending || op.queued {
 		op.lock.Unlock()
 		return fmt.Errorf("Only pending operations can be started")
 	}
 
+	if op.class == OperationClassTask && op.onRun != nil && !tasksTryAcquire() {
+		op.queued = true
+		op.lock.Unlock()
+
+		op.logger.Debug("Queued operation")
+
+		go func(op *Operation) {
+			if !tasksAcquire(op) {
+				// The operation was either cancelled or the daemon is shutting down.
+				if op.finished.Err() != nil {
+					return
+				}
+
+				op.lock.Lock()
+				op.status = api.Failure
+				op.err = fmt.Errorf("Incus is shutting down")
+				op.lock.Unlock()
+				op.done()
+
+				_, md, _ := op.Render()
+
+				op.lock.Lock()
+				op.sendEvent(md)
+				op.lock.Unlock()
+
+				return
+			}
+
+			op.lock.Lock()
+			op.queued = false
+			op.run()
+		}(op)
+
+		return nil
+	}
+
+	op.run()
+
+	return nil
+}
+
+// run marks the operation as running and runs its Run hook in the background.
+// It must be called with the operation lock held, which it releases.
+func (op *Operation) run() {
 	op.status = api.Running
 
 	if op.onRun != nil {
 		go func(op *Operation) {
+			if op.class == OperationClassTask {
+				defer tasksRelease()
+			}
+
 			err := op.onRun(op)
 			if err != nil {
 				op.lock.Lock()
@@ -319,14 +383,32 @@ func (op *Operation) Start() error {
 	op.lock.Lock()
 	op.sendEvent(md)
 	op.lock.Unlock()
-
-	return nil
 }
 
 // Cancel cancels a running operation. If the operation cannot be cancelled, it
 // returns an error.
 func (op *Operation) Cancel() (chan error, error) {
 	op.lock.Lock()
+
+	// Queued operations haven't started yet so can always be cancelled.
+	if op.status == api.Pending && op.queued {
+		op.status = api.Cancelled
+		op.lock.Unlock()
+		op.done()
+
+		chanCancel := make(chan error, 1)
+		chanCancel <- nil
+
+		op.logger.Debug("Cancelled queued operation")
+		_, md, _ := op.Render()
+
+		op.lock.Lock()
+		op.sendEvent(md)
+		op.lock.Unlock()
+
+		return chanCancel, nil
+	}
+
 	if op.status != api.Running {
 		op.lock.Unlock()
 		return nil, fmt.Errorf("Only running operations can be cancelled")
@@ -445,7 +527,7 @@ func (op *Operation) Connect(r *http.Request, w http.ResponseWriter) (chan error
 }
 
 func (op *Operation) mayCancel() bool {
-	if op.class == OperationClassToken {
+	if op.class == OperationClassToken || op.queued {
 		return true
 	}
 
@@ -632,6 +714,17 @@ func (op *Operation) ExtendMetadata(metadata any) error {
 	return nil
 }
 
+// Context returns a context which is cancelled when the operation is cancelled or has finished.
+// Long running work should observe it so that cancelling the operation stops it promptly.
+// Work run without an operation gets a context which is never cancelled.
+func (op *Operation) Context() context.Context {
+	if op == nil || op.running == nil {
+		return context.Background()
+	}
+
+	return op.running
+}
+
 // ID returns the operation ID.
 func (op *Operation) ID() string {
 	return op.id
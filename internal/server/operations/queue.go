@@ -0,0 +1,108 @@
+package operations
+
+import (
+	"context"
+	"sync"
+)
+
+// Maximum number of task operations running at once, 0 meaning no limit.
+var tasksLimit int
+
+// Number of task operations currently running.
+var tasksRunning int
+
+// Number of task operations waiting for a slot.
+var tasksQueued int
+
+var tasksLock sync.Mutex
+var tasksCond = sync.NewCond(&tasksLock)
+
+// SetTasksLimit sets the maximum number of task operations allowed to run at once.
+// Task operations started past that limit are queued until a running one completes. 0 disables the limit.
+func SetTasksLimit(limit int) {
+	tasksLock.Lock()
+	tasksLimit = limit
+	tasksLock.Unlock()
+
+	// Let queued operations start if the limit was raised.
+	tasksCond.Broadcast()
+}
+
+// TasksCount returns the number of task operations running and the number of those queued past the limit.
+func TasksCount() (running int, queued int) {
+	tasksLock.Lock()
+	defer tasksLock.Unlock()
+
+	return tasksRunning, tasksQueued
+}
+
+// tasksTryAcquire reserves a slot for a task operation if one is available.
+func tasksTryAcquire() bool {
+	tasksLock.Lock()
+	defer tasksLock.Unlock()
+
+	if tasksLimit > 0 && tasksRunning >= tasksLimit {
+		return false
+	}
+
+	tasksRunning++
+
+	return true
+}
+
+// tasksAcquire waits for a slot for a queued task operation.
+// Returns false if the operation was cancelled or the daemon started shutting down while waiting.
+func tasksAcquire(op *Operation) bool {
+	shutdownCtx := context.Background()
+	if op.state != nil {
+		shutdownCtx = op.state.ShutdownCtx
+	}
+
+	// Wake up the waiters when the operation is cancelled or on shutdown.
+	stop := make(chan struct{})
+	defer close(stop)
+
+	go func() {
+		select {
+		case <-shutdownCtx.Done():
+		case <-op.finished.Done():
+		case <-stop:
+			return
+		}
+
+		tasksLock.Lock()
+		tasksCond.Broadcast()
+		tasksLock.Unlock()
+	}()
+
+	tasksLock.Lock()
+	defer tasksLock.Unlock()
+
+	tasksQueued++
+	defer func() { tasksQueued-- }()
+
+	for {
+		if shutdownCtx.Err() != nil || op.finished.Err() != nil {
+			return false
+		}
+
+		if tasksLimit <= 0 || tasksRunning < tasksLimit {
+			break
+		}
+
+		tasksCond.Wait()
+	}
+
+	tasksRunning++
+
+	return true
+}
+
+// tasksRelease frees the slot of a completed task operation.
+func tasksRelease() {
+	tasksLock.Lock()
+	tasksRunning--
+	tasksLock.Unlock()
+
+	tasksCond.Broadcast()
+}
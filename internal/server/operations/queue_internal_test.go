@@ -0,0 +1,144 @@
+package operations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/shared/cancel"
+)
+
+// resetTasks sets the given limit with no running task operations.
+func resetTasks(limit int) {
+	SetTasksLimit(limit)
+	tasksLock.Lock()
+	tasksRunning = 0
+	tasksLock.Unlock()
+}
+
+// setupTasks sets the given limit with no running task operations, restoring the defaults afterwards.
+func setupTasks(t *testing.T, limit int) {
+	resetTasks(limit)
+	t.Cleanup(func() { resetTasks(0) })
+}
+
+// queueTask waits for a slot for a new task operation in the background.
+func queueTask() (*Operation, chan bool) {
+	op := &Operation{finished: cancel.New(context.Background())}
+	acquired := make(chan bool, 1)
+
+	go func() { acquired <- tasksAcquire(op) }()
+
+	return op, acquired
+}
+
+func TestTasksTryAcquire(t *testing.T) {
+	cases := []struct {
+		name     string
+		limit    int
+		attempts int
+		acquired int
+	}{
+		{"no limit", 0, 10, 10},
+		{"under limit", 5, 3, 3},
+		{"at limit", 3, 3, 3},
+		{"over limit", 2, 5, 2},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			setupTasks(t, c.limit)
+
+			acquired := 0
+			for i := 0; i < c.attempts; i++ {
+				if tasksTryAcquire() {
+					acquired++
+				}
+			}
+
+			assert.Equal(t, c.acquired, acquired)
+			assert.Equal(t, c.acquired, tasksRunning)
+		})
+	}
+}
+
+func TestTasksRelease(t *testing.T) {
+	setupTasks(t, 1)
+
+	require.True(t, tasksTryAcquire())
+	require.False(t, tasksTryAcquire())
+
+	tasksRelease()
+	assert.Equal(t, 0, tasksRunning)
+	assert.True(t, tasksTryAcquire())
+}
+
+func TestTasksAcquire_Queued(t *testing.T) {
+	setupTasks(t, 1)
+	require.True(t, tasksTryAcquire())
+
+	_, acquired := queueTask()
+
+	select {
+	case <-acquired:
+		t.Fatal("Queued task operation started past the limit")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	running, queued := TasksCount()
+	assert.Equal(t, 1, running)
+	assert.Equal(t, 1, queued)
+
+	// Completing the running operation lets the queued one start.
+	tasksRelease()
+
+	select {
+	case ok := <-acquired:
+		assert.True(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Queued task operation didn't start after release")
+	}
+
+	running, queued = TasksCount()
+	assert.Equal(t, 1, running)
+	assert.Equal(t, 0, queued)
+}
+
+func TestTasksAcquire_LimitRaised(t *testing.T) {
+	setupTasks(t, 1)
+	require.True(t, tasksTryAcquire())
+
+	_, acquired := queueTask()
+
+	SetTasksLimit(2)
+
+	select {
+	case ok := <-acquired:
+		assert.True(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Queued task operation didn't start after the limit was raised")
+	}
+
+	assert.Equal(t, 2, tasksRunning)
+}
+
+func TestTasksAcquire_Cancelled(t *testing.T) {
+	setupTasks(t, 1)
+	require.True(t, tasksTryAcquire())
+
+	op, acquired := queueTask()
+
+	op.finished.Cancel()
+
+	select {
+	case ok := <-acquired:
+		assert.False(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Cancelled task operation is still queued")
+	}
+
+	assert.Equal(t, 1, tasksRunning)
+}
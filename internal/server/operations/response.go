@@ -13,18 +13,26 @@ import (
 
 // Operation response.
 type operationResponse struct {
-	op *Operation
+	op      *Operation
+	started bool
 }
 
 // OperationResponse returns an operation response.
 func OperationResponse(op *Operation) response.Response {
-	return &operationResponse{op}
+	return &operationResponse{op: op}
+}
+
+// StartedOperationResponse returns an operation response for an operation which was already started.
+func StartedOperationResponse(op *Operation) response.Response {
+	return &operationResponse{op: op, started: true}
 }
 
 func (r *operationResponse) Render(w http.ResponseWriter) error {
-	err := r.op.Start()
-	if err != nil {
-		return err
+	if !r.started {
+		err := r.op.Start()
+		if err != nil {
+			return err
+		}
 	}
 
 	url, md, err := r.op.Render()
@@ -0,0 +1,81 @@
+// Package pkcs11 provides access to private keys held in hardware tokens through PKCS#11.
+package pkcs11
+
+import (
+	"crypto"
+	"fmt"
+	"sync"
+
+	"github.com/ThalesIgnite/crypto11"
+)
+
+// contexts holds the token sessions, which are kept open for the lifetime of the daemon.
+var contexts = map[string]*crypto11.Context{}
+var contextsMu sync.Mutex
+
+// NewSigner returns a crypto.Signer backed by the private key referenced by the PKCS#11 URI.
+func NewSigner(uri string) (crypto.Signer, error) {
+	u, err := ParseURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := tokenContext(u)
+	if err != nil {
+		return nil, err
+	}
+
+	var label []byte
+	if u.Object != "" {
+		label = []byte(u.Object)
+	}
+
+	signer, err := ctx.FindKeyPair(u.ID, label)
+	if err != nil {
+		return nil, fmt.Errorf("Failed looking up PKCS#11 key: %w", err)
+	}
+
+	if signer == nil {
+		return nil, fmt.Errorf("PKCS#11 key not found")
+	}
+
+	return signer, nil
+}
+
+// tokenContext returns an open session to the token referenced by the URI.
+func tokenContext(u *URI) (*crypto11.Context, error) {
+	contextsMu.Lock()
+	defer contextsMu.Unlock()
+
+	key := fmt.Sprintf("%s\x00%s\x00%s", u.ModulePath, u.Token, u.Serial)
+
+	ctx, ok := contexts[key]
+	if ok {
+		return ctx, nil
+	}
+
+	pin, err := u.pin()
+	if err != nil {
+		return nil, err
+	}
+
+	config := &crypto11.Config{
+		Path: u.ModulePath,
+		Pin:  pin,
+	}
+
+	if u.Serial != "" {
+		config.TokenSerial = u.Serial
+	} else {
+		config.TokenLabel = u.Token
+	}
+
+	ctx, err = crypto11.Configure(config)
+	if err != nil {
+		return nil, fmt.Errorf("Failed opening PKCS#11 token: %w", err)
+	}
+
+	contexts[key] = ctx
+
+	return ctx, nil
+}
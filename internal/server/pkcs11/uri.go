@@ -0,0 +1,138 @@
+package pkcs11
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// URI represents a PKCS#11 URI (RFC 7512) referencing a private key.
+type URI struct {
+	// Path to the PKCS#11 module to load.
+	ModulePath string
+
+	// Label of the token holding the key.
+	Token string
+
+	// Serial number of the token holding the key.
+	Serial string
+
+	// Label of the key object.
+	Object string
+
+	// Identifier of the key object.
+	ID []byte
+
+	// PIN used to log into the token.
+	PIN string
+
+	// Path to the file holding the PIN, read when opening the token.
+	PINSource string
+}
+
+// ParseURI parses a PKCS#11 URI such as "pkcs11:token=incus;object=server?module-path=/usr/lib/softhsm/libsofthsm2.so&pin-value=1234".
+func ParseURI(uri string) (*URI, error) {
+	rest, found := strings.CutPrefix(uri, "pkcs11:")
+	if !found {
+		return nil, fmt.Errorf("PKCS#11 URI must start with \"pkcs11:\"")
+	}
+
+	path, query, _ := strings.Cut(rest, "?")
+
+	u := &URI{}
+
+	for _, attr := range strings.Split(path, ";") {
+		if attr == "" {
+			continue
+		}
+
+		key, value, err := parseAttribute(attr)
+		if err != nil {
+			return nil, err
+		}
+
+		switch key {
+		case "token":
+			u.Token = value
+		case "serial":
+			u.Serial = value
+		case "object":
+			u.Object = value
+		case "id":
+			u.ID = []byte(value)
+		case "type":
+			if value != "private" {
+				return nil, fmt.Errorf("PKCS#11 URI must reference a private key, not %q", value)
+			}
+		}
+	}
+
+	for _, attr := range strings.Split(query, "&") {
+		if attr == "" {
+			continue
+		}
+
+		key, value, err := parseAttribute(attr)
+		if err != nil {
+			return nil, err
+		}
+
+		switch key {
+		case "module-path":
+			u.ModulePath = value
+		case "pin-value":
+			u.PIN = value
+		case "pin-source":
+			pinFile, found := strings.CutPrefix(value, "file:")
+			if !found {
+				pinFile = value
+			}
+
+			u.PINSource = pinFile
+		}
+	}
+
+	if u.ModulePath == "" {
+		return nil, fmt.Errorf("PKCS#11 URI is missing the \"module-path\" attribute")
+	}
+
+	if u.Token == "" && u.Serial == "" {
+		return nil, fmt.Errorf("PKCS#11 URI must specify either \"token\" or \"serial\"")
+	}
+
+	if u.Object == "" && len(u.ID) == 0 {
+		return nil, fmt.Errorf("PKCS#11 URI must specify either \"object\" or \"id\"")
+	}
+
+	return u, nil
+}
+
+// pin returns the PIN used to log into the token, reading it from the PIN source file if set.
+func (u *URI) pin() (string, error) {
+	if u.PINSource == "" {
+		return u.PIN, nil
+	}
+
+	pin, err := os.ReadFile(u.PINSource)
+	if err != nil {
+		return "", fmt.Errorf("Failed reading PKCS#11 PIN: %w", err)
+	}
+
+	return strings.TrimSpace(string(pin)), nil
+}
+
+// parseAttribute splits a "key=value" URI attribute and decodes its value.
+func parseAttribute(attr string) (string, string, error) {
+	key, value, found := strings.Cut(attr, "=")
+	if !found {
+		return "", "", fmt.Errorf("Invalid PKCS#11 URI attribute %q", attr)
+	}
+
+	value, err := url.PathUnescape(value)
+	if err != nil {
+		return "", "", fmt.Errorf("Invalid PKCS#11 URI attribute %q: %w", attr, err)
+	}
+
+	return key, value, nil
+}
@@ -0,0 +1,54 @@
+package pkcs11
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseURI(t *testing.T) {
+	u, err := ParseURI("pkcs11:token=incus;object=server%20key;type=private?module-path=/usr/lib/softhsm/libsofthsm2.so&pin-value=1234")
+	require.NoError(t, err)
+	assert.Equal(t, "/usr/lib/softhsm/libsofthsm2.so", u.ModulePath)
+	assert.Equal(t, "incus", u.Token)
+	assert.Equal(t, "server key", u.Object)
+	assert.Equal(t, "1234", u.PIN)
+
+	u, err = ParseURI("pkcs11:serial=abc;id=%01%02?module-path=/lib/p11.so")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", u.Serial)
+	assert.Equal(t, []byte{1, 2}, u.ID)
+
+	pinFile := filepath.Join(t.TempDir(), "pin")
+	require.NoError(t, os.WriteFile(pinFile, []byte("5678\n"), 0600))
+
+	u, err = ParseURI("pkcs11:token=incus;object=server?module-path=/lib/p11.so&pin-source=file:" + pinFile)
+	require.NoError(t, err)
+	assert.Equal(t, pinFile, u.PINSource)
+
+	pin, err := u.pin()
+	require.NoError(t, err)
+	assert.Equal(t, "5678", pin)
+
+	// The PIN source is only read when opening the token.
+	u, err = ParseURI("pkcs11:token=incus;object=server?module-path=/lib/p11.so&pin-source=/nonexistent")
+	require.NoError(t, err)
+
+	_, err = u.pin()
+	assert.Error(t, err)
+
+	for _, uri := range []string{
+		"token=incus;object=server?module-path=/lib/p11.so",
+		"pkcs11:token=incus;object=server",
+		"pkcs11:object=server?module-path=/lib/p11.so",
+		"pkcs11:token=incus?module-path=/lib/p11.so",
+		"pkcs11:token=incus;object=server;type=cert?module-path=/lib/p11.so",
+		"pkcs11:token=incus;object?module-path=/lib/p11.so",
+	} {
+		_, err := ParseURI(uri)
+		assert.Error(t, err, uri)
+	}
+}
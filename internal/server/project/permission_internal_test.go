@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lxc/incus/internal/idmap"
+	"github.com/lxc/incus/shared/api"
 )
 
 func TestParseHostIDMapRange(t *testing.T) {
@@ -75,3 +76,49 @@ func TestParseHostIDMapRange(t *testing.T) {
 		assert.Equal(t, idmaps, expected)
 	}
 }
+
+func TestRestrictionViolations(t *testing.T) {
+	project := api.Project{
+		Name: "tenant",
+		ProjectPut: api.ProjectPut{
+			Config: map[string]string{
+				"restricted":                 "true",
+				"restricted.networks.access": "ovn0",
+			},
+		},
+	}
+
+	instances := []api.Instance{{
+		Name: "c1",
+		Type: "container",
+		InstancePut: api.InstancePut{
+			Config: map[string]string{"security.privileged": "true"},
+			Devices: map[string]map[string]string{
+				"eth0": {"type": "nic", "network": "incusbr0"},
+				"eth1": {"type": "nic", "network": "ovn0"},
+			},
+		},
+	}}
+
+	profiles := []api.Profile{{
+		Name: "web",
+		ProfilePut: api.ProfilePut{
+			Devices: map[string]map[string]string{
+				"gpu": {"type": "gpu"},
+			},
+		},
+	}}
+
+	// All the violations are reported, not only the first one.
+	violations, err := restrictionViolations(project, instances, profiles)
+	assert.NoError(t, err)
+	assert.Len(t, violations, 3)
+
+	// The first violation is returned by checkRestrictions.
+	assert.Error(t, checkRestrictions(project, instances, profiles))
+
+	// Allowed entities aren't reported.
+	violations, err = restrictionViolations(project, nil, []api.Profile{{Name: "default"}})
+	assert.NoError(t, err)
+	assert.Empty(t, violations)
+}
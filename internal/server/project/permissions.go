@@ -79,6 +79,61 @@ func AllowInstanceCreation(tx *db.ClusterTx, projectName string, req api.Instanc
 		return err
 	}
 
+	// Check that the instance's root disk is on a storage pool allowed in the project.
+	instances, err := expandInstancesConfigAndDevices([]api.Instance{info.Instances[len(info.Instances)-1]}, info.Profiles)
+	if err != nil {
+		return err
+	}
+
+	_, rootDiskDevice, err := instance.GetRootDiskDevice(instances[0].Devices)
+	if err == nil {
+		err = checkStoragePoolAllowed(tx, &info.Project, rootDiskDevice["pool"])
+		if err != nil {
+			return err
+		}
+	}
+
+	err = checkRestrictionsAndAggregateLimits(tx, info)
+	if err != nil {
+		return fmt.Errorf("Failed checking if instance creation allowed: %w", err)
+	}
+
+	return nil
+}
+
+// CheckInstanceLimits checks that the instances recorded in the project (including the one being added) don't
+// exceed the project limits. It's meant to be called in the transaction adding a new instance record, so that the
+// record acts as a reservation: as the cluster database transactions are serialized, instances created concurrently
+// (possibly on different cluster members) can't all pass the checks of AllowInstanceCreation and together
+// overshoot the limits.
+func CheckInstanceLimits(tx *db.ClusterTx, projectName string, instanceType instancetype.Type) error {
+	info, err := fetchProject(tx, projectName, true)
+	if err != nil {
+		return err
+	}
+
+	if info == nil {
+		return nil
+	}
+
+	count, limit, err := getInstanceCountLimit(info, instanceType)
+	if err != nil {
+		return err
+	}
+
+	if limit >= 0 && count > limit {
+		return fmt.Errorf("Reached maximum number of instances of type %q in project %q", instanceType, info.Project.Name)
+	}
+
+	count, limit, err = getTotalInstanceCountLimit(info)
+	if err != nil {
+		return err
+	}
+
+	if limit >= 0 && count > limit {
+		return fmt.Errorf("Reached maximum number of instances in project %q", info.Project.Name)
+	}
+
 	err = checkRestrictionsAndAggregateLimits(tx, info)
 	if err != nil {
 		return fmt.Errorf("Failed checking if instance creation allowed: %w", err)
@@ -433,6 +488,21 @@ func parseHostIDMapRange(isUID bool, isGID bool, listValue string) ([]idmap.Idma
 // Check that the project's restrictions are not violated across the given
 // instances and profiles.
 func checkRestrictions(project api.Project, instances []api.Instance, profiles []api.Profile) error {
+	violations, err := restrictionViolations(project, instances, profiles)
+	if err != nil {
+		return err
+	}
+
+	if len(violations) > 0 {
+		return violations[0]
+	}
+
+	return nil
+}
+
+// restrictionViolations returns all the violations of the project's restrictions across the given instances and
+// profiles, rather than stopping at the first one.
+func restrictionViolations(project api.Project, instances []api.Instance, profiles []api.Profile) ([]error, error) {
 	containerConfigChecks := map[string]func(value string) error{}
 	devicesChecks := map[string]func(value map[string]string) error{}
 
@@ -633,20 +703,22 @@ func checkRestrictions(project api.Project, instances []api.Instance, profiles [
 			var err error
 			allowedIDMapHostUIDs, err = parseHostIDMapRange(true, false, restrictionValue)
 			if err != nil {
-				return fmt.Errorf("Failed parsing %q: %w", "restricted.idmap.uid", err)
+				return nil, fmt.Errorf("Failed parsing %q: %w", "restricted.idmap.uid", err)
 			}
 
 		case "restricted.idmap.gid":
 			var err error
 			allowedIDMapHostGIDs, err = parseHostIDMapRange(false, true, restrictionValue)
 			if err != nil {
-				return fmt.Errorf("Failed parsing %q: %w", "restricted.idmap.uid", err)
+				return nil, fmt.Errorf("Failed parsing %q: %w", "restricted.idmap.uid", err)
 			}
 		}
 	}
 
+	var violations []error
+
 	// Common config check logic between instances and profiles.
-	entityConfigChecker := func(instType instancetype.Type, entityName string, config map[string]string) error {
+	entityConfigChecker := func(instType instancetype.Type, entityName string, config map[string]string) {
 		entityTypeLabel := instType.String()
 		if instType == instancetype.Any {
 			entityTypeLabel = "profile"
@@ -669,17 +741,21 @@ func checkRestrictions(project api.Project, instances []api.Instance, profiles [
 					// are allowed based on the project's allowed ID map Host UIDs and GIDs.
 					idmaps, err := idmap.ParseRawIdmap(value)
 					if err != nil {
-						return err
+						violations = append(violations, err)
+						continue
 					}
 
 					for idmapIndex, idmap := range idmaps {
 						if !idmap.HostIDsCoveredBy(allowedIDMapHostUIDs, allowedIDMapHostGIDs) {
-							return fmt.Errorf(`Use of low-level "raw.idmap" element %d on %s %q of project %q is forbidden`, idmapIndex, entityTypeLabel, entityName, project.Name)
+							violations = append(violations, fmt.Errorf(`Use of low-level "raw.idmap" element %d on %s %q of project %q is forbidden`, idmapIndex, entityTypeLabel, entityName, project.Name))
 						}
 					}
+
+					continue
 				} else if (isContainerOrProfile && isContainerLowLevelOptionForbidden(key)) || (isVMOrProfile && isVMLowLevelOptionForbidden(key)) {
 					// Otherwise check if the key is a forbidden low-level one.
-					return fmt.Errorf("Use of low-level config %q on %s %q of project %q is forbidden", key, entityTypeLabel, entityName, project.Name)
+					violations = append(violations, fmt.Errorf("Use of low-level config %q on %s %q of project %q is forbidden", key, entityTypeLabel, entityName, project.Name))
+					continue
 				}
 			}
 
@@ -694,15 +770,13 @@ func checkRestrictions(project api.Project, instances []api.Instance, profiles [
 
 			err := checker(value)
 			if err != nil {
-				return fmt.Errorf("Invalid value %q for config %q on %s %q of project %q: %w", value, key, instType, entityName, project.Name, err)
+				violations = append(violations, fmt.Errorf("Invalid value %q for config %q on %s %q of project %q: %w", value, key, instType, entityName, project.Name, err))
 			}
 		}
-
-		return nil
 	}
 
 	// Common devices check logic between instances and profiles.
-	entityDevicesChecker := func(instType instancetype.Type, entityName string, devices map[string]map[string]string) error {
+	entityDevicesChecker := func(instType instancetype.Type, entityName string, devices map[string]map[string]string) {
 		entityTypeLabel := instType.String()
 		if instType == instancetype.Any {
 			entityTypeLabel = "profile"
@@ -716,42 +790,27 @@ func checkRestrictions(project api.Project, instances []api.Instance, profiles [
 
 			err := check(device)
 			if err != nil {
-				return fmt.Errorf("Invalid device %q on %s %q of project %q: %w", name, entityTypeLabel, entityName, project.Name, err)
+				violations = append(violations, fmt.Errorf("Invalid device %q on %s %q of project %q: %w", name, entityTypeLabel, entityName, project.Name, err))
 			}
 		}
-		return nil
 	}
 
 	for _, instance := range instances {
 		instType, err := instancetype.New(instance.Type)
 		if err != nil {
-			return err
-		}
-
-		err = entityConfigChecker(instType, instance.Name, instance.Config)
-		if err != nil {
-			return err
+			return nil, err
 		}
 
-		err = entityDevicesChecker(instType, instance.Name, instance.Devices)
-		if err != nil {
-			return err
-		}
+		entityConfigChecker(instType, instance.Name, instance.Config)
+		entityDevicesChecker(instType, instance.Name, instance.Devices)
 	}
 
 	for _, profile := range profiles {
-		err := entityConfigChecker(instancetype.Any, profile.Name, profile.Config)
-		if err != nil {
-			return err
-		}
-
-		err = entityDevicesChecker(instancetype.Any, profile.Name, profile.Devices)
-		if err != nil {
-			return err
-		}
+		entityConfigChecker(instancetype.Any, profile.Name, profile.Config)
+		entityDevicesChecker(instancetype.Any, profile.Name, profile.Devices)
 	}
 
-	return nil
+	return violations, nil
 }
 
 // CheckRestrictedDevicesDiskPaths checks whether the disk's source path is within the allowed paths specified in
@@ -809,10 +868,13 @@ var allRestrictions = map[string]string{
 	"restricted.idmap.gid":                 "",
 	"restricted.networks.access":           "",
 	"restricted.snapshots":                 "block",
+	"restricted.storage.drivers":           "",
 }
 
 // allowableIntercept lists all syscall interception keys which may be allowed.
 var allowableIntercept = []string{
+	"security.syscalls.intercept.audit",
+	"security.syscalls.intercept.audit.limit",
 	"security.syscalls.intercept.bpf",
 	"security.syscalls.intercept.bpf.devices",
 	"security.syscalls.intercept.mknod",
@@ -869,11 +931,13 @@ func AllowInstanceUpdate(tx *db.ClusterTx, projectName, instanceName string, req
 	}
 
 	// Change the instance being updated.
+	var currentInstance api.Instance
 	for i, instance := range info.Instances {
 		if instance.Name != instanceName {
 			continue
 		}
 
+		currentInstance = instance
 		info.Instances[i].Profiles = req.Profiles
 		info.Instances[i].Config = req.Config
 		info.Instances[i].Devices = req.Devices
@@ -893,6 +957,17 @@ func AllowInstanceUpdate(tx *db.ClusterTx, projectName, instanceName string, req
 		return err
 	}
 
+	// Check the storage pool of the root disk if it changed.
+	instances, err := expandInstancesConfigAndDevices([]api.Instance{currentInstance, *updatedInstance}, info.Profiles)
+	if err != nil {
+		return err
+	}
+
+	err = checkRootDiskStoragePoolChange(tx, &info.Project, instances[0].Devices, instances[1].Devices)
+	if err != nil {
+		return err
+	}
+
 	err = checkRestrictionsAndAggregateLimits(tx, info)
 	if err != nil {
 		return fmt.Errorf("Failed checking if instance update allowed: %w", err)
@@ -953,6 +1028,12 @@ func AllowProfileUpdate(tx *db.ClusterTx, projectName, profileName string, req a
 			continue
 		}
 
+		// Check the storage pool of the root disk if it changed.
+		err = checkRootDiskStoragePoolChange(tx, &info.Project, profile.Devices, req.Devices)
+		if err != nil {
+			return err
+		}
+
 		info.Profiles[i].Config = req.Config
 		info.Profiles[i].Devices = req.Devices
 	}
@@ -980,21 +1061,11 @@ func AllowProjectUpdate(tx *db.ClusterTx, projectName string, config map[string]
 	// List of keys that need to check aggregate values across all project
 	// instances.
 	aggregateKeys := []string{}
+	restrictionsChanged := false
 
 	for _, key := range changed {
-		if strings.HasPrefix(key, "restricted.") {
-			project := api.Project{
-				Name: projectName,
-				ProjectPut: api.ProjectPut{
-					Config: config,
-				},
-			}
-
-			err := checkRestrictions(project, info.Instances, info.Profiles)
-			if err != nil {
-				return fmt.Errorf("Conflict detected when changing %q in project %q: %w", key, projectName, err)
-			}
-
+		if key == "restricted" || strings.HasPrefix(key, "restricted.") {
+			restrictionsChanged = true
 			continue
 		}
 
@@ -1024,6 +1095,20 @@ func AllowProjectUpdate(tx *db.ClusterTx, projectName string, config map[string]
 		}
 	}
 
+	// Report all the entities which the new restrictions would forbid at once.
+	if restrictionsChanged {
+		info.Project.Config = config
+
+		violations, err := projectRestrictionViolations(tx, info)
+		if err != nil {
+			return err
+		}
+
+		if len(violations) > 0 {
+			return fmt.Errorf("Conflict detected when changing the restrictions of project %q: %s", projectName, strings.Join(violations, "; "))
+		}
+	}
+
 	if len(aggregateKeys) > 0 {
 		totals, err := getTotalsAcrossProjectEntities(info, aggregateKeys, false)
 		if err != nil {
@@ -1041,6 +1126,49 @@ func AllowProjectUpdate(tx *db.ClusterTx, projectName string, config map[string]
 	return nil
 }
 
+// projectRestrictionViolations returns the violations of the project's restrictions by its (expanded) instances,
+// profiles and custom volumes, so that tightening the restrictions of a project reports everything it would
+// forbid. Nothing is returned if the project isn't restricted.
+func projectRestrictionViolations(tx *db.ClusterTx, info *projectInfo) ([]string, error) {
+	if util.IsFalseOrEmpty(info.Project.Config["restricted"]) {
+		return nil, nil
+	}
+
+	errs, err := restrictionViolations(info.Project, info.Instances, info.Profiles)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := make([]string, 0, len(errs))
+	for _, err := range errs {
+		violations = append(violations, err.Error())
+	}
+
+	// Check the storage pools used by the instances and custom volumes.
+	if !StoragePoolAllowed(info.Project.Config, "") {
+		for _, inst := range info.Instances {
+			_, rootDiskDevice, err := instance.GetRootDiskDevice(inst.Devices)
+			if err != nil {
+				continue
+			}
+
+			err = checkStoragePoolAllowed(tx, &info.Project, rootDiskDevice["pool"])
+			if err != nil {
+				violations = append(violations, fmt.Sprintf("Invalid root disk on instance %q of project %q: %v", inst.Name, info.Project.Name, err))
+			}
+		}
+
+		for _, vol := range info.Volumes {
+			err := checkStoragePoolAllowed(tx, &info.Project, vol.PoolName)
+			if err != nil {
+				violations = append(violations, fmt.Sprintf("Invalid custom volume %q of project %q: %v", vol.Name, info.Project.Name, err))
+			}
+		}
+	}
+
+	return violations, nil
+}
+
 // Check that limits.instances, i.e. the total limit of containers/virtual machines allocated
 // to the user is equal to or above the current count.
 func validateTotalInstanceCountLimit(instances []api.Instance, value, project string) error {
@@ -1506,6 +1634,65 @@ func AllowBackupCreation(tx *db.ClusterTx, projectName string) error {
 	return nil
 }
 
+// AllowStoragePoolUse returns an error if the project's restrictions don't allow placing volumes on the
+// storage pool.
+func AllowStoragePoolUse(tx *db.ClusterTx, projectName string, poolName string) error {
+	ctx := context.Background()
+	dbProject, err := cluster.GetProject(ctx, tx.Tx(), projectName)
+	if err != nil {
+		return err
+	}
+
+	project, err := dbProject.ToAPI(ctx, tx.Tx())
+	if err != nil {
+		return err
+	}
+
+	return checkStoragePoolAllowed(tx, project, poolName)
+}
+
+// checkRootDiskStoragePoolChange returns an error if the root disk of the devices moves to a storage pool that the
+// project isn't allowed to use.
+func checkRootDiskStoragePoolChange(tx *db.ClusterTx, project *api.Project, currentDevices map[string]map[string]string, devices map[string]map[string]string) error {
+	_, rootDiskDevice, err := instance.GetRootDiskDevice(devices)
+	if err != nil {
+		return nil
+	}
+
+	_, currentRootDiskDevice, _ := instance.GetRootDiskDevice(currentDevices)
+	if rootDiskDevice["pool"] == currentRootDiskDevice["pool"] {
+		return nil
+	}
+
+	return checkStoragePoolAllowed(tx, project, rootDiskDevice["pool"])
+}
+
+// checkStoragePoolAllowed returns an error if the project isn't allowed to use the storage pool based on its
+// restricted.storage.drivers setting.
+func checkStoragePoolAllowed(tx *db.ClusterTx, project *api.Project, poolName string) error {
+	// Skip loading the storage pool if the project doesn't restrict the drivers.
+	if poolName == "" || StoragePoolAllowed(project.Config, "") {
+		return nil
+	}
+
+	ctx := context.Background()
+	poolID, err := tx.GetStoragePoolID(ctx, poolName)
+	if err != nil {
+		return err
+	}
+
+	driver, err := tx.GetStoragePoolDriver(ctx, poolID)
+	if err != nil {
+		return err
+	}
+
+	if !StoragePoolAllowed(project.Config, driver) {
+		return api.StatusErrorf(http.StatusForbidden, "Storage pool %q using driver %q not allowed in project %q", poolName, driver, project.Name)
+	}
+
+	return nil
+}
+
 // AllowSnapshotCreation returns an error if any project-specific restriction is violated
 // when creating a new snapshot in a project.
 func AllowSnapshotCreation(p *api.Project) error {
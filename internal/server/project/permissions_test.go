@@ -153,6 +153,66 @@ func TestAllowInstanceCreation_AboveInstances(t *testing.T) {
 	assert.EqualError(t, err, `Reached maximum number of instances in project "p1"`)
 }
 
+// The instances already recorded in the project, including the one being added, must fit in the limits.
+func TestCheckInstanceLimits(t *testing.T) {
+	cases := []struct {
+		name      string
+		instances map[string]instancetype.Type
+		err       string
+	}{
+		{
+			name:      "below",
+			instances: map[string]instancetype.Type{"c1": instancetype.Container},
+		},
+		{
+			name:      "at type limit",
+			instances: map[string]instancetype.Type{"c1": instancetype.Container, "c2": instancetype.Container},
+		},
+		{
+			name:      "above type limit",
+			instances: map[string]instancetype.Type{"c1": instancetype.Container, "c2": instancetype.Container, "c3": instancetype.Container},
+			err:       `Reached maximum number of instances of type "container" in project "p1"`,
+		},
+		{
+			name:      "above instances limit",
+			instances: map[string]instancetype.Type{"c1": instancetype.Container, "c2": instancetype.Container, "vm1": instancetype.VM, "vm2": instancetype.VM},
+			err:       `Reached maximum number of instances in project "p1"`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tx, cleanup := db.NewTestClusterTx(t)
+			defer cleanup()
+
+			ctx := context.Background()
+			id, err := cluster.CreateProject(ctx, tx.Tx(), cluster.Project{Name: "p1"})
+			require.NoError(t, err)
+
+			err = cluster.CreateProjectConfig(ctx, tx.Tx(), id, map[string]string{"limits.containers": "2", "limits.instances": "3"})
+			require.NoError(t, err)
+
+			for name, instanceType := range c.instances {
+				_, err = cluster.CreateInstance(ctx, tx.Tx(), cluster.Instance{
+					Project:      "p1",
+					Name:         name,
+					Type:         instanceType,
+					Architecture: 1,
+					Node:         "none",
+				})
+				require.NoError(t, err)
+			}
+
+			err = project.CheckInstanceLimits(tx, "p1", instancetype.Container)
+			if c.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, c.err)
+			}
+		})
+	}
+}
+
 // If a direct targeting is blocked, the check fails.
 func TestCheckClusterTargetRestriction_RestrictedTrue(t *testing.T) {
 	tx, cleanup := db.NewTestClusterTx(t)
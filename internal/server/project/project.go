@@ -28,10 +28,20 @@ func Instance(projectName string, instanceName string) string {
 
 // DNS adds ".<project>" as a suffix to instance name when the given project name is not "default".
 func DNS(projectName string, instanceName string) string {
+	return DNSWithDefaultSuffix(projectName, instanceName, "")
+}
+
+// DNSWithDefaultSuffix adds ".<project>" as a suffix to instance name when the given project name is not "default".
+// Otherwise it adds ".<defaultSuffix>" as a suffix when defaultSuffix isn't empty.
+func DNSWithDefaultSuffix(projectName string, instanceName string, defaultSuffix string) string {
 	if projectName != Default {
 		return fmt.Sprintf("%s.%s", instanceName, projectName)
 	}
 
+	if defaultSuffix != "" {
+		return fmt.Sprintf("%s.%s", instanceName, defaultSuffix)
+	}
+
 	return instanceName
 }
 
@@ -159,6 +169,106 @@ func StorageBucketProjectFromRecord(p *api.Project) string {
 	return Default
 }
 
+// ImageProject returns the effective project to use for the images based on the requested project.
+// If the requested project has the "features.images" flag enabled then the requested project's info is returned,
+// otherwise the default project's info is returned.
+func ImageProject(ctx context.Context, c *db.Cluster, projectName string) (*api.Project, error) {
+	var p *api.Project
+	err := c.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		dbProject, err := cluster.GetProject(ctx, tx.Tx(), projectName)
+		if err != nil {
+			return fmt.Errorf("Failed loading project %q: %w", projectName, err)
+		}
+
+		p, err = dbProject.ToAPI(ctx, tx.Tx())
+		if err != nil {
+			return fmt.Errorf("Failed loading config for project %q: %w", projectName, err)
+		}
+
+		effectiveProjectName := ImageProjectFromRecord(p)
+		if effectiveProjectName == p.Name {
+			return nil
+		}
+
+		dbProject, err = cluster.GetProject(ctx, tx.Tx(), effectiveProjectName)
+		if err != nil {
+			return fmt.Errorf("Failed loading project %q: %w", effectiveProjectName, err)
+		}
+
+		p, err = dbProject.ToAPI(ctx, tx.Tx())
+		if err != nil {
+			return fmt.Errorf("Failed loading config for project %q: %w", effectiveProjectName, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// ImageProjectFromRecord returns the project name to use for the images based on the supplied project.
+// If the project supplied has the "features.images" flag enabled then the project name is returned,
+// otherwise the default project name is returned.
+func ImageProjectFromRecord(p *api.Project) string {
+	// Images only use the project specified if the project has the features.images feature enabled,
+	// otherwise the default project is used.
+	if util.IsTrue(p.Config["features.images"]) {
+		return p.Name
+	}
+
+	return Default
+}
+
+// ImageRemotesPrefix is the prefix of the project configuration keys defining image remotes.
+const ImageRemotesPrefix = "images.remotes."
+
+// ImageRemote returns the URL and protocol of the image remote with the given name, defined by the
+// "images.remotes.<name>.url" and "images.remotes.<name>.protocol" settings of the effective project for images.
+// The protocol defaults to "simplestreams".
+func ImageRemote(p *api.Project, name string) (string, string, bool) {
+	remoteURL := p.Config[ImageRemotesPrefix+name+".url"]
+	if name == "" || strings.Contains(name, ".") || remoteURL == "" {
+		return "", "", false
+	}
+
+	protocol := p.Config[ImageRemotesPrefix+name+".protocol"]
+	if protocol == "" {
+		protocol = "simplestreams"
+	}
+
+	return remoteURL, protocol, true
+}
+
+// ImageServerAllowed returns whether images can be downloaded from the server based on the "images.servers"
+// setting of the effective project for images. All servers are allowed if the setting is empty.
+// Servers are matched on their URL, also allowing any URL below a listed one. The image remotes of the project
+// are always allowed.
+func ImageServerAllowed(p *api.Project, server string) bool {
+	servers := util.SplitNTrimSpace(p.Config["images.servers"], ",", -1, true)
+	if len(servers) == 0 {
+		return true
+	}
+
+	for k, v := range p.Config {
+		if strings.HasPrefix(k, ImageRemotesPrefix) && strings.HasSuffix(k, ".url") {
+			servers = append(servers, v)
+		}
+	}
+
+	server = strings.TrimSuffix(server, "/")
+	for _, allowed := range servers {
+		allowed = strings.TrimSuffix(allowed, "/")
+		if server == allowed || strings.HasPrefix(server, allowed+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
 // NetworkProject returns the effective project name to use for the network based on the requested project.
 // If the requested project has the "features.networks" flag enabled then the requested project's name is returned,
 // otherwise the default project name is returned.
@@ -224,6 +334,24 @@ func NetworkAllowed(reqProjectConfig map[string]string, networkName string, isMa
 	return util.ValueInSlice(networkName, allowedRestrictedNetworks)
 }
 
+// StoragePoolAllowed returns whether access is allowed to a storage pool using a particular driver based on
+// projectConfig.
+func StoragePoolAllowed(reqProjectConfig map[string]string, poolDriver string) bool {
+	// If project is not restricted, then access to storage pool is allowed.
+	if util.IsFalseOrEmpty(reqProjectConfig["restricted"]) {
+		return true
+	}
+
+	// If restricted.storage.drivers is not set then allow access to all storage pools.
+	if reqProjectConfig["restricted.storage.drivers"] == "" {
+		return true
+	}
+
+	// Check if the storage pool's driver is in list of allowed drivers.
+	allowedDrivers := util.SplitNTrimSpace(reqProjectConfig["restricted.storage.drivers"], ",", -1, false)
+	return util.ValueInSlice(poolDriver, allowedDrivers)
+}
+
 // ProfileProject returns the effective project to use for the profile based on the requested project.
 // If the requested project has the "features.profiles" flag enabled then the requested project's info is returned,
 // otherwise the default project's info is returned.
@@ -276,6 +404,55 @@ func ProfileProjectFromRecord(p *api.Project) string {
 	return Default
 }
 
+// InstanceDefaultsPrefix is the prefix of the project configuration keys setting the default instance configuration.
+const InstanceDefaultsPrefix = "instances.defaults."
+
+// InstanceDefaults returns the default instance configuration set in the project configuration.
+// The project supplied should be the effective project for profiles (see ProfileProjectFromRecord).
+func InstanceDefaults(p *api.Project) map[string]string {
+	defaults := map[string]string{}
+	for k, v := range p.Config {
+		key, found := strings.CutPrefix(k, InstanceDefaultsPrefix)
+		if found {
+			defaults[key] = v
+		}
+	}
+
+	return defaults
+}
+
+// ApplyInstanceDefaults returns the instance configuration with the default keys added, unless the key is already
+// set in the instance configuration or in any of the instance's profiles.
+func ApplyInstanceDefaults(config map[string]string, defaults map[string]string, profiles []api.Profile) map[string]string {
+	if len(defaults) == 0 {
+		return config
+	}
+
+	if config == nil {
+		config = make(map[string]string, len(defaults))
+	}
+
+	for k, v := range defaults {
+		_, found := config[k]
+		if found {
+			continue
+		}
+
+		for _, profile := range profiles {
+			_, found = profile.Config[k]
+			if found {
+				break
+			}
+		}
+
+		if !found {
+			config[k] = v
+		}
+	}
+
+	return config
+}
+
 // NetworkZoneProject returns the effective project name to use for network zone based on the requested project.
 // If the requested project has the "features.networks.zones" flag enabled then the requested project's name is
 // returned, otherwise the default project name is returned.
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/lxc/incus/internal/server/project"
+	"github.com/lxc/incus/shared/api"
 )
 
 func ExampleInstance() {
@@ -44,3 +45,88 @@ func ExampleStorageVolume() {
 	// Output: default_test
 	// project_name_test1
 }
+
+func ExampleDNSWithDefaultSuffix() {
+	fmt.Println(project.DNS(project.Default, "c1"))
+	fmt.Println(project.DNS("tenant", "c1"))
+	fmt.Println(project.DNSWithDefaultSuffix(project.Default, "c1", "br0"))
+	fmt.Println(project.DNSWithDefaultSuffix("tenant", "c1", "br0"))
+
+	// Output: c1
+	// c1.tenant
+	// c1.br0
+	// c1.tenant
+}
+
+func ExampleApplyInstanceDefaults() {
+	p := &api.Project{
+		Name: "tenant",
+		Config: map[string]string{
+			"features.profiles":                   "true",
+			"instances.defaults.limits.cpu":       "2",
+			"instances.defaults.limits.memory":    "2GiB",
+			"instances.defaults.security.nesting": "true",
+		},
+	}
+
+	profiles := []api.Profile{{Name: "default", ProfilePut: api.ProfilePut{Config: map[string]string{"limits.memory": "4GiB"}}}}
+
+	config := project.ApplyInstanceDefaults(map[string]string{"limits.cpu": "4"}, project.InstanceDefaults(p), profiles)
+	fmt.Println(config)
+
+	// Output: map[limits.cpu:4 security.nesting:true]
+}
+
+func ExampleImageServerAllowed() {
+	p := &api.Project{
+		Name:   "tenant",
+		Config: map[string]string{"images.servers": "https://images.example.net/, https://mirror.example.net/images"},
+	}
+
+	fmt.Println(project.ImageServerAllowed(p, "https://images.example.net"))
+	fmt.Println(project.ImageServerAllowed(p, "https://mirror.example.net/images/alpine.tar.xz"))
+	fmt.Println(project.ImageServerAllowed(p, "https://mirror.example.net/images-other"))
+	fmt.Println(project.ImageServerAllowed(p, "https://other.example.net"))
+	fmt.Println(project.ImageServerAllowed(&api.Project{Name: "default"}, "https://other.example.net"))
+
+	// Output: true
+	// true
+	// false
+	// false
+	// true
+}
+
+func ExampleStoragePoolAllowed() {
+	config := map[string]string{"restricted": "true", "restricted.storage.drivers": "ceph, lvm"}
+
+	fmt.Println(project.StoragePoolAllowed(config, "ceph"))
+	fmt.Println(project.StoragePoolAllowed(config, "dir"))
+	fmt.Println(project.StoragePoolAllowed(map[string]string{"restricted": "true"}, "dir"))
+	fmt.Println(project.StoragePoolAllowed(map[string]string{"restricted.storage.drivers": "ceph"}, "dir"))
+
+	// Output: true
+	// false
+	// true
+	// true
+}
+
+func ExampleImageRemote() {
+	p := &api.Project{
+		Name: "tenant",
+		Config: map[string]string{
+			"images.remotes.mirror.url":      "https://mirror.example.net",
+			"images.remotes.mirror.protocol": "incus",
+			"images.remotes.images.url":      "https://images.example.net",
+		},
+	}
+
+	fmt.Println(project.ImageRemote(p, "mirror"))
+	fmt.Println(project.ImageRemote(p, "images"))
+
+	_, _, found := project.ImageRemote(p, "other")
+	fmt.Println(found)
+
+	// Output: https://mirror.example.net incus true
+	// https://images.example.net simplestreams true
+	// false
+}
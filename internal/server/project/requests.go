@@ -0,0 +1,75 @@
+package project
+
+import (
+	"sync"
+	"time"
+)
+
+// RequestQuotaWindow is the period over which project API requests are counted.
+const RequestQuotaWindow = time.Minute
+
+// RequestQuota keeps track of the number of API requests made against each project.
+type RequestQuota struct {
+	mu      sync.Mutex
+	windows map[string]*requestWindow
+	now     func() time.Time
+}
+
+type requestWindow struct {
+	start time.Time
+	count int64
+}
+
+// NewRequestQuota returns a new RequestQuota.
+func NewRequestQuota() *RequestQuota {
+	return &RequestQuota{
+		windows: map[string]*requestWindow{},
+		now:     time.Now,
+	}
+}
+
+// window returns the current window for the project, starting a new one if the previous one is over.
+// Must be called with the lock held.
+func (q *RequestQuota) window(projectName string) *requestWindow {
+	now := q.now()
+
+	w, ok := q.windows[projectName]
+	if !ok || now.Sub(w.start) >= RequestQuotaWindow {
+		w = &requestWindow{start: now}
+		q.windows[projectName] = w
+	}
+
+	return w
+}
+
+// Allow records a request against the project if it fits within the limit.
+// Returns false if the limit has already been reached for the current window.
+func (q *RequestQuota) Allow(projectName string, limit int64) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	w := q.window(projectName)
+	if w.count >= limit {
+		return false
+	}
+
+	w.count++
+
+	return true
+}
+
+// Usage returns the number of requests recorded against the project in the current window.
+func (q *RequestQuota) Usage(projectName string) int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.window(projectName).count
+}
+
+// Forget removes any record of the project (used when a project is deleted or renamed).
+func (q *RequestQuota) Forget(projectName string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.windows, projectName)
+}
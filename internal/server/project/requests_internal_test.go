@@ -0,0 +1,32 @@
+package project
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestQuota(t *testing.T) {
+	now := time.Now()
+
+	q := NewRequestQuota()
+	q.now = func() time.Time { return now }
+
+	assert.True(t, q.Allow("p1", 2))
+	assert.True(t, q.Allow("p1", 2))
+	assert.False(t, q.Allow("p1", 2))
+	assert.Equal(t, int64(2), q.Usage("p1"))
+
+	// Other projects are tracked separately.
+	assert.True(t, q.Allow("p2", 2))
+	assert.Equal(t, int64(1), q.Usage("p2"))
+
+	// A new window resets the count.
+	now = now.Add(RequestQuotaWindow)
+	assert.Equal(t, int64(0), q.Usage("p1"))
+	assert.True(t, q.Allow("p1", 2))
+
+	q.Forget("p1")
+	assert.Equal(t, int64(0), q.Usage("p1"))
+}
@@ -40,4 +40,7 @@ const (
 
 	// HeaderForwardedProtocol is the forwarded protocol field in request header.
 	HeaderForwardedProtocol = "X-Incus-forwarded-protocol"
+
+	// HeaderTimeout is the time the client is willing to wait for a response, as a duration (e.g. "30s").
+	HeaderTimeout = "X-Incus-timeout"
 )
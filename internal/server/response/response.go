@@ -237,18 +237,24 @@ func (r *syncResponse) String() string {
 
 // Error response.
 type errorResponse struct {
-	code int    // Code to return in both the HTTP header and Code field of the response body.
-	msg  string // Message to return in the Error field of the response body.
+	code   int    // Code to return in both the HTTP header and Code field of the response body.
+	msg    string // Message to return in the Error field of the response body.
+	reason string // Stable reason to return in the ErrorReason field of the response body.
+}
+
+// newErrorResponse returns an error response carrying the reason of err, if any.
+func newErrorResponse(code int, msg string, err error) *errorResponse {
+	return &errorResponse{code: code, msg: msg, reason: api.StatusErrorReason(err)}
 }
 
 // ErrorResponse returns an error response with the given code and msg.
 func ErrorResponse(code int, msg string) Response {
-	return &errorResponse{code, msg}
+	return &errorResponse{code: code, msg: msg}
 }
 
 // BadRequest returns a bad request response (400) with the given error.
 func BadRequest(err error) Response {
-	return &errorResponse{http.StatusBadRequest, err.Error()}
+	return newErrorResponse(http.StatusBadRequest, err.Error(), err)
 }
 
 // Conflict returns a conflict response (409) with the given error.
@@ -258,22 +264,21 @@ func Conflict(err error) Response {
 		message = err.Error()
 	}
 
-	return &errorResponse{http.StatusConflict, message}
+	return newErrorResponse(http.StatusConflict, message, err)
 }
 
 // Forbidden returns a forbidden response (403) with the given error.
 func Forbidden(err error) Response {
-	message := "not authorized"
-	if err != nil {
-		message = err.Error()
+	if err == nil {
+		return &errorResponse{code: http.StatusForbidden, msg: "not authorized", reason: api.ErrorReasonNotAuthorized}
 	}
 
-	return &errorResponse{http.StatusForbidden, message}
+	return newErrorResponse(http.StatusForbidden, err.Error(), err)
 }
 
 // InternalError returns an internal error response (500) with the given error.
 func InternalError(err error) Response {
-	return &errorResponse{http.StatusInternalServerError, err.Error()}
+	return newErrorResponse(http.StatusInternalServerError, err.Error(), err)
 }
 
 // NotFound returns a not found response (404) with the given error.
@@ -283,7 +288,7 @@ func NotFound(err error) Response {
 		message = err.Error()
 	}
 
-	return &errorResponse{http.StatusNotFound, message}
+	return newErrorResponse(http.StatusNotFound, message, err)
 }
 
 // NotImplemented returns a not implemented response (501) with the given error.
@@ -293,13 +298,23 @@ func NotImplemented(err error) Response {
 		message = err.Error()
 	}
 
-	return &errorResponse{http.StatusNotImplemented, message}
+	return newErrorResponse(http.StatusNotImplemented, message, err)
 }
 
 // PreconditionFailed returns a precondition failed response (412) with the
 // given error.
 func PreconditionFailed(err error) Response {
-	return &errorResponse{http.StatusPreconditionFailed, err.Error()}
+	return newErrorResponse(http.StatusPreconditionFailed, err.Error(), err)
+}
+
+// TooManyRequests returns a too many requests response (429) with the given error.
+func TooManyRequests(err error) Response {
+	message := "too many requests"
+	if err != nil {
+		message = err.Error()
+	}
+
+	return newErrorResponse(http.StatusTooManyRequests, message, err)
 }
 
 // Unavailable return an unavailable response (503) with the given error.
@@ -309,7 +324,7 @@ func Unavailable(err error) Response {
 		message = err.Error()
 	}
 
-	return &errorResponse{http.StatusServiceUnavailable, message}
+	return newErrorResponse(http.StatusServiceUnavailable, message, err)
 }
 
 func (r *errorResponse) String() string {
@@ -328,9 +343,10 @@ func (r *errorResponse) Render(w http.ResponseWriter) error {
 	}
 
 	resp := api.ResponseRaw{
-		Type:  api.ErrorResponse,
-		Error: r.msg,
-		Code:  r.code, // Set the error code in the Code field of the response body.
+		Type:        api.ErrorResponse,
+		Error:       r.msg,
+		Code:        r.code, // Set the error code in the Code field of the response body.
+		ErrorReason: r.reason,
 	}
 
 	err := json.NewEncoder(output).Encode(resp)
@@ -557,5 +573,5 @@ func Unauthorized(err error) Response {
 		message = err.Error()
 	}
 
-	return &errorResponse{http.StatusUnauthorized, message}
+	return newErrorResponse(http.StatusUnauthorized, message, err)
 }
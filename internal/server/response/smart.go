@@ -25,7 +25,7 @@ func SmartError(err error) Response {
 
 	statusCode, found := api.StatusErrorMatch(err)
 	if found {
-		return &errorResponse{statusCode, err.Error()}
+		return newErrorResponse(statusCode, err.Error(), err)
 	}
 
 	for httpStatusCode, checkErrs := range httpResponseErrors {
@@ -33,17 +33,17 @@ func SmartError(err error) Response {
 			if errors.Is(err, checkErr) {
 				if err != checkErr {
 					// If the error has been wrapped return the top-level error message.
-					return &errorResponse{httpStatusCode, err.Error()}
+					return newErrorResponse(httpStatusCode, err.Error(), err)
 				}
 
 				// If the error hasn't been wrapped, replace the error message with the generic
 				// HTTP status text.
-				return &errorResponse{httpStatusCode, http.StatusText(httpStatusCode)}
+				return newErrorResponse(httpStatusCode, http.StatusText(httpStatusCode), err)
 			}
 		}
 	}
 
-	return &errorResponse{http.StatusInternalServerError, err.Error()}
+	return newErrorResponse(http.StatusInternalServerError, err.Error(), err)
 }
 
 // IsNotFoundError returns true if the error is considered a Not Found error.
@@ -126,6 +126,53 @@ func InstancePlacementRun(ctx context.Context, l logger.Logger, s *state.State,
 		return rv, nil
 	}
 
+	getClusterMemberFeaturesFunc := func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var memberName string
+
+		err := starlark.UnpackArgs(b.Name(), args, kwargs, "member_name", &memberName)
+		if err != nil {
+			return nil, err
+		}
+
+		var features *api.ResourcesFeatures
+
+		// Get the local features.
+		if memberName == s.ServerName {
+			features = s.OS.Features()
+			features.IdmappedMountsPolicy = s.LocalConfig.IdmappedMounts()
+		} else {
+			// Get remote member features.
+			var targetMember *db.NodeInfo
+			for i := range candidateMembers {
+				if candidateMembers[i].Name == memberName {
+					targetMember = &candidateMembers[i]
+					break
+				}
+			}
+
+			if targetMember == nil {
+				return starlark.String("Invalid member name"), nil
+			}
+
+			client, err := cluster.Connect(targetMember.Address, s.Endpoints.NetworkCert(), s.ServerCert(), nil, true)
+			if err != nil {
+				return nil, err
+			}
+
+			features, err = client.GetServerResourceFeatures()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		rv, err := StarlarkMarshal(features)
+		if err != nil {
+			return nil, fmt.Errorf("Marshalling member features for %q failed: %w", memberName, err)
+		}
+
+		return rv, nil
+	}
+
 	getClusterMemberStateFunc := func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
 		var memberName string
 
@@ -304,6 +351,7 @@ func InstancePlacementRun(ctx context.Context, l logger.Logger, s *state.State,
 		"log_warn":                     starlark.NewBuiltin("log_warn", logFunc),
 		"log_error":                    starlark.NewBuiltin("log_error", logFunc),
 		"set_target":                   starlark.NewBuiltin("set_target", setTargetFunc),
+		"get_cluster_member_features":  starlark.NewBuiltin("get_cluster_member_features", getClusterMemberFeaturesFunc),
 		"get_cluster_member_resources": starlark.NewBuiltin("get_cluster_member_resources", getClusterMemberResourcesFunc),
 		"get_cluster_member_state":     starlark.NewBuiltin("get_cluster_member_state", getClusterMemberStateFunc),
 		"get_instance_resources":       starlark.NewBuiltin("get_instance_resources", getInstanceResourcesFunc),
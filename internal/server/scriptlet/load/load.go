@@ -20,6 +20,7 @@ func InstancePlacementCompile(src string) (*starlark.Program, error) {
 			"log_warn",
 			"log_error",
 			"set_target",
+			"get_cluster_member_features",
 			"get_cluster_member_resources",
 			"get_cluster_member_state",
 			"get_instance_resources",
@@ -0,0 +1,58 @@
+package seccomp
+
+import (
+	"sync"
+	"time"
+)
+
+// AuditWindow is the period over which audited system calls are counted against the limit.
+const AuditWindow = time.Minute
+
+// auditLimiter limits the number of audit events sent for each instance.
+type auditLimiter struct {
+	mu      sync.Mutex
+	windows map[string]*auditWindow
+	now     func() time.Time
+}
+
+type auditWindow struct {
+	start   time.Time
+	count   int64
+	dropped int64
+}
+
+func newAuditLimiter() *auditLimiter {
+	return &auditLimiter{
+		windows: map[string]*auditWindow{},
+		now:     time.Now,
+	}
+}
+
+// allow records an audit event for the instance if it fits within the limit.
+// When allowed, it also returns how many events were dropped since the last one was sent.
+func (l *auditLimiter) allow(instance string, limit int64) (bool, int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	w, ok := l.windows[instance]
+	if !ok {
+		w = &auditWindow{start: now}
+		l.windows[instance] = w
+	} else if now.Sub(w.start) >= AuditWindow {
+		w.start = now
+		w.count = 0
+	}
+
+	if w.count >= limit {
+		w.dropped++
+		return false, 0
+	}
+
+	w.count++
+	dropped := w.dropped
+	w.dropped = 0
+
+	return true, dropped
+}
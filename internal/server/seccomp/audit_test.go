@@ -0,0 +1,38 @@
+package seccomp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLimiter(t *testing.T) {
+	now := time.Now()
+
+	l := newAuditLimiter()
+	l.now = func() time.Time { return now }
+
+	ok, dropped := l.allow("c1", 2)
+	assert.True(t, ok)
+	assert.Equal(t, int64(0), dropped)
+
+	ok, _ = l.allow("c1", 2)
+	assert.True(t, ok)
+
+	ok, _ = l.allow("c1", 2)
+	assert.False(t, ok)
+
+	ok, _ = l.allow("c1", 2)
+	assert.False(t, ok)
+
+	// Other instances are tracked separately.
+	ok, _ = l.allow("c2", 2)
+	assert.True(t, ok)
+
+	// A new window reports the dropped events.
+	now = now.Add(AuditWindow)
+	ok, dropped = l.allow("c1", 2)
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), dropped)
+}
@@ -0,0 +1,94 @@
+package seccomp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Profiles is the library of named system call interception profiles.
+// Each profile enables a set of interception options and can be selected through the
+// `security.syscalls.intercept.profiles` instance option.
+var Profiles = map[string]map[string]string{
+	// Processes creating a limited subset of char/block devices.
+	"device-nodes": {
+		"security.syscalls.intercept.mknod": "true",
+	},
+
+	// Container managers (like Docker) running inside of the instance.
+	"container-runtime": {
+		"security.syscalls.intercept.bpf":         "true",
+		"security.syscalls.intercept.bpf.devices": "true",
+		"security.syscalls.intercept.mknod":       "true",
+		"security.syscalls.intercept.setxattr":    "true",
+	},
+
+	// Tools relying on `sysinfo` to report the instance's resources.
+	"accurate-sysinfo": {
+		"security.syscalls.intercept.sysinfo": "true",
+	},
+
+	// Workloads needing to raise their process priority.
+	"realtime-scheduling": {
+		"security.syscalls.intercept.sched_setscheduler": "true",
+	},
+}
+
+// profileNames returns the names of the profiles listed in the given value.
+func profileNames(value string) []string {
+	names := []string{}
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// ValidateProfiles checks that all the profiles in the comma-separated list exist.
+func ValidateProfiles(value string) error {
+	for _, name := range profileNames(value) {
+		_, ok := Profiles[name]
+		if !ok {
+			known := make([]string, 0, len(Profiles))
+			for profileName := range Profiles {
+				known = append(known, profileName)
+			}
+
+			sort.Strings(known)
+
+			return fmt.Errorf("Unknown system call interception profile %q (supported profiles: %s)", name, strings.Join(known, ", "))
+		}
+	}
+
+	return nil
+}
+
+// ProfileConfig returns a copy of the instance configuration with the options of its selected profiles applied.
+// Options explicitly set on the instance take precedence over the profiles.
+func ProfileConfig(config map[string]string) map[string]string {
+	names := profileNames(config["security.syscalls.intercept.profiles"])
+	if len(names) == 0 {
+		return config
+	}
+
+	result := make(map[string]string, len(config))
+	for k, v := range config {
+		result[k] = v
+	}
+
+	for _, name := range names {
+		for k, v := range Profiles[name] {
+			_, ok := config[k]
+			if ok {
+				continue
+			}
+
+			result[k] = v
+		}
+	}
+
+	return result
+}
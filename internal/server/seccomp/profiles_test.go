@@ -0,0 +1,31 @@
+package seccomp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateProfiles(t *testing.T) {
+	assert.NoError(t, ValidateProfiles(""))
+	assert.NoError(t, ValidateProfiles("device-nodes, accurate-sysinfo"))
+	assert.Error(t, ValidateProfiles("device-nodes,unknown"))
+}
+
+func TestProfileConfig(t *testing.T) {
+	config := map[string]string{
+		"security.syscalls.intercept.profiles": "container-runtime",
+		"security.syscalls.intercept.bpf":      "false",
+	}
+
+	result := ProfileConfig(config)
+	assert.Equal(t, "true", result["security.syscalls.intercept.mknod"])
+	assert.Equal(t, "true", result["security.syscalls.intercept.setxattr"])
+
+	// Explicitly set options take precedence.
+	assert.Equal(t, "false", result["security.syscalls.intercept.bpf"])
+
+	// The original configuration isn't modified.
+	_, ok := config["security.syscalls.intercept.mknod"]
+	assert.False(t, ok)
+}
@@ -630,7 +630,7 @@ func ProfilePath(c Instance) string {
 
 // InstanceNeedsPolicy returns whether the instance needs a policy or not.
 func InstanceNeedsPolicy(c Instance) bool {
-	config := c.ExpandedConfig()
+	config := ProfileConfig(c.ExpandedConfig())
 
 	// Check for text keys
 	keys := []string{
@@ -691,7 +691,7 @@ func InstanceNeedsIntercept(s *state.State, c Instance) (bool, error) {
 		return false, nil
 	}
 
-	config := c.ExpandedConfig()
+	config := ProfileConfig(c.ExpandedConfig())
 
 	var keys = map[string]func(state *state.State) error{
 		"security.syscalls.intercept.mknod":              lxcSupportSeccompNotify,
@@ -734,7 +734,7 @@ func MakePidFd(pid int, s *state.State) (int, *os.File) {
 }
 
 func seccompGetPolicyContent(s *state.State, c Instance) (string, error) {
-	config := c.ExpandedConfig()
+	config := ProfileConfig(c.ExpandedConfig())
 
 	// Full policy override
 	raw := config["raw.seccomp"]
@@ -881,9 +881,10 @@ func DeleteProfile(c Instance) {
 
 // Server defines a seccomp server.
 type Server struct {
-	s    *state.State
-	path string
-	l    net.Listener
+	s     *state.State
+	path  string
+	l     net.Listener
+	audit *auditLimiter
 }
 
 // Iovec defines an iovec to move data between kernel and userspace.
@@ -1063,9 +1064,10 @@ func NewSeccompServer(s *state.State, path string, findPID func(pid int32, state
 
 	// Start the server
 	server := Server{
-		s:    s,
-		path: path,
-		l:    l,
+		s:     s,
+		path:  path,
+		l:     l,
+		audit: newAuditLimiter(),
 	}
 
 	go func() {
@@ -2251,7 +2253,7 @@ func (s *Server) HandleBpfSyscall(c Instance, siov *Iovec) int {
 	defer logger.Debug("Handling bpf syscall", ctx)
 	var bpfCmd, bpfProgType, bpfAttachType C.int
 
-	if util.IsFalseOrEmpty(c.ExpandedConfig()["security.syscalls.intercept.bpf.devices"]) {
+	if util.IsFalseOrEmpty(ProfileConfig(c.ExpandedConfig())["security.syscalls.intercept.bpf.devices"]) {
 		ctx["syscall_continue"] = "true"
 		ctx["syscall_handler_reason"] = "No bpf policy specified"
 		C.seccomp_notify_update_response(siov.resp, 0, C.uint32_t(seccompUserNotifFlagContinue))
@@ -2295,24 +2297,94 @@ func (s *Server) HandleBpfSyscall(c Instance, siov *Iovec) int {
 }
 
 func (s *Server) handleSyscall(c Instance, siov *Iovec) int {
+	var name string
+	var errno int
+
 	switch int(C.seccomp_notify_get_syscall(siov.req, siov.resp)) {
 	case incusSeccompNotifyMknod:
-		return s.HandleMknodSyscall(c, siov)
+		name = "mknod"
+		errno = s.HandleMknodSyscall(c, siov)
 	case incusSeccompNotifyMknodat:
-		return s.HandleMknodatSyscall(c, siov)
+		name = "mknodat"
+		errno = s.HandleMknodatSyscall(c, siov)
 	case incusSeccompNotifySetxattr:
-		return s.HandleSetxattrSyscall(c, siov)
+		name = "setxattr"
+		errno = s.HandleSetxattrSyscall(c, siov)
 	case incusSeccompNotifyMount:
-		return s.HandleMountSyscall(c, siov)
+		name = "mount"
+		errno = s.HandleMountSyscall(c, siov)
 	case incusSeccompNotifyBpf:
-		return s.HandleBpfSyscall(c, siov)
+		name = "bpf"
+		errno = s.HandleBpfSyscall(c, siov)
 	case incusSeccompNotifySchedSetscheduler:
-		return s.HandleSchedSetschedulerSyscall(c, siov)
+		name = "sched_setscheduler"
+		errno = s.HandleSchedSetschedulerSyscall(c, siov)
 	case incusSeccompNotifySysinfo:
-		return s.HandleSysinfoSyscall(c, siov)
+		name = "sysinfo"
+		errno = s.HandleSysinfoSyscall(c, siov)
+	default:
+		return int(-C.EINVAL)
+	}
+
+	s.auditSyscall(c, siov, name, errno)
+
+	return errno
+}
+
+// auditSyscall reports an intercepted system call along with the decision taken on it through the events stream.
+func (s *Server) auditSyscall(c Instance, siov *Iovec, name string, errno int) {
+	config := c.ExpandedConfig()
+	if util.IsFalseOrEmpty(config["security.syscalls.intercept.audit"]) {
+		return
+	}
+
+	limit := int64(60)
+	if config["security.syscalls.intercept.audit.limit"] != "" {
+		value, err := strconv.ParseInt(config["security.syscalls.intercept.audit.limit"], 10, 64)
+		if err == nil {
+			limit = value
+		}
+	}
+
+	ok, dropped := s.audit.allow(project.Instance(c.Project().Name, c.Name()), limit)
+	if !ok {
+		return
+	}
+
+	decision := "allowed"
+	if uint32(siov.resp.flags)&seccompUserNotifFlagContinue != 0 {
+		decision = "continued"
+	} else if errno != 0 {
+		decision = "denied"
+	}
+
+	args := make([]string, 0, len(siov.req.data.args))
+	for _, arg := range siov.req.data.args {
+		args = append(args, fmt.Sprintf("%#x", uint64(arg)))
 	}
 
-	return int(-C.EINVAL)
+	ctx := map[string]string{
+		"instance": c.Name(),
+		"project":  c.Project().Name,
+		"syscall":  name,
+		"pid":      fmt.Sprintf("%d", siov.req.pid),
+		"args":     strings.Join(args, ","),
+		"decision": decision,
+		"errno":    fmt.Sprintf("%d", -errno),
+	}
+
+	if dropped > 0 {
+		ctx["dropped"] = fmt.Sprintf("%d", dropped)
+	}
+
+	err := s.s.Events.Send(c.Project().Name, api.EventTypeSyscall, api.EventLogging{
+		Message: fmt.Sprintf("Intercepted %s system call", name),
+		Level:   "info",
+		Context: ctx,
+	})
+	if err != nil {
+		logger.Warn("Failed sending system call audit event", logger.Ctx{"instance": c.Name(), "project": c.Project().Name, "err": err})
+	}
 }
 
 const seccompUserNotifFlagContinue uint32 = 0x00000001
@@ -1342,7 +1342,8 @@ func (b *backend) RefreshCustomVolume(projectName string, srcProjectName string,
 			}
 		}
 
-		ctx, cancel := context.WithCancel(context.Background())
+		// Stop the transfer if the operation is cancelled.
+		ctx, cancel := context.WithCancel(op.Context())
 
 		// Use in-memory pipe pair to simulate a connection between the sender and receiver.
 		aEnd, bEnd := memorypipe.NewPipePair(ctx)
@@ -4559,6 +4560,11 @@ func (b *backend) CreateCustomVolumeFromCopy(projectName string, srcProjectName
 			revert.Add(func() { _ = VolumeDBDelete(b, projectName, newSnapshotName, vol.Type()) })
 		}
 
+		// Don't start copying if the operation was cancelled in the meantime.
+		if op.Context().Err() != nil {
+			return fmt.Errorf("Volume copy cancelled")
+		}
+
 		err = b.driver.CreateVolumeFromCopy(vol, srcVol, snapshots, false, op)
 		if err != nil {
 			return err
@@ -4616,7 +4622,8 @@ func (b *backend) CreateCustomVolumeFromCopy(projectName string, srcProjectName
 		}
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
+	// Stop the transfer if the operation is cancelled.
+	ctx, cancel := context.WithCancel(op.Context())
 
 	// Use in-memory pipe pair to simulate a connection between the sender and receiver.
 	aEnd, bEnd := memorypipe.NewPipePair(ctx)
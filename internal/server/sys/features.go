@@ -0,0 +1,45 @@
+//go:build linux && cgo && !agent
+
+package sys
+
+import (
+	"github.com/lxc/incus/shared/api"
+)
+
+// Features returns the kernel, LXC and AppArmor features detected at startup.
+func (s *OS) Features() *api.ResourcesFeatures {
+	features := api.ResourcesFeatures{
+		Kernel: map[string]bool{
+			"close_range":               s.CloseRange,
+			"container_core_scheduling": s.ContainerCoreScheduling,
+			"core_scheduling":           s.CoreScheduling,
+			"idmapped_mounts":           s.IdmappedMounts,
+			"native_terminals":          s.NativeTerminals,
+			"netnsid_getifaddrs":        s.NetnsGetifaddrs,
+			"nodev":                     s.Nodev,
+			"pidfd_setns":               s.PidFdSetns,
+			"pidfds":                    s.PidFds,
+			"running_in_userns":         s.RunningInUserNS,
+			"seccomp_listener":          s.SeccompListener,
+			"seccomp_listener_addfd":    s.SeccompListenerAddfd,
+			"seccomp_listener_continue": s.SeccompListenerContinue,
+			"uevent_injection":          s.UeventInjection,
+			"unpriv_fscaps":             s.VFS3Fscaps,
+		},
+		LXC: map[string]bool{},
+		AppArmor: map[string]bool{
+			"admin":     s.AppArmorAdmin,
+			"available": s.AppArmorAvailable,
+			"confined":  s.AppArmorConfined,
+			"stacked":   s.AppArmorStacked,
+			"stacking":  s.AppArmorStacking,
+		},
+		CGroupLayout: s.CGInfo.Mode(),
+	}
+
+	for name, supported := range s.LXCFeatures {
+		features.LXC[name] = supported
+	}
+
+	return &features
+}
@@ -26,16 +26,21 @@ import (
 // Accepts a title to prefix the JSON log with, a *bytes.Buffer containing the JSON and a logger to use for
 // logging the JSON (allowing for custom context to be added to the log).
 func DebugJSON(title string, r *bytes.Buffer, l logger.Logger) {
+	LogJSON(title, r, l.Debug)
+}
+
+// LogJSON pretty prints a JSON buffer using the given logging function.
+func LogJSON(title string, r *bytes.Buffer, log func(msg string, ctx ...logger.Ctx)) {
 	pretty := &bytes.Buffer{}
 	err := json.Indent(pretty, r.Bytes(), "\t", "\t")
 	if err != nil {
-		l.Debug("Error indenting JSON", logger.Ctx{"err": err})
+		log("Error indenting JSON", logger.Ctx{"err": err})
 		return
 	}
 
 	// Print the JSON without the last "\n"
 	str := pretty.String()
-	l.Debug(fmt.Sprintf("%s\n\t%s", title, str[0:len(str)-1]))
+	log(fmt.Sprintf("%s\n\t%s", title, str[0:len(str)-1]))
 }
 
 // WriteJSON encodes the body as JSON and sends it back to the client
@@ -153,8 +158,10 @@ type ContextAwareRequest interface {
 // CheckTrustState checks whether the given client certificate is trusted
 // (i.e. it has a valid time span and it belongs to the given list of trusted
 // certificates).
+// When trusting CA-signed certificates, isRevoked is called (if not nil) to check whether the certificate was revoked
+// by the CA. Certificates in the list of trusted certificates aren't checked for revocation.
 // Returns whether or not the certificate is trusted, and the fingerprint of the certificate.
-func CheckTrustState(cert x509.Certificate, trustedCerts map[string]x509.Certificate, networkCert *localtls.CertInfo, trustCACertificates bool) (bool, string) {
+func CheckTrustState(cert x509.Certificate, trustedCerts map[string]x509.Certificate, networkCert *localtls.CertInfo, trustCACertificates bool, isRevoked func(cert *x509.Certificate, issuer *x509.Certificate) bool) (bool, string) {
 	// Extra validity check (should have been caught by TLS stack)
 	if time.Now().Before(cert.NotBefore) || time.Now().After(cert.NotAfter) {
 		return false, ""
@@ -176,7 +183,10 @@ func CheckTrustState(cert x509.Certificate, trustedCerts map[string]x509.Certifi
 			}
 
 			// Certificate not revoked, so trust it as is signed by CA cert.
-			return true, localtls.CertFingerprint(&cert)
+			// If OCSP or a CRL report it as revoked, it may still be explicitly trusted below.
+			if isRevoked == nil || !isRevoked(&cert, ca) {
+				return true, localtls.CertFingerprint(&cert)
+			}
 		}
 	}
 
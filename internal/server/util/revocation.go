@@ -0,0 +1,243 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/lxc/incus/shared/logger"
+	localtls "github.com/lxc/incus/shared/tls"
+)
+
+// revocationTimeout is the maximum time given to each OCSP or CRL request.
+const revocationTimeout = 5 * time.Second
+
+// revocationErrorTTL is the maximum time for which a failed revocation check is cached.
+const revocationErrorTTL = time.Minute
+
+// revocationMaxSize is the maximum size of an OCSP response or CRL.
+const revocationMaxSize = 10 * 1024 * 1024
+
+// RevocationConfig represents the revocation checks to run against a certificate.
+type RevocationConfig struct {
+	// Whether to query the OCSP responders listed in the certificate.
+	OCSP bool
+
+	// Whether to fetch the CRLs listed in the certificate.
+	CRL bool
+
+	// Whether to consider the certificate revoked when its status can't be determined.
+	FailClosed bool
+
+	// How long to cache the status of a certificate, 0 disabling the cache.
+	CacheTTL time.Duration
+}
+
+// RevocationChecker checks whether certificates were revoked by their issuer using OCSP or CRLs.
+// The status of each certificate is cached to avoid network requests on every connection.
+type RevocationChecker struct {
+	client *http.Client
+
+	cache   map[string]revocationCacheEntry
+	cacheMu sync.Mutex
+}
+
+type revocationCacheEntry struct {
+	revoked bool
+	expiry  time.Time
+}
+
+// NewRevocationChecker returns a new RevocationChecker with an empty cache.
+func NewRevocationChecker() *RevocationChecker {
+	return &RevocationChecker{
+		client: &http.Client{Timeout: revocationTimeout},
+		cache:  map[string]revocationCacheEntry{},
+	}
+}
+
+// Flush removes all the cached certificate statuses.
+func (c *RevocationChecker) Flush() {
+	c.cacheMu.Lock()
+	c.cache = map[string]revocationCacheEntry{}
+	c.cacheMu.Unlock()
+}
+
+// Revoked returns whether the certificate was revoked by its issuer.
+// When the status of the certificate can't be determined, the certificate is considered revoked only if
+// config.FailClosed is set. Certificates which don't list any OCSP responder or CRL are considered valid.
+func (c *RevocationChecker) Revoked(cert *x509.Certificate, issuer *x509.Certificate, config RevocationConfig) bool {
+	if !config.OCSP && !config.CRL {
+		return false
+	}
+
+	cacheKey := fmt.Sprintf("%s|%s", localtls.CertFingerprint(issuer), cert.SerialNumber.String())
+
+	if config.CacheTTL > 0 {
+		c.cacheMu.Lock()
+		entry, ok := c.cache[cacheKey]
+		c.cacheMu.Unlock()
+
+		if ok && time.Now().Before(entry.expiry) {
+			return entry.revoked
+		}
+	}
+
+	ttl := config.CacheTTL
+	revoked, err := c.check(cert, issuer, config)
+	if err != nil {
+		logger.Warn("Failed checking certificate revocation", logger.Ctx{"subject": cert.Subject.String(), "serial": cert.SerialNumber.String(), "failClosed": config.FailClosed, "err": err})
+		revoked = config.FailClosed
+
+		// Retry sooner when the status couldn't be determined.
+		if ttl > revocationErrorTTL {
+			ttl = revocationErrorTTL
+		}
+	}
+
+	if ttl > 0 {
+		c.cacheMu.Lock()
+		c.cache[cacheKey] = revocationCacheEntry{revoked: revoked, expiry: time.Now().Add(ttl)}
+		c.cacheMu.Unlock()
+	}
+
+	return revoked
+}
+
+// check queries the OCSP responders and then the CRLs listed in the certificate until one of them gives an answer.
+func (c *RevocationChecker) check(cert *x509.Certificate, issuer *x509.Certificate, config RevocationConfig) (bool, error) {
+	var errs []error
+
+	if config.OCSP {
+		for _, server := range cert.OCSPServer {
+			revoked, err := c.checkOCSP(server, cert, issuer)
+			if err == nil {
+				return revoked, nil
+			}
+
+			errs = append(errs, fmt.Errorf("OCSP responder %q: %w", server, err))
+		}
+	}
+
+	if config.CRL {
+		for _, distributionPoint := range cert.CRLDistributionPoints {
+			revoked, err := c.checkCRL(distributionPoint, cert, issuer)
+			if err == nil {
+				return revoked, nil
+			}
+
+			errs = append(errs, fmt.Errorf("CRL %q: %w", distributionPoint, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return false, fmt.Errorf("Couldn't determine certificate status: %v", errs)
+	}
+
+	return false, nil
+}
+
+// fetch runs the request and returns the body of a successful response.
+func (c *RevocationChecker) fetch(req *http.Request) ([]byte, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Unexpected status code %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, revocationMaxSize))
+}
+
+// checkOCSP queries an OCSP responder for the status of the certificate.
+func (c *RevocationChecker) checkOCSP(server string, cert *x509.Certificate, issuer *x509.Certificate) (bool, error) {
+	ocspReq, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return false, fmt.Errorf("Failed creating request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), revocationTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", server, bytes.NewReader(ocspReq))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/ocsp-request")
+	req.Header.Set("Accept", "application/ocsp-response")
+
+	body, err := c.fetch(req)
+	if err != nil {
+		return false, err
+	}
+
+	ocspResp, err := ocsp.ParseResponseForCert(body, cert, issuer)
+	if err != nil {
+		return false, fmt.Errorf("Failed parsing response: %w", err)
+	}
+
+	switch ocspResp.Status {
+	case ocsp.Good:
+		return false, nil
+	case ocsp.Revoked:
+		return true, nil
+	}
+
+	return false, fmt.Errorf("Certificate status is unknown")
+}
+
+// checkCRL fetches a CRL signed by the issuer and looks for the certificate in it.
+func (c *RevocationChecker) checkCRL(distributionPoint string, cert *x509.Certificate, issuer *x509.Certificate) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), revocationTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", distributionPoint, nil)
+	if err != nil {
+		return false, err
+	}
+
+	body, err := c.fetch(req)
+	if err != nil {
+		return false, err
+	}
+
+	// Some distribution points serve PEM rather than DER.
+	block, _ := pem.Decode(body)
+	if block != nil {
+		body = block.Bytes
+	}
+
+	crl, err := x509.ParseRevocationList(body)
+	if err != nil {
+		return false, fmt.Errorf("Failed parsing CRL: %w", err)
+	}
+
+	err = crl.CheckSignatureFrom(issuer)
+	if err != nil {
+		return false, fmt.Errorf("Invalid CRL signature: %w", err)
+	}
+
+	if !crl.NextUpdate.IsZero() && time.Now().After(crl.NextUpdate) {
+		return false, fmt.Errorf("CRL is outdated")
+	}
+
+	for _, revoked := range crl.RevokedCertificates {
+		if cert.SerialNumber.Cmp(revoked.SerialNumber) == 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
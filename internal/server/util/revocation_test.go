@@ -0,0 +1,116 @@
+package util
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// revocationTestCert creates a certificate signed by the given issuer (or self-signed if nil).
+func revocationTestCert(t *testing.T, serial int64, crlURL string, issuer *x509.Certificate, issuerKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+
+	if crlURL != "" {
+		template.CRLDistributionPoints = []string{crlURL}
+	}
+
+	if issuer == nil {
+		template.IsCA = true
+		issuer = template
+		issuerKey = key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, issuerKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+func TestRevocationChecker_CRL(t *testing.T) {
+	ca, caKey := revocationTestCert(t, 1, "", nil, nil)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		crl, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+			Number:              big.NewInt(1),
+			ThisUpdate:          time.Now().Add(-time.Minute),
+			NextUpdate:          time.Now().Add(time.Hour),
+			RevokedCertificates: []pkix.RevokedCertificate{{SerialNumber: big.NewInt(3), RevocationTime: time.Now()}},
+		}, ca, caKey)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_, _ = w.Write(crl)
+	}))
+	defer server.Close()
+
+	valid, _ := revocationTestCert(t, 2, server.URL, ca, caKey)
+	revoked, _ := revocationTestCert(t, 3, server.URL, ca, caKey)
+
+	checker := NewRevocationChecker()
+	config := RevocationConfig{CRL: true, CacheTTL: time.Hour}
+
+	assert.False(t, checker.Revoked(valid, ca, config))
+	assert.True(t, checker.Revoked(revoked, ca, config))
+	assert.Equal(t, 2, requests)
+
+	// The statuses are cached.
+	assert.False(t, checker.Revoked(valid, ca, config))
+	assert.True(t, checker.Revoked(revoked, ca, config))
+	assert.Equal(t, 2, requests)
+
+	// Until the cache is flushed.
+	checker.Flush()
+	assert.True(t, checker.Revoked(revoked, ca, config))
+	assert.Equal(t, 3, requests)
+
+	// Disabled methods aren't used.
+	assert.False(t, checker.Revoked(revoked, ca, RevocationConfig{OCSP: true}))
+}
+
+func TestRevocationChecker_FailClosed(t *testing.T) {
+	ca, caKey := revocationTestCert(t, 1, "", nil, nil)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cert, _ := revocationTestCert(t, 2, server.URL, ca, caKey)
+	noCRL, _ := revocationTestCert(t, 3, "", ca, caKey)
+
+	checker := NewRevocationChecker()
+
+	assert.False(t, checker.Revoked(cert, ca, RevocationConfig{CRL: true}))
+	assert.True(t, checker.Revoked(cert, ca, RevocationConfig{CRL: true, FailClosed: true}))
+
+	// Certificates without a CRL can't be checked so are considered valid.
+	assert.False(t, checker.Revoked(noCRL, ca, RevocationConfig{CRL: true, FailClosed: true}))
+}
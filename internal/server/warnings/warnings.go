@@ -11,6 +11,7 @@ import (
 )
 
 // ResolveWarningsByLocalNodeOlderThan resolves all warnings which are older than the provided time.
+// Warnings of types which aren't resolved on startup are left untouched.
 func ResolveWarningsByLocalNodeOlderThan(dbCluster *db.Cluster, date time.Time) error {
 	var err error
 	var localName string
@@ -42,6 +43,10 @@ func ResolveWarningsByLocalNodeOlderThan(dbCluster *db.Cluster, date time.Time)
 				continue
 			}
 
+			if !w.TypeCode.ResolvedOnStartup() {
+				continue
+			}
+
 			if w.LastSeenDate.Before(date) {
 				err = tx.UpdateWarningStatus(w.UUID, warningtype.StatusResolved)
 				if err != nil {
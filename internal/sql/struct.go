@@ -23,3 +23,10 @@ type SQLResult struct {
 	Rows         [][]any  `json:"rows"          yaml:"rows"`
 	RowsAffected int64    `json:"rows_affected" yaml:"rows_affected"`
 }
+
+// SQLReadQuery represents a predefined read-only query.
+type SQLReadQuery struct {
+	Name        string   `json:"name"        yaml:"name"`
+	Description string   `json:"description" yaml:"description"`
+	Filters     []string `json:"filters"     yaml:"filters"`
+}
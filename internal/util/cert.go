@@ -1,9 +1,14 @@
 package util
 
 import (
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
+	"time"
 
 	localtls "github.com/lxc/incus/shared/tls"
 	"github.com/lxc/incus/shared/util"
@@ -14,12 +19,17 @@ import (
 // If a cluster certificate is found it will be loaded instead.
 // If neither a server or cluster certfificate exists, a new server certificate will be generated.
 func LoadCert(dir string) (*localtls.CertInfo, error) {
+	return LoadCertWithKey(dir, localtls.KeyParams{})
+}
+
+// LoadCertWithKey is like LoadCert but generates a missing server certificate with the given key type and size.
+func LoadCertWithKey(dir string, keyParams localtls.KeyParams) (*localtls.CertInfo, error) {
 	prefix := "server"
 	if util.PathExists(filepath.Join(dir, "cluster.crt")) {
 		prefix = "cluster"
 	}
 
-	cert, err := localtls.KeyPairAndCA(dir, prefix, localtls.CertServer, true)
+	cert, err := localtls.KeyPairAndCAWithKey(dir, prefix, localtls.CertServer, true, keyParams)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
 	}
@@ -43,8 +53,13 @@ func LoadClusterCert(dir string) (*localtls.CertInfo, error) {
 
 // LoadServerCert reads the server certificate from the given var dir.
 func LoadServerCert(dir string) (*localtls.CertInfo, error) {
+	return LoadServerCertWithKey(dir, localtls.KeyParams{})
+}
+
+// LoadServerCertWithKey is like LoadServerCert but generates a missing certificate with the given key type and size.
+func LoadServerCertWithKey(dir string, keyParams localtls.KeyParams) (*localtls.CertInfo, error) {
 	prefix := "server"
-	cert, err := localtls.KeyPairAndCA(dir, prefix, localtls.CertServer, true)
+	cert, err := localtls.KeyPairAndCAWithKey(dir, prefix, localtls.CertServer, true, keyParams)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
 	}
@@ -74,3 +89,42 @@ func WriteCert(dir, prefix string, cert, key, ca []byte) error {
 
 	return nil
 }
+
+// RenewCert generates a new self-signed certificate and key valid for the same names as the given certificate.
+// Keeping the names lets peers verifying the current certificate by name also verify the new one.
+// The new key is generated with the given key type and size.
+func RenewCert(cert *x509.Certificate, keyParams localtls.KeyParams) ([]byte, []byte, error) {
+	privk, keyPEM, err := keyParams.GenerateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to generate serial number: %w", err)
+	}
+
+	validFrom := time.Now()
+
+	template := x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               cert.Subject,
+		NotBefore:             validFrom,
+		NotAfter:              validFrom.Add(10 * 365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           cert.ExtKeyUsage,
+		BasicConstraintsValid: true,
+		DNSNames:              cert.DNSNames,
+		IPAddresses:           cert.IPAddresses,
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, privk.Public(), privk)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to create certificate: %w", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
+
+	return certPEM, keyPEM, nil
+}
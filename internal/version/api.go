@@ -382,6 +382,84 @@ var APIExtensions = []string{
 	"disk_initial_volume_configuration",
 	"operation_wait",
 	"image_restriction_privileged",
+	"openfga",
+	"projects_limits_requests",
+	"request_timeout",
+	"certificates_import",
+	"pkcs11_keys",
+	"metrics_certificate",
+	"events_logging",
+	"log_sampling",
+	"server_config_export",
+	"server_config_schema",
+	"cluster_healing_policy",
+	"cluster_leader_step_down",
+	"cluster_raft_snapshot",
+	"cluster_heartbeat_state",
+	"guestapi_metrics",
+	"guestapi_config_recursion",
+	"devmonitor_paths",
+	"devmonitor_quiet_period",
+	"resources_assignments",
+	"syscall_intercept_profiles",
+	"syscall_intercept_audit",
+	"core_firewall_driver",
+	"network_leases_expiry",
+	"api_extensions_availability",
+	"resources_features",
+	"idmapped_mounts_policy",
+	"instances_placement_scriptlet_features",
+	"cluster_features",
+	"operations_concurrency_limit",
+	"cluster_member_offline_threshold",
+	"cluster_heartbeat_interval",
+	"cluster_heartbeat_profile",
+	"resources_usage",
+	"error_reasons",
+	"server_ready",
+	"cluster_certificate_rotate",
+	"certificate_key_type",
+	"acme_hook",
+	"certificates_trust_store",
+	"certificate_revocation",
+	"cluster_join_token_options",
+	"auth_failure_throttle",
+	"connections",
+	"api_log_routes",
+	"server_message",
+	"metrics_warnings",
+	"instances_autostart_retry",
+	"projects_instance_defaults",
+	"projects_images_servers",
+	"projects_move",
+	"projects_dependencies",
+	"projects_read_only",
+	"projects_restricted_storage_drivers",
+	"projects_networks_address_pools",
+	"projects_dns_records",
+	"instances_name_pattern",
+	"projects_bulk",
+	"projects_storage_volume_attribution",
+	"network_zone_default_project_suffix",
+	"instances_effective_profiles",
+	"images_streaming_upload",
+	"cluster_images_sync_throttling",
+	"cluster_images_sync_filter",
+	"server_config_reload_operation",
+	"cluster_members_raft",
+	"cluster_member_state_delay",
+	"cluster_quorum_loss_action",
+	"cluster_member_force_remove",
+	"database_retry_policy",
+	"database_slow_threshold",
+	"internal_sql_read_queries",
+	"operations_history",
+	"instance_console_log_size",
+	"logs_expiry",
+	"audit_log",
+	"instances_lifecycle_hook",
+	"cluster_local_event_types",
+	"event_listener_stats",
 }
 
 // APIExtensionsCount returns the number of available API extensions.
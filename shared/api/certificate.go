@@ -126,3 +126,99 @@ func (t *CertificateAddToken) String() string {
 
 	return base64.StdEncoding.EncodeToString(joinTokenJSON)
 }
+
+// CertificatesImportPost represents a batch of certificates to add to the trust store.
+//
+// swagger:model
+//
+// API extension: certificates_import.
+type CertificatesImportPost struct {
+	// List of certificates to add (the certificate field is required)
+	Certificates []CertificatePut `json:"certificates" yaml:"certificates"`
+
+	// Whether to reject the whole batch if any of the certificates can't be imported
+	// Example: true
+	Atomic bool `json:"atomic" yaml:"atomic"`
+}
+
+// CertificatesImportResult represents the outcome of a batch certificate import.
+//
+// swagger:model
+//
+// API extension: certificates_import.
+type CertificatesImportResult struct {
+	// Fingerprints of the certificates which were added to the trust store
+	// Example: ["fd200419b271f1dc2a5591b693cc5774b7f234e1ff8c6b78ad703b6888fe2b69"]
+	Imported []string `json:"imported" yaml:"imported"`
+
+	// Certificates which couldn't be imported
+	Failed []CertificatesImportFailure `json:"failed" yaml:"failed"`
+}
+
+// CertificatesImportFailure represents a certificate which couldn't be imported.
+//
+// swagger:model
+//
+// API extension: certificates_import.
+type CertificatesImportFailure struct {
+	// Position of the certificate in the request
+	// Example: 2
+	Index int `json:"index" yaml:"index"`
+
+	// Name associated with the certificate
+	// Example: castiana
+	Name string `json:"name" yaml:"name"`
+
+	// SHA256 fingerprint of the certificate (if it could be parsed)
+	// Example: fd200419b271f1dc2a5591b693cc5774b7f234e1ff8c6b78ad703b6888fe2b69
+	Fingerprint string `json:"fingerprint" yaml:"fingerprint"`
+
+	// Reason for the failure
+	// Example: Certificate already in trust store
+	Error string `json:"error" yaml:"error"`
+}
+
+// CertificatesTrustStore represents the trust store currently in use by the server.
+//
+// swagger:model
+//
+// API extension: certificates_trust_store.
+type CertificatesTrustStore struct {
+	// Trusted certificates grouped by certificate type
+	Certificates map[string][]CertificatesTrustStoreEntry `json:"certificates" yaml:"certificates"`
+}
+
+// CertificatesTrustStoreEntry represents a certificate loaded in the trust store.
+//
+// swagger:model
+//
+// API extension: certificates_trust_store.
+type CertificatesTrustStoreEntry struct {
+	// SHA256 fingerprint of the certificate
+	// Example: fd200419b271f1dc2a5591b693cc5774b7f234e1ff8c6b78ad703b6888fe2b69
+	Fingerprint string `json:"fingerprint" yaml:"fingerprint"`
+
+	// Subject of the certificate
+	// Example: CN=user@host,O=Linux Containers
+	Subject string `json:"subject" yaml:"subject"`
+
+	// Issuer of the certificate
+	// Example: CN=user@host,O=Linux Containers
+	Issuer string `json:"issuer" yaml:"issuer"`
+
+	// When the certificate becomes valid
+	// Example: 2021-03-23T20:00:00-04:00
+	NotBefore time.Time `json:"not_before" yaml:"not_before"`
+
+	// When the certificate expires
+	// Example: 2031-03-23T20:00:00-04:00
+	NotAfter time.Time `json:"not_after" yaml:"not_after"`
+
+	// Whether the certificate is limited to the listed projects
+	// Example: true
+	Restricted bool `json:"restricted" yaml:"restricted"`
+
+	// List of allowed projects (applies when restricted)
+	// Example: ["default", "foo", "bar"]
+	Projects []string `json:"projects" yaml:"projects"`
+}
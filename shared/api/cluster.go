@@ -25,6 +25,26 @@ type Cluster struct {
 	//
 	// API extension: clustering_join
 	MemberConfig []ClusterMemberConfigKey `json:"member_config" yaml:"member_config"`
+
+	// Database snapshot settings in use by the cluster member answering the request
+	//
+	// API extension: cluster_raft_snapshot
+	RaftSnapshot *ClusterRaftSnapshot `json:"raft_snapshot,omitempty" yaml:"raft_snapshot,omitempty"`
+}
+
+// ClusterRaftSnapshot represents the database snapshot settings of a cluster member.
+//
+// swagger:model
+//
+// API extension: cluster_raft_snapshot.
+type ClusterRaftSnapshot struct {
+	// Number of database log entries after which a snapshot is taken
+	// Example: 1024
+	Threshold uint64 `json:"threshold" yaml:"threshold"`
+
+	// Number of database log entries kept after a snapshot
+	// Example: 8192
+	Trailing uint64 `json:"trailing" yaml:"trailing"`
 }
 
 // ClusterMemberConfigKey represents a single config key that a new member of
@@ -97,6 +117,18 @@ type ClusterMembersPost struct {
 	// The name of the new cluster member
 	// Example: server02
 	ServerName string `json:"server_name" yaml:"server_name"`
+
+	// How long the join token is valid for (defaults to cluster.join_token_expiry)
+	// Example: 30M
+	//
+	// API extension: cluster_join_token_options
+	Expiry string `json:"expiry" yaml:"expiry"`
+
+	// Whether the join token remains valid after being used, until it expires
+	// Example: false
+	//
+	// API extension: cluster_join_token_options
+	Reusable bool `json:"reusable" yaml:"reusable"`
 }
 
 // ClusterMemberJoinToken represents the fields contained within an encoded cluster member join token.
@@ -239,6 +271,17 @@ type ClusterCertificatePut struct {
 	ClusterCertificateKey string `json:"cluster_certificate_key" yaml:"cluster_certificate_key"`
 }
 
+// ClusterCertificate represents the certificate of the cluster.
+//
+// swagger:model
+//
+// API extension: cluster_certificate_rotate.
+type ClusterCertificate struct {
+	// The certificate (X509 PEM encoded) of the cluster
+	// Example: X509 PEM certificate
+	ClusterCertificate string `json:"cluster_certificate" yaml:"cluster_certificate"`
+}
+
 // ClusterMemberStatePost represents the fields required to evacuate a cluster member.
 //
 // swagger:model
@@ -309,3 +352,68 @@ type ClusterGroupPut struct {
 func (c *ClusterGroup) Writable() ClusterGroupPut {
 	return c.ClusterGroupPut
 }
+
+// ClusterMemberHeartbeat represents the state of a cluster member as last seen by the heartbeat.
+//
+// swagger:model
+//
+// API extension: cluster_heartbeat_state.
+type ClusterMemberHeartbeat struct {
+	// Name of the cluster member
+	// Example: server01
+	ServerName string `json:"server_name" yaml:"server_name"`
+
+	// Address of the cluster member
+	// Example: 10.0.0.1:8443
+	Address string `json:"address" yaml:"address"`
+
+	// Whether the cluster member is online
+	// Example: true
+	Online bool `json:"online" yaml:"online"`
+
+	// Last time the cluster member responded to a heartbeat
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	LastHeartbeat time.Time `json:"last_heartbeat" yaml:"last_heartbeat"`
+
+	// Round-trip time of the last heartbeat in milliseconds
+	// Example: 3
+	Latency int64 `json:"latency" yaml:"latency"`
+
+	// Role of the cluster member in the database (voter, stand-by or spare, empty if not part of it)
+	// Example: voter
+	DatabaseRole string `json:"database_role" yaml:"database_role"`
+
+	// ID of the cluster member in the raft cluster (0 if not part of it)
+	// Example: 1
+	//
+	// API extension: cluster_members_raft
+	RaftID uint64 `json:"raft_id" yaml:"raft_id"`
+
+	// Whether the cluster member is the raft leader
+	// Example: true
+	//
+	// API extension: cluster_members_raft
+	Leader bool `json:"leader" yaml:"leader"`
+
+	// Database schema version of the cluster member
+	// Example: 69
+	Schema int `json:"schema" yaml:"schema"`
+
+	// Number of API extensions supported by the cluster member
+	// Example: 350
+	APIExtensions int `json:"api_extensions" yaml:"api_extensions"`
+}
+
+// ClusterMemberFeatures represents the kernel and LXC features detected on a cluster member.
+//
+// swagger:model
+//
+// API extension: cluster_features.
+type ClusterMemberFeatures struct {
+	// Name of the cluster member
+	// Example: server01
+	ServerName string `json:"server_name" yaml:"server_name"`
+
+	// Features detected on the cluster member
+	Features ResourcesFeatures `json:"features" yaml:"features"`
+}
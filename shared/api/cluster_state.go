@@ -25,4 +25,10 @@ type ClusterMemberSysInfo struct {
 type ClusterMemberState struct {
 	SysInfo      ClusterMemberSysInfo        `json:"sysinfo" yaml:"sysinfo"`
 	StoragePools map[string]StoragePoolState `json:"storage_pools" yaml:"storage_pools"`
+
+	// Offline threshold in effect on the cluster member (in seconds)
+	// Example: 20
+	//
+	// API extension: cluster_member_offline_threshold
+	OfflineThreshold int64 `json:"offline_threshold" yaml:"offline_threshold"`
 }
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"time"
+)
+
+// Connection represents a live client connection to the server.
+//
+// swagger:model
+//
+// API extension: connections.
+type Connection struct {
+	// Unique identifier of the connection
+	// Example: 2c4f0c30-1b49-4e33-9d7c-0cb3b2a1d1c4
+	ID string `json:"id" yaml:"id"`
+
+	// Listener the connection was accepted on (network, cluster, metrics or storage-buckets)
+	// Example: network
+	Listener string `json:"listener" yaml:"listener"`
+
+	// Address of the client
+	// Example: 192.0.2.10:51326
+	RemoteAddress string `json:"remote_address" yaml:"remote_address"`
+
+	// Protocol the client authenticated with (empty if not authenticated yet)
+	// Example: tls
+	Protocol string `json:"protocol" yaml:"protocol"`
+
+	// Identity of the authenticated client (user name or certificate fingerprint)
+	// Example: 57bb0ff4340b5bb28517e062023101adf788c37846dc8b619eb2c3cb4ef29436
+	Identity string `json:"identity" yaml:"identity"`
+
+	// When the connection was established
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	ConnectedAt time.Time `json:"connected_at" yaml:"connected_at"`
+}
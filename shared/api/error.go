@@ -6,6 +6,22 @@ import (
 	"net/http"
 )
 
+// Stable reasons attached to error responses.
+// Unlike error messages, those never change and can be relied on by API clients.
+const (
+	// ErrorReasonNotAuthorized is used when the client isn't trusted or isn't allowed to access the resource.
+	ErrorReasonNotAuthorized = "not_authorized"
+
+	// ErrorReasonAuthenticationFailed is used when the credentials provided by the client couldn't be verified.
+	ErrorReasonAuthenticationFailed = "authentication_failed"
+
+	// ErrorReasonSetupInProgress is used when the server is still starting up and can't handle the request yet.
+	ErrorReasonSetupInProgress = "setup_in_progress"
+
+	// ErrorReasonQuorumLost is used when the request is refused as the cluster database lost quorum.
+	ErrorReasonQuorumLost = "quorum_lost"
+)
+
 // StatusErrorf returns a new StatusError containing the specified status and message.
 func StatusErrorf(status int, format string, a ...any) StatusError {
 	var msg string
@@ -21,10 +37,19 @@ func StatusErrorf(status int, format string, a ...any) StatusError {
 	}
 }
 
+// StatusReasonErrorf returns a new StatusError containing the specified status, reason and message.
+func StatusReasonErrorf(status int, reason string, format string, a ...any) StatusError {
+	err := StatusErrorf(status, format, a...)
+	err.reason = reason
+
+	return err
+}
+
 // StatusError error type that contains an HTTP status code and message.
 type StatusError struct {
 	status int
 	msg    string
+	reason string
 }
 
 // Error returns the error message or the http.StatusText() of the status code if message is empty.
@@ -41,6 +66,22 @@ func (e StatusError) Status() int {
 	return e.status
 }
 
+// Reason returns the stable reason of the error, if any.
+func (e StatusError) Reason() string {
+	return e.reason
+}
+
+// StatusErrorReason returns the stable reason of err if it was caused by a StatusError, otherwise an empty string.
+func StatusErrorReason(err error) string {
+	var statusErr StatusError
+
+	if errors.As(err, &statusErr) {
+		return statusErr.Reason()
+	}
+
+	return ""
+}
+
 // StatusErrorMatch checks if err was caused by StatusError. Can optionally also check whether the StatusError's
 // status code matches one of the supplied status codes in matchStatus.
 // Returns the matched StatusError status code and true if match criteria are met, otherwise false.
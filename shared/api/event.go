@@ -12,6 +12,7 @@ const (
 	EventTypeLogging    = "logging"
 	EventTypeOperation  = "operation"
 	EventTypeNetworkACL = "network-acl"
+	EventTypeSyscall    = "syscall"
 )
 
 // Event represents an event entry (over websocket)
@@ -45,7 +46,7 @@ type Event struct {
 
 // ToLogging creates log record for the event.
 func (event *Event) ToLogging() (EventLogRecord, error) {
-	if event.Type == EventTypeLogging || event.Type == EventTypeNetworkACL {
+	if event.Type == EventTypeLogging || event.Type == EventTypeNetworkACL || event.Type == EventTypeSyscall {
 		e := &EventLogging{}
 		err := json.Unmarshal(event.Metadata, &e)
 		if err != nil {
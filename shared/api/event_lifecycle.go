@@ -16,6 +16,8 @@ const (
 	EventLifecycleClusterMemberRemoved              = "cluster-member-removed"
 	EventLifecycleClusterMemberRenamed              = "cluster-member-renamed"
 	EventLifecycleClusterMemberUpdated              = "cluster-member-updated"
+	EventLifecycleClusterQuorumLost                 = "cluster-quorum-lost"
+	EventLifecycleClusterQuorumRestored             = "cluster-quorum-restored"
 	EventLifecycleClusterTokenCreated               = "cluster-token-created"
 	EventLifecycleConfigUpdated                     = "config-updated"
 	EventLifecycleImageAliasCreated                 = "image-alias-created"
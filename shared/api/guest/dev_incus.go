@@ -23,3 +23,16 @@ type DevIncusGet struct {
 	// Example: server01
 	Location string `json:"location" yaml:"location"`
 }
+
+// DevIncusMetric represents a metric published by the instance.
+//
+// API extension: guestapi_metrics.
+type DevIncusMetric struct {
+	// Metric type (gauge or counter)
+	// Example: gauge
+	Type string `json:"type" yaml:"type"`
+
+	// Current value of the metric
+	// Example: 42
+	Value float64 `json:"value" yaml:"value"`
+}
@@ -173,6 +173,39 @@ type InstanceRebuildPost struct {
 	Source InstanceSource `json:"source" yaml:"source"`
 }
 
+// InstanceEffectiveProfile represents a profile applied to an instance.
+//
+// swagger:model
+//
+// API extension: instances_effective_profiles.
+type InstanceEffectiveProfile struct {
+	// Profile name
+	// Example: default
+	Name string `json:"name" yaml:"name"`
+
+	// Project the profile was resolved from
+	// Example: default
+	Project string `json:"project" yaml:"project"`
+
+	// URL of the profile
+	// Example: /1.0/profiles/default?project=default
+	URL string `json:"url" yaml:"url"`
+}
+
+// InstanceEffectiveProfiles represents the profiles applied to an instance, in the order they're applied.
+//
+// swagger:model
+//
+// API extension: instances_effective_profiles.
+type InstanceEffectiveProfiles struct {
+	// Project the instance's profiles are resolved from (the default project unless features.profiles is enabled)
+	// Example: default
+	ProfilesProject string `json:"profiles_project" yaml:"profiles_project"`
+
+	// Profiles applied to the instance, in order
+	Profiles []InstanceEffectiveProfile `json:"profiles" yaml:"profiles"`
+}
+
 // Instance represents an instance.
 //
 // swagger:model
@@ -1,5 +1,9 @@
 package api
 
+import (
+	"time"
+)
+
 // NetworksPost represents the fields of a new network
 //
 // swagger:model
@@ -135,6 +139,12 @@ type NetworkLease struct {
 	//
 	// API extension: network_leases_location
 	Location string `json:"location" yaml:"location"`
+
+	// When the dynamic lease expires (not set for static records or leases which never expire)
+	// Example: 2023-10-06T14:20:41Z
+	//
+	// API extension: network_leases_expiry
+	ExpiresAt *time.Time `json:"expires_at" yaml:"expires_at"`
 }
 
 // NetworkState represents the network state
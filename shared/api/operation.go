@@ -69,6 +69,57 @@ type Operation struct {
 	Location string `json:"location" yaml:"location"`
 }
 
+// OperationHistory represents a completed operation kept in the operations history
+//
+// swagger:model
+//
+// API extension: operations_history.
+type OperationHistory struct {
+	// UUID of the operation
+	// Example: 6916c8a6-9b7d-4abd-90b3-aedfec7ec7da
+	ID string `json:"id" yaml:"id"`
+
+	// Type of the operation
+	// Example: instance-create
+	Type string `json:"type" yaml:"type"`
+
+	// Description of the operation
+	// Example: Creating instance
+	Description string `json:"description" yaml:"description"`
+
+	// Final status name
+	// Example: Success
+	Status string `json:"status" yaml:"status"`
+
+	// Final status code
+	// Example: 200
+	StatusCode StatusCode `json:"status_code" yaml:"status_code"`
+
+	// Operation error mesage
+	// Example: Some error message
+	Err string `json:"err" yaml:"err"`
+
+	// Project the operation belonged to
+	// Example: default
+	Project string `json:"project" yaml:"project"`
+
+	// What cluster member the operation ran on
+	// Example: server01
+	Location string `json:"location" yaml:"location"`
+
+	// User who triggered the operation
+	// Example: admin
+	Requestor string `json:"requestor" yaml:"requestor"`
+
+	// Operation creation time
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+
+	// Operation completion time
+	// Example: 2021-03-23T17:38:41.152734829-04:00
+	FinishedAt time.Time `json:"finished_at" yaml:"finished_at"`
+}
+
 // ToCertificateAddToken creates a certificate add token from the operation metadata.
 func (op *Operation) ToCertificateAddToken() (*CertificateAddToken, error) {
 	req, ok := op.Metadata["request"].(map[string]any)
@@ -24,6 +24,178 @@ type ProjectPost struct {
 	Name string `json:"name" yaml:"name"`
 }
 
+// ProjectsBulkPost represents the fields required to create several projects from a template
+//
+// swagger:model
+//
+// API extension: projects_bulk.
+type ProjectsBulkPost struct {
+	// Names of the projects to create
+	// Example: ["tenant1", "tenant2"]
+	Names []string `json:"names" yaml:"names"`
+
+	// Description and configuration applied to each project
+	Template ProjectPut `json:"template" yaml:"template"`
+
+	// Whether to create all the projects or none of them
+	// Example: true
+	AllOrNothing bool `json:"all_or_nothing" yaml:"all_or_nothing"`
+}
+
+// ProjectsBulkResult represents the outcome of a bulk project creation
+//
+// swagger:model
+//
+// API extension: projects_bulk.
+type ProjectsBulkResult struct {
+	// URLs of the created projects
+	// Example: ["/1.0/projects/tenant1"]
+	Created []string `json:"created" yaml:"created"`
+
+	// Errors of the projects which couldn't be created, indexed by project name
+	// Example: {"tenant2": "Project \"tenant2\" already exists"}
+	Failed map[string]string `json:"failed" yaml:"failed"`
+}
+
+// ProjectMovePost represents the entities to move (or copy) from a project to another
+//
+// swagger:model
+//
+// API extension: projects_move.
+type ProjectMovePost struct {
+	// Target project
+	// Example: tenant
+	Project string `json:"project" yaml:"project"`
+
+	// Whether to copy the entities rather than move them (only supported for profiles)
+	// Example: false
+	Copy bool `json:"copy" yaml:"copy"`
+
+	// Names of the profiles to move
+	// Example: ["web"]
+	Profiles []string `json:"profiles" yaml:"profiles"`
+
+	// Names of the networks to move
+	// Example: ["ovn0"]
+	Networks []string `json:"networks" yaml:"networks"`
+}
+
+// ProjectMoveResult represents the entities moved (or copied) from a project to another
+//
+// swagger:model
+//
+// API extension: projects_move.
+type ProjectMoveResult struct {
+	// URLs of the moved (or copied) profiles in the target project
+	// Example: ["/1.0/profiles/web?project=tenant"]
+	Profiles []string `json:"profiles" yaml:"profiles"`
+
+	// URLs of the moved networks in the target project
+	// Example: ["/1.0/networks/ovn0?project=tenant"]
+	Networks []string `json:"networks" yaml:"networks"`
+}
+
+// ProjectDependencies represents the entities still attributed to a project, preventing its deletion
+//
+// swagger:model
+//
+// API extension: projects_dependencies.
+type ProjectDependencies struct {
+	// URLs of the instances in the project
+	// Example: ["/1.0/instances/c1?project=tenant"]
+	Instances []string `json:"instances" yaml:"instances"`
+
+	// URLs of the images in the project
+	// Example: ["/1.0/images/aaaa?project=tenant"]
+	Images []string `json:"images" yaml:"images"`
+
+	// URLs of the profiles in the project (other than the default profile)
+	// Example: ["/1.0/profiles/web?project=tenant"]
+	Profiles []string `json:"profiles" yaml:"profiles"`
+
+	// URLs of the custom storage volumes in the project
+	// Example: ["/1.0/storage-pools/local/volumes/custom/vol1?project=tenant"]
+	StorageVolumes []string `json:"storage_volumes" yaml:"storage_volumes"`
+
+	// URLs of the storage buckets in the project
+	// Example: ["/1.0/storage-pools/local/buckets/bucket1?project=tenant"]
+	StorageBuckets []string `json:"storage_buckets" yaml:"storage_buckets"`
+
+	// URLs of the networks in the project
+	// Example: ["/1.0/networks/ovn0?project=tenant"]
+	Networks []string `json:"networks" yaml:"networks"`
+
+	// URLs of the network ACLs in the project
+	// Example: ["/1.0/network-acls/web?project=tenant"]
+	NetworkACLs []string `json:"network_acls" yaml:"network_acls"`
+
+	// URLs of the network zones in the project
+	// Example: ["/1.0/network-zones/example.net?project=tenant"]
+	NetworkZones []string `json:"network_zones" yaml:"network_zones"`
+}
+
+// ProjectDNSZone represents the DNS records a network zone generates for the instances of a project
+//
+// swagger:model
+//
+// API extension: projects_dns_records.
+type ProjectDNSZone struct {
+	// Name of the network zone
+	// Example: example.net
+	Name string `json:"name" yaml:"name"`
+
+	// Records generated for the instances of the project
+	Records []NetworkZoneRecord `json:"records" yaml:"records"`
+}
+
+// ProjectDNSRecords represents the DNS records that would be generated for a project
+//
+// swagger:model
+//
+// API extension: projects_dns_records.
+type ProjectDNSRecords struct {
+	// Project the network zones are taken from
+	// Example: default
+	ZoneProject string `json:"zone_project" yaml:"zone_project"`
+
+	// Whether the zones currently serve the records of the project
+	// Example: false
+	Served bool `json:"served" yaml:"served"`
+
+	// Forward zones
+	Forward []ProjectDNSZone `json:"forward" yaml:"forward"`
+
+	// Reverse (ARPA) zones
+	Reverse []ProjectDNSZone `json:"reverse" yaml:"reverse"`
+}
+
+// ProjectStorageVolumeAttribution represents the project that storage volumes of a type requested in a project are attributed to
+//
+// swagger:model
+//
+// API extension: projects_storage_volume_attribution.
+type ProjectStorageVolumeAttribution struct {
+	// Requested project
+	// Example: tenant
+	Project string `json:"project" yaml:"project"`
+
+	// Storage volume type
+	// Example: custom
+	Type string `json:"type" yaml:"type"`
+
+	// Project the storage volumes are attributed to
+	// Example: default
+	EffectiveProject string `json:"effective_project" yaml:"effective_project"`
+
+	// Name of the storage volume (if provided)
+	// Example: vol1
+	Volume string `json:"volume" yaml:"volume"`
+
+	// Project prefixed name of the storage volume, as used by the storage drivers (if a volume was provided)
+	// Example: default_vol1
+	PrefixedName string `json:"prefixed_name" yaml:"prefixed_name"`
+}
+
 // ProjectPut represents the modifiable fields of a project
 //
 // swagger:model
@@ -1086,3 +1086,100 @@ type ResourcesSystemMotherboard struct {
 	// Example: None
 	Version string `json:"version" yaml:"version"`
 }
+
+// ResourcesAssignment represents the host resources assigned to a running instance
+//
+// swagger:model
+//
+// API extension: resources_assignments.
+type ResourcesAssignment struct {
+	// Project the instance belongs to
+	// Example: default
+	Project string `json:"project" yaml:"project"`
+
+	// Instance name
+	// Example: c1
+	Instance string `json:"instance" yaml:"instance"`
+
+	// CPU threads the instance was pinned to by the scheduler
+	// Example: [0, 1]
+	CPUs []int64 `json:"cpus" yaml:"cpus"`
+
+	// Host devices (GPU, USB and PCI) passed to the instance
+	// Example: {"gpu0": {"type": "gpu", "pci": "0000:01:00.0"}}
+	Devices map[string]map[string]string `json:"devices" yaml:"devices"`
+}
+
+// ResourcesUsage represents the instances consuming a class of limited host resources
+//
+// swagger:model
+//
+// API extension: resources_usage.
+type ResourcesUsage struct {
+	// Class of host resource (cpu, gpu, usb, pci, nic or disk)
+	// Example: gpu
+	Class string `json:"class" yaml:"class"`
+
+	// Total amount of the resource consumed by instances
+	// Example: 2
+	Total int64 `json:"total" yaml:"total"`
+
+	// Instances consuming the resource
+	Consumers []ResourcesUsageConsumer `json:"consumers" yaml:"consumers"`
+}
+
+// ResourcesUsageConsumer represents an instance consuming limited host resources
+//
+// swagger:model
+//
+// API extension: resources_usage.
+type ResourcesUsageConsumer struct {
+	// Project the instance belongs to
+	// Example: default
+	Project string `json:"project" yaml:"project"`
+
+	// Instance name
+	// Example: c1
+	Instance string `json:"instance" yaml:"instance"`
+
+	// Name of the instance device consuming the resource (empty for CPU)
+	// Example: gpu0
+	Device string `json:"device" yaml:"device"`
+
+	// Amount of the resource consumed (CPU threads or devices)
+	// Example: 1
+	Amount int64 `json:"amount" yaml:"amount"`
+
+	// Host resources consumed (CPU thread IDs, device addresses or paths)
+	// Example: ["0000:01:00.0"]
+	Resources []string `json:"resources" yaml:"resources"`
+}
+
+// ResourcesFeatures represents the kernel and LXC features detected on the server
+//
+// swagger:model
+//
+// API extension: resources_features.
+type ResourcesFeatures struct {
+	// Kernel features and whether they're usable
+	// Example: {"idmapped_mounts": true, "pidfds": true}
+	Kernel map[string]bool `json:"kernel" yaml:"kernel"`
+
+	// LXC features and whether they're supported
+	// Example: {"mount_injection_file": true, "seccomp_notify": true}
+	LXC map[string]bool `json:"lxc" yaml:"lxc"`
+
+	// AppArmor features and whether they're usable
+	// Example: {"available": true, "stacking": true}
+	AppArmor map[string]bool `json:"apparmor" yaml:"apparmor"`
+
+	// Control group layout
+	// Example: cgroup2
+	CGroupLayout string `json:"cgroup_layout" yaml:"cgroup_layout"`
+
+	// Whether containers may fall back to shifting their filesystem on disk when idmapped mounts can't be used
+	// Example: fallback
+	//
+	// API extension: idmapped_mounts_policy
+	IdmappedMountsPolicy string `json:"idmapped_mounts_policy" yaml:"idmapped_mounts_policy"`
+}
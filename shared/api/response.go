@@ -19,6 +19,9 @@ type ResponseRaw struct {
 	Code  int    `json:"error_code" yaml:"error_code"`
 	Error string `json:"error" yaml:"error"`
 
+	// API extension: error_reasons
+	ErrorReason string `json:"error_reason,omitempty" yaml:"error_reason,omitempty"`
+
 	Metadata any `json:"metadata" yaml:"metadata"`
 }
 
@@ -37,6 +40,9 @@ type Response struct {
 	Code  int    `json:"error_code" yaml:"error_code"`
 	Error string `json:"error" yaml:"error"`
 
+	// API extension: error_reasons
+	ErrorReason string `json:"error_reason,omitempty" yaml:"error_reason,omitempty"`
+
 	// Valid for Sync and Error responses
 	Metadata json.RawMessage `json:"metadata" yaml:"metadata"`
 }
@@ -1,11 +1,21 @@
 package api
 
+import (
+	"time"
+)
+
 // ServerEnvironment represents the read-only environment fields of a server configuration.
 type ServerEnvironment struct {
 	// List of addresses the server is listening on
 	// Example: [":8443"]
 	Addresses []string `json:"addresses" yaml:"addresses"`
 
+	// Runtime availability of the API extensions which depend on the host (kernel features, instance drivers)
+	// Example: {"virtual-machines": true, "container_syscall_intercept": false}
+	//
+	// API extension: api_extensions_availability
+	APIExtensionsAvailability map[string]bool `json:"api_extensions_availability" yaml:"api_extensions_availability"`
+
 	// List of architectures supported by the server
 	// Example: ["x86_64", "i686"]
 	Architectures []string `json:"architectures" yaml:"architectures"`
@@ -212,9 +222,84 @@ type Server struct {
 	// Read-only status/configuration information
 	// Read only: true
 	Environment ServerEnvironment `json:"environment" yaml:"environment"`
+
+	// Message set by the server administrators, if any
+	// Read only: true
+	//
+	// API extension: server_message
+	Message *ServerMessage `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// ServerMessage represents a message set by the server administrators to inform the users of the server
+//
+// swagger:model
+//
+// API extension: server_message.
+type ServerMessage struct {
+	// The message
+	// Example: Scheduled maintenance on Saturday from 08:00 to 10:00 UTC
+	Message string `json:"message" yaml:"message"`
+
+	// Severity of the message (one of "info" or "warning")
+	// Example: warning
+	Severity string `json:"severity" yaml:"severity"`
+
+	// When the message expires
+	// Example: 2024-03-16T10:00:00Z
+	ExpiresAt *time.Time `json:"expires_at" yaml:"expires_at"`
 }
 
 // Writable converts a full Server struct into a ServerPut struct (filters read-only fields).
 func (srv *Server) Writable() ServerPut {
 	return srv.ServerPut
 }
+
+// ServerConfig represents the full configuration of a server, split by scope
+//
+// swagger:model
+//
+// API extension: server_config_export.
+type ServerConfig struct {
+	// Version of the server the configuration was exported from (newer versions are rejected on import)
+	// Example: 0.2
+	Version string `json:"version" yaml:"version"`
+
+	// Server-specific configuration
+	// Example: {"core.https_address": ":8443"}
+	Local map[string]string `json:"local" yaml:"local"`
+
+	// Configuration shared by all cluster members
+	// Example: {"images.auto_update_interval": "6"}
+	Global map[string]string `json:"global" yaml:"global"`
+}
+
+// ServerConfigKey represents the definition of a server configuration key
+//
+// swagger:model
+//
+// API extension: server_config_schema.
+type ServerConfigKey struct {
+	// Name of the key
+	// Example: core.https_address
+	Key string `json:"key" yaml:"key"`
+
+	// Whether the key is specific to each server ("local") or shared by the cluster ("global")
+	// Example: local
+	Scope string `json:"scope" yaml:"scope"`
+
+	// Type of the value (one of "string", "bool" or "integer")
+	// Example: string
+	Type string `json:"type" yaml:"type"`
+
+	// Value used when the key isn't set
+	// Example: 5
+	Default string `json:"default" yaml:"default"`
+
+	// Deprecation message (if the key is deprecated)
+	// Example: Use core.https_address instead
+	Deprecated string `json:"deprecated" yaml:"deprecated"`
+
+	// Whether changes only take effect after restarting the daemon
+	// Example: false
+	RequiresRestart bool `json:"requires_restart" yaml:"requires_restart"`
+}
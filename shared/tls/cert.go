@@ -6,8 +6,8 @@
 package tls
 
 import (
+	"crypto"
 	"crypto/ecdsa"
-	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
@@ -47,12 +47,18 @@ import (
 // If a CA certificate is found, it will be returned as well as second return
 // value (otherwise it will be nil).
 func KeyPairAndCA(dir, prefix string, kind CertKind, addHosts bool) (*CertInfo, error) {
+	return KeyPairAndCAWithKey(dir, prefix, kind, addHosts, KeyParams{})
+}
+
+// KeyPairAndCAWithKey is like KeyPairAndCA but generates a missing key pair
+// with the given key type and size.
+func KeyPairAndCAWithKey(dir, prefix string, kind CertKind, addHosts bool, keyParams KeyParams) (*CertInfo, error) {
 	certFilename := filepath.Join(dir, prefix+".crt")
 	keyFilename := filepath.Join(dir, prefix+".key")
 
 	// Ensure that the certificate exists, or create a new one if it does
 	// not.
-	err := FindOrGenCert(certFilename, keyFilename, kind == CertClient, addHosts)
+	err := FindOrGenCertWithKey(certFilename, keyFilename, kind == CertClient, addHosts, keyParams)
 	if err != nil {
 		return nil, err
 	}
@@ -63,6 +69,40 @@ func KeyPairAndCA(dir, prefix string, kind CertKind, addHosts bool) (*CertInfo,
 		return nil, err
 	}
 
+	return keyPairWithCA(dir, prefix, keypair)
+}
+
+// KeyPairAndCAWithSigner returns a CertInfo object for the certificate located
+// in the given directory and having the given name prefix, using the provided
+// signer as its private key instead of reading the <prefix>.key file.
+//
+// This allows for the private key to be held outside of the filesystem, for
+// example in a hardware token. The certificate must already exist and match
+// the public key of the signer.
+func KeyPairAndCAWithSigner(dir, prefix string, signer crypto.Signer) (*CertInfo, error) {
+	cert, err := ReadCert(filepath.Join(dir, prefix+".crt"))
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !pubKey.Equal(cert.PublicKey) {
+		return nil, fmt.Errorf("Private key doesn't match the %q certificate", prefix)
+	}
+
+	keypair := tls.Certificate{
+		Certificate: [][]byte{cert.Raw},
+		PrivateKey:  signer,
+		Leaf:        cert,
+	}
+
+	return keyPairWithCA(dir, prefix, keypair)
+}
+
+// keyPairWithCA returns a CertInfo for the key pair, along with the CA certificate and CRL if present in the directory.
+func keyPairWithCA(dir, prefix string, keypair tls.Certificate) (*CertInfo, error) {
+	var err error
+
 	// If available, load the CA data as well.
 	caFilename := filepath.Join(dir, prefix+".ca")
 	var ca *x509.Certificate
@@ -245,13 +285,18 @@ func mynames() ([]string, error) {
 // FindOrGenCert generates a keypair if needed.
 // The type argument is false for server, true for client.
 func FindOrGenCert(certf string, keyf string, certtype bool, addHosts bool) error {
+	return FindOrGenCertWithKey(certf, keyf, certtype, addHosts, KeyParams{})
+}
+
+// FindOrGenCertWithKey is like FindOrGenCert but generates the keypair with the given key type and size.
+func FindOrGenCertWithKey(certf string, keyf string, certtype bool, addHosts bool, keyParams KeyParams) error {
 	if util.PathExists(certf) && util.PathExists(keyf) {
 		return nil
 	}
 
 	/* If neither stat succeeded, then this is our first run and we
 	 * need to generate cert and privkey */
-	err := GenCert(certf, keyf, certtype, addHosts)
+	err := GenCertWithKey(certf, keyf, certtype, addHosts, keyParams)
 	if err != nil {
 		return err
 	}
@@ -261,6 +306,11 @@ func FindOrGenCert(certf string, keyf string, certtype bool, addHosts bool) erro
 
 // GenCert will create and populate a certificate file and a key file.
 func GenCert(certf string, keyf string, certtype bool, addHosts bool) error {
+	return GenCertWithKey(certf, keyf, certtype, addHosts, KeyParams{})
+}
+
+// GenCertWithKey is like GenCert but generates the key with the given key type and size.
+func GenCertWithKey(certf string, keyf string, certtype bool, addHosts bool, keyParams KeyParams) error {
 	/* Create the basenames if needed */
 	dir := filepath.Dir(certf)
 	err := os.MkdirAll(dir, 0750)
@@ -274,7 +324,7 @@ func GenCert(certf string, keyf string, certtype bool, addHosts bool) error {
 		return err
 	}
 
-	certBytes, keyBytes, err := GenerateMemCert(certtype, addHosts)
+	certBytes, keyBytes, err := GenerateMemCertWithKey(certtype, addHosts, keyParams)
 	if err != nil {
 		return err
 	}
@@ -315,9 +365,14 @@ func GenCert(certf string, keyf string, certtype bool, addHosts bool) error {
 // GenerateMemCert creates client or server certificate and key pair,
 // returning them as byte arrays in memory.
 func GenerateMemCert(client bool, addHosts bool) ([]byte, []byte, error) {
-	privk, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	return GenerateMemCertWithKey(client, addHosts, KeyParams{})
+}
+
+// GenerateMemCertWithKey is like GenerateMemCert but generates the key with the given key type and size.
+func GenerateMemCertWithKey(client bool, addHosts bool, keyParams KeyParams) ([]byte, []byte, error) {
+	privk, key, err := keyParams.GenerateKey()
 	if err != nil {
-		return nil, nil, fmt.Errorf("Failed to generate key: %w", err)
+		return nil, nil, err
 	}
 
 	validFrom := time.Now()
@@ -384,18 +439,12 @@ func GenerateMemCert(client bool, addHosts bool) ([]byte, []byte, error) {
 		template.DNSNames = []string{"unspecified"}
 	}
 
-	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, &privk.PublicKey, privk)
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, privk.Public(), privk)
 	if err != nil {
 		return nil, nil, fmt.Errorf("Failed to create certificate: %w", err)
 	}
 
-	data, err := x509.MarshalECPrivateKey(privk)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
-	key := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: data})
 
 	return cert, key, nil
 }
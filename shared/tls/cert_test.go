@@ -1,6 +1,11 @@
 package tls
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
 	"os"
@@ -61,6 +66,54 @@ func TestKeyPairAndCA(t *testing.T) {
 	}
 }
 
+// The private key can be provided by a signer, in which case the key file isn't used.
+func TestKeyPairAndCAWithSigner(t *testing.T) {
+	dir, err := os.MkdirTemp("", "incus-shared-test-")
+	if err != nil {
+		t.Errorf("failed to create temporary dir: %v", err)
+	}
+
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	info, err := KeyPairAndCA(dir, "test", CertServer, true)
+	if err != nil {
+		t.Errorf("initial call to KeyPairAndCA failed: %v", err)
+	}
+
+	signer, ok := info.KeyPair().PrivateKey.(crypto.Signer)
+	if !ok {
+		t.Fatalf("expected private key to be a signer")
+	}
+
+	err = os.Remove(filepath.Join(dir, "test.key"))
+	if err != nil {
+		t.Errorf("failed to remove key file: %v", err)
+	}
+
+	info, err = KeyPairAndCAWithSigner(dir, "test", signer)
+	if err != nil {
+		t.Errorf("KeyPairAndCAWithSigner failed: %v", err)
+	}
+
+	if info.KeyPair().PrivateKey != signer {
+		t.Errorf("expected key pair to use the signer")
+	}
+
+	if info.PrivateKey() == nil {
+		t.Errorf("expected in-memory signer to be encodable")
+	}
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Errorf("failed to generate key: %v", err)
+	}
+
+	_, err = KeyPairAndCAWithSigner(dir, "test", otherKey)
+	if err == nil {
+		t.Errorf("expected mismatched signer to be rejected")
+	}
+}
+
 func TestGenerateMemCert(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping cert generation in short mode")
@@ -100,3 +153,44 @@ func TestGenerateMemCert(t *testing.T) {
 		t.Errorf("GenerateMemCert returned a cert with Type %q not \"EC PRIVATE KEY\"", block.Type)
 	}
 }
+
+// The key of the generated key pair matches the requested type and size.
+func TestGenerateMemCertWithKey(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping cert generation in short mode")
+	}
+
+	tests := []struct {
+		params  KeyParams
+		keyType string
+	}{
+		{KeyParams{}, "EC PRIVATE KEY"},
+		{KeyParams{Type: KeyTypeECDSA, Size: 256}, "EC PRIVATE KEY"},
+		{KeyParams{Type: KeyTypeRSA, Size: 2048}, "RSA PRIVATE KEY"},
+	}
+
+	for _, test := range tests {
+		cert, key, err := GenerateMemCertWithKey(false, false, test.params)
+		if err != nil {
+			t.Errorf("GenerateMemCertWithKey(%+v) failed: %v", test.params, err)
+			continue
+		}
+
+		block, _ := pem.Decode(key)
+		if block.Type != test.keyType {
+			t.Errorf("GenerateMemCertWithKey(%+v) returned a key with Type %q not %q", test.params, block.Type, test.keyType)
+		}
+
+		_, err = tls.X509KeyPair(cert, key)
+		if err != nil {
+			t.Errorf("GenerateMemCertWithKey(%+v) returned an invalid key pair: %v", test.params, err)
+		}
+	}
+
+	for _, params := range []KeyParams{{Type: "dsa"}, {Type: KeyTypeECDSA, Size: 521}, {Type: KeyTypeRSA, Size: 1024}} {
+		_, _, err := GenerateMemCertWithKey(false, false, params)
+		if err == nil {
+			t.Errorf("GenerateMemCertWithKey(%+v) didn't fail", params)
+		}
+	}
+}
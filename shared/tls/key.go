@@ -0,0 +1,105 @@
+package tls
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+)
+
+// Key types supported for generated certificates.
+const (
+	KeyTypeECDSA = "ecdsa"
+	KeyTypeRSA   = "rsa"
+)
+
+// KeyParams represents the type and size of the private key of a generated certificate.
+// The zero value stands for the default, an ECDSA P-384 key.
+type KeyParams struct {
+	// Type of key (ecdsa or rsa).
+	Type string
+
+	// Size of the key in bits (curve size for ECDSA), 0 for the default of the type.
+	Size int
+}
+
+// Validate checks that the key type and size combination is supported.
+func (p KeyParams) Validate() error {
+	switch p.Type {
+	case "", KeyTypeECDSA:
+		if p.Size != 0 && p.Size != 256 && p.Size != 384 {
+			return fmt.Errorf("Unsupported ECDSA key size %d (must be 256 or 384)", p.Size)
+		}
+
+	case KeyTypeRSA:
+		if p.Size != 0 && p.Size != 2048 && p.Size != 3072 && p.Size != 4096 {
+			return fmt.Errorf("Unsupported RSA key size %d (must be 2048, 3072 or 4096)", p.Size)
+		}
+
+	default:
+		return fmt.Errorf("Unsupported key type %q", p.Type)
+	}
+
+	return nil
+}
+
+// Resolved returns the key parameters with the defaults filled in.
+func (p KeyParams) Resolved() KeyParams {
+	if p.Type == "" {
+		p.Type = KeyTypeECDSA
+	}
+
+	if p.Size == 0 {
+		if p.Type == KeyTypeRSA {
+			p.Size = 3072
+		} else {
+			p.Size = 384
+		}
+	}
+
+	return p
+}
+
+// GenerateKey generates a new private key and returns it along with its PEM encoding.
+func (p KeyParams) GenerateKey() (crypto.Signer, []byte, error) {
+	err := p.Validate()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p = p.Resolved()
+
+	if p.Type == KeyTypeRSA {
+		privk, err := rsa.GenerateKey(rand.Reader, p.Size)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed to generate key: %w", err)
+		}
+
+		key := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privk)})
+
+		return privk, key, nil
+	}
+
+	curve := elliptic.P384()
+	if p.Size == 256 {
+		curve = elliptic.P256()
+	}
+
+	privk, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to generate key: %w", err)
+	}
+
+	data, err := x509.MarshalECPrivateKey(privk)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: data})
+
+	return privk, key, nil
+}